@@ -55,7 +55,7 @@ if [[ $platform != 'windows' ]]; then
     install_and_test rnd/dsfmt 1
 fi
 
-for p in rnd opt ml/imgd ml ode pde tsr; do
+for p in rnd opt stat ml/imgd ml ode pde tsr; do
     install_and_test $p 1
 done
 
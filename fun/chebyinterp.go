@@ -310,6 +310,17 @@ func (o *ChebyInterp) EstimateMaxErr(f Ss, projection bool) (maxerr, xloc float6
 	return
 }
 
+// ErrorEstimate estimates the interpolation error by sampling f at nTest points in the interior
+// of each interval between the points X (and the ends of [-1,1]). Computes:
+//
+//    maxErr = max |f(x) - I{f}(x)|    and    rmsErr = sqrt(Σ (f(x) - I{f}(x))² / nsamples)
+//
+//    NOTE: CoefI must be computed first
+//
+func (o *ChebyInterp) ErrorEstimate(f Ss, nTest int) (maxErr, rmsErr float64) {
+	return interpErrorEstimate(append([]float64{-1, 1}, o.X...), f, o.I, nTest)
+}
+
 // HierarchicalT computes Tn(x) using hierarchical definition (but NOT recursive)
 //   NOTE: this function is not as efficient as ChebyshevT and should be used for testing only
 func (o *ChebyInterp) HierarchicalT(i int, x float64) float64 {
@@ -0,0 +1,115 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// RootsOnInterval finds all (real) roots of f(x) in [a,b]
+//
+//   f is interpolated by a Chebyshev polynomial using Gauss-Lobatto points mapped to [a,b]
+//
+//               N
+//     I{f}(x) = Σ  c_k ⋅ T_k(y)      with   y = (2⋅x - a - b) / (b - a)
+//              k=0
+//
+//   and the roots of I{f} are computed as the eigenvalues of the "colleague" matrix [1]
+//
+//         ┌                                   ┐
+//         │  0    1                            │
+//         │ 1/2   0   1/2                      │
+//     C = │      ...  ...  ...                 │       (N × N)
+//         │           1/2   0   1/2            │
+//         │                1/2   0             │
+//         └                                   ┘ - (1/2c_N) ⋅ e_N ⊗ [c₀, c₁, …, c_{N-1}]
+//
+//   Only the real eigenvalues inside [-1,1] are kept. Trailing coefficients which are negligible
+//   compared with max(|c_k|) are removed before building C.
+//
+//   INPUT:
+//     f      -- function
+//     a, b   -- interval with a < b
+//     degree -- degree N of the interpolating polynomial (≥ 1); e.g. 50 or 100
+//
+//   OUTPUT:
+//     roots -- sorted roots in [a,b]
+//     err   -- error if the input is invalid or f is (numerically) zero everywhere
+//
+//   Reference:
+//     [1] Boyd JP (2002) Computing zeros on a real interval through Chebyshev expansion and
+//         polynomial rootfinding. SIAM J. Numer. Anal., Vol. 40, No. 5, pp. 1666-1682
+//
+func RootsOnInterval(f func(float64) float64, a, b float64, degree int) (roots la.Vector, err error) {
+
+	// check
+	if degree < 1 {
+		return nil, chk.Err("degree must be at least 1. degree = %d is invalid", degree)
+	}
+	if a >= b {
+		return nil, chk.Err("interval is invalid: a=%g must be smaller than b=%g", a, b)
+	}
+
+	// interpolation coefficients
+	cheby := NewChebyInterp(degree, false)
+	cheby.CalcCoefI(func(y float64) float64 {
+		return f(((b-a)*y + a + b) / 2.0)
+	})
+	c := cheby.CoefI
+
+	// effective degree
+	cmax := 0.0
+	for _, v := range c {
+		cmax = math.Max(cmax, math.Abs(v))
+	}
+	if cmax == 0 {
+		return nil, chk.Err("function is zero everywhere in [%g,%g]", a, b)
+	}
+	N := degree
+	for N > 0 && math.Abs(c[N]) <= 1e-13*cmax {
+		N--
+	}
+	if N == 0 {
+		return la.NewVector(0), nil // constant function
+	}
+
+	// colleague matrix
+	C := la.NewMatrix(N, N)
+	if N == 1 {
+		C.Set(0, 0, -c[0]/c[1])
+	} else {
+		C.Set(0, 1, 1)
+		for i := 1; i < N-1; i++ {
+			C.Set(i, i-1, 0.5)
+			C.Set(i, i+1, 0.5)
+		}
+		C.Set(N-1, N-2, 0.5)
+		for j := 0; j < N; j++ {
+			C.Add(N-1, j, -c[j]/(2.0*c[N]))
+		}
+	}
+
+	// eigenvalues
+	λ := la.NewVectorC(N)
+	la.EigenVal(λ, C, false)
+
+	// select real roots in [-1,1]
+	tol := 1e-8
+	var res []float64
+	for _, v := range λ {
+		y := real(v)
+		if math.Abs(imag(v)) > tol || y < -1-tol || y > 1+tol {
+			continue
+		}
+		y = math.Max(-1, math.Min(1, y))
+		res = append(res, ((b-a)*y+a+b)/2.0)
+	}
+	sort.Float64s(res)
+	return la.NewVectorSlice(res), nil
+}
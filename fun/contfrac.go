@@ -0,0 +1,64 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// ContinuedFraction evaluates the continued fraction
+//
+//                    a1
+//     f = b0 + ――――――――――――――――――
+//                       a2
+//               b1 + ――――――――――――
+//                          a3
+//                    b2 + ――――――
+//                          b3 + …
+//
+//   using the modified Lentz algorithm (Section 5.2 of [1])
+//
+//   Input:
+//     a     -- generates the partial numerators a(n) for n ≥ 1 (a(0) is not used)
+//     b     -- generates the partial denominators b(n) for n ≥ 0
+//     tol   -- tolerance on the relative change of f; e.g. 1e-15
+//     maxit -- max number of terms
+//
+//   Output:
+//     f   -- value of the continued fraction
+//     err -- error if the fraction did not converge after maxit terms
+//
+//   REFERENCES:
+//   [1] Press WH, Teukolsky SA, Vetterling WT, Fnannery BP (2007) Numerical Recipes: The Art of
+//       Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+//
+func ContinuedFraction(a, b func(n int) float64, tol float64, maxit int) (f float64, err error) {
+	tiny := 1e-300 // replaces zero denominators
+	f = b(0)
+	if f == 0 {
+		f = tiny
+	}
+	C, D := f, 0.0
+	for n := 1; n <= maxit; n++ {
+		an, bn := a(n), b(n)
+		D = bn + an*D
+		if D == 0 {
+			D = tiny
+		}
+		C = bn + an/C
+		if C == 0 {
+			C = tiny
+		}
+		D = 1.0 / D
+		Δ := C * D
+		f *= Δ
+		if math.Abs(Δ-1.0) <= tol {
+			return
+		}
+	}
+	return f, chk.Err("continued fraction did not converge after %d terms", maxit)
+}
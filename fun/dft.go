@@ -4,11 +4,7 @@
 
 package fun
 
-import (
-	"math"
-
-	"github.com/cpmech/gosl/fun/fftw"
-)
+import "math"
 
 // Dft1d computes the discrete Fourier transform (DFT) in 1D.
 // It replaces data by its discrete Fourier transform, if inverse==false
@@ -24,14 +20,25 @@ import (
 //                      j=0
 //
 //   NOTE: (1) the inverse operation does not divide by N
-//         (2) ideally, N=len(data) is an integer power of 2.
+//         (2) any N=len(data) is accepted without zero-padding. FFTW selects the algorithm from
+//             the factorisation of N: mixed-radix Cooley-Tukey with optimised codelets if the
+//             prime factors of N are small (2, 3, 5, 7, 11, 13); and O(N⋅log(N)) algorithms for
+//             the other factors, including large primes (Rader). Powers of 2 are the fastest
 //         (3) using FFTW: http://fftw.org/fftw3_doc/What-FFTW-Really-Computes.html
+//         (4) the plans are computed once for each N and kept in a cache (see FFTPlan and
+//             FreeFFTPlanCache). The cache is not bounded: one FFTW plan and one buffer of N
+//             numbers are kept for each distinct N until FreeFFTPlanCache is called. Thus, call
+//             FreeFFTPlanCache when many different sizes are used or use FFTPlan directly
 //
 func Dft1d(data []complex128, inverse bool) {
-	plan := fftw.NewPlan1d(data, inverse, false)
-	defer plan.Free()
-	plan.Execute()
-	return
+	plan := cachedFFTPlan(len(data))
+	plan.lock.Lock()
+	defer plan.lock.Unlock()
+	if inverse {
+		plan.Inverse(data)
+		return
+	}
+	plan.Forward(data)
 }
 
 // dft1dslow computes the discrete Fourier transform of x (complex) by using the "slow" method; i.e.
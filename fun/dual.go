@@ -0,0 +1,168 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/la"
+)
+
+// Dual implements a dual number a + b⋅ε with ε² = 0 for forward-mode automatic differentiation.
+// If x is seeded with D = 1 (i.e. x = x₀ + ε), then any function f computed with dual arithmetic
+// gives f(x) = f(x₀) + f'(x₀)⋅ε; i.e. the derivative is exact up to round-off
+//
+//  Example:
+//              f(x) = x⋅sin(x)
+//
+//     x := NewDual(2, 1)
+//     f := x.Mul(x.Sin())    ⇒    f.V = 2⋅sin(2)    and    f.D = sin(2) + 2⋅cos(2)
+//
+type Dual struct {
+	V float64 // value
+	D float64 // derivative (dual part)
+}
+
+// NewDual returns a new dual number with value v and derivative d
+func NewDual(v, d float64) Dual {
+	return Dual{V: v, D: d}
+}
+
+// DualConst returns a dual number representing a constant (zero derivative)
+func DualConst(c float64) Dual {
+	return Dual{V: c}
+}
+
+// Gradient computes the gradient of f(x) using forward-mode automatic differentiation
+//  NOTE: the gradient is computed with len(x) evaluations of f; each one seeding one coordinate
+func Gradient(f func(x []Dual) Dual, x la.Vector) (grad la.Vector) {
+	grad = la.NewVector(len(x))
+	xd := make([]Dual, len(x))
+	for i := range x {
+		xd[i] = Dual{V: x[i]}
+	}
+	for i := range x {
+		xd[i].D = 1
+		grad[i] = f(xd).D
+		xd[i].D = 0
+	}
+	return
+}
+
+// Derivative computes f(x) and df/dx @ x using forward-mode automatic differentiation
+func Derivative(f func(x Dual) Dual, x float64) (val, deriv float64) {
+	res := f(Dual{V: x, D: 1})
+	return res.V, res.D
+}
+
+// arithmetic //////////////////////////////////////////////////////////////////////////////////////
+
+// Add returns a + b
+func (a Dual) Add(b Dual) Dual {
+	return Dual{a.V + b.V, a.D + b.D}
+}
+
+// Sub returns a - b
+func (a Dual) Sub(b Dual) Dual {
+	return Dual{a.V - b.V, a.D - b.D}
+}
+
+// Mul returns a ⋅ b
+func (a Dual) Mul(b Dual) Dual {
+	return Dual{a.V * b.V, a.D*b.V + a.V*b.D}
+}
+
+// Div returns a / b
+func (a Dual) Div(b Dual) Dual {
+	return Dual{a.V / b.V, (a.D*b.V - a.V*b.D) / (b.V * b.V)}
+}
+
+// Neg returns -a
+func (a Dual) Neg() Dual {
+	return Dual{-a.V, -a.D}
+}
+
+// AddScalar returns a + s
+func (a Dual) AddScalar(s float64) Dual {
+	return Dual{a.V + s, a.D}
+}
+
+// Scale returns s ⋅ a
+func (a Dual) Scale(s float64) Dual {
+	return Dual{s * a.V, s * a.D}
+}
+
+// elementary functions ////////////////////////////////////////////////////////////////////////////
+
+// Sin returns sin(a)
+func (a Dual) Sin() Dual {
+	return Dual{math.Sin(a.V), a.D * math.Cos(a.V)}
+}
+
+// Cos returns cos(a)
+func (a Dual) Cos() Dual {
+	return Dual{math.Cos(a.V), -a.D * math.Sin(a.V)}
+}
+
+// Tan returns tan(a)
+func (a Dual) Tan() Dual {
+	t := math.Tan(a.V)
+	return Dual{t, a.D * (1.0 + t*t)}
+}
+
+// Exp returns exp(a)
+func (a Dual) Exp() Dual {
+	e := math.Exp(a.V)
+	return Dual{e, a.D * e}
+}
+
+// Log returns log(a)
+func (a Dual) Log() Dual {
+	return Dual{math.Log(a.V), a.D / a.V}
+}
+
+// Sqrt returns √a
+func (a Dual) Sqrt() Dual {
+	s := math.Sqrt(a.V)
+	return Dual{s, a.D / (2.0 * s)}
+}
+
+// Pow returns aᵖ with a real exponent p
+func (a Dual) Pow(p float64) Dual {
+	if p == 0 {
+		return Dual{1, 0}
+	}
+	return Dual{math.Pow(a.V, p), a.D * p * math.Pow(a.V, p-1.0)}
+}
+
+// PowDual returns aᵇ = exp(b⋅log(a)) with a dual exponent b
+//  NOTE: a.V must be positive
+func (a Dual) PowDual(b Dual) Dual {
+	v := math.Pow(a.V, b.V)
+	return Dual{v, v * (b.D*math.Log(a.V) + b.V*a.D/a.V)}
+}
+
+// Abs returns |a|
+//  NOTE: the derivative @ a.V = 0 is taken as zero
+func (a Dual) Abs() Dual {
+	switch {
+	case a.V > 0:
+		return a
+	case a.V < 0:
+		return a.Neg()
+	}
+	return Dual{0, 0}
+}
+
+// Tanh returns tanh(a)
+func (a Dual) Tanh() Dual {
+	t := math.Tanh(a.V)
+	return Dual{t, a.D * (1.0 - t*t)}
+}
+
+// Atan returns atan(a)
+func (a Dual) Atan() Dual {
+	return Dual{math.Atan(a.V), a.D / (1.0 + a.V*a.V)}
+}
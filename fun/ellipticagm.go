@@ -0,0 +1,192 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// This file implements the elliptic integrals in terms of the parameter m = k² (where k is the
+// modulus used by Elliptic1 and Elliptic2) and the Jacobi elliptic functions using the
+// arithmetic-geometric mean (AGM) of a₀ = 1 and b₀ = √(1-m):
+//
+//   aₙ = (aₙ₋₁ + bₙ₋₁) / 2     bₙ = √(aₙ₋₁ ⋅ bₙ₋₁)     cₙ = (aₙ₋₁ - bₙ₋₁) / 2     c₀ = √m
+//
+//   REFERENCES:
+//   [1] Abramowitz M and Stegun IA (1972) Handbook of Mathematical Functions with Formulas,
+//       Graphs, and Mathematical Tables. Dover, New York. 1046p.
+
+// agmMaxIt is the max number of AGM iterations (the convergence is quadratic)
+const agmMaxIt = 30
+
+// agmTol is the tolerance on cₙ/aₙ to stop the AGM iterations
+const agmTol = 1e-15
+
+// checkEllipticM checks the parameter m
+func checkEllipticM(m float64) {
+	if m > 1 {
+		chk.Panic("parameter of elliptic integrals and functions must satisfy m ≤ 1. m=%g is invalid\n", m)
+	}
+}
+
+// EllipticK computes the complete elliptic integral of the first kind (Eq. 17.6.1 of [1])
+//
+//                π/2
+//               ⌠          dt            π
+//     K(m)  =   │  ―――――――――――――――  =  ――――――
+//               ⌡  √(1 - m sin²t)      2 a_N
+//              0
+//
+//   where a_N is the converged AGM of 1 and √(1-m)
+//
+//   NOTE: m ≤ 1 (m = k²); K(1) = +Inf
+//
+func EllipticK(m float64) float64 {
+	checkEllipticM(m)
+	if m == 1 {
+		return math.Inf(1)
+	}
+	a, b := 1.0, math.Sqrt(1.0-m)
+	for it := 0; it < agmMaxIt && math.Abs(a-b) > agmTol*a; it++ {
+		a, b = (a+b)/2.0, math.Sqrt(a*b)
+	}
+	return math.Pi / (2.0 * a)
+}
+
+// EllipticE computes the complete elliptic integral of the second kind (Eq. 17.6.4 of [1])
+//
+//                π/2
+//               ⌠                                 ⎛     1   N             ⎞
+//     E(m)  =   │  √(1 - m sin²t) dt  =  K(m) ⋅  ⎜ 1 - ―   Σ  2ⁿ ⋅ cₙ²   ⎟
+//               ⌡                                 ⎝     2  n=0            ⎠
+//              0
+//
+//   NOTE: m ≤ 1 (m = k²); E(1) = 1
+//
+func EllipticE(m float64) float64 {
+	checkEllipticM(m)
+	if m == 1 {
+		return 1
+	}
+	a, b := 1.0, math.Sqrt(1.0-m)
+	sum := m // 2⁰ ⋅ c₀² with c₀² = m (valid for m < 0 as well)
+	pow := 1.0
+	for it := 0; it < agmMaxIt && math.Abs(a-b) > agmTol*a; it++ {
+		c := (a - b) / 2.0
+		a, b = (a+b)/2.0, math.Sqrt(a*b)
+		pow *= 2.0
+		sum += pow * c * c
+	}
+	return math.Pi / (2.0 * a) * (1.0 - sum/2.0)
+}
+
+// EllipticF computes the incomplete elliptic integral of the first kind using the AGM with the
+// descending Landen transformation of the amplitude φ (Eqs. 17.5.4 and 17.6.8 of [1])
+//
+//                  φ
+//                 ⌠          dt                 φ_N
+//     F(φ|m)  =   │  ―――――――――――――――  =  ――――――――――     with  tan(φₙ₊₁ - φₙ) = (bₙ/aₙ) tan(φₙ)
+//                 ⌡  √(1 - m sin²t)       2ᴺ ⋅ a_N
+//                0
+//
+//   NOTE: (1) m ≤ 1 (m = k²) and φ may be any real number; e.g. F(φ+π|m) = F(φ|m) + 2 K(m)
+//         (2) F(φ|m) = Elliptic1(φ, √m) for 0 ≤ φ ≤ π/2 and 0 ≤ m ≤ 1
+//         (3) with m = 1, F(φ|1) = atanh(sin φ) if |φ| < π/2; otherwise ±Inf
+//
+func EllipticF(φ, m float64) float64 {
+	checkEllipticM(m)
+	if m == 1 {
+		if math.Abs(φ) >= math.Pi/2 {
+			return math.Copysign(math.Inf(1), φ)
+		}
+		return math.Atanh(math.Sin(φ))
+	}
+	a, b := 1.0, math.Sqrt(1.0-m)
+	twoN := 1.0
+	for it := 0; it < agmMaxIt && math.Abs(a-b) > agmTol*a; it++ {
+		θ := math.Atan2(b*math.Sin(φ), a*math.Cos(φ)) // φₙ₊₁ - φₙ in (-π, π]
+
+		// select the continuous branch; i.e. φₙ₊₁ - φₙ close to φₙ
+		φ += θ + 2.0*math.Pi*math.Round((φ-θ)/(2.0*math.Pi))
+		a, b = (a+b)/2.0, math.Sqrt(a*b)
+		twoN *= 2.0
+	}
+	return φ / (twoN * a)
+}
+
+// JacobiElliptic computes the Jacobi elliptic functions sn(u|m), cn(u|m) and dn(u|m) using the
+// AGM and the descending Landen transformation (Section 16.4 of [1]):
+//
+//     φ_N = 2ᴺ a_N u     sin(2φₙ₋₁ - φₙ) = (cₙ/aₙ) sin(φₙ)
+//
+//     sn = sin(φ₀)     cn = cos(φ₀)     dn = √(1 - m + m cn²)
+//
+//   where dn is computed from dn² = 1 - m sn² = 1 - m + m cn², without cancellation for 0 < m < 1
+//
+//   NOTE: (1) m ≤ 1 (m = k²); the case m < 0 is transformed to 0 < μ < 1 by Eq. 16.10.2 of [1]
+//         (2) sn(u|0) = sin(u), cn(u|0) = cos(u) and dn(u|0) = 1
+//         (3) sn(u|1) = tanh(u), cn(u|1) = dn(u|1) = sech(u)
+//         (4) sn(F(φ|m)|m) = sin(φ) and cn(F(φ|m)|m) = cos(φ); i.e. φ is the amplitude of u
+//
+func JacobiElliptic(u, m float64) (sn, cn, dn float64) {
+	checkEllipticM(m)
+
+	// special cases
+	switch {
+	case m == 0:
+		return math.Sin(u), math.Cos(u), 1
+	case m == 1:
+		sech := 1.0 / math.Cosh(u)
+		return math.Tanh(u), sech, sech
+	case m < 0: // sn(u|m) = sd(v|μ)/√(1-m), cn(u|m) = cd(v|μ) and dn(u|m) = nd(v|μ)
+		s := math.Sqrt(1.0 - m)
+		μ := -m / (1.0 - m)
+		snμ, cnμ, dnμ := JacobiElliptic(u*s, μ)
+		return snμ / (dnμ * s), cnμ / dnμ, 1.0 / dnμ
+	}
+
+	// AGM
+	var as, cs [agmMaxIt + 1]float64
+	as[0], cs[0] = 1.0, math.Sqrt(m)
+	b := math.Sqrt(1.0 - m)
+	twoN := 1.0
+	n := 0
+	for n < agmMaxIt && math.Abs(cs[n]/as[n]) > agmTol {
+		a := as[n]
+		as[n+1], cs[n+1] = (a+b)/2.0, (a-b)/2.0
+		b = math.Sqrt(a * b)
+		twoN *= 2.0
+		n++
+	}
+
+	// backward recurrence of the amplitude
+	φ := twoN * as[n] * u
+	for ; n > 0; n-- {
+		φ = (math.Asin(cs[n]*math.Sin(φ)/as[n]) + φ) / 2.0
+	}
+	sn, cn = math.Sin(φ), math.Cos(φ)
+	dn = math.Sqrt(1.0 - m + m*cn*cn)
+	return
+}
+
+// JacobiSN computes the Jacobi elliptic function sn(u|m). See JacobiElliptic
+func JacobiSN(u, m float64) float64 {
+	sn, _, _ := JacobiElliptic(u, m)
+	return sn
+}
+
+// JacobiCN computes the Jacobi elliptic function cn(u|m). See JacobiElliptic
+func JacobiCN(u, m float64) float64 {
+	_, cn, _ := JacobiElliptic(u, m)
+	return cn
+}
+
+// JacobiDN computes the Jacobi elliptic function dn(u|m). See JacobiElliptic
+func JacobiDN(u, m float64) float64 {
+	_, _, dn := JacobiElliptic(u, m)
+	return dn
+}
@@ -0,0 +1,122 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"sync"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/fftw"
+	"github.com/cpmech/gosl/la"
+)
+
+// FFTPlan holds the FFTW plans (i.e. the precomputed twiddle factors and the ordering of the
+// operations) to compute forward and inverse discrete Fourier transforms of size N repeatedly
+//
+//   Computes (in place):
+//                      N-1         -i 2 π j k / N
+//     Forward:  X[k] =  Σ  x[j] ⋅ e
+//                      j=0
+//
+//                      N-1         +i 2 π j k / N
+//     Inverse:  Y[k] =  Σ  y[j] ⋅ e                     thus x[k] = Y[k] / N
+//                      j=0
+//
+//   NOTE: (1) the inverse operation does not divide by N
+//         (2) the data is copied into an internal buffer (allocated once) before executing the
+//             plan; thus any vector with length N can be transformed
+//         (3) an FFTPlan must not be used by several goroutines at the same time
+//
+//   Create a new object with NewFFTPlan(...) AND deallocate memory with Free()
+//
+type FFTPlan struct {
+	N    int          // size of transforms
+	buf  la.VectorC   // [N] internal buffer
+	fwd  *fftw.Plan1d // "plan" to compute forward transforms
+	inv  *fftw.Plan1d // "plan" to compute inverse transforms
+	lock sync.Mutex   // lock for plans in the cache (see Dft1d)
+}
+
+// NewFFTPlan allocates a new FFTPlan object
+//
+//   n -- size of transforms; any n ≥ 1 is accepted and the transforms are O(n⋅log(n)) (see Dft1d)
+//
+//   NOTE: remember to call Free in the end to release memory allocated by FFTW; e.g.
+//         defer o.Free()
+//
+func NewFFTPlan(n int) (o *FFTPlan) {
+	if n < 1 {
+		chk.Panic("size of transforms must be at least 1. n=%d is invalid\n", n)
+	}
+	o = new(FFTPlan)
+	o.N = n
+	o.buf = la.NewVectorC(n)
+	o.fwd = fftw.NewPlan1d(o.buf, false, false)
+	o.inv = fftw.NewPlan1d(o.buf, true, false)
+	return
+}
+
+// Free frees internal FFTW data
+func (o *FFTPlan) Free() {
+	if o.fwd != nil {
+		o.fwd.Free()
+	}
+	if o.inv != nil {
+		o.inv.Free()
+	}
+}
+
+// Forward replaces x by its discrete Fourier transform
+func (o *FFTPlan) Forward(x la.VectorC) {
+	o.execute(o.fwd, x)
+}
+
+// Inverse replaces x by its (non-normalised) inverse discrete Fourier transform
+func (o *FFTPlan) Inverse(x la.VectorC) {
+	o.execute(o.inv, x)
+}
+
+// execute copies x into the buffer, executes plan and copies the result back to x
+func (o *FFTPlan) execute(plan *fftw.Plan1d, x la.VectorC) {
+	if len(x) != o.N {
+		chk.Panic("length of x must be equal to the size of the plan. %d != %d\n", len(x), o.N)
+	}
+	copy(o.buf, x)
+	plan.Execute()
+	copy(x, o.buf)
+}
+
+// plan cache /////////////////////////////////////////////////////////////////////////////////////
+
+// fftPlanCache holds the plans used by Dft1d, keyed by size
+var fftPlanCache = struct {
+	sync.Mutex
+	plans map[int]*FFTPlan
+}{plans: make(map[int]*FFTPlan)}
+
+// cachedFFTPlan returns the plan of size n from the cache; allocating a new one if needed
+func cachedFFTPlan(n int) (plan *FFTPlan) {
+	fftPlanCache.Lock()
+	defer fftPlanCache.Unlock()
+	plan, ok := fftPlanCache.plans[n]
+	if !ok {
+		plan = NewFFTPlan(n)
+		fftPlanCache.plans[n] = plan
+	}
+	return
+}
+
+// FreeFFTPlanCache frees all plans in the cache used by Dft1d
+//   NOTE: the cache grows with each new size passed to Dft1d; it is only released by this function
+func FreeFFTPlanCache() {
+	fftPlanCache.Lock()
+	defer fftPlanCache.Unlock()
+	for n, plan := range fftPlanCache.plans {
+		plan.lock.Lock()
+		plan.Free()
+		plan.lock.Unlock()
+		delete(fftPlanCache.plans, n)
+	}
+}
@@ -74,6 +74,80 @@ func Beta(a, b float64) float64 {
 	return float64(sgnla*sgnlb*sgnlc) * math.Exp(la+lb-lc)
 }
 
+// BetaInc computes the regularized incomplete beta function
+//
+//                  1      x
+//   I_x(a,b) = ―――――――  ∫  t^(a-1) (1-t)^(b-1) dt     with 0 ≤ x ≤ 1 and a, b > 0
+//              B(a,b)   0
+//
+//  NOTE: (1) the continued fraction in [1] is evaluated with the modified Lentz's method
+//        (2) this function panics if a, b or x are invalid (including NaN)
+//
+//  Reference:
+//    [1] Press WH, Teukolsky SA, Vetterling WT, Fnannery BP (2007) Numerical Recipes: The Art of
+//        Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+//
+func BetaInc(a, b, x float64) float64 {
+	if !(a > 0) || !(b > 0) { // also rejects NaN
+		chk.Panic("BetaInc requires a > 0 and b > 0. a=%g and b=%g are invalid\n", a, b)
+	}
+	if !(x >= 0 && x <= 1) { // also rejects NaN
+		chk.Panic("BetaInc requires 0 ≤ x ≤ 1. x=%g is invalid\n", x)
+	}
+	if x == 0 || x == 1 {
+		return x
+	}
+	lab, _ := math.Lgamma(a + b)
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	bt := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log1p(-x))
+	if x < (a+1.0)/(a+b+2.0) {
+		return bt * betaContFrac(a, b, x) / a
+	}
+	return 1.0 - bt*betaContFrac(b, a, 1.0-x)/b
+}
+
+// betaContFrac evaluates the continued fraction of the incomplete beta function
+func betaContFrac(a, b, x float64) float64 {
+	const maxIt = 1000
+	const eps = 1e-16
+	const tiny = 1e-300
+	qab, qap, qam := a+b, a+1.0, a-1.0
+	c, d := 1.0, 1.0-qab*x/qap
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1.0 / d
+	h := d
+	for m := 1; m <= maxIt; m++ {
+		fm := float64(m)
+		m2 := 2.0 * fm
+		for k := 0; k < 2; k++ {
+			var aa float64
+			if k == 0 { // even step
+				aa = fm * (b - fm) * x / ((qam + m2) * (a + m2))
+			} else { // odd step
+				aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+			}
+			d = 1.0 + aa*d
+			if math.Abs(d) < tiny {
+				d = tiny
+			}
+			c = 1.0 + aa/c
+			if math.Abs(c) < tiny {
+				c = tiny
+			}
+			d = 1.0 / d
+			h *= d * c
+			if k == 1 && math.Abs(d*c-1.0) < eps {
+				return h
+			}
+		}
+	}
+	chk.Panic("continued fraction of incomplete beta function did not converge with a=%g, b=%g, x=%g\n", a, b, x)
+	return h
+}
+
 // Binomial comptues the binomial coefficient (n k)^T
 func Binomial(n, k int) float64 {
 	if n < 0 || k < 0 || k > n {
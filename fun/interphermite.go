@@ -0,0 +1,60 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// HermiteInterp implements the piecewise cubic Hermite interpolator of data with known values and
+// first derivatives at the nodes. In each interval [x[k],x[k+1]]:
+//
+//   p(x) = h00(t)⋅y[k] + h10(t)⋅Δ⋅y'[k] + h01(t)⋅y[k+1] + h11(t)⋅Δ⋅y'[k+1]
+//
+//   t = (x - x[k]) / Δ    Δ = x[k+1] - x[k]
+//
+//   h00 = 2t³ - 3t² + 1    h10 = t³ - 2t² + t    h01 = -2t³ + 3t²    h11 = t³ - t²
+//
+//  NOTE: cubic polynomials are reproduced exactly. This interpolator is useful to post-process
+//        the output of ODE solvers, since the derivatives are given by the right-hand side
+type HermiteInterp struct {
+	X    la.Vector // x-data (strictly increasing)
+	Y    la.Vector // y-data
+	Dydx la.Vector // derivatives dy/dx at the nodes
+}
+
+// NewHermiteInterp returns a new cubic Hermite interpolator
+//  xs   -- x-data; must be strictly increasing
+//  ys   -- y-data
+//  dydx -- derivatives at the nodes
+//  NOTE: the slices are not copied
+func NewHermiteInterp(xs, ys, dydx la.Vector) (o *HermiteInterp, err error) {
+	if err = checkTable(xs, ys, 2); err != nil {
+		return
+	}
+	if len(dydx) != len(xs) {
+		return nil, chk.Err("length of dydx must be equal to the length of xs. %d != %d", len(dydx), len(xs))
+	}
+	return &HermiteInterp{X: xs, Y: ys, Dydx: dydx}, nil
+}
+
+// Eval computes the interpolated value @ x
+//  NOTE: the end polynomials are used for extrapolation
+func (o *HermiteInterp) Eval(x float64) float64 {
+	k := locateInterval(o.X, x)
+	return hermiteCubic(o.X[k], o.X[k+1], o.Y[k], o.Y[k+1], o.Dydx[k], o.Dydx[k+1], x)
+}
+
+// Deriv computes the first derivative dp/dx @ x
+func (o *HermiteInterp) Deriv(x float64) float64 {
+	k := locateInterval(o.X, x)
+	return hermiteCubicDeriv(o.X[k], o.X[k+1], o.Y[k], o.Y[k+1], o.Dydx[k], o.Dydx[k+1], x)
+}
+
+// P computes the interpolated value @ x (same as Eval); thus HermiteInterp is an Interpolator
+func (o *HermiteInterp) P(x float64) float64 {
+	return o.Eval(x)
+}
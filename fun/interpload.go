@@ -0,0 +1,114 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// LoadInterpCSV loads tabulated data (x,y) from a CSV file and returns a new interpolator
+//
+//  The first two columns hold the x and y values. A header line (e.g. "x,y") is skipped if the
+//  first row is not numeric. Empty lines and lines starting with '#' are ignored. Example:
+//
+//     x,y
+//     0.0,1.0
+//     0.5,1.5
+//     1.0,3.0
+//
+//  INPUT:
+//   filename -- CSV file
+//   kind     -- InterpLinear, InterpSpline or InterpMonotone
+//
+//  OUTPUT:
+//   o   -- interpolator
+//   err -- error if the file cannot be read or the x values are not strictly increasing
+//
+func LoadInterpCSV(filename string, kind InterpKind) (o Interpolator, err error) {
+
+	// read records
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, chk.Err("cannot open file %q: %v", filename, err)
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, chk.Err("cannot read CSV file %q: %v", filename, err)
+	}
+
+	// parse values
+	var xx, yy []float64
+	for i, rec := range records {
+		if len(rec) < 2 {
+			return nil, chk.Err("line %d of file %q must have at least two columns", i+1, filename)
+		}
+		x, errx := strconv.ParseFloat(strings.TrimSpace(rec[0]), 64)
+		y, erry := strconv.ParseFloat(strings.TrimSpace(rec[1]), 64)
+		if errx != nil || erry != nil {
+			if i == 0 { // header
+				continue
+			}
+			return nil, chk.Err("line %d of file %q has invalid numbers: %q", i+1, filename, strings.Join(rec, ","))
+		}
+		xx = append(xx, x)
+		yy = append(yy, y)
+	}
+
+	// interpolator
+	o, err = NewInterpolator(kind, xx, yy)
+	if err != nil {
+		return nil, chk.Err("invalid data in file %q: %v", filename, err)
+	}
+	return
+}
+
+// LoadInterpJSON loads tabulated data (x,y) from a JSON file and returns a new interpolator
+//
+//  The file must contain an object with the "x" and "y" arrays. Example:
+//
+//     {"x": [0.0, 0.5, 1.0], "y": [1.0, 1.5, 3.0]}
+//
+//  INPUT:
+//   filename -- JSON file
+//   kind     -- InterpLinear, InterpSpline or InterpMonotone
+//
+//  OUTPUT:
+//   o   -- interpolator
+//   err -- error if the file cannot be read or the x values are not strictly increasing
+//
+func LoadInterpJSON(filename string, kind InterpKind) (o Interpolator, err error) {
+
+	// read data
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, chk.Err("cannot read file %q: %v", filename, err)
+	}
+	var data struct {
+		X []float64 `json:"x"`
+		Y []float64 `json:"y"`
+	}
+	if err = json.Unmarshal(b, &data); err != nil {
+		return nil, chk.Err("cannot parse JSON file %q: %v", filename, err)
+	}
+
+	// interpolator
+	o, err = NewInterpolator(kind, data.X, data.Y)
+	if err != nil {
+		return nil, chk.Err("invalid data in file %q: %v", filename, err)
+	}
+	return
+}
@@ -0,0 +1,362 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// InterpKind defines the kind of 1D interpolator of tabulated data
+type InterpKind int
+
+const (
+
+	// InterpLinear defines the piecewise linear interpolator
+	InterpLinear InterpKind = iota
+
+	// InterpSpline defines the natural cubic spline interpolator
+	InterpSpline
+
+	// InterpMonotone defines the monotone piecewise cubic (Fritsch-Carlson) interpolator
+	InterpMonotone
+)
+
+// Interpolator defines the interface of 1D interpolators of tabulated data
+type Interpolator interface {
+	P(x float64) float64 // computes the interpolated value @ x
+}
+
+// NewInterpolator returns a new interpolator of the tabulated data (xx,yy)
+//  kind -- InterpLinear, InterpSpline or InterpMonotone
+//  xx   -- x-data; must be strictly increasing
+//  yy   -- y-data
+func NewInterpolator(kind InterpKind, xx, yy []float64) (o Interpolator, err error) {
+	if err = checkTable(xx, yy, 2); err != nil {
+		return
+	}
+	switch kind {
+	case InterpLinear:
+		return NewDataInterp("lin", 1, xx, yy), nil
+	case InterpSpline:
+		return NewCubicSpline(xx, yy)
+	case InterpMonotone:
+		return NewMonotoneCubic(xx, yy)
+	}
+	return nil, chk.Err("kind of interpolator %d is invalid", kind)
+}
+
+// CubicSpline implements the natural cubic spline interpolator; i.e. a piecewise cubic function
+// with continuous first and second derivatives and zero second derivatives at the ends
+type CubicSpline struct {
+	X  []float64 // x-data (strictly increasing)
+	Y  []float64 // y-data
+	D2 []float64 // second derivatives at the nodes
+}
+
+// NewCubicSpline returns a new natural cubic spline interpolating (xx,yy)
+//  NOTE: xx must be strictly increasing; xx and yy are not copied
+func NewCubicSpline(xx, yy []float64) (o *CubicSpline, err error) {
+
+	// check
+	if err = checkTable(xx, yy, 2); err != nil {
+		return
+	}
+
+	// second derivatives
+	o = &CubicSpline{X: xx, Y: yy, D2: splineD2(xx, yy)}
+	return
+}
+
+// AdaptiveInterp returns a natural cubic spline interpolating f on [a,b] with nodes placed
+// adaptively. Starting with a coarse uniform grid, the midpoints of the intervals where the
+// interpolation error (sampled at 3 points in the interior of each interval) exceeds tol are
+// inserted as new nodes until the tolerance is met everywhere. Thus, the nodes are concentrated
+// near localized features of f (e.g. peaks)
+//
+//  INPUT:
+//   f   -- function to be interpolated
+//   a   -- start of the interval
+//   b   -- end of the interval; b > a
+//   tol -- tolerance on the absolute interpolation error; tol > 0
+//
+//  OUTPUT:
+//   o   -- spline with (non-uniform) nodes in o.X
+//   err -- error if the input is invalid or if the tolerance cannot be met with 100000 nodes
+//
+func AdaptiveInterp(f Ss, a, b, tol float64) (o *CubicSpline, err error) {
+
+	// check
+	if !(b > a) {
+		return nil, chk.Err("interval must satisfy b > a. a=%g and b=%g are invalid", a, b)
+	}
+	if !(tol > 0) {
+		return nil, chk.Err("tolerance must be positive. tol=%g is invalid", tol)
+	}
+
+	// coarse grid
+	n0, nmax := 9, 100000
+	xx := make([]float64, n0)
+	yy := make([]float64, n0)
+	for i := 0; i < n0; i++ {
+		xx[i] = a + (b-a)*float64(i)/float64(n0-1)
+		yy[i] = f(xx[i])
+	}
+
+	// refine
+	for {
+		o, err = NewCubicSpline(xx, yy)
+		if err != nil {
+			return
+		}
+		var xnew, ynew []float64
+		refined := false
+		for k := 0; k < len(xx)-1; k++ {
+			xnew, ynew = append(xnew, xx[k]), append(ynew, yy[k])
+			h := xx[k+1] - xx[k]
+			for _, t := range []float64{0.25, 0.5, 0.75} {
+				if math.Abs(f(xx[k]+t*h)-o.P(xx[k]+t*h)) > tol {
+					xm := xx[k] + 0.5*h
+					if !(xm > xx[k] && xm < xx[k+1]) {
+						return nil, chk.Err("cannot meet tolerance tol=%g: interval [%g,%g] is too small", tol, xx[k], xx[k+1])
+					}
+					xnew, ynew = append(xnew, xm), append(ynew, f(xm))
+					refined = true
+					break
+				}
+			}
+		}
+		if !refined {
+			return
+		}
+		xx, yy = append(xnew, xx[len(xx)-1]), append(ynew, yy[len(yy)-1])
+		if len(xx) > nmax {
+			return nil, chk.Err("cannot meet tolerance tol=%g with %d nodes", tol, nmax)
+		}
+	}
+}
+
+// P computes the interpolated value @ x
+//  NOTE: the end polynomials are used for extrapolation
+func (o *CubicSpline) P(x float64) float64 {
+	k := locateInterval(o.X, x)
+	h := o.X[k+1] - o.X[k]
+	a := (o.X[k+1] - x) / h
+	b := (x - o.X[k]) / h
+	return a*o.Y[k] + b*o.Y[k+1] + ((a*a*a-a)*o.D2[k]+(b*b*b-b)*o.D2[k+1])*(h*h)/6.0
+}
+
+// G computes the first derivative dP/dx @ x
+func (o *CubicSpline) G(x float64) float64 {
+	k := locateInterval(o.X, x)
+	h := o.X[k+1] - o.X[k]
+	a := (o.X[k+1] - x) / h
+	b := (x - o.X[k]) / h
+	return (o.Y[k+1]-o.Y[k])/h - (3.0*a*a-1.0)*h*o.D2[k]/6.0 + (3.0*b*b-1.0)*h*o.D2[k+1]/6.0
+}
+
+// MonotoneCubic implements the monotone piecewise cubic Hermite interpolator (PCHIP). The slopes
+// at the nodes are selected such that the interpolant preserves the monotonicity of the data
+// and does not overshoot
+//
+//  Reference:
+//    [1] Fritsch FN and Carlson RE (1980) Monotone piecewise cubic interpolation. SIAM Journal
+//        on Numerical Analysis, 17(2):238-246
+type MonotoneCubic struct {
+	X []float64 // x-data (strictly increasing)
+	Y []float64 // y-data
+	D []float64 // slopes at the nodes
+}
+
+// NewMonotoneCubic returns a new monotone piecewise cubic interpolating (xx,yy)
+//  NOTE: xx must be strictly increasing; xx and yy are not copied
+func NewMonotoneCubic(xx, yy []float64) (o *MonotoneCubic, err error) {
+
+	// check
+	if err = checkTable(xx, yy, 2); err != nil {
+		return
+	}
+
+	// slopes
+	o = &MonotoneCubic{X: xx, Y: yy, D: pchipSlopes(xx, yy)}
+	return
+}
+
+// P computes the interpolated value @ x
+func (o *MonotoneCubic) P(x float64) float64 {
+	k := locateInterval(o.X, x)
+	return hermiteCubic(o.X[k], o.X[k+1], o.Y[k], o.Y[k+1], o.D[k], o.D[k+1], x)
+}
+
+// G computes the first derivative dP/dx @ x
+func (o *MonotoneCubic) G(x float64) float64 {
+	k := locateInterval(o.X, x)
+	return hermiteCubicDeriv(o.X[k], o.X[k+1], o.Y[k], o.Y[k+1], o.D[k], o.D[k+1], x)
+}
+
+// ErrorEstimate estimates the interpolation error by sampling f at nTest points in the interior
+// of each interval between nodes. See interpErrorEstimate
+func (o *CubicSpline) ErrorEstimate(f Ss, nTest int) (maxErr, rmsErr float64) {
+	return interpErrorEstimate(o.X, f, o.P, nTest)
+}
+
+// ErrorEstimate estimates the interpolation error by sampling f at nTest points in the interior
+// of each interval between nodes. See interpErrorEstimate
+func (o *MonotoneCubic) ErrorEstimate(f Ss, nTest int) (maxErr, rmsErr float64) {
+	return interpErrorEstimate(o.X, f, o.P, nTest)
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// checkTable checks that xx is strictly increasing and that xx and yy have the same length
+func checkTable(xx, yy []float64, nmin int) error {
+	if len(xx) != len(yy) {
+		return chk.Err("lengths of data sets must be the same. %d != %d", len(xx), len(yy))
+	}
+	if len(xx) < nmin {
+		return chk.Err("length of data sets must be at least %d. %d is invalid", nmin, len(xx))
+	}
+	for i := 1; i < len(xx); i++ {
+		if !(xx[i] > xx[i-1]) {
+			return chk.Err("x-data must be sorted and strictly increasing. x[%d]=%g and x[%d]=%g are invalid", i-1, xx[i-1], i, xx[i])
+		}
+	}
+	return nil
+}
+
+// locateInterval returns k such that xx[k] ≤ x < xx[k+1] with 0 ≤ k ≤ len(xx)-2
+func locateInterval(xx []float64, x float64) int {
+	k := sort.SearchFloat64s(xx, x) - 1 // xx[k] < x ≤ xx[k+1]
+	if k < 0 {
+		return 0
+	}
+	if k > len(xx)-2 {
+		return len(xx) - 2
+	}
+	return k
+}
+
+// hermiteCubic computes the cubic Hermite polynomial on [xa,xb] with values ya,yb and slopes da,db
+func hermiteCubic(xa, xb, ya, yb, da, db, x float64) float64 {
+	h := xb - xa
+	t := (x - xa) / h
+	t2, t3 := t*t, t*t*t
+	h00 := 2.0*t3 - 3.0*t2 + 1.0
+	h10 := t3 - 2.0*t2 + t
+	h01 := -2.0*t3 + 3.0*t2
+	h11 := t3 - t2
+	return h00*ya + h10*h*da + h01*yb + h11*h*db
+}
+
+// hermiteCubicDeriv computes the derivative of the cubic Hermite polynomial on [xa,xb]
+func hermiteCubicDeriv(xa, xb, ya, yb, da, db, x float64) float64 {
+	h := xb - xa
+	t := (x - xa) / h
+	t2 := t * t
+	d00 := (6.0*t2 - 6.0*t) / h
+	d10 := 3.0*t2 - 4.0*t + 1.0
+	d01 := (-6.0*t2 + 6.0*t) / h
+	d11 := 3.0*t2 - 2.0*t
+	return d00*ya + d10*da + d01*yb + d11*db
+}
+
+// splineD2 computes the second derivatives at the nodes of the natural cubic spline by solving
+// the tridiagonal system with the Thomas algorithm
+func splineD2(xx, yy []float64) (d2 []float64) {
+	n := len(xx)
+	d2 = make([]float64, n)
+	u := make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		σ := (xx[i] - xx[i-1]) / (xx[i+1] - xx[i-1])
+		p := σ*d2[i-1] + 2.0
+		d2[i] = (σ - 1.0) / p
+		u[i] = (yy[i+1]-yy[i])/(xx[i+1]-xx[i]) - (yy[i]-yy[i-1])/(xx[i]-xx[i-1])
+		u[i] = (6.0*u[i]/(xx[i+1]-xx[i-1]) - σ*u[i-1]) / p
+	}
+	d2[n-1] = 0
+	for k := n - 2; k >= 0; k-- {
+		d2[k] = d2[k]*d2[k+1] + u[k]
+	}
+	return
+}
+
+// pchipSlopes computes the slopes at the nodes of the monotone cubic interpolator
+func pchipSlopes(xx, yy []float64) (d []float64) {
+
+	// secants
+	n := len(xx)
+	d = make([]float64, n)
+	h := make([]float64, n-1)
+	δ := make([]float64, n-1)
+	for k := 0; k < n-1; k++ {
+		h[k] = xx[k+1] - xx[k]
+		δ[k] = (yy[k+1] - yy[k]) / h[k]
+	}
+	if n == 2 {
+		d[0], d[1] = δ[0], δ[0]
+		return
+	}
+
+	// interior slopes: weighted harmonic mean of secants with the same sign
+	for k := 1; k < n-1; k++ {
+		if δ[k-1]*δ[k] > 0 {
+			w1 := 2.0*h[k] + h[k-1]
+			w2 := h[k] + 2.0*h[k-1]
+			d[k] = (w1 + w2) / (w1/δ[k-1] + w2/δ[k])
+		}
+	}
+
+	// end slopes: non-centered three-point formula preserving shape
+	d[0] = pchipEndSlope(h[0], h[1], δ[0], δ[1])
+	d[n-1] = pchipEndSlope(h[n-2], h[n-3], δ[n-2], δ[n-3])
+	return
+}
+
+// pchipEndSlope computes the slope at an end node of the monotone cubic interpolator
+func pchipEndSlope(h0, h1, δ0, δ1 float64) (d float64) {
+	d = ((2.0*h0+h1)*δ0 - h0*δ1) / (h0 + h1)
+	if math.Signbit(d) != math.Signbit(δ0) || δ0 == 0 {
+		return 0
+	}
+	if math.Signbit(δ0) != math.Signbit(δ1) && math.Abs(d) > math.Abs(3.0*δ0) {
+		return 3.0 * δ0
+	}
+	return
+}
+
+// interpErrorEstimate estimates the error of an interpolant p of f by sampling at nTest equally
+// spaced points in the interior of each interval between consecutive breakpoints
+//
+//   maxErr = max |f(x) - p(x)|    and    rmsErr = sqrt(Σ (f(x) - p(x))² / nsamples)
+//
+//  NOTE: the breakpoints (e.g. nodes) may be unsorted; they are not modified
+func interpErrorEstimate(breakpoints []float64, f, p Ss, nTest int) (maxErr, rmsErr float64) {
+	if nTest < 1 {
+		chk.Panic("number of test points per interval must be at least 1. nTest=%d is invalid\n", nTest)
+	}
+	xx := make([]float64, len(breakpoints))
+	copy(xx, breakpoints)
+	sort.Float64s(xx)
+	nsamples := 0
+	for k := 0; k < len(xx)-1; k++ {
+		h := (xx[k+1] - xx[k]) / float64(nTest+1)
+		if h == 0 {
+			continue
+		}
+		for i := 1; i <= nTest; i++ {
+			x := xx[k] + float64(i)*h
+			e := math.Abs(f(x) - p(x))
+			maxErr = math.Max(maxErr, e)
+			rmsErr += e * e
+			nsamples++
+		}
+	}
+	if nsamples > 0 {
+		rmsErr = math.Sqrt(rmsErr / float64(nsamples))
+	}
+	return
+}
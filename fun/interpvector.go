@@ -0,0 +1,103 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// VectorInterp implements an interpolator of vector-valued tabulated data; i.e. y(x) ∈ Rⁿ. Each
+// component (column of Y) is interpolated independently but all components share the same
+// abscissae; thus the interval containing x is located only once per evaluation
+//
+//   Y = | y0(x0)  y1(x0)  …  yn-1(x0) |
+//       | y0(x1)  y1(x1)  …  yn-1(x1) |
+//       |   ⋮       ⋮           ⋮     |
+//
+type VectorInterp struct {
+	Kind InterpKind // InterpLinear, InterpSpline or InterpMonotone
+	X    la.Vector  // x-data (strictly increasing)
+	Y    *la.Matrix // [npts][ncomp] y-data; each column is one component
+	D    *la.Matrix // [npts][ncomp] second derivatives (spline) or slopes (monotone); nil if linear
+}
+
+// NewVectorInterp returns a new interpolator of vector-valued data
+//  xs   -- [npts] x-data; must be strictly increasing
+//  ys   -- [npts][ncomp] y-data; each column is one component
+//  kind -- InterpLinear, InterpSpline or InterpMonotone
+//  NOTE: xs and ys are not copied
+func NewVectorInterp(xs la.Vector, ys *la.Matrix, kind InterpKind) (o *VectorInterp, err error) {
+
+	// check
+	if ys.M != len(xs) {
+		return nil, chk.Err("number of rows of ys must be equal to the length of xs. %d != %d", ys.M, len(xs))
+	}
+	if ys.N < 1 {
+		return nil, chk.Err("ys must have at least one column")
+	}
+	if err = checkTable(xs, xs, 2); err != nil {
+		return
+	}
+
+	// coefficients
+	o = &VectorInterp{Kind: kind, X: xs, Y: ys}
+	switch kind {
+	case InterpLinear:
+	case InterpSpline:
+		o.D = la.NewMatrix(ys.M, ys.N)
+		for j := 0; j < ys.N; j++ {
+			copy(o.D.Col(j), splineD2(xs, ys.Col(j)))
+		}
+	case InterpMonotone:
+		o.D = la.NewMatrix(ys.M, ys.N)
+		for j := 0; j < ys.N; j++ {
+			copy(o.D.Col(j), pchipSlopes(xs, ys.Col(j)))
+		}
+	default:
+		return nil, chk.Err("kind of interpolator %d is invalid", kind)
+	}
+	return
+}
+
+// Ncomp returns the number of components
+func (o *VectorInterp) Ncomp() int {
+	return o.Y.N
+}
+
+// Eval computes all interpolated components @ x
+func (o *VectorInterp) Eval(x float64) (res la.Vector) {
+	res = la.NewVector(o.Y.N)
+	o.EvalInto(res, x)
+	return
+}
+
+// EvalInto computes all interpolated components @ x and stores them in res (len(res) = Ncomp)
+func (o *VectorInterp) EvalInto(res la.Vector, x float64) {
+	k := locateInterval(o.X, x)
+	h := o.X[k+1] - o.X[k]
+	a := (o.X[k+1] - x) / h
+	b := (x - o.X[k]) / h
+	switch o.Kind {
+	case InterpLinear:
+		for j := 0; j < o.Y.N; j++ {
+			res[j] = a*o.Y.Get(k, j) + b*o.Y.Get(k+1, j)
+		}
+	case InterpSpline:
+		ca, cb := (a*a*a-a)*(h*h)/6.0, (b*b*b-b)*(h*h)/6.0
+		for j := 0; j < o.Y.N; j++ {
+			res[j] = a*o.Y.Get(k, j) + b*o.Y.Get(k+1, j) + ca*o.D.Get(k, j) + cb*o.D.Get(k+1, j)
+		}
+	case InterpMonotone:
+		b2, b3 := b*b, b*b*b
+		h00 := 2.0*b3 - 3.0*b2 + 1.0
+		h10 := (b3 - 2.0*b2 + b) * h
+		h01 := -2.0*b3 + 3.0*b2
+		h11 := (b3 - b2) * h
+		for j := 0; j < o.Y.N; j++ {
+			res[j] = h00*o.Y.Get(k, j) + h10*o.D.Get(k, j) + h01*o.Y.Get(k+1, j) + h11*o.D.Get(k+1, j)
+		}
+	}
+}
@@ -478,6 +478,17 @@ func (o *LagrangeInterp) EstimateMaxErr(nStations int, f Ss) (maxerr, xloc float
 	return
 }
 
+// ErrorEstimate estimates the interpolation error by sampling f at nTest points in the interior
+// of each interval between the nodes X (and the ends of [-1,1]). Computes:
+//
+//   maxErr = max |f(x) - I{f}(x)|    and    rmsErr = sqrt(Σ (f(x) - I{f}(x))² / nsamples)
+//
+//   NOTE: U[i] = f(x[i]) must be calculated with o.CalcU or set first
+//
+func (o *LagrangeInterp) ErrorEstimate(f Ss, nTest int) (maxErr, rmsErr float64) {
+	return interpErrorEstimate(append([]float64{-1, 1}, o.X...), f, o.I, nTest)
+}
+
 // plotting ////////////////////////////////////////////////////////////////////////////////////////
 
 // PlotLagInterpL plots cardinal polynomials ℓ
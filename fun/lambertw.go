@@ -0,0 +1,120 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// LambertW0 computes the principal branch W₀ of the Lambert W function; i.e. the solution w ≥ -1
+// of the equation
+//
+//     w ⋅ exp(w) = x     with  x ≥ -1/e
+//
+//   The solution is computed by Halley's iterations (Eq. 5.9 of [1]) starting from the series
+//   about the branch point x = -1/e (Eq. 4.22 of [1]), from log(1+x) or from the asymptotic
+//   expansion for large x (Eq. 4.19 of [1])
+//
+//   NOTE: W₀(-1/e) = -1, W₀(0) = 0 and W₀(e) = 1
+//
+//   REFERENCES:
+//   [1] Corless RM, Gonnet GH, Hare DEG, Jeffrey DJ and Knuth DE (1996) On the Lambert W
+//       function. Advances in Computational Mathematics, 5:329-359
+//
+func LambertW0(x float64) (w float64, err error) {
+	switch {
+	case math.IsNaN(x) || x < -1.0/math.E:
+		return math.NaN(), chk.Err("LambertW0 requires x ≥ -1/e. x=%g is invalid", x)
+	case x == 0 || math.IsInf(x, 1):
+		return x, nil
+	}
+	switch {
+	case x < -0.25:
+		w = lambertWbranch(x, 1)
+	case x < 3:
+		w = math.Log1p(x)
+	default:
+		l1 := math.Log(x)
+		l2 := math.Log(l1)
+		w = l1 - l2 + l2/l1
+	}
+	return lambertWhalley(x, w, "LambertW0")
+}
+
+// LambertWm1 computes the lower branch W₋₁ of the Lambert W function; i.e. the solution w ≤ -1
+// of the equation
+//
+//     w ⋅ exp(w) = x     with  -1/e ≤ x < 0
+//
+//   The solution is computed by Halley's iterations (Eq. 5.9 of [1]) starting from the series
+//   about the branch point x = -1/e (Eq. 4.22 of [1]) if x < -1/4; or by Newton's iterations
+//   starting from the asymptotic expansion for x → 0⁻ (Eq. 4.19 of [1]) otherwise
+//
+//   NOTE: (1) W₋₁(-1/e) = -1 and W₋₁(x) → -∞ as x → 0⁻
+//         (2) for -1/4 ≤ x < 0 the equation is solved in logarithmic form, w + log(-w) = log(-x),
+//             by Newton's iterations; thus W₋₁ is finite for tiny (e.g. subnormal) x; for example,
+//             W₋₁(-5e-324) ≈ -751.1
+//
+//   REFERENCES:
+//   [1] Corless RM, Gonnet GH, Hare DEG, Jeffrey DJ and Knuth DE (1996) On the Lambert W
+//       function. Advances in Computational Mathematics, 5:329-359
+//
+func LambertWm1(x float64) (w float64, err error) {
+	if math.IsNaN(x) || x < -1.0/math.E || x >= 0 {
+		return math.NaN(), chk.Err("LambertWm1 requires -1/e ≤ x < 0. x=%g is invalid", x)
+	}
+	if x < -0.25 {
+		w = lambertWbranch(x, -1)
+		return lambertWhalley(x, w, "LambertWm1")
+	}
+	l1 := math.Log(-x)
+	l2 := math.Log(-l1)
+	w = l1 - l2 + l2/l1
+	return lambertWm1log(l1, w)
+}
+
+// lambertWbranch returns the series about the branch point x = -1/e; sign = 1 gives W₀ and
+// sign = -1 gives W₋₁
+func lambertWbranch(x, sign float64) float64 {
+	p := sign * math.Sqrt(math.Max(2.0*(math.E*x+1.0), 0)) // max prevents round-off errors near -1/e
+	return -1.0 + p - p*p/3.0 + 11.0/72.0*p*p*p
+}
+
+// lambertWm1log performs Newton's iterations to solve w + log(-w) = l = log(-x) starting from
+// w ≤ -2; i.e. the equation w ⋅ exp(w) = x in logarithmic form, which avoids the underflow of
+// exp(w) as x → 0⁻ (e.g. subnormal x)
+func lambertWm1log(l, w float64) (float64, error) {
+	maxit := 50
+	eps := math.Nextafter(1, 2) - 1.0 // machine epsilon
+	for it := 0; it < maxit; it++ {
+		δ := (w + math.Log(-w) - l) / (1.0 + 1.0/w)
+		w -= δ
+		if math.Abs(δ) <= 4.0*eps*(1.0+math.Abs(w)) {
+			return w, nil
+		}
+	}
+	return w, chk.Err("LambertWm1 did not converge after %d iterations with log(-x)=%g", maxit, l)
+}
+
+// lambertWhalley performs Halley's iterations to solve w ⋅ exp(w) = x starting from w
+func lambertWhalley(x, w float64, name string) (float64, error) {
+	maxit := 50
+	eps := math.Nextafter(1, 2) - 1.0 // machine epsilon
+	for it := 0; it < maxit; it++ {
+		ew := math.Exp(w)
+		f := w*ew - x
+		if math.Abs(f) <= eps*math.Abs(x) || w == -1 { // converged on residual or branch point
+			return w, nil
+		}
+		δ := f / (ew*(w+1.0) - (w+2.0)*f/(2.0*w+2.0))
+		w -= δ
+		if math.Abs(δ) <= 4.0*eps*(1.0+math.Abs(w)) {
+			return w, nil
+		}
+	}
+	return w, chk.Err("%s did not converge after %d iterations with x=%g", name, maxit, x)
+}
@@ -0,0 +1,68 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// InverseLaplace computes the inverse Laplace transform f(t) of F(s) using the fixed Talbot
+// method with M = 32 (see InverseLaplaceTalbot)
+//
+//                 1     c+i∞
+//     f(t) =   ――――――  ∫   F(s) ⋅ exp(s⋅t) ds
+//               2πi   c-i∞
+//
+func InverseLaplace(F func(s complex128) complex128, t float64) float64 {
+	return InverseLaplaceTalbot(F, t, 32)
+}
+
+// InverseLaplaceTalbot computes the inverse Laplace transform f(t) of F(s) using the fixed Talbot
+// method of [1]. The Bromwich contour is deformed into the Talbot contour
+//
+//     s(θ) = r⋅θ⋅(cot(θ) + i)   with   -π < θ < π   and   r = 2M / (5t)
+//
+//   which is discretised by the trapezoidal rule with M points
+//
+//   Input:
+//     F -- Laplace transform F(s)
+//     t -- time; must be positive
+//     M -- number of terms (≥ 2); the truncation error decreases with M, but the round-off
+//          errors grow with exp(0.4⋅M) in double precision; values of about 20 to 32 are adequate.
+//          Larger values are needed for oscillatory responses
+//
+//   NOTE: (1) F must be analytic in the region enclosed by the contour; i.e. the singularities of
+//             F must lie to the left of the contour. This is the case if they are on the negative
+//             real axis or near the real axis; e.g. rational and exponential transforms with
+//             poles with Re(s) ≤ 0. Transforms with poles far from the real axis (e.g. highly
+//             oscillatory responses) or with Re(s) > 0 are not well inverted
+//         (2) F(s) is evaluated M times
+//
+//   REFERENCES:
+//   [1] Abate J and Valkó PP (2004) Multi-precision Laplace transform inversion. International
+//       Journal for Numerical Methods in Engineering, 60:979-993
+//
+func InverseLaplaceTalbot(F func(s complex128) complex128, t float64, M int) float64 {
+	if t <= 0 {
+		chk.Panic("time must be positive. t=%g is invalid\n", t)
+	}
+	if M < 2 {
+		chk.Panic("number of terms must be at least 2. M=%d is invalid\n", M)
+	}
+	fM := float64(M)
+	r := 2.0 * fM / (5.0 * t)
+	sum := 0.5 * real(F(complex(r, 0))) * math.Exp(r*t)
+	for k := 1; k < M; k++ {
+		θ := float64(k) * math.Pi / fM
+		cot := 1.0 / math.Tan(θ)
+		s := complex(r*θ*cot, r*θ) // s(θ) = r⋅θ⋅(cot(θ) + i)
+		σ := θ + (θ*cot-1.0)*cot   // s'(θ) = i⋅r⋅(1 + i⋅σ(θ))
+		sum += real(cmplx.Exp(s*complex(t, 0)) * F(s) * complex(1, σ))
+	}
+	return r / fM * sum
+}
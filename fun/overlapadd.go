@@ -0,0 +1,151 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// OverlapAdd filters a stream of samples x by an FIR kernel h using FFT-based convolution with
+// the overlap-add method. The stream is processed in blocks of at most B samples; each block is
+// convolved with the kernel by means of FFTs of size N ≥ B + M - 1 (M = len(h)) and the last
+// M - 1 values of the convolution (the overlap) are added to the next outputs:
+//
+//             M-1
+//     y[n] =   Σ  h[k] ⋅ x[n-k]
+//             k=0
+//
+//   NOTE: (1) the spectrum of the kernel, the FFT plans and all buffers are allocated by
+//             NewOverlapAdd; thus Process does not allocate memory
+//         (2) the output returned by Process and Flush is an internal array that is overwritten
+//             by the next call; make a copy if it has to be kept
+//         (3) the concatenation of the outputs of Process followed by the output of Flush is
+//             the full convolution of the stream with the kernel; with length nx + M - 1
+//
+//   Create a new object with NewOverlapAdd(...) AND deallocate memory with Free()
+//
+type OverlapAdd struct {
+	M int // length of kernel
+	B int // max number of samples in each block
+	N int // size of the FFTs: the smallest power of 2 such that N ≥ B + M - 1
+
+	// internal
+	plan *FFTPlan   // FFT plan of size N
+	hhat la.VectorC // [N] spectrum of the kernel
+	work la.VectorC // [N] workspace for the convolution of each block
+	tail la.Vector  // [M-1] overlap to be added to the next outputs
+	out  la.Vector  // [max(B,M-1)] output
+}
+
+// NewOverlapAdd allocates a new OverlapAdd object
+//
+//   kernel    -- [M] FIR filter coefficients h
+//   blockSize -- max number of samples in each block B passed to Process
+//
+//   NOTE: remember to call Free in the end to release memory allocated by FFTW; e.g.
+//         defer o.Free()
+//
+func NewOverlapAdd(kernel la.Vector, blockSize int) (o *OverlapAdd) {
+	if len(kernel) < 1 {
+		chk.Panic("kernel must have at least one coefficient\n")
+	}
+	if blockSize < 1 {
+		chk.Panic("block size must be at least 1. blockSize=%d is invalid\n", blockSize)
+	}
+	o = new(OverlapAdd)
+	o.M = len(kernel)
+	o.B = blockSize
+	o.N = 1
+	for o.N < o.B+o.M-1 {
+		o.N *= 2
+	}
+	o.plan = NewFFTPlan(o.N)
+	o.hhat = la.NewVectorC(o.N)
+	for k := 0; k < o.M; k++ {
+		o.hhat[k] = complex(kernel[k], 0)
+	}
+	o.plan.Forward(o.hhat)
+	o.work = la.NewVectorC(o.N)
+	o.tail = la.NewVector(o.M - 1)
+	nout := o.B
+	if o.M-1 > nout {
+		nout = o.M - 1
+	}
+	o.out = la.NewVector(nout)
+	return
+}
+
+// Free frees internal FFTW data
+func (o *OverlapAdd) Free() {
+	if o.plan != nil {
+		o.plan.Free()
+	}
+}
+
+// Reset clears the overlap; i.e. the next block starts a new stream
+func (o *OverlapAdd) Reset() {
+	o.tail.Fill(0)
+}
+
+// Process filters the next block of samples
+//
+//   Input:
+//     block -- [L] samples with 1 ≤ L ≤ B
+//
+//   Output:
+//     y -- [L] filtered values corresponding to the samples in block (internal array)
+//
+func (o *OverlapAdd) Process(block la.Vector) (y la.Vector) {
+
+	// check
+	L := len(block)
+	if L < 1 || L > o.B {
+		chk.Panic("number of samples in block must satisfy 1 ≤ L ≤ %d. L=%d is invalid\n", o.B, L)
+	}
+
+	// convolution of block: IFFT(FFT(block) ⋅ FFT(kernel)) / N
+	o.work.Fill(0)
+	for i := 0; i < L; i++ {
+		o.work[i] = complex(block[i], 0)
+	}
+	o.plan.Forward(o.work)
+	for k := 0; k < o.N; k++ {
+		o.work[k] *= o.hhat[k]
+	}
+	o.plan.Inverse(o.work)
+	den := float64(o.N)
+
+	// output: first L values plus the overlap of the previous blocks
+	y = o.out[:L]
+	for i := 0; i < L; i++ {
+		y[i] = real(o.work[i]) / den
+		if i < o.M-1 {
+			y[i] += o.tail[i]
+		}
+	}
+
+	// new overlap: remaining values of the convolution plus the remaining old overlap
+	for i := 0; i < o.M-1; i++ {
+		o.tail[i] = real(o.work[L+i]) / den
+		if L+i < o.M-1 {
+			o.tail[i] += o.tail[L+i]
+		}
+	}
+	return
+}
+
+// Flush returns the overlap; i.e. the last M - 1 values of the full convolution, and resets
+// the object for a new stream
+//
+//   Output:
+//     y -- [M-1] last values of the convolution (internal array)
+//
+func (o *OverlapAdd) Flush() (y la.Vector) {
+	y = o.out[:o.M-1]
+	copy(y, o.tail)
+	o.Reset()
+	return
+}
@@ -0,0 +1,130 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// polygammaBernoulli holds the Bernoulli numbers B2, B4, …, B16 used in the asymptotic series
+var polygammaBernoulli = []float64{
+	1.0 / 6.0, -1.0 / 30.0, 1.0 / 42.0, -1.0 / 30.0, 5.0 / 66.0, -691.0 / 2730.0, 7.0 / 6.0, -3617.0 / 510.0,
+}
+
+// Digamma computes the digamma function ψ(x) = d ln Γ(x) / dx = Γ'(x) / Γ(x)
+//
+//   The recurrence ψ(x) = ψ(x+1) - 1/x is used to shift x to x ≥ 10; then, the asymptotic
+//   series (Eq. 6.3.18 of [1]) is evaluated:
+//
+//                        1       ∞    B_2k
+//     ψ(x) ≈ ln(x) - ――――― -  Σ  ――――――――――
+//                      2 x     k=1  2k x^(2k)
+//
+//   NOTE: (1) for x < 0, the reflection formula ψ(x) = ψ(1-x) - π / tan(π x) is used
+//         (2) NaN is returned at the poles x = 0, -1, -2, …
+//
+//   REFERENCES:
+//   [1] Abramowitz M and Stegun IA (1972) Handbook of Mathematical Functions with Formulas,
+//       Graphs, and Mathematical Tables. Dover, New York. 1046p.
+//
+func Digamma(x float64) float64 {
+	switch {
+	case math.IsNaN(x) || math.IsInf(x, -1):
+		return math.NaN()
+	case math.IsInf(x, 1):
+		return x
+	case x <= 0 && x == math.Floor(x):
+		return math.NaN()
+	case x < 0:
+		return Digamma(1.0-x) - math.Pi/math.Tan(math.Pi*x)
+	}
+	res := 0.0
+	for x < 10 {
+		res -= 1.0 / x
+		x++
+	}
+	x2 := 1.0 / (x * x)
+	pow := x2
+	sum := 0.0
+	for k, b := range polygammaBernoulli {
+		sum += b / float64(2*k+2) * pow
+		pow *= x2
+	}
+	return res + math.Log(x) - 0.5/x - sum
+}
+
+// Polygamma computes the polygamma function of order n; i.e. the n-th derivative of the digamma
+// function ψ⁽ⁿ⁾(x) = dⁿψ(x) / dxⁿ. For instance, n = 1 gives the trigamma function
+//
+//                             ∞       1
+//     ψ⁽ⁿ⁾(x) = (-1)ⁿ⁺¹ n!  Σ  ―――――――――――     (n ≥ 1)
+//                            k=0  (x + k)ⁿ⁺¹
+//
+//   The recurrence ψ⁽ⁿ⁾(x) = ψ⁽ⁿ⁾(x+1) - (-1)ⁿ n! / xⁿ⁺¹ is used to shift x to x ≥ 10 + 2n;
+//   then, the asymptotic series (Eq. 6.4.11 of [1]) is evaluated:
+//
+//                          (n-1)!     n!       ∞        (2k+n-1)!
+//     ψ⁽ⁿ⁾(x) ≈ (-1)ⁿ⁺¹ [ ―――――― + ―――――――― +  Σ  B_2k ――――――――――――― ]
+//                           xⁿ     2 xⁿ⁺¹     k=1     (2k)! x^(2k+n)
+//
+//   NOTE: (1) n = 0 gives the digamma function (see Digamma)
+//         (2) at the poles x = 0, -1, -2, …, +Inf is returned if n is odd and NaN if n is even
+//         (3) for x < 0, the number of terms of the recurrence is proportional to |x|
+//
+//   REFERENCES:
+//   [1] Abramowitz M and Stegun IA (1972) Handbook of Mathematical Functions with Formulas,
+//       Graphs, and Mathematical Tables. Dover, New York. 1046p.
+//
+func Polygamma(n int, x float64) float64 {
+	if n < 0 {
+		chk.Panic("order of polygamma function must be non-negative. n=%d is invalid\n", n)
+	}
+	if n == 0 {
+		return Digamma(x)
+	}
+	switch {
+	case math.IsNaN(x) || math.IsInf(x, -1):
+		return math.NaN()
+	case math.IsInf(x, 1):
+		return 0
+	case x <= 0 && x == math.Floor(x):
+		if n%2 == 1 {
+			return math.Inf(1)
+		}
+		return math.NaN()
+	}
+
+	// (-1)ⁿ⁺¹ and n!
+	sign := -1.0
+	if n%2 == 1 {
+		sign = 1.0
+	}
+	nf := float64(n)
+	fact := math.Gamma(nf + 1.0)
+
+	// recurrence
+	res := 0.0
+	for xmin := 10.0 + 2.0*nf; x < xmin; x++ {
+		res += fact / math.Pow(x, nf+1.0)
+	}
+
+	// asymptotic series
+	xn := math.Pow(x, nf)
+	res += fact/(nf*xn) + fact/(2.0*xn*x)
+	x2 := 1.0 / (x * x)
+	c := fact * (nf + 1.0) / 2.0 * x2 / xn // (2k+n-1)! / ((2k)! x^(2k+n)) with k = 1
+	for k, b := range polygammaBernoulli {
+		term := b * c
+		res += term
+		if math.Abs(term) < 1e-17*math.Abs(res) {
+			break
+		}
+		m := float64(2*k + 2) // 2k
+		c *= (m + nf) * (m + nf + 1.0) / ((m + 1.0) * (m + 2.0)) * x2
+	}
+	return sign * res
+}
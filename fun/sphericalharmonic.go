@@ -0,0 +1,114 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// SphericalHarmonic computes the (complex) spherical harmonic Yₗᵐ(θ, φ) of degree l and order m
+// with the Condon-Shortley phase; i.e. the orthonormal functions on the unit sphere
+//
+//     Yₗᵐ(θ,φ) = (-1)ᵐ Nₗᵐ Pₗᵐ(cos θ) exp(i m φ)     with  Nₗᵐ = √((2l+1)/(4π) ⋅ (l-m)!/(l+m)!)
+//
+//   where Pₗᵐ is the associated Legendre function without the (-1)ᵐ factor (m ≥ 0) and
+//
+//     Yₗ⁻ᵐ(θ,φ) = (-1)ᵐ conj(Yₗᵐ(θ,φ))
+//
+//   Input:
+//     l -- degree (l ≥ 0)
+//     m -- order (-l ≤ m ≤ l)
+//     θ -- polar angle (colatitude) in [0, π]
+//     φ -- azimuthal angle (longitude)
+//
+//   NOTE: (1) the normalized associated Legendre functions are computed by the stable recurrences
+//             of Section 6.7 of [1]; thus, no factorial is evaluated and degrees l up to a few
+//             hundreds can be used without overflow
+//         (2) this function panics if l < 0 or |m| > l
+//
+//   REFERENCES:
+//   [1] Press WH, Teukolsky SA, Vetterling WT, Fnannery BP (2007) Numerical Recipes:
+//       The Art of Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+//
+func SphericalHarmonic(l, m int, θ, φ float64) complex128 {
+	p := sphNormLegendre(l, m, θ)
+	if m > 0 {
+		p *= NegOnePowN(m) // Condon-Shortley phase; cancelled by (-1)ᵐ in Yₗ⁻ᵐ if m < 0
+	}
+	mφ := float64(m) * φ
+	return complex(p*math.Cos(mφ), p*math.Sin(mφ))
+}
+
+// SphericalHarmonicReal computes the real spherical harmonic Yₗₘ(θ, φ) of degree l and order m;
+// i.e. the orthonormal real functions on the unit sphere defined by
+//
+//                ⎧ √2 (-1)ᵐ Im(Yₗ^|m|)  =  √2 Nₗ^|m| Pₗ^|m|(cos θ) sin(|m| φ)     if m < 0
+//     Yₗₘ(θ,φ) = ⎨ Yₗ⁰                  =     Nₗ⁰ Pₗ⁰(cos θ)                      if m = 0
+//                ⎩ √2 (-1)ᵐ Re(Yₗᵐ)     =  √2 Nₗᵐ Pₗᵐ(cos θ) cos(m φ)             if m > 0
+//
+//   where Yₗᵐ is the complex spherical harmonic (see SphericalHarmonic), Nₗᵐ is the normalization
+//   factor and Pₗᵐ is the associated Legendre function without the (-1)ᵐ factor. For instance,
+//   Y₁₋₁, Y₁₀ and Y₁₁ are proportional to y, z and x, respectively
+//
+//   NOTE: this function panics if l < 0 or |m| > l
+//
+func SphericalHarmonicReal(l, m int, θ, φ float64) float64 {
+	p := sphNormLegendre(l, m, θ)
+	switch {
+	case m < 0:
+		return math.Sqrt2 * p * math.Sin(float64(-m)*φ)
+	case m > 0:
+		return math.Sqrt2 * p * math.Cos(float64(m)*φ)
+	}
+	return p
+}
+
+// sphNormLegendre computes the normalized associated Legendre function Nₗ^|m| Pₗ^|m|(cos θ)
+// without the (-1)ᵐ factor using the recurrences of Section 6.7 of [1] (see SphericalHarmonic):
+//
+//     P̄ₘᵐ   = √((2m+1)/(4π) ⋅ ∏ᵢ₌₁ᵐ (2i-1)/(2i)) ⋅ sinᵐθ
+//     P̄ₘ₊₁ᵐ = √(2m+3) ⋅ cos θ ⋅ P̄ₘᵐ
+//     P̄ₗᵐ   = aₗ ⋅ (cos θ ⋅ P̄ₗ₋₁ᵐ - P̄ₗ₋₂ᵐ / aₗ₋₁)     with  aₗ = √((4l²-1)/(l²-m²))
+//
+func sphNormLegendre(l, m int, θ float64) float64 {
+	if l < 0 || m < -l || m > l {
+		chk.Panic("degree and order of spherical harmonics must satisfy l ≥ 0 and |m| ≤ l. l=%d and m=%d are invalid\n", l, m)
+	}
+	if m < 0 {
+		m = -m
+	}
+	x, s := math.Cos(θ), math.Abs(math.Sin(θ))
+
+	// P̄ₘᵐ
+	pmm := 1.0
+	for i := 1; i <= m; i++ {
+		fi := float64(i)
+		pmm *= math.Sqrt((2.0*fi-1.0)/(2.0*fi)) * s
+	}
+	fm := float64(m)
+	pmm *= math.Sqrt((2.0*fm + 1.0) / (4.0 * math.Pi))
+	if l == m {
+		return pmm
+	}
+
+	// P̄ₘ₊₁ᵐ
+	pll := math.Sqrt(2.0*fm+3.0) * x * pmm
+	if l == m+1 {
+		return pll
+	}
+
+	// P̄ₗᵐ
+	m2 := fm * fm
+	aold := math.Sqrt(2.0*fm + 3.0) // aₘ₊₁
+	for k := m + 2; k <= l; k++ {
+		fk := float64(k)
+		a := math.Sqrt((4.0*fk*fk - 1.0) / (fk*fk - m2))
+		pmm, pll = pll, a*(x*pll-pmm/aold)
+		aold = a
+	}
+	return pll
+}
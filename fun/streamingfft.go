@@ -0,0 +1,114 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/fftw"
+	"github.com/cpmech/gosl/la"
+)
+
+// StreamingFFT computes the discrete Fourier transform of a stream of real samples over a sliding
+// window. The last N=window samples are kept in a circular buffer and, once the buffer is full, the
+// spectrum of the windowed data is computed every hop samples:
+//
+//                      N-1                    -i 2 π j k / N
+//            X[k] =     Σ  w[j] ⋅ x[m-N+1+j] ⋅ e                  with m = index of latest sample
+//                      j=0
+//
+//            w[j] = (1 - cos(2 π j / N)) / 2                       (Hann window)
+//
+//   NOTE: (1) all memory, including the FFTW plan, is allocated by NewStreamingFFT; thus Push
+//             does not allocate memory
+//         (2) the spectrum returned by Push is an internal array that is overwritten by the
+//             next transform; make a copy if it has to be kept
+//         (3) the frequency corresponding to X[k] is k⋅fs/N where fs is the sampling frequency.
+//             Only X[0...N/2] are relevant because x is real
+//
+//   Create a new object with NewStreamingFFT(...) AND deallocate memory with Free()
+//
+type StreamingFFT struct {
+	N   int        // window size (number of samples in each transform)
+	Hop int        // number of samples between transforms
+	W   la.Vector  // [N] window function (Hann) [may be modified before pushing samples]
+	X   la.VectorC // [N] latest spectrum
+
+	// internal
+	buf   la.Vector    // [N] circular buffer with latest samples
+	pos   int          // position of the next sample in buf
+	count int          // number of samples pushed so far
+	plan  *fftw.Plan1d // "plan" to compute the spectrum in X
+}
+
+// NewStreamingFFT allocates a new StreamingFFT object
+//
+//   window -- number of samples in each transform; ideally power of 2, e.g. N = 2ⁿ
+//   hop    -- number of samples between transforms; 1 ≤ hop ≤ window
+//
+//   NOTE: remember to call Free in the end to release memory allocated by FFTW; e.g.
+//         defer o.Free()
+//
+func NewStreamingFFT(window, hop int) (o *StreamingFFT) {
+	if window < 2 {
+		chk.Panic("window must be at least 2. window=%d is invalid\n", window)
+	}
+	if hop < 1 || hop > window {
+		chk.Panic("hop must satisfy 1 ≤ hop ≤ window. hop=%d is invalid with window=%d\n", hop, window)
+	}
+	o = new(StreamingFFT)
+	o.N = window
+	o.Hop = hop
+	o.W = la.NewVector(o.N)
+	for j := 0; j < o.N; j++ {
+		o.W[j] = (1.0 - math.Cos(2.0*math.Pi*float64(j)/float64(o.N))) / 2.0
+	}
+	o.X = la.NewVectorC(o.N)
+	o.buf = la.NewVector(o.N)
+	o.plan = fftw.NewPlan1d(o.X, false, false)
+	return
+}
+
+// Free frees internal FFTW data
+func (o *StreamingFFT) Free() {
+	if o.plan != nil {
+		o.plan.Free()
+	}
+}
+
+// Reset clears the buffer; thus the next spectrum is computed after N new samples
+func (o *StreamingFFT) Reset() {
+	o.buf.Fill(0)
+	o.pos = 0
+	o.count = 0
+}
+
+// Push adds a sample to the stream
+//
+//   Output:
+//     spectrum -- the latest spectrum X (internal array) if ready; otherwise nil
+//     ready    -- a new spectrum has been computed; i.e. the buffer is full and hop samples have
+//                 been pushed since the previous transform
+//
+func (o *StreamingFFT) Push(sample float64) (spectrum la.VectorC, ready bool) {
+
+	// buffer sample
+	o.buf[o.pos] = sample
+	o.pos = (o.pos + 1) % o.N
+	o.count++
+	if o.count < o.N || (o.count-o.N)%o.Hop != 0 {
+		return nil, false
+	}
+
+	// windowed data from oldest to newest sample; o.pos now points to the oldest one
+	for j := 0; j < o.N; j++ {
+		o.X[j] = complex(o.W[j]*o.buf[(o.pos+j)%o.N], 0)
+	}
+
+	// transform
+	o.plan.Execute()
+	return o.X, true
+}
@@ -0,0 +1,49 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/fun/fftw"
+	"github.com/cpmech/gosl/la"
+)
+
+var (
+	benchFFTdata la.VectorC
+)
+
+func init() {
+	benchFFTdata = la.NewVectorMappedC(1024, func(j int) complex128 { return complex(math.Sin(float64(j)), 0) })
+}
+
+func BenchmarkFFTunplanned(b *testing.B) {
+	x := benchFFTdata.GetCopy()
+	for i := 0; i < b.N; i++ {
+		copy(x, benchFFTdata)
+		plan := fftw.NewPlan1d(x, false, false)
+		plan.Execute()
+		plan.Free()
+	}
+}
+
+func BenchmarkFFTplanned(b *testing.B) {
+	x := benchFFTdata.GetCopy()
+	plan := NewFFTPlan(len(x))
+	defer plan.Free()
+	for i := 0; i < b.N; i++ {
+		copy(x, benchFFTdata)
+		plan.Forward(x)
+	}
+}
+
+func BenchmarkFFTdft1d(b *testing.B) {
+	x := benchFFTdata.GetCopy()
+	for i := 0; i < b.N; i++ {
+		copy(x, benchFFTdata)
+		Dft1d(x, false)
+	}
+}
@@ -630,3 +630,34 @@ func TestChebyInterp08(tst *testing.T) {
 	io.Pf("use D1: err(D2{f}) = %v\n", maxDiff)
 	chk.Float64(tst, "err(D2{f})", 1e-12, maxDiff, 0)
 }
+
+func TestChebyInterp09(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ChebyInterp09. error estimate")
+
+	// smooth function
+	f := func(x float64) float64 {
+		return math.Cos(math.Exp(2.0 * x))
+	}
+
+	// errors decrease with the degree
+	for _, gauss := range []bool{true, false} {
+		prev := math.Inf(1)
+		for _, N := range []int{8, 16, 32} {
+			o := NewChebyInterp(N, gauss)
+			o.CalcCoefI(f)
+			maxErr, rmsErr := o.ErrorEstimate(f, 5)
+			io.Pf("gauss = %v  N = %2d  max = %.3e  rms = %.3e\n", gauss, N, maxErr, rmsErr)
+			if rmsErr > maxErr {
+				tst.Errorf("rms error must not be greater than max error\n")
+				return
+			}
+			if maxErr >= prev {
+				tst.Errorf("error estimate must decrease with the degree\n")
+				return
+			}
+			prev = maxErr
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestChebyRoots01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ChebyRoots01. all roots of functions on an interval")
+
+	// polynomial with known roots
+	f := func(x float64) float64 { return (x - 0.5) * (x + 1.5) * (x - 2) }
+	roots, err := RootsOnInterval(f, -2, 3, 10)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("roots = %v\n", roots)
+	chk.Array(tst, "polynomial", 1e-12, roots, []float64{-1.5, 0.5, 2})
+
+	// function with several roots: sin(x) in [-1, 10] ⇒ 0, π, 2π, 3π
+	roots, err = RootsOnInterval(math.Sin, -1, 10, 40)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("roots = %v\n", roots)
+	chk.Array(tst, "sin", 1e-12, roots, []float64{0, math.Pi, 2 * math.Pi, 3 * math.Pi})
+
+	// oscillatory function: cos(20⋅x) in [0,1] ⇒ (2k+1)π/40 < 1
+	roots, err = RootsOnInterval(func(x float64) float64 { return math.Cos(20 * x) }, 0, 1, 60)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	var correct []float64
+	for k := 0; float64(2*k+1)*math.Pi/40 < 1; k++ {
+		correct = append(correct, float64(2*k+1)*math.Pi/40)
+	}
+	io.Pforan("roots = %v\n", roots)
+	chk.Array(tst, "cos(20x)", 1e-10, roots, correct)
+
+	// no roots
+	roots, err = RootsOnInterval(func(x float64) float64 { return 2 + x*x }, -1, 1, 10)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Int(tst, "number of roots", len(roots), 0)
+
+	// errors
+	if _, err = RootsOnInterval(f, 1, 0, 10); err == nil {
+		tst.Errorf("invalid interval should cause an error\n")
+	}
+	if _, err = RootsOnInterval(f, 0, 1, 0); err == nil {
+		tst.Errorf("invalid degree should cause an error\n")
+	}
+	if _, err = RootsOnInterval(func(x float64) float64 { return 0 }, 0, 1, 5); err == nil {
+		tst.Errorf("zero function should cause an error\n")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestContinuedFraction01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ContinuedFraction01. tan, arctan and constants")
+
+	// tan(x) = x / (1 - x² / (3 - x² / (5 - …)))
+	for _, x := range []float64{-1.5, -0.3, 0, 0.1, 1, math.Pi / 4, 1.5} {
+		f, err := ContinuedFraction(func(n int) float64 {
+			if n == 1 {
+				return x
+			}
+			return -x * x
+		}, func(n int) float64 {
+			if n == 0 {
+				return 0
+			}
+			return float64(2*n - 1)
+		}, 1e-15, 100)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		io.Pforan("tan(%5.2f) = %23.15e\n", x, f)
+		chk.Float64(tst, io.Sf("tan(%g)", x), 1e-14, f, math.Tan(x))
+	}
+
+	// π = 4 ⋅ arctan(1) = 4 / (1 + 1² / (3 + 2² / (5 + 3² / (7 + …))))
+	f, err := ContinuedFraction(func(n int) float64 {
+		if n == 1 {
+			return 4
+		}
+		return float64((n - 1) * (n - 1))
+	}, func(n int) float64 {
+		if n == 0 {
+			return 0
+		}
+		return float64(2*n - 1)
+	}, 1e-15, 100)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "π", 1e-14, f, math.Pi)
+
+	// golden ratio = 1 + 1 / (1 + 1 / (1 + …))
+	one := func(n int) float64 { return 1 }
+	f, err = ContinuedFraction(one, one, 1e-15, 100)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "golden ratio", 1e-15, f, (1+math.Sqrt(5))/2)
+
+	// √2 = 1 + 1 / (2 + 1 / (2 + …))
+	f, err = ContinuedFraction(one, func(n int) float64 {
+		if n == 0 {
+			return 1
+		}
+		return 2
+	}, 1e-15, 100)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "√2", 1e-15, f, math.Sqrt2)
+
+	// not enough terms
+	if _, err = ContinuedFraction(one, one, 1e-15, 5); err == nil {
+		tst.Errorf("continued fraction should not converge with 5 terms\n")
+	} else {
+		io.Pf("error: %v\n", err)
+	}
+}
+
+func TestContinuedFraction02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ContinuedFraction02. incomplete gamma function: erfc")
+
+	// Γ(a,x) = exp(-x)⋅xᵃ / (x + 1 - a - 1⋅(1-a) / (x + 3 - a - 2⋅(2-a) / (x + 5 - a - …)))
+	// with a = ½ and x = z² gives erfc(z) = Γ(½,z²)/√π
+	α := 0.5
+	for _, z := range []float64{1, 1.5, 2, 3, 5} {
+		x := z * z
+		cf, err := ContinuedFraction(func(n int) float64 {
+			if n == 1 {
+				return 1
+			}
+			m := float64(n - 1)
+			return -m * (m - α)
+		}, func(n int) float64 {
+			if n == 0 {
+				return 0
+			}
+			return x + float64(2*n-1) - α
+		}, 1e-15, 200)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		erfc := math.Exp(-x) * math.Pow(x, α) * cf / math.Sqrt(math.Pi)
+		io.Pforan("erfc(%g) = %23.15e\n", z, erfc)
+		chk.Float64(tst, io.Sf("erfc(%g)", z), 1e-14*math.Erfc(z), erfc, math.Erfc(z)) // relative error
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestDual01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Dual01. derivatives of elementary functions")
+
+	tests := []struct {
+		name string
+		f    func(x Dual) Dual
+		g    func(x float64) float64
+		x    float64
+	}{
+		{"x⋅sin(x)", func(x Dual) Dual { return x.Mul(x.Sin()) }, func(x float64) float64 { return math.Sin(x) + x*math.Cos(x) }, 2},
+		{"cos(x)/x", func(x Dual) Dual { return x.Cos().Div(x) }, func(x float64) float64 { return -math.Sin(x)/x - math.Cos(x)/(x*x) }, 0.7},
+		{"tan(x)", func(x Dual) Dual { return x.Tan() }, func(x float64) float64 { return 1 / math.Pow(math.Cos(x), 2) }, 0.3},
+		{"exp(-x²)", func(x Dual) Dual { return x.Mul(x).Neg().Exp() }, func(x float64) float64 { return -2 * x * math.Exp(-x*x) }, 1.1},
+		{"log(3x+1)", func(x Dual) Dual { return x.Scale(3).AddScalar(1).Log() }, func(x float64) float64 { return 3 / (3*x + 1) }, 0.5},
+		{"√x - x", func(x Dual) Dual { return x.Sqrt().Sub(x) }, func(x float64) float64 { return 0.5/math.Sqrt(x) - 1 }, 4},
+		{"x^2.5", func(x Dual) Dual { return x.Pow(2.5) }, func(x float64) float64 { return 2.5 * math.Pow(x, 1.5) }, 1.3},
+		{"x^x", func(x Dual) Dual { return x.PowDual(x) }, func(x float64) float64 { return math.Pow(x, x) * (math.Log(x) + 1) }, 1.7},
+		{"|x|", func(x Dual) Dual { return x.Abs() }, func(x float64) float64 { return -1 }, -2},
+		{"tanh(x)", func(x Dual) Dual { return x.Tanh() }, func(x float64) float64 { return 1 - math.Pow(math.Tanh(x), 2) }, 0.4},
+		{"atan(x)", func(x Dual) Dual { return x.Atan() }, func(x float64) float64 { return 1 / (1 + x*x) }, 2.2},
+	}
+	for _, t := range tests {
+		val, deriv := Derivative(t.f, t.x)
+		io.Pforan("%10s: f = %23.15e  df/dx = %23.15e\n", t.name, val, deriv)
+		chk.Float64(tst, t.name, 1e-14, deriv, t.g(t.x))
+	}
+
+	// values
+	x := NewDual(2, 1)
+	chk.Float64(tst, "x⋅sin(x)", 1e-15, x.Mul(x.Sin()).V, 2*math.Sin(2))
+	chk.Float64(tst, "const", 1e-15, DualConst(3).Mul(x).D, 3)
+	chk.Float64(tst, "x^0", 1e-15, x.Pow(0).D, 0)
+}
+
+func TestDual02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Dual02. gradients")
+
+	// Rosenbrock function
+	rosen := func(x []Dual) Dual {
+		one := DualConst(1)
+		a := one.Sub(x[0])
+		b := x[1].Sub(x[0].Mul(x[0]))
+		return a.Mul(a).Add(b.Mul(b).Scale(100))
+	}
+	rosenGrad := func(x la.Vector) la.Vector {
+		return []float64{-2*(1-x[0]) - 400*x[0]*(x[1]-x[0]*x[0]), 200 * (x[1] - x[0]*x[0])}
+	}
+
+	// f(x) = Σ exp(xᵢ) ⋅ sin(xᵢ₊₁)
+	chain := func(x []Dual) (res Dual) {
+		for i := 0; i < len(x)-1; i++ {
+			res = res.Add(x[i].Exp().Mul(x[i+1].Sin()))
+		}
+		return
+	}
+	chainGrad := func(x la.Vector) (g la.Vector) {
+		g = la.NewVector(len(x))
+		for i := 0; i < len(x)-1; i++ {
+			g[i] += math.Exp(x[i]) * math.Sin(x[i+1])
+			g[i+1] += math.Exp(x[i]) * math.Cos(x[i+1])
+		}
+		return
+	}
+
+	// check
+	for _, x := range []la.Vector{{-1.2, 1}, {0.5, 0.5}, {2, -3}} {
+		g := Gradient(rosen, x)
+		io.Pforan("rosen: x = %v  g = %v\n", x, g)
+		chk.Array(tst, "rosen", 1e-12, g, rosenGrad(x))
+	}
+	x := la.NewVectorSlice(utl.LinSpace(-1, 1, 7))
+	chk.Array(tst, "chain", 1e-14, Gradient(chain, x), chainGrad(x))
+}
@@ -0,0 +1,179 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestEllipticAgm01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("EllipticAgm01. complete integrals K(m) and E(m)")
+
+	// known values
+	chk.Float64(tst, "K(0)", 1e-15, EllipticK(0), math.Pi/2)
+	chk.Float64(tst, "E(0)", 1e-15, EllipticE(0), math.Pi/2)
+	chk.Float64(tst, "K(0.5)", 1e-15, EllipticK(0.5), 1.854074677301372)
+	chk.Float64(tst, "E(0.5)", 1e-15, EllipticE(0.5), 1.350643881047675)
+	chk.Float64(tst, "K(0.9)", 1e-15, EllipticK(0.9), 2.578092113348173)
+	chk.Float64(tst, "E(0.9)", 1e-15, EllipticE(0.9), 1.104774732704073)
+	chk.Float64(tst, "K(-1)", 1e-15, EllipticK(-1), 1.311028777146060)
+	chk.Float64(tst, "E(1)", 1e-15, EllipticE(1), 1)
+	if !math.IsInf(EllipticK(1), 1) {
+		tst.Errorf("K(1) should be +Inf\n")
+		return
+	}
+
+	// compare with Carlson's functions and check Legendre's relation E K' + E' K - K K' = π/2
+	for _, m := range []float64{1e-12, 0.01, 0.1, 0.3, 0.5, 0.7, 0.9, 0.99, 0.999999} {
+		K, E := EllipticK(m), EllipticE(m)
+		Kc, Ec := EllipticK(1-m), EllipticE(1-m)
+		io.Pforan("m = %8g  K = %23.15e  E = %23.15e\n", m, K, E)
+		if m < 0.999 { // Elliptic1 and Elliptic2 lose accuracy in 1-k² with k = √m
+			chk.Float64(tst, io.Sf("K(%g)", m), 1e-14, K, Elliptic1(math.Pi/2, math.Sqrt(m)))
+			chk.Float64(tst, io.Sf("E(%g)", m), 1e-14, E, Elliptic2(math.Pi/2, math.Sqrt(m)))
+		}
+		chk.Float64(tst, io.Sf("Legendre(%g)", m), 1e-14, E*Kc+Ec*K-K*Kc, math.Pi/2)
+	}
+
+	// tabulated values with φ = π/2
+	p90 := math.Pi / 2
+	_, datF := io.ReadTable("data/as-17-elliptic-integrals-table17.5-small.cmp")
+	for i, p := range datF["phi"] {
+		k := datF["k"][i]
+		if math.Abs(p-p90) < 1e-15 && k < 1 {
+			chk.Float64(tst, io.Sf("K(%.8f)", k*k), 1e-14, EllipticK(k*k), datF["F"][i])
+		}
+	}
+	_, datE := io.ReadTable("data/as-17-elliptic-integrals-table17.6-small.cmp")
+	for i, p := range datE["phi"] {
+		k := datE["k"][i]
+		if math.Abs(p-p90) < 1e-15 {
+			chk.Float64(tst, io.Sf("E(%.8f)", k*k), 1e-14, EllipticE(k*k), datE["E"][i])
+		}
+	}
+
+	// invalid parameter
+	defer chk.RecoverTstPanicIsOK(tst)
+	EllipticK(1.1)
+}
+
+func TestEllipticAgm02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("EllipticAgm02. incomplete integral F(φ|m)")
+
+	// tabulated values
+	p90 := math.Pi / 2
+	_, dat := io.ReadTable("data/as-17-elliptic-integrals-table17.5-small.cmp")
+	for i, p := range dat["phi"] {
+		k := dat["k"][i]
+		F := EllipticF(p, k*k)
+		if math.Abs(k-1.0) < 1e-15 && math.Abs(p-p90) < 1e-15 {
+			if !math.IsInf(F, 1) {
+				tst.Errorf("F(90°|1) should be +Inf\n")
+				return
+			}
+		} else {
+			chk.Float64(tst, io.Sf("F(%.8f|%.8f)=%23.15e", p, k*k, F), 1e-14, F, dat["F"][i])
+		}
+	}
+
+	// symmetry and quasi-periodicity: F(-φ|m) = -F(φ|m) and F(φ+π|m) = F(φ|m) + 2 K(m)
+	for _, m := range []float64{-2, 0, 0.3, 0.8, 0.99} {
+		K := EllipticK(m)
+		for _, φ := range []float64{0.1, 0.9, 1.5, 2.5, 4, 7.5, 20} {
+			F := EllipticF(φ, m)
+			io.Pforan("F(%4.1f|%5.2f) = %23.15e\n", φ, m, F)
+			chk.Float64(tst, io.Sf("F(-%g|%g)", φ, m), 1e-15, EllipticF(-φ, m), -F)
+			chk.Float64(tst, io.Sf("F(%g+π|%g)", φ, m), 1e-13, EllipticF(φ+math.Pi, m), F+2*K)
+		}
+	}
+
+	// derivative dF/dφ = 1/√(1 - m sin²φ)
+	for _, m := range []float64{-0.5, 0.4, 0.9} {
+		for _, φ := range []float64{0.3, 1.2, 3.5} {
+			s := math.Sin(φ)
+			chk.DerivScaSca(tst, io.Sf("dF/dφ(%g|%g)", φ, m), 1e-9, 1/math.Sqrt(1-m*s*s), φ, 1e-3, chk.Verbose, func(t float64) float64 {
+				return EllipticF(t, m)
+			})
+		}
+	}
+
+	// m = 1
+	chk.Float64(tst, "F(1|1)", 1e-15, EllipticF(1, 1), math.Atanh(math.Sin(1)))
+	if !math.IsInf(EllipticF(-2, 1), -1) {
+		tst.Errorf("F(-2|1) should be -Inf\n")
+	}
+}
+
+func TestJacobiElliptic01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("JacobiElliptic01. sn, cn and dn")
+
+	// reference values
+	sn, cn, dn := JacobiElliptic(0.5, 0.5)
+	chk.Float64(tst, "sn(0.5|0.5)", 1e-15, sn, 0.470750473655657)
+	chk.Float64(tst, "cn(0.5|0.5)", 1e-15, cn, 0.882266394890440)
+	chk.Float64(tst, "dn(0.5|0.5)", 1e-15, dn, 0.942972425777386)
+
+	// identities sn² + cn² = 1 and dn² + m sn² = 1; amplitude sn(F(φ|m)|m) = sin φ
+	for _, m := range []float64{-3, -0.5, 1e-20, 0.01, 0.3, 0.7, 0.95, 0.999999} {
+		for _, u := range []float64{-4, -0.7, 0, 0.2, 1, 2.5, 6, 15} {
+			sn, cn, dn := JacobiElliptic(u, m)
+			io.Pforan("u = %5.1f  m = %8g  sn = %23.15e  cn = %23.15e  dn = %23.15e\n", u, m, sn, cn, dn)
+			chk.Float64(tst, io.Sf("sn²+cn²(%g|%g)", u, m), 1e-15, sn*sn+cn*cn, 1)
+			chk.Float64(tst, io.Sf("dn²+m sn²(%g|%g)", u, m), 1e-14, dn*dn+m*sn*sn, 1)
+			chk.Float64(tst, io.Sf("sn(%g|%g)", u, m), 1e-15, JacobiSN(u, m), sn)
+			chk.Float64(tst, io.Sf("cn(%g|%g)", u, m), 1e-15, JacobiCN(u, m), cn)
+			chk.Float64(tst, io.Sf("dn(%g|%g)", u, m), 1e-15, JacobiDN(u, m), dn)
+		}
+		for _, φ := range []float64{0.3, 1.2, 2.8} {
+			u := EllipticF(φ, m)
+			chk.Float64(tst, io.Sf("sn(F(%g|%g))", φ, m), 1e-13, JacobiSN(u, m), math.Sin(φ))
+			chk.Float64(tst, io.Sf("cn(F(%g|%g))", φ, m), 1e-13, JacobiCN(u, m), math.Cos(φ))
+		}
+	}
+
+	// quarter period: sn(K) = 1, cn(K) = 0 and dn(K) = √(1-m)
+	for _, m := range []float64{-1, 0.2, 0.5, 0.9} {
+		sn, cn, dn := JacobiElliptic(EllipticK(m), m)
+		chk.Float64(tst, io.Sf("sn(K|%g)", m), 1e-15, sn, 1)
+		chk.Float64(tst, io.Sf("cn(K|%g)", m), 1e-14, cn, 0)
+		chk.Float64(tst, io.Sf("dn(K|%g)", m), 1e-14, dn, math.Sqrt(1-m))
+	}
+
+	// derivatives: d sn/du = cn dn, d cn/du = -sn dn and d dn/du = -m sn cn
+	for _, m := range []float64{-0.8, 0.25, 0.85} {
+		for _, u := range []float64{0.4, 1.7, 3.1} {
+			sn, cn, dn := JacobiElliptic(u, m)
+			chk.DerivScaSca(tst, io.Sf("dsn/du(%g|%g)", u, m), 1e-9, cn*dn, u, 1e-3, chk.Verbose, func(t float64) float64 {
+				return JacobiSN(t, m)
+			})
+			chk.DerivScaSca(tst, io.Sf("dcn/du(%g|%g)", u, m), 1e-9, -sn*dn, u, 1e-3, chk.Verbose, func(t float64) float64 {
+				return JacobiCN(t, m)
+			})
+			chk.DerivScaSca(tst, io.Sf("ddn/du(%g|%g)", u, m), 1e-9, -m*sn*cn, u, 1e-3, chk.Verbose, func(t float64) float64 {
+				return JacobiDN(t, m)
+			})
+		}
+	}
+
+	// limiting cases
+	sn, cn, dn = JacobiElliptic(0.8, 0)
+	chk.Array(tst, "m=0", 1e-15, []float64{sn, cn, dn}, []float64{math.Sin(0.8), math.Cos(0.8), 1})
+	sn, cn, dn = JacobiElliptic(0.8, 1)
+	chk.Array(tst, "m=1", 1e-15, []float64{sn, cn, dn}, []float64{math.Tanh(0.8), 1 / math.Cosh(0.8), 1 / math.Cosh(0.8)})
+
+	// invalid parameter
+	defer chk.RecoverTstPanicIsOK(tst)
+	JacobiSN(1, 2)
+}
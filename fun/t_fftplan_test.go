@@ -0,0 +1,106 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestFFTPlan01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("FFTPlan01. forward and inverse transforms with plans")
+
+	for _, n := range []int{1, 4, 12, 64} {
+
+		// plan
+		plan := NewFFTPlan(n)
+		defer plan.Free()
+
+		// several transforms with the same plan
+		for trial := 0; trial < 3; trial++ {
+			x := la.NewVectorMappedC(n, func(j int) complex128 {
+				return complex(math.Sin(float64(j+trial)), math.Cos(float64(2*j*trial)))
+			})
+
+			// forward
+			X := x.GetCopy()
+			plan.Forward(X)
+			chk.ArrayC(tst, io.Sf("n=%d trial=%d: X", n, trial), 1e-12, X, dft1dslow(x))
+
+			// inverse
+			Y := X.GetCopy()
+			plan.Inverse(Y)
+			Y.Apply(complex(1.0/float64(n), 0), Y)
+			chk.ArrayC(tst, io.Sf("n=%d trial=%d: Y/N = x", n, trial), 1e-14, Y, x)
+		}
+	}
+
+	// wrong size
+	defer chk.RecoverTstPanicIsOK(tst)
+	plan := NewFFTPlan(4)
+	defer plan.Free()
+	plan.Forward(la.NewVectorC(8))
+}
+
+func TestFFTPlan02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("FFTPlan02. cache of plans used by Dft1d")
+
+	// transforms of different sizes
+	FreeFFTPlanCache()
+	for _, n := range []int{8, 16, 8, 16, 32} {
+		x := la.NewVectorMappedC(n, func(j int) complex128 { return complex(float64(j), 1) })
+		X := x.GetCopy()
+		Dft1d(X, false)
+		chk.ArrayC(tst, io.Sf("n=%d: X", n), 1e-11, X, dft1dslow(x))
+	}
+
+	// check cache
+	chk.Int(tst, "number of cached plans", len(fftPlanCache.plans), 3)
+	plan := cachedFFTPlan(16)
+	if plan != fftPlanCache.plans[16] {
+		tst.Errorf("plan of size 16 should have been reused\n")
+	}
+
+	// free cache
+	FreeFFTPlanCache()
+	chk.Int(tst, "number of cached plans (freed)", len(fftPlanCache.plans), 0)
+}
+
+func TestFFTPlan03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("FFTPlan03. sizes that are not powers of 2")
+
+	// 6 = 2⋅3, 12 = 2²⋅3, 100 = 2²⋅5², 105 = 3⋅5⋅7, 1009 (prime), 2⋅1009
+	for _, n := range []int{6, 12, 100, 105, 1009, 2018} {
+
+		// data
+		x := la.NewVectorMappedC(n, func(j int) complex128 {
+			return complex(math.Cos(0.3*float64(j)), math.Sin(0.7*float64(j*j)))
+		})
+
+		// forward
+		X := x.GetCopy()
+		Dft1d(X, false)
+		Xref := dft1dslow(x)
+		tol := 1e-12 * float64(n)
+		chk.ArrayC(tst, io.Sf("n=%4d: X", n), tol, X, Xref)
+
+		// inverse
+		plan := NewFFTPlan(n)
+		plan.Inverse(X)
+		plan.Free()
+		X.Apply(complex(1.0/float64(n), 0), X)
+		chk.ArrayC(tst, io.Sf("n=%4d: Y/N = x", n), 1e-13, X, x)
+	}
+}
@@ -298,6 +298,45 @@ func Test_beta01(tst *testing.T) {
 	}
 }
 
+func Test_betainc01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("betainc01. regularized incomplete beta function")
+
+	// integer a and b: I_x(a,b) = Σ_{j=a}^{a+b-1} C(a+b-1,j) xʲ (1-x)^(a+b-1-j)
+	for _, a := range []int{1, 2, 5, 10} {
+		for _, b := range []int{1, 3, 20} {
+			for _, x := range []float64{0.01, 0.3, 0.5, 0.9} {
+				n := a + b - 1
+				ana := 0.0
+				for j := a; j <= n; j++ {
+					ana += Binomial(n, j) * math.Pow(x, float64(j)) * math.Pow(1-x, float64(n-j))
+				}
+				chk.Float64(tst, io.Sf("I(%d,%d,%g)", a, b, x), 1e-14, BetaInc(float64(a), float64(b), x), ana)
+			}
+		}
+	}
+
+	// arcsine distribution: I_x(½,½) = 2/π asin(√x)
+	for _, x := range []float64{0, 0.1, 0.3, 0.5, 0.99, 1} {
+		chk.Float64(tst, io.Sf("I(½,½,%g)", x), 1e-15, BetaInc(0.5, 0.5, x), 2.0/math.Pi*math.Asin(math.Sqrt(x)))
+	}
+
+	// symmetry
+	chk.Float64(tst, "I(a,a,½)", 1e-15, BetaInc(7.3, 7.3, 0.5), 0.5)
+	chk.Float64(tst, "symmetry", 1e-14, BetaInc(2.5, 40, 0.07), 1-BetaInc(40, 2.5, 0.93))
+
+	// errors
+	for _, x := range []float64{1.5, -0.1, math.NaN()} {
+		func() {
+			defer chk.RecoverTstPanicIsOK(tst)
+			BetaInc(1, 1, x)
+		}()
+	}
+	defer chk.RecoverTstPanicIsOK(tst)
+	BetaInc(math.NaN(), 1, 0.5)
+}
+
 func Test_binomial01(tst *testing.T) {
 
 	//verbose()
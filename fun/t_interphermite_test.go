@@ -0,0 +1,79 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestInterpHermite01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("InterpHermite01. cubic polynomial is reproduced exactly")
+
+	// f(x) = 2x³ - 3x² + x - 5
+	f := func(x float64) float64 { return 2*x*x*x - 3*x*x + x - 5 }
+	g := func(x float64) float64 { return 6*x*x - 6*x + 1 }
+
+	// data at a few non-uniform points
+	xs := la.NewVectorSlice([]float64{-1, -0.2, 0.5, 2})
+	ys := la.NewVectorMapped(len(xs), func(i int) float64 { return f(xs[i]) })
+	dydx := la.NewVectorMapped(len(xs), func(i int) float64 { return g(xs[i]) })
+	o, err := NewHermiteInterp(xs, ys, dydx)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+
+	// check
+	for _, x := range utl.LinSpace(-1.5, 2.5, 17) {
+		chk.AnaNum(tst, io.Sf("p(%5.2f)", x), 1e-13, o.Eval(x), f(x), chk.Verbose)
+		chk.AnaNum(tst, io.Sf("p'(%5.2f)", x), 1e-13, o.Deriv(x), g(x), chk.Verbose)
+	}
+	var interp Interpolator = o
+	chk.Float64(tst, "P(0.1)", 1e-15, interp.P(0.1), f(0.1))
+}
+
+func TestInterpHermite02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("InterpHermite02. convergence and errors")
+
+	// fourth-order convergence for smooth functions
+	var errs []float64
+	for _, n := range []int{5, 9, 17} {
+		xs := la.NewVectorSlice(utl.LinSpace(0, math.Pi, n))
+		ys := la.NewVectorMapped(n, func(i int) float64 { return math.Sin(xs[i]) })
+		dydx := la.NewVectorMapped(n, func(i int) float64 { return math.Cos(xs[i]) })
+		o, _ := NewHermiteInterp(xs, ys, dydx)
+		emax := 0.0
+		for _, x := range utl.LinSpace(0, math.Pi, 101) {
+			emax = utl.Max(emax, math.Abs(o.Eval(x)-math.Sin(x)))
+		}
+		errs = append(errs, emax)
+	}
+	io.Pforan("errors = %v\n", errs)
+	for i := 1; i < len(errs); i++ {
+		rate := math.Log2(errs[i-1] / errs[i])
+		io.Pf("rate = %v\n", rate)
+		if rate < 3.8 {
+			tst.Errorf("convergence rate %g is too low\n", rate)
+		}
+	}
+
+	// errors
+	if _, err := NewHermiteInterp([]float64{0, 1}, []float64{0, 1}, []float64{1}); err == nil {
+		tst.Errorf("incompatible lengths should cause an error\n")
+	}
+	if _, err := NewHermiteInterp([]float64{1, 0}, []float64{0, 1}, []float64{1, 1}); err == nil {
+		tst.Errorf("unsorted x should cause an error\n")
+	}
+}
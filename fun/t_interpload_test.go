@@ -0,0 +1,64 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestInterpLoad01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("InterpLoad01. load tabulated data from CSV and JSON files")
+
+	// f(x) = x³ - x
+
+	// CSV
+	lin, err := LoadInterpCSV("data/interp-table.csv", InterpLinear)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "csv: lin(0.5)", 1e-15, lin.P(0.5), -0.375)
+	chk.Float64(tst, "csv: lin(1.25)", 1e-15, lin.P(1.25), 0.9375)
+
+	spl, err := LoadInterpCSV("data/interp-table.csv", InterpSpline)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "csv: spl(1.5)", 1e-15, spl.P(1.5), 1.875)
+	io.Pforan("spl(0.75) = %v (exact: %v)\n", spl.P(0.75), 0.75*0.75*0.75-0.75)
+
+	// JSON
+	mon, err := LoadInterpJSON("data/interp-table.json", InterpMonotone)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "json: mon(2)", 1e-15, mon.P(2), 6)
+	spl2, err := LoadInterpJSON("data/interp-table.json", InterpSpline)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "json: spl(0.75) == csv: spl(0.75)", 1e-15, spl2.P(0.75), spl.P(0.75))
+
+	// errors
+	if _, err = LoadInterpCSV("data/interp-unsorted.csv", InterpLinear); err == nil {
+		tst.Errorf("unsorted x should cause an error\n")
+	} else {
+		io.Pf("error: %v\n", err)
+	}
+	if _, err = LoadInterpCSV("data/does-not-exist.csv", InterpLinear); err == nil {
+		tst.Errorf("missing file should cause an error\n")
+	}
+	if _, err = LoadInterpJSON("data/interp-table.csv", InterpLinear); err == nil {
+		tst.Errorf("invalid JSON should cause an error\n")
+	}
+}
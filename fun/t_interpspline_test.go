@@ -0,0 +1,234 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/plt"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestInterpSpline01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("InterpSpline01. natural cubic spline")
+
+	// data
+	xx := utl.LinSpace(0, math.Pi, 11)
+	yy := utl.GetMapped(xx, math.Sin)
+	o, err := NewCubicSpline(xx, yy)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+
+	// interpolates data
+	for i, x := range xx {
+		chk.Float64(tst, io.Sf("P(%.3f)", x), 1e-15, o.P(x), yy[i])
+	}
+
+	// natural end conditions
+	chk.Float64(tst, "D2[0]", 1e-17, o.D2[0], 0)
+	chk.Float64(tst, "D2[n-1]", 1e-17, o.D2[len(xx)-1], 0)
+
+	// accuracy
+	for _, x := range utl.LinSpace(0, math.Pi, 35) {
+		chk.AnaNum(tst, io.Sf("P(%.3f)", x), 1e-3, o.P(x), math.Sin(x), chk.Verbose)
+		chk.AnaNum(tst, io.Sf("G(%.3f)", x), 1e-2, o.G(x), math.Cos(x), chk.Verbose)
+	}
+
+	// linear data is reproduced exactly
+	s, _ := NewCubicSpline([]float64{0, 1, 3, 4}, []float64{1, 3, 7, 9})
+	chk.Float64(tst, "linear: P(2.5)", 1e-15, s.P(2.5), 6)
+	chk.Float64(tst, "linear: G(2.5)", 1e-15, s.G(2.5), 2)
+}
+
+func TestInterpSpline02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("InterpSpline02. monotone cubic")
+
+	// step-like data
+	xx := []float64{0, 1, 2, 3, 4, 5, 6}
+	yy := []float64{0, 0, 0.1, 4, 4.1, 4.1, 4.1}
+	o, err := NewMonotoneCubic(xx, yy)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	spl, _ := NewCubicSpline(xx, yy)
+
+	// interpolates data and preserves monotonicity
+	for i, x := range xx {
+		chk.Float64(tst, io.Sf("P(%g)", x), 1e-15, o.P(x), yy[i])
+	}
+	X := utl.LinSpace(0, 6, 121)
+	overshoot := false
+	for i := 1; i < len(X); i++ {
+		if o.P(X[i]) < o.P(X[i-1])-1e-15 {
+			tst.Errorf("monotone interpolator must not decrease: P(%g)=%g < P(%g)=%g\n", X[i], o.P(X[i]), X[i-1], o.P(X[i-1]))
+			return
+		}
+		if spl.P(X[i]) > 4.1 || spl.P(X[i]) < 0 {
+			overshoot = true
+		}
+	}
+	if !overshoot {
+		tst.Errorf("the cubic spline was expected to overshoot the step-like data\n")
+	}
+
+	// plot
+	if chk.Verbose {
+		plt.Reset(true, nil)
+		plt.Plot(xx, yy, &plt.A{C: "k", M: "o", Ls: "none", L: "data", NoClip: true})
+		plt.Plot(X, utl.GetMapped(X, o.P), &plt.A{C: "r", L: "monotone", NoClip: true})
+		plt.Plot(X, utl.GetMapped(X, spl.P), &plt.A{C: "b", Ls: "--", L: "spline", NoClip: true})
+		plt.Gll("$x$", "$y$", nil)
+		plt.Save("/tmp/gosl/fun", "interpspline02")
+	}
+}
+
+func TestInterpSpline03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("InterpSpline03. Interpolator interface and errors")
+
+	xx := []float64{0, 1, 2}
+	yy := []float64{1, 3, 2}
+	for _, kind := range []InterpKind{InterpLinear, InterpSpline, InterpMonotone} {
+		o, err := NewInterpolator(kind, xx, yy)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		chk.Float64(tst, io.Sf("kind %d: P(1)", kind), 1e-15, o.P(1), 3)
+	}
+	lin, _ := NewInterpolator(InterpLinear, xx, yy)
+	chk.Float64(tst, "lin: P(1.5)", 1e-15, lin.P(1.5), 2.5)
+
+	// errors
+	if _, err := NewInterpolator(InterpSpline, []float64{0, 2, 1}, yy); err == nil {
+		tst.Errorf("unsorted x should cause an error\n")
+	}
+	if _, err := NewInterpolator(InterpSpline, []float64{0, 1, 1}, yy); err == nil {
+		tst.Errorf("repeated x should cause an error\n")
+	}
+	if _, err := NewInterpolator(InterpMonotone, xx, []float64{1}); err == nil {
+		tst.Errorf("incompatible lengths should cause an error\n")
+	}
+	if _, err := NewInterpolator(InterpKind(-1), xx, yy); err == nil {
+		tst.Errorf("invalid kind should cause an error\n")
+	}
+}
+
+func TestInterpSpline04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("InterpSpline04. error estimate")
+
+	// smooth function
+	f := func(x float64) float64 { return math.Exp(-x) * math.Sin(3*x) }
+
+	// errors decrease with the number of nodes
+	prevSpl, prevMon := math.Inf(1), math.Inf(1)
+	for _, n := range []int{6, 11, 21, 41} {
+		xx := utl.LinSpace(0, 2, n)
+		yy := utl.GetMapped(xx, f)
+		spl, _ := NewCubicSpline(xx, yy)
+		mon, _ := NewMonotoneCubic(xx, yy)
+		maxSpl, rmsSpl := spl.ErrorEstimate(f, 10)
+		maxMon, rmsMon := mon.ErrorEstimate(f, 10)
+		io.Pf("n = %2d  spline: max = %.3e rms = %.3e  monotone: max = %.3e rms = %.3e\n", n, maxSpl, rmsSpl, maxMon, rmsMon)
+		if rmsSpl > maxSpl || rmsMon > maxMon {
+			tst.Errorf("rms error must not be greater than max error\n")
+			return
+		}
+		if maxSpl >= prevSpl || maxMon >= prevMon {
+			tst.Errorf("error estimate must decrease with the number of nodes\n")
+			return
+		}
+		prevSpl, prevMon = maxSpl, maxMon
+	}
+
+	// exact for linear data
+	lin := func(x float64) float64 { return 2*x + 1 }
+	s, _ := NewCubicSpline([]float64{0, 1, 3, 4}, []float64{1, 3, 7, 9})
+	maxErr, rmsErr := s.ErrorEstimate(lin, 5)
+	chk.Float64(tst, "linear: maxErr", 1e-14, maxErr, 0)
+	chk.Float64(tst, "linear: rmsErr", 1e-14, rmsErr, 0)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	s.ErrorEstimate(lin, 0)
+}
+
+func TestInterpSpline05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("InterpSpline05. adaptive nodes")
+
+	// function with a sharp peak @ x = 0.3
+	f := func(x float64) float64 { return 1.0/(1.0+math.Pow((x-0.3)/0.01, 2)) + 0.1*x }
+	tol := 1e-4
+	o, err := AdaptiveInterp(f, 0, 1, tol)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	n := len(o.X)
+
+	// global error
+	maxErr, rmsErr := o.ErrorEstimate(f, 50)
+	io.Pforan("number of nodes = %d  maxErr = %v  rmsErr = %v\n", n, maxErr, rmsErr)
+	if maxErr > tol {
+		tst.Errorf("error %g is greater than the tolerance %g\n", maxErr, tol)
+		return
+	}
+
+	// nodes cluster near the peak: a uniform grid would have 10% of the nodes in [0.25,0.35]
+	near := 0
+	for _, x := range o.X {
+		if math.Abs(x-0.3) < 0.05 {
+			near++
+		}
+	}
+	io.Pf("fraction of nodes near the peak = %v\n", float64(near)/float64(n))
+	if float64(near)/float64(n) < 0.3 {
+		tst.Errorf("nodes should be concentrated near the peak\n")
+		return
+	}
+
+	// a uniform grid with the same number of nodes is much less accurate
+	xx := utl.LinSpace(0, 1, n)
+	uni, _ := NewCubicSpline(xx, utl.GetMapped(xx, f))
+	maxUni, _ := uni.ErrorEstimate(f, 50)
+	io.Pf("uniform grid: maxErr = %v\n", maxUni)
+	if maxUni < 10*maxErr {
+		tst.Errorf("adaptive nodes should be more accurate than uniform nodes\n")
+	}
+
+	// plot
+	if chk.Verbose {
+		X := utl.LinSpace(0, 1, 1001)
+		plt.Reset(true, nil)
+		plt.Plot(X, utl.GetMapped(X, f), &plt.A{C: "k", L: "f"})
+		plt.Plot(X, utl.GetMapped(X, o.P), &plt.A{C: "r", Ls: "--", L: "spline"})
+		plt.Plot(o.X, o.Y, &plt.A{C: "r", Ls: "none", M: ".", NoClip: true})
+		plt.Gll("$x$", "$y$", nil)
+		plt.Save("/tmp/gosl/fun", "interpspline05")
+	}
+
+	// errors
+	if _, err = AdaptiveInterp(f, 1, 0, tol); err == nil {
+		tst.Errorf("b < a should cause an error\n")
+	}
+	if _, err = AdaptiveInterp(f, 0, 1, 0); err == nil {
+		tst.Errorf("tol = 0 should cause an error\n")
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestInterpVector01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("InterpVector01. vector-valued interpolator")
+
+	// data: y(x) = {sin(x), cos(x), x², step}
+	xs := la.NewVectorSlice(utl.LinSpace(0, 2, 9))
+	xs[3] += 0.1 // non-uniform
+	ys := la.NewMatrix(len(xs), 4)
+	for i, x := range xs {
+		ys.Set(i, 0, math.Sin(x))
+		ys.Set(i, 1, math.Cos(x))
+		ys.Set(i, 2, x*x)
+		if x > 1 {
+			ys.Set(i, 3, 1)
+		}
+	}
+
+	// check each component against the scalar interpolator
+	X := utl.LinSpace(-0.1, 2.1, 23)
+	for _, kind := range []InterpKind{InterpLinear, InterpSpline, InterpMonotone} {
+		o, err := NewVectorInterp(xs, ys, kind)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		chk.Int(tst, "ncomp", o.Ncomp(), 4)
+		for j := 0; j < ys.N; j++ {
+			scalar, err := NewInterpolator(kind, xs, ys.Col(j))
+			if err != nil {
+				tst.Errorf("%v\n", err)
+				return
+			}
+			for _, x := range X {
+				res := o.Eval(x)
+				chk.Float64(tst, io.Sf("kind %d: y%d(%.2f)", kind, j, x), 1e-14, res[j], scalar.P(x))
+			}
+		}
+	}
+
+	// errors
+	if _, err := NewVectorInterp(xs, la.NewMatrix(3, 2), InterpLinear); err == nil {
+		tst.Errorf("incompatible dimensions should cause an error\n")
+	}
+	if _, err := NewVectorInterp([]float64{0, 1, 1}, la.NewMatrix(3, 2), InterpSpline); err == nil {
+		tst.Errorf("repeated x should cause an error\n")
+	}
+	if _, err := NewVectorInterp(xs, ys, InterpKind(3)); err == nil {
+		tst.Errorf("invalid kind should cause an error\n")
+	}
+}
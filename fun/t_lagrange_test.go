@@ -476,3 +476,34 @@ func TestLagInterp08(tst *testing.T) {
 	io.Pf("no eta: err(D2{f}) = %v\n", maxDiff)
 	chk.Float64(tst, "err(D2{f})", 1e-12, maxDiff, 0)
 }
+
+func TestLagInterp09(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LagInterp09. error estimate")
+
+	// smooth function
+	f := func(x float64) float64 {
+		return math.Cos(math.Exp(2.0 * x))
+	}
+
+	// errors decrease with the degree
+	prev := math.Inf(1)
+	for _, N := range []int{8, 16, 32, 64} {
+		o := NewLagrangeInterp(N, "cgl")
+		o.CalcU(f)
+		maxErr, rmsErr := o.ErrorEstimate(f, 5)
+		e, _ := o.EstimateMaxErr(0, f)
+		io.Pf("N = %2d  max = %.3e  rms = %.3e  EstimateMaxErr = %.3e\n", N, maxErr, rmsErr, e)
+		if rmsErr > maxErr {
+			tst.Errorf("rms error must not be greater than max error\n")
+			return
+		}
+		if maxErr >= prev && maxErr > 1e-14 {
+			tst.Errorf("error estimate must decrease with the degree\n")
+			return
+		}
+		chk.Float64(tst, "max error", math.Max(e/2, 1e-14), maxErr, e)
+		prev = maxErr
+	}
+}
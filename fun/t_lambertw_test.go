@@ -0,0 +1,115 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestLambertW01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LambertW01. principal branch W₀")
+
+	// known values
+	Ω := 0.56714329040978387300 // omega constant: W₀(1)
+	for _, c := range []struct{ x, w float64 }{{-1 / math.E, -1}, {0, 0}, {1, Ω}, {math.E, 1}, {2 * math.E * math.E, 2}, {-math.Log(2) / 2, -math.Log(2)}} {
+		w, err := LambertW0(c.x)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		chk.Float64(tst, io.Sf("W₀(%g)", c.x), 1e-15, w, c.w)
+	}
+
+	// w ⋅ exp(w) = x across the domain
+	xx := append(utl.LinSpace(-1/math.E, 0, 21), utl.LinSpace(0.01, 10, 21)...)
+	xx = append(xx, 20, 1e3, 1e10, 1e100, 1e300, -1/math.E+1e-12, -1e-300)
+	for _, x := range xx {
+		w, err := LambertW0(x)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		io.Pforan("W₀(%23.15e) = %23.15e\n", x, w)
+		if w < -1 {
+			tst.Errorf("W₀(%g) = %g should be ≥ -1\n", x, w)
+		}
+		tol := 1e-15 * math.Max(1, math.Abs(x)) * (1 + math.Abs(w)) // relative error of exp(w) ≈ error of w
+		chk.Float64(tst, io.Sf("W₀(%g)⋅exp(W₀(%g))", x, x), tol, w*math.Exp(w), x)
+	}
+
+	// infinity
+	w, _ := LambertW0(math.Inf(1))
+	if !math.IsInf(w, 1) {
+		tst.Errorf("W₀(+Inf) should be +Inf\n")
+	}
+
+	// invalid inputs
+	for _, x := range []float64{-1/math.E - 1e-10, -1, math.NaN(), math.Inf(-1)} {
+		if _, err := LambertW0(x); err == nil {
+			tst.Errorf("LambertW0(%g) should fail\n", x)
+		}
+	}
+}
+
+func TestLambertW02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LambertW02. lower branch W₋₁")
+
+	// known values
+	for _, c := range []struct{ x, w float64 }{{-1 / math.E, -1}, {-math.Log(2) / 2, -2 * math.Log(2)}, {-2 * math.Exp(-2), -2}, {-10 * math.Exp(-10), -10}} {
+		w, err := LambertWm1(c.x)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		chk.Float64(tst, io.Sf("W₋₁(%g)", c.x), 1e-14, w, c.w)
+	}
+
+	// w ⋅ exp(w) = x across the domain
+	xx := utl.LinSpace(-1/math.E, -1e-3, 31)
+	xx = append(xx, -1e-5, -1e-10, -1e-100, -1e-300, -1/math.E+1e-12)
+	for _, x := range xx {
+		w, err := LambertWm1(x)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		io.Pforan("W₋₁(%23.15e) = %23.15e\n", x, w)
+		if w > -1 {
+			tst.Errorf("W₋₁(%g) = %g should be ≤ -1\n", x, w)
+		}
+		tol := 1e-15 * math.Abs(x) * (1 + math.Abs(w)) // relative error of exp(w) ≈ error of w
+		chk.Float64(tst, io.Sf("W₋₁(%g)⋅exp(W₋₁(%g))", x, x), tol, w*math.Exp(w), x)
+	}
+
+	// tiny and subnormal x: exp(w) underflows; thus check w + log(-w) = log(-x)
+	for _, x := range []float64{-2.2250738585072014e-308, -1e-310, -math.SmallestNonzeroFloat64} {
+		w, err := LambertWm1(x)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		io.Pforan("W₋₁(%23.15e) = %23.15e\n", x, w)
+		if math.IsInf(w, 0) || math.IsNaN(w) || w > -1 {
+			tst.Errorf("W₋₁(%g) = %g should be finite and ≤ -1\n", x, w)
+		}
+		chk.Float64(tst, io.Sf("W₋₁(%g) + log(-W₋₁(%g))", x, x), 1e-15*math.Abs(w), w+math.Log(-w), math.Log(-x))
+	}
+
+	// invalid inputs
+	for _, x := range []float64{-1/math.E - 1e-10, 0, 1, math.NaN()} {
+		if _, err := LambertWm1(x); err == nil {
+			tst.Errorf("LambertWm1(%g) should fail\n", x)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestInverseLaplace01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("InverseLaplace01. known transform pairs")
+
+	a, ω := 0.5, 2.0
+	tests := []struct {
+		name string
+		F    func(s complex128) complex128
+		f    func(t float64) float64
+		tol  float64
+	}{
+		{"1/s → 1", func(s complex128) complex128 { return 1 / s }, func(t float64) float64 { return 1 }, 1e-10},
+		{"1/s² → t", func(s complex128) complex128 { return 1 / (s * s) }, func(t float64) float64 { return t }, 1e-10},
+		{"1/(s+a) → exp(-at)", func(s complex128) complex128 { return 1 / (s + complex(a, 0)) }, func(t float64) float64 { return math.Exp(-a * t) }, 1e-10},
+		{"ω/(s²+ω²) → sin(ωt)", func(s complex128) complex128 { return complex(ω, 0) / (s*s + complex(ω*ω, 0)) }, func(t float64) float64 { return math.Sin(ω * t) }, 1e-8},
+		{"1/√s → 1/√(πt)", func(s complex128) complex128 { return 1 / cmplx.Sqrt(s) }, func(t float64) float64 { return 1 / math.Sqrt(math.Pi*t) }, 1e-10},
+		{"exp(-s)/s → H(t-1)", func(s complex128) complex128 { return cmplx.Exp(-s) / s }, func(t float64) float64 { return 1 }, 1e-10}, // t > 1 only
+		{"log(s)/s → -γ-log(t)", func(s complex128) complex128 { return cmplx.Log(s) / s }, func(t float64) float64 { return -0.5772156649015329 - math.Log(t) }, 1e-9},
+	}
+	for _, test := range tests {
+		maxerr := 0.0
+		for _, t := range []float64{1.5, 2, 3, 5} {
+			f := InverseLaplace(test.F, t)
+			chk.Float64(tst, io.Sf("%s @ t=%g", test.name, t), test.tol, f, test.f(t))
+			maxerr = math.Max(maxerr, math.Abs(f-test.f(t)))
+		}
+		io.Pforan("%24s: max error = %.2e\n", test.name, maxerr)
+	}
+
+	// small times
+	for _, t := range []float64{1e-3, 0.01, 0.1} {
+		chk.Float64(tst, io.Sf("1/(s+a) @ t=%g", t), 1e-10, InverseLaplace(tests[2].F, t), math.Exp(-a*t))
+	}
+}
+
+func TestInverseLaplace02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("InverseLaplace02. number of terms and errors")
+
+	F := func(s complex128) complex128 { return 1 / ((s + 1) * (s + 2)) } // exp(-t) - exp(-2t)
+	t := 1.0
+	ana := math.Exp(-t) - math.Exp(-2*t)
+	prev := math.Inf(1)
+	for _, M := range []int{4, 8, 16, 24} {
+		err := math.Abs(InverseLaplaceTalbot(F, t, M) - ana)
+		io.Pforan("M = %2d: error = %.2e\n", M, err)
+		if err >= prev {
+			tst.Errorf("error should decrease with M\n")
+		}
+		prev = err
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	InverseLaplace(F, 0)
+}
@@ -0,0 +1,93 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// convolution computes the full convolution of x and h directly
+func convolution(x, h la.Vector) (y la.Vector) {
+	y = la.NewVector(len(x) + len(h) - 1)
+	for n := 0; n < len(y); n++ {
+		for k := 0; k < len(h); k++ {
+			if n-k >= 0 && n-k < len(x) {
+				y[n] += h[k] * x[n-k]
+			}
+		}
+	}
+	return
+}
+
+func TestOverlapAdd01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("OverlapAdd01. streaming FIR filter")
+
+	// long signal
+	nx := 1000
+	x := la.NewVectorMapped(nx, func(i int) float64 {
+		t := float64(i) / 100.0
+		return math.Sin(2*math.Pi*3*t) + 0.3*math.Sin(2*math.Pi*40*t+0.2) + 0.1*math.Cos(float64(i*i))
+	})
+
+	// kernels: moving average h = 1/M and windowed sinc (low-pass)
+	for _, M := range []int{1, 5, 31, 100} {
+		h := la.NewVectorMapped(M, func(k int) float64 {
+			if M < 31 {
+				return 1.0 / float64(M)
+			}
+			c := float64(k) - float64(M-1)/2.0
+			return 0.1 * Sinc(0.1*math.Pi*c) * (0.54 - 0.46*math.Cos(2*math.Pi*float64(k)/float64(M-1)))
+		})
+		yref := convolution(x, h)
+
+		// blocks of constant and variable sizes
+		for _, B := range []int{16, 64, 250} {
+			o := NewOverlapAdd(h, B)
+			var y []float64
+			for i, L := 0, 0; i < nx; i += L {
+				L = B
+				if i%3 == 1 {
+					L = 1 + i%B // variable size
+				}
+				if i+L > nx {
+					L = nx - i
+				}
+				y = append(y, o.Process(x[i:i+L])...)
+			}
+			y = append(y, o.Flush()...)
+			o.Free()
+			chk.Int(tst, io.Sf("M=%3d B=%3d: len(y)", M, B), len(y), nx+M-1)
+			chk.Array(tst, io.Sf("M=%3d B=%3d (N=%4d): y", M, B, o.N), 1e-12, y, yref)
+		}
+	}
+}
+
+func TestOverlapAdd02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("OverlapAdd02. reset and invalid block")
+
+	// impulse response
+	h := la.NewVectorSlice([]float64{1, 2, 3})
+	o := NewOverlapAdd(h, 4)
+	defer o.Free()
+	chk.Int(tst, "N", o.N, 8)
+	chk.Array(tst, "y", 1e-15, o.Process(la.NewVectorSlice([]float64{1, 0, 0, 0})), []float64{1, 2, 3, 0})
+	o.Process(la.NewVectorSlice([]float64{5, 5}))
+	o.Reset()
+	chk.Array(tst, "y (reset)", 1e-15, o.Process(la.NewVectorSlice([]float64{0, 1})), []float64{0, 1})
+	chk.Array(tst, "tail", 1e-15, o.Flush(), []float64{2, 3})
+
+	// block too large
+	defer chk.RecoverTstPanicIsOK(tst)
+	o.Process(la.NewVector(5))
+}
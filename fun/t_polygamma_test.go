@@ -0,0 +1,103 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestDigamma01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Digamma01. known values, recurrence and reflection")
+
+	// known values
+	γ := 0.57721566490153286061 // Euler-Mascheroni constant
+	chk.Float64(tst, "ψ(1)", 1e-15, Digamma(1), -γ)
+	chk.Float64(tst, "ψ(1/2)", 1e-15, Digamma(0.5), -γ-2*math.Ln2)
+	chk.Float64(tst, "ψ(2)", 1e-15, Digamma(2), 1-γ)
+	chk.Float64(tst, "ψ(1/4)", 1e-14, Digamma(0.25), -γ-math.Pi/2-3*math.Ln2)
+	chk.Float64(tst, "ψ(100)", 1e-14, Digamma(100), 4.600161852738087400)
+	chk.Float64(tst, "ψ(-1/2)", 1e-14, Digamma(-0.5), 2-γ-2*math.Ln2)
+
+	// recurrence ψ(x+1) = ψ(x) + 1/x and reflection ψ(1-x) - ψ(x) = π cot(π x)
+	for _, x := range []float64{-7.3, -2.5, -0.8, 0.01, 0.3, 1.7, 4.2, 9.99, 25, 1e3} {
+		ψ := Digamma(x)
+		io.Pforan("ψ(%6.2f) = %23.15e\n", x, ψ)
+		chk.Float64(tst, io.Sf("ψ(%g+1)", x), 1e-13*math.Max(1, math.Abs(ψ)), Digamma(x+1), ψ+1/x)
+		if x != math.Floor(x) { // 1-x is not a pole
+			chk.Float64(tst, io.Sf("ψ(1-%g)", x), 1e-12, Digamma(1-x)-ψ, math.Pi/math.Tan(math.Pi*x))
+		}
+	}
+
+	// derivative of ln Γ
+	for _, x := range []float64{0.2, 1.5, 3, 12.5} {
+		chk.DerivScaSca(tst, io.Sf("dlnΓ/dx(%g)", x), 1e-9, Digamma(x), x, 1e-3, chk.Verbose, func(t float64) float64 {
+			lg, _ := math.Lgamma(t)
+			return lg
+		})
+	}
+
+	// special values
+	for _, x := range []float64{0, -1, -4, math.NaN(), math.Inf(-1)} {
+		if !math.IsNaN(Digamma(x)) {
+			tst.Errorf("ψ(%g) should be NaN\n", x)
+		}
+	}
+	if !math.IsInf(Digamma(math.Inf(1)), 1) {
+		tst.Errorf("ψ(+Inf) should be +Inf\n")
+	}
+}
+
+func TestPolygamma01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Polygamma01. known values, recurrence and derivatives")
+
+	// known values: ψ⁽ⁿ⁾(1) = (-1)ⁿ⁺¹ n! ζ(n+1)
+	ζ3 := 1.2020569031595942854
+	chk.Float64(tst, "ψ0(1)", 1e-15, Polygamma(0, 1), Digamma(1))
+	chk.Float64(tst, "ψ1(1)", 1e-15, Polygamma(1, 1), math.Pi*math.Pi/6)
+	chk.Float64(tst, "ψ1(1/2)", 1e-14, Polygamma(1, 0.5), math.Pi*math.Pi/2)
+	chk.Float64(tst, "ψ2(1)", 1e-14, Polygamma(2, 1), -2*ζ3)
+	chk.Float64(tst, "ψ3(1)", 1e-14, Polygamma(3, 1), math.Pow(math.Pi, 4)/15)
+	chk.Float64(tst, "ψ1(-1/2)", 1e-13, Polygamma(1, -0.5), math.Pi*math.Pi/2+4)
+
+	// recurrence ψ⁽ⁿ⁾(x+1) = ψ⁽ⁿ⁾(x) + (-1)ⁿ n! / xⁿ⁺¹
+	for n := 1; n <= 6; n++ {
+		fact := math.Gamma(float64(n + 1))
+		for _, x := range []float64{-3.3, -0.6, 0.05, 0.5, 2.2, 7.5, 30} {
+			ψn := Polygamma(n, x)
+			io.Pforan("ψ%d(%6.2f) = %23.15e\n", n, x, ψn)
+			chk.Float64(tst, io.Sf("ψ%d(%g+1)", n, x), 1e-13*math.Max(1, math.Abs(ψn)), Polygamma(n, x+1), ψn+NegOnePowN(n)*fact/math.Pow(x, float64(n+1)))
+		}
+	}
+
+	// derivatives dψ⁽ⁿ⁻¹⁾/dx = ψ⁽ⁿ⁾
+	for n := 1; n <= 4; n++ {
+		for _, x := range []float64{0.7, 2.5, 11} {
+			chk.DerivScaSca(tst, io.Sf("dψ%d/dx(%g)", n-1, x), 1e-8, Polygamma(n, x), x, 1e-3, chk.Verbose, func(t float64) float64 {
+				return Polygamma(n-1, t)
+			})
+		}
+	}
+
+	// special values
+	if !math.IsInf(Polygamma(1, 0), 1) || !math.IsInf(Polygamma(3, -2), 1) {
+		tst.Errorf("ψ⁽ⁿ⁾ should be +Inf at the poles if n is odd\n")
+	}
+	if !math.IsNaN(Polygamma(2, -1)) || !math.IsNaN(Polygamma(1, math.NaN())) {
+		tst.Errorf("ψ⁽ⁿ⁾ should be NaN at the poles if n is even and NaN for NaN\n")
+	}
+	chk.Float64(tst, "ψ1(+Inf)", 1e-15, Polygamma(1, math.Inf(1)), 0)
+
+	// invalid order
+	defer chk.RecoverTstPanicIsOK(tst)
+	Polygamma(-1, 1)
+}
@@ -0,0 +1,150 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// sphGaussLegendre computes the n points and weights of the Gauss-Legendre quadrature in [-1, 1]
+func sphGaussLegendre(n int) (x, w []float64) {
+	x, w = make([]float64, n), make([]float64, n)
+	for i := 0; i < n; i++ {
+		z := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		var dp float64
+		for it := 0; it < 100; it++ {
+			p0, p1 := 1.0, z
+			for k := 2; k <= n; k++ {
+				p0, p1 = p1, ((2*float64(k)-1)*z*p1-(float64(k)-1)*p0)/float64(k)
+			}
+			dp = float64(n) * (z*p1 - p0) / (z*z - 1)
+			δ := p1 / dp
+			z -= δ
+			if math.Abs(δ) < 1e-15 {
+				break
+			}
+		}
+		x[i], w[i] = z, 2/((1-z*z)*dp*dp)
+	}
+	return
+}
+
+func TestSphericalHarmonic01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("SphericalHarmonic01. low-order harmonics")
+
+	for _, θ := range []float64{0, 0.3, 1.1, math.Pi / 2, 2.5, math.Pi} {
+		for _, φ := range []float64{0, 0.7, 2.9, 5.5} {
+			c, s := math.Cos(θ), math.Sin(θ)
+			e := func(m float64) complex128 { return cmplx.Exp(complex(0, m*φ)) }
+			ref := map[[2]int]complex128{
+				{0, 0}: complex(0.5/math.Sqrt(math.Pi), 0),
+				{1, 0}: complex(0.5*math.Sqrt(3/math.Pi)*c, 0),
+				{1, 1}: complex(-0.5*math.Sqrt(3/(2*math.Pi))*s, 0) * e(1),
+				{2, 0}: complex(0.25*math.Sqrt(5/math.Pi)*(3*c*c-1), 0),
+				{2, 1}: complex(-0.5*math.Sqrt(15/(2*math.Pi))*s*c, 0) * e(1),
+				{2, 2}: complex(0.25*math.Sqrt(15/(2*math.Pi))*s*s, 0) * e(2),
+				{3, 0}: complex(0.25*math.Sqrt(7/math.Pi)*(5*c*c*c-3*c), 0),
+				{3, 1}: complex(-0.125*math.Sqrt(21/math.Pi)*s*(5*c*c-1), 0) * e(1),
+				{3, 2}: complex(0.25*math.Sqrt(105/(2*math.Pi))*s*s*c, 0) * e(2),
+				{3, 3}: complex(-0.125*math.Sqrt(35/math.Pi)*s*s*s, 0) * e(3),
+			}
+			for lm, y := range ref {
+				l, m := lm[0], lm[1]
+				Y := SphericalHarmonic(l, m, θ, φ)
+				chk.Complex128(tst, io.Sf("Y(%d,%d,%g,%g)", l, m, θ, φ), 1e-15, Y, y)
+
+				// negative order: Yₗ⁻ᵐ = (-1)ᵐ conj(Yₗᵐ)
+				Yneg := SphericalHarmonic(l, -m, θ, φ)
+				chk.Complex128(tst, io.Sf("Y(%d,%d,%g,%g)", l, -m, θ, φ), 1e-15, Yneg, complex(NegOnePowN(m), 0)*cmplx.Conj(y))
+			}
+
+			// real harmonics of degree 1 are proportional to y, z and x
+			x, y, z := s*math.Cos(φ), s*math.Sin(φ), c
+			k := math.Sqrt(3 / (4 * math.Pi))
+			chk.Float64(tst, "Y1-1", 1e-15, SphericalHarmonicReal(1, -1, θ, φ), k*y)
+			chk.Float64(tst, "Y10", 1e-15, SphericalHarmonicReal(1, 0, θ, φ), k*z)
+			chk.Float64(tst, "Y11", 1e-15, SphericalHarmonicReal(1, 1, θ, φ), k*x)
+			chk.Float64(tst, "Y2-2", 1e-15, SphericalHarmonicReal(2, -2, θ, φ), 0.5*math.Sqrt(15/math.Pi)*x*y)
+			chk.Float64(tst, "Y22", 1e-15, SphericalHarmonicReal(2, 2, θ, φ), 0.25*math.Sqrt(15/math.Pi)*(x*x-y*y))
+		}
+	}
+
+	// invalid degree and order
+	for _, lm := range [][2]int{{-1, 0}, {2, 3}, {2, -3}} {
+		func() {
+			defer chk.RecoverTstPanicIsOK(tst)
+			SphericalHarmonic(lm[0], lm[1], 0.5, 0.5)
+		}()
+	}
+}
+
+func TestSphericalHarmonic02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("SphericalHarmonic02. orthonormality and addition theorem")
+
+	// quadrature over the sphere: Gauss-Legendre in cos θ and trapezoidal rule in φ
+	lmax := 6
+	xg, wg := sphGaussLegendre(lmax + 1)
+	nφ := 2*lmax + 1
+	dφ := 2 * math.Pi / float64(nφ)
+
+	// orthonormality
+	for l1 := 0; l1 <= lmax/2; l1++ {
+		for m1 := -l1; m1 <= l1; m1++ {
+			for l2 := 0; l2 <= lmax/2; l2++ {
+				for m2 := -l2; m2 <= l2; m2++ {
+					var res complex128
+					var resReal float64
+					for i, xi := range xg {
+						θ := math.Acos(xi)
+						for j := 0; j < nφ; j++ {
+							φ := float64(j) * dφ
+							w := wg[i] * dφ
+							res += complex(w, 0) * SphericalHarmonic(l1, m1, θ, φ) * cmplx.Conj(SphericalHarmonic(l2, m2, θ, φ))
+							resReal += w * SphericalHarmonicReal(l1, m1, θ, φ) * SphericalHarmonicReal(l2, m2, θ, φ)
+						}
+					}
+					δ := 0.0
+					if l1 == l2 && m1 == m2 {
+						δ = 1
+					}
+					chk.Complex128(tst, io.Sf("<Y%d%d,Y%d%d>", l1, m1, l2, m2), 1e-14, res, complex(δ, 0))
+					chk.Float64(tst, io.Sf("<Y%d%d,Y%d%d>real", l1, m1, l2, m2), 1e-14, resReal, δ)
+				}
+			}
+		}
+	}
+
+	// addition theorem Σₘ |Yₗᵐ|² = (2l+1)/(4π) with large degrees
+	for _, l := range []int{10, 40, 80, 150} {
+		for _, θ := range []float64{1e-3, 0.4, 1.3, 2.9} {
+			sum, sumReal := 0.0, 0.0
+			for m := -l; m <= l; m++ {
+				Y := SphericalHarmonic(l, m, θ, 0.8)
+				Yr := SphericalHarmonicReal(l, m, θ, 0.8)
+				sum += real(Y * cmplx.Conj(Y))
+				sumReal += Yr * Yr
+			}
+			ref := float64(2*l+1) / (4 * math.Pi)
+			io.Pforan("l = %3d  θ = %5.3f  Σ|Y|² = %23.15e\n", l, θ, sum)
+			chk.Float64(tst, io.Sf("Σ|Y%d|²(%g)", l, θ), 1e-12*ref, sum, ref)
+			chk.Float64(tst, io.Sf("ΣY%d²(%g)", l, θ), 1e-12*ref, sumReal, ref)
+		}
+	}
+
+	// poles: only m = 0 is nonzero
+	l := 30
+	chk.Float64(tst, "Y30,0(0)", 1e-13, real(SphericalHarmonic(l, 0, 0, 0)), math.Sqrt(float64(2*l+1)/(4*math.Pi)))
+	chk.Float64(tst, "Y30,0(π)", 1e-13, real(SphericalHarmonic(l, 0, math.Pi, 0)), math.Sqrt(float64(2*l+1)/(4*math.Pi)))
+	chk.Complex128(tst, "Y30,5(0)", 1e-15, SphericalHarmonic(l, 5, 0, 1), 0)
+}
@@ -0,0 +1,109 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// dominantBin returns the index of the largest |X[k]| with k in [1, N/2]
+func dominantBin(X la.VectorC) (kmax int) {
+	amax := 0.0
+	for k := 1; k <= len(X)/2; k++ {
+		if a := cmplx.Abs(X[k]); a > amax {
+			kmax, amax = k, a
+		}
+	}
+	return
+}
+
+func TestStreamingFFT01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("StreamingFFT01. sine stream")
+
+	// sampling
+	fs := 64.0 // sampling frequency [Hz]
+	N := 64    // window size
+	hop := 16  // hop size
+	f1 := 5.0  // frequency of signal before switching [Hz]
+	f2 := 12.0 // frequency of signal after switching [Hz]
+	nswitch := 160
+
+	// streaming FFT
+	o := NewStreamingFFT(N, hop)
+	defer o.Free()
+
+	// feed samples
+	var samples []float64
+	var ready []int
+	for m := 0; m < 320; m++ {
+		t := float64(m) / fs
+		x := 0.25 + math.Sin(2*math.Pi*f1*t)
+		if m >= nswitch {
+			x = 0.25 + 0.5*math.Sin(2*math.Pi*f2*t)
+		}
+		samples = append(samples, x)
+		X, ok := o.Push(x)
+		if !ok {
+			if X != nil {
+				tst.Errorf("spectrum must be nil if not ready\n")
+				return
+			}
+			continue
+		}
+		ready = append(ready, m)
+
+		// compare with slow DFT of windowed data
+		xw := make([]complex128, N)
+		for j := 0; j < N; j++ {
+			xw[j] = complex(o.W[j]*samples[m-N+1+j], 0)
+		}
+		chk.ArrayC(tst, io.Sf("m=%3d: X", m), 1e-12, X, dft1dslow(xw))
+
+		// dominant bin
+		kmax := dominantBin(X)
+		io.Pf("m = %3d  kmax = %2d  frequency = %g Hz\n", m, kmax, float64(kmax)*fs/float64(N))
+		switch {
+		case m < nswitch:
+			chk.Int(tst, "kmax (f1)", kmax, int(f1))
+		case m >= nswitch+N-1:
+			chk.Int(tst, "kmax (f2)", kmax, int(f2))
+		}
+	}
+
+	// check instants of transforms
+	chk.Ints(tst, "ready", ready, []int{63, 79, 95, 111, 127, 143, 159, 175, 191, 207, 223, 239, 255, 271, 287, 303, 319})
+
+	// reset
+	o.Reset()
+	for m := 0; m < N-1; m++ {
+		if _, ok := o.Push(1); ok {
+			tst.Errorf("spectrum must not be ready before the buffer is full again\n")
+			return
+		}
+	}
+	X, ok := o.Push(1)
+	if !ok {
+		tst.Errorf("spectrum must be ready after the buffer is full\n")
+		return
+	}
+	chk.Complex128(tst, "X[0] = Σw", 1e-12, X[0], complex(o.W.Accum(), 0))
+}
+
+func TestStreamingFFT02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("StreamingFFT02. invalid input")
+
+	defer chk.RecoverTstPanicIsOK(tst)
+	NewStreamingFFT(8, 9)
+}
@@ -0,0 +1,91 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestTape01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Tape01. reverse-mode automatic differentiation")
+
+	// f(x,y) = x⋅y + sin(x)
+	t := NewTape()
+	x, y := t.NewVar(1), t.NewVar(2)
+	f := x.Mul(y).Add(x.Sin())
+	f.Backward()
+	chk.Int(tst, "len(tape)", t.Len(), 5)
+	chk.Float64(tst, "f", 1e-15, f.V, 2+math.Sin(1))
+	chk.Float64(tst, "df/dx", 1e-15, x.Adj(), 2+math.Cos(1))
+	chk.Float64(tst, "df/dy", 1e-15, y.Adj(), 1)
+
+	// g(x,y) = exp(x/y) - √y ⋅ log(x)  (reusing the tape)
+	g := x.Div(y).Exp().Sub(y.Sqrt().Mul(x.Log()))
+	g.Backward()
+	chk.Float64(tst, "dg/dx", 1e-15, x.Adj(), math.Exp(0.5)/2-math.Sqrt(2))
+	chk.Float64(tst, "dg/dy", 1e-15, y.Adj(), -math.Exp(0.5)/4)
+
+	// a node used many times accumulates its adjoint
+	h := x.Pow(3).Scale(2).Add(x.Tanh()).Add(x.Cos().Neg()).AddScalar(5)
+	h.Backward()
+	chk.Float64(tst, "dh/dx", 1e-15, x.Adj(), 6+1-math.Pow(math.Tanh(1), 2)+math.Sin(1))
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	x.Add(NewTape().NewVar(1))
+}
+
+func TestTape02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Tape02. GradReverse versus finite differences and forward mode")
+
+	// f(x) = Σ exp(xᵢ) ⋅ sin(xᵢ₊₁) + (1 - xᵢ)² / (1 + xᵢ₊₁²)
+	fvar := func(x []*Var) (res *Var) {
+		res = x[0].Scale(0)
+		for i := 0; i < len(x)-1; i++ {
+			a := x[i].Exp().Mul(x[i+1].Sin())
+			b := x[i].Neg().AddScalar(1).Pow(2).Div(x[i+1].Pow(2).AddScalar(1))
+			res = res.Add(a).Add(b)
+		}
+		return
+	}
+	fdual := func(x []Dual) (res Dual) {
+		for i := 0; i < len(x)-1; i++ {
+			a := x[i].Exp().Mul(x[i+1].Sin())
+			b := x[i].Neg().AddScalar(1).Pow(2).Div(x[i+1].Pow(2).AddScalar(1))
+			res = res.Add(a).Add(b)
+		}
+		return
+	}
+	ffloat := func(x la.Vector) (res float64) {
+		for i := 0; i < len(x)-1; i++ {
+			res += math.Exp(x[i])*math.Sin(x[i+1]) + math.Pow(1-x[i], 2)/(1+x[i+1]*x[i+1])
+		}
+		return
+	}
+
+	// check
+	x := la.NewVectorSlice(utl.LinSpace(-1, 1.5, 10))
+	g := GradReverse(fvar, x)
+	io.Pforan("g = %v\n", g)
+	chk.Array(tst, "reverse == forward", 1e-14, g, Gradient(fdual, x))
+	xtmp := x.GetCopy()
+	for i := range x {
+		chk.DerivScaSca(tst, io.Sf("df/dx%d", i), 1e-8, g[i], x[i], 1e-3, chk.Verbose, func(s float64) float64 {
+			copy(xtmp, x)
+			xtmp[i] = s
+			return ffloat(xtmp)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// scatteredPoints2d generates deterministic scattered points in [0,1]² using the Halton sequence
+func scatteredPoints2d(n, skip int) (points []la.Vector) {
+	halton := func(i, base int) (h float64) {
+		f := 1.0
+		for ; i > 0; i /= base {
+			f /= float64(base)
+			h += f * float64(i%base)
+		}
+		return
+	}
+	points = make([]la.Vector, n)
+	for i := 0; i < n; i++ {
+		points[i] = la.Vector{halton(i+skip, 2), halton(i+skip, 3)}
+	}
+	return
+}
+
+func TestTps01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Tps01. thin-plate spline: linear function and data points")
+
+	// linear function is reproduced exactly with trend
+	f := func(p la.Vector) float64 { return 1 + 2*p[0] - 3*p[1] }
+	points := scatteredPoints2d(12, 1)
+	values := la.NewVectorMapped(len(points), func(i int) float64 { return f(points[i]) })
+	o, err := NewThinPlateSpline(points, values)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("a = %v\n", o.A)
+	chk.Array(tst, "a", 1e-12, o.A, []float64{1, 2, -3})
+	chk.Array(tst, "w", 1e-12, o.W, nil)
+	for _, p := range scatteredPoints2d(10, 100) {
+		chk.Float64(tst, io.Sf("s(%.3f,%.3f)", p[0], p[1]), 1e-12, o.Eval(p), f(p))
+	}
+
+	// data points are interpolated without trend as well
+	g := func(p la.Vector) float64 { return math.Exp(-p[0]) * math.Sin(3*p[1]) }
+	values = la.NewVectorMapped(len(points), func(i int) float64 { return g(points[i]) })
+	o, err = NewThinPlateSplineNoTrend(points, values)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	if o.A != nil {
+		tst.Errorf("A should be nil without trend\n")
+	}
+	for i, p := range points {
+		chk.Float64(tst, io.Sf("s(p%d)", i), 1e-12, o.Eval(p), values[i])
+	}
+}
+
+func TestTps02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Tps02. thin-plate spline: accuracy at held-out points")
+
+	// smooth function
+	f := func(p la.Vector) float64 { return math.Sin(math.Pi*p[0]) * math.Cos(math.Pi*p[1]/2) }
+
+	// samples
+	points := scatteredPoints2d(200, 1)
+	values := la.NewVectorMapped(len(points), func(i int) float64 { return f(points[i]) })
+	o, err := NewThinPlateSpline(points, values)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+
+	// held-out points away from the boundary
+	emax := 0.0
+	for _, p := range scatteredPoints2d(100, 1000) {
+		p[0], p[1] = 0.1+0.8*p[0], 0.1+0.8*p[1]
+		emax = math.Max(emax, math.Abs(o.Eval(p)-f(p)))
+	}
+	io.Pforan("max error = %v\n", emax)
+	if emax > 2e-3 {
+		tst.Errorf("max error at held-out points is too large: %g\n", emax)
+	}
+}
+
+func TestTps03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Tps03. thin-plate spline: errors")
+
+	p := []la.Vector{{0, 0}, {1, 0}, {0, 1}, {1, 1}}
+	if _, err := NewThinPlateSpline(p, la.Vector{1, 2}); err == nil {
+		tst.Errorf("incompatible lengths should cause an error\n")
+	}
+	if _, err := NewThinPlateSpline([]la.Vector{{0, 0}, {1}, {0, 1}}, la.Vector{1, 2, 3}); err == nil {
+		tst.Errorf("points with different dimensions should cause an error\n")
+	}
+	if _, err := NewThinPlateSpline(p[:2], la.Vector{1, 2}); err == nil {
+		tst.Errorf("too few points should cause an error\n")
+	}
+	_, err := NewThinPlateSpline([]la.Vector{{0, 0}, {1, 0}, {0, 1}, {0, 1}}, la.Vector{1, 2, 3, 3})
+	if err == nil {
+		tst.Errorf("repeated points should cause an error\n")
+	} else {
+		io.Pf("error: %v\n", err)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// Tape records the operations on Var nodes for reverse-mode automatic differentiation. Each node
+// stores the partial derivatives with respect to its (at most two) parents; thus Backward
+// computes the derivatives of one output with respect to all nodes in a single reverse pass
+//
+//  Example:
+//              f(x,y) = x⋅y + sin(x)
+//
+//     t := NewTape()
+//     x, y := t.NewVar(1), t.NewVar(2)
+//     f := x.Mul(y).Add(x.Sin())
+//     f.Backward()           ⇒    x.Adj() = y + cos(x)    and    y.Adj() = x
+//
+//  NOTE: the cost of Backward is proportional to the number of recorded operations and does
+//        not depend on the number of inputs. Compare with Gradient (forward mode)
+type Tape struct {
+	nodes []tapeNode // recorded nodes
+	adj   []float64  // adjoints (derivatives of output w.r.t nodes) computed by Backward
+}
+
+// tapeNode holds the parents of a node and the corresponding partial derivatives
+type tapeNode struct {
+	parents  [2]int     // indices of parents; -1 if none
+	partials [2]float64 // ∂node/∂parent
+}
+
+// Var implements a node (variable) of the Tape
+type Var struct {
+	V    float64 // value
+	tape *Tape   // tape holding this node
+	id   int     // index of node in tape
+}
+
+// NewTape returns a new Tape
+func NewTape() (o *Tape) {
+	return new(Tape)
+}
+
+// NewVar records a new independent variable (input) with value v
+func (o *Tape) NewVar(v float64) *Var {
+	return o.push(v, -1, 0, -1, 0)
+}
+
+// Len returns the number of recorded nodes
+func (o *Tape) Len() int {
+	return len(o.nodes)
+}
+
+// Backward computes the derivatives of v with respect to all nodes recorded before v. The results
+// are retrieved with Adj
+func (v *Var) Backward() {
+	o := v.tape
+	if len(o.adj) != len(o.nodes) {
+		o.adj = make([]float64, len(o.nodes))
+	}
+	for i := range o.adj {
+		o.adj[i] = 0
+	}
+	o.adj[v.id] = 1
+	for i := v.id; i >= 0; i-- {
+		a := o.adj[i]
+		if a == 0 {
+			continue
+		}
+		node := o.nodes[i]
+		for k := 0; k < 2; k++ {
+			if node.parents[k] >= 0 {
+				o.adj[node.parents[k]] += a * node.partials[k]
+			}
+		}
+	}
+}
+
+// Adj returns the adjoint of v; i.e. the derivative of the output of the last call to Backward
+// with respect to v
+func (v *Var) Adj() float64 {
+	if v.id >= len(v.tape.adj) {
+		return 0
+	}
+	return v.tape.adj[v.id]
+}
+
+// GradReverse computes the gradient of f(x) using reverse-mode automatic differentiation
+//  NOTE: f is evaluated once on a new tape and the gradient is computed with one reverse pass
+func GradReverse(f func(vars []*Var) *Var, x la.Vector) (grad la.Vector) {
+	t := NewTape()
+	vars := make([]*Var, len(x))
+	for i := range x {
+		vars[i] = t.NewVar(x[i])
+	}
+	out := f(vars)
+	out.Backward()
+	grad = la.NewVector(len(x))
+	for i, v := range vars {
+		grad[i] = v.Adj()
+	}
+	return
+}
+
+// arithmetic //////////////////////////////////////////////////////////////////////////////////////
+
+// Add returns a + b
+func (a *Var) Add(b *Var) *Var {
+	a.check(b)
+	return a.tape.push(a.V+b.V, a.id, 1, b.id, 1)
+}
+
+// Sub returns a - b
+func (a *Var) Sub(b *Var) *Var {
+	a.check(b)
+	return a.tape.push(a.V-b.V, a.id, 1, b.id, -1)
+}
+
+// Mul returns a ⋅ b
+func (a *Var) Mul(b *Var) *Var {
+	a.check(b)
+	return a.tape.push(a.V*b.V, a.id, b.V, b.id, a.V)
+}
+
+// Div returns a / b
+func (a *Var) Div(b *Var) *Var {
+	a.check(b)
+	return a.tape.push(a.V/b.V, a.id, 1.0/b.V, b.id, -a.V/(b.V*b.V))
+}
+
+// Neg returns -a
+func (a *Var) Neg() *Var {
+	return a.unary(-a.V, -1)
+}
+
+// AddScalar returns a + s
+func (a *Var) AddScalar(s float64) *Var {
+	return a.unary(a.V+s, 1)
+}
+
+// Scale returns s ⋅ a
+func (a *Var) Scale(s float64) *Var {
+	return a.unary(s*a.V, s)
+}
+
+// elementary functions ////////////////////////////////////////////////////////////////////////////
+
+// Sin returns sin(a)
+func (a *Var) Sin() *Var {
+	return a.unary(math.Sin(a.V), math.Cos(a.V))
+}
+
+// Cos returns cos(a)
+func (a *Var) Cos() *Var {
+	return a.unary(math.Cos(a.V), -math.Sin(a.V))
+}
+
+// Exp returns exp(a)
+func (a *Var) Exp() *Var {
+	e := math.Exp(a.V)
+	return a.unary(e, e)
+}
+
+// Log returns log(a)
+func (a *Var) Log() *Var {
+	return a.unary(math.Log(a.V), 1.0/a.V)
+}
+
+// Sqrt returns √a
+func (a *Var) Sqrt() *Var {
+	s := math.Sqrt(a.V)
+	return a.unary(s, 0.5/s)
+}
+
+// Pow returns aᵖ with a real exponent p
+func (a *Var) Pow(p float64) *Var {
+	if p == 0 {
+		return a.unary(1, 0)
+	}
+	return a.unary(math.Pow(a.V, p), p*math.Pow(a.V, p-1.0))
+}
+
+// Tanh returns tanh(a)
+func (a *Var) Tanh() *Var {
+	t := math.Tanh(a.V)
+	return a.unary(t, 1.0-t*t)
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// push records a new node
+func (o *Tape) push(v float64, p0 int, d0 float64, p1 int, d1 float64) *Var {
+	o.nodes = append(o.nodes, tapeNode{parents: [2]int{p0, p1}, partials: [2]float64{d0, d1}})
+	return &Var{V: v, tape: o, id: len(o.nodes) - 1}
+}
+
+// unary records a node with a as the only parent and ∂node/∂a = d
+func (a *Var) unary(v, d float64) *Var {
+	return a.tape.push(v, a.id, d, -1, 0)
+}
+
+// check checks that a and b belong to the same tape
+func (a *Var) check(b *Var) {
+	if a.tape != b.tape {
+		chk.Panic("variables must belong to the same tape\n")
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fun
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// TPS implements the thin-plate spline interpolator of scattered data in any dimension
+//
+//   s(p) = Σ_i w[i] ⋅ φ(‖p - p[i]‖)  +  a[0] + Σ_k a[k+1] ⋅ p[k]
+//
+//   φ(r) = r² ⋅ log(r)
+//
+//  The coefficients are found by solving the (symmetric and indefinite) dense system:
+//
+//   | K   P | | w |   | f |          K[i][j] = φ(‖p[i] - p[j]‖)
+//   |       | |   | = |   |   with
+//   | Pᵀ  0 | | a |   | 0 |          P[i]    = [1, p[i][0], …, p[i][ndim-1]]
+//
+//  NOTE: the linear trend term (a) is optional; however, since φ is conditionally positive
+//        definite of order 2, the system without trend may be singular for some point sets
+//
+//  Reference:
+//    [1] Wahba G (1990) Spline Models for Observational Data. SIAM, Philadelphia
+type TPS struct {
+	Points []la.Vector // [npts][ndim] data points (not copied)
+	Ndim   int         // space dimension
+	W      la.Vector   // [npts] kernel coefficients
+	A      la.Vector   // [ndim+1] polynomial (linear trend) coefficients; nil if without trend
+}
+
+// NewThinPlateSpline returns a new thin-plate spline with linear trend interpolating values @ points
+//  points -- [npts][ndim] scattered points; must be distinct
+//  values -- [npts] values at points
+func NewThinPlateSpline(points []la.Vector, values la.Vector) (o *TPS, err error) {
+	return newTPS(points, values, true)
+}
+
+// NewThinPlateSplineNoTrend returns a new thin-plate spline without the linear trend term
+//  points -- [npts][ndim] scattered points; must be distinct
+//  values -- [npts] values at points
+func NewThinPlateSplineNoTrend(points []la.Vector, values la.Vector) (o *TPS, err error) {
+	return newTPS(points, values, false)
+}
+
+// Eval computes the interpolated value @ p
+func (o *TPS) Eval(p la.Vector) (res float64) {
+	for i, q := range o.Points {
+		res += o.W[i] * tpsKernel(distPoints(p, q))
+	}
+	if o.A != nil {
+		res += o.A[0]
+		for k := 0; k < o.Ndim; k++ {
+			res += o.A[k+1] * p[k]
+		}
+	}
+	return
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// newTPS allocates and solves the TPS system
+func newTPS(points []la.Vector, values la.Vector, trend bool) (o *TPS, err error) {
+
+	// check
+	npts := len(points)
+	if npts < 1 {
+		return nil, chk.Err("at least one point is required")
+	}
+	if len(values) != npts {
+		return nil, chk.Err("number of values must be equal to the number of points. %d != %d", len(values), npts)
+	}
+	ndim := len(points[0])
+	for i, p := range points {
+		if len(p) != ndim || ndim < 1 {
+			return nil, chk.Err("all points must have the same dimension ≥ 1. len(points[%d]) = %d is invalid", i, len(p))
+		}
+	}
+	if trend && npts < ndim+1 {
+		return nil, chk.Err("at least ndim+1 = %d points are required with the linear trend. %d is invalid", ndim+1, npts)
+	}
+
+	// system
+	n := npts
+	if trend {
+		n += ndim + 1
+	}
+	K := la.NewMatrix(n, n)
+	for i := 0; i < npts; i++ {
+		for j := i + 1; j < npts; j++ {
+			φ := tpsKernel(distPoints(points[i], points[j]))
+			K.Set(i, j, φ)
+			K.Set(j, i, φ)
+		}
+		if trend {
+			K.Set(i, npts, 1)
+			K.Set(npts, i, 1)
+			for k := 0; k < ndim; k++ {
+				K.Set(i, npts+1+k, points[i][k])
+				K.Set(npts+1+k, i, points[i][k])
+			}
+		}
+	}
+	b := la.NewVector(n)
+	copy(b, values)
+
+	// solve using LU decomposition with partial pivoting
+	x := la.NewVector(n)
+	err = denSolveErr(x, K, b)
+	if err != nil {
+		return nil, chk.Err("cannot solve thin-plate spline system (repeated or degenerate points?): %v", err)
+	}
+
+	// results
+	o = &TPS{Points: points, Ndim: ndim, W: x[:npts]}
+	if trend {
+		o.A = x[npts:]
+	}
+	return
+}
+
+// tpsKernel computes φ(r) = r²⋅log(r) with φ(0) = 0
+func tpsKernel(r float64) float64 {
+	if r < math.SmallestNonzeroFloat64 {
+		return 0
+	}
+	return r * r * math.Log(r)
+}
+
+// distPoints computes the Euclidean distance between p and q
+func distPoints(p, q la.Vector) (d float64) {
+	for k := 0; k < len(p); k++ {
+		d += (p[k] - q[k]) * (p[k] - q[k])
+	}
+	return math.Sqrt(d)
+}
+
+// denSolveErr solves a dense linear system and converts LAPACK panics into errors
+func denSolveErr(x la.Vector, A *la.Matrix, b la.Vector) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = chk.Err("%v", e)
+		}
+	}()
+	la.DenSolve(x, A, b, false)
+	for _, v := range x {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return chk.Err("solution has NaN or Inf values")
+		}
+	}
+	return
+}
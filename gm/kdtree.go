@@ -0,0 +1,134 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gm
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// KdTree implements a k-d tree for fast neighbor queries of points in N-dim space
+//
+//  NOTE: the tree is balanced by splitting at the median of the cycling coordinates; thus the
+//        construction costs O(n⋅log²n) and queries cost O(log n) on average
+type KdTree struct {
+	Ndim   int         // space dimension
+	Points []la.Vector // [npts][ndim] points (not copied)
+	nodes  []kdNode    // nodes of the tree
+	root   int         // index of root node; -1 if empty
+}
+
+// kdNode holds the data of a node of the k-d tree
+type kdNode struct {
+	id    int // index of point
+	axis  int // splitting axis
+	left  int // index of left node; -1 if none
+	right int // index of right node; -1 if none
+}
+
+// NewKdTree returns a new k-d tree holding points
+//  NOTE: all points must have the same dimension
+func NewKdTree(points []la.Vector) (o *KdTree) {
+	o = new(KdTree)
+	o.Points = points
+	o.root = -1
+	if len(points) == 0 {
+		return
+	}
+	o.Ndim = len(points[0])
+	for i, p := range points {
+		if len(p) != o.Ndim {
+			chk.Panic("all points must have the same dimension. len(points[%d]) = %d is invalid; %d is required\n", i, len(p), o.Ndim)
+		}
+	}
+	ids := make([]int, len(points))
+	for i := range ids {
+		ids[i] = i
+	}
+	o.nodes = make([]kdNode, 0, len(points))
+	o.root = o.build(ids, 0)
+	return
+}
+
+// InRadius returns the indices of all points q such that ‖q - p‖ ≤ radius
+func (o *KdTree) InRadius(p la.Vector, radius float64) (ids []int) {
+	o.inRadius(o.root, p, radius, radius*radius, &ids)
+	return
+}
+
+// Nearest returns the index of the point closest to p and the corresponding distance
+//  NOTE: id = -1 if the tree is empty
+func (o *KdTree) Nearest(p la.Vector) (id int, dist float64) {
+	id, sqDist := -1, math.Inf(1)
+	o.nearest(o.root, p, &id, &sqDist)
+	return id, math.Sqrt(sqDist)
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// build builds the subtree with points ids and returns the index of the subtree node
+func (o *KdTree) build(ids []int, depth int) int {
+	if len(ids) == 0 {
+		return -1
+	}
+	axis := depth % o.Ndim
+	sort.Slice(ids, func(a, b int) bool { return o.Points[ids[a]][axis] < o.Points[ids[b]][axis] })
+	mid := len(ids) / 2
+	k := len(o.nodes)
+	o.nodes = append(o.nodes, kdNode{id: ids[mid], axis: axis})
+	left := o.build(ids[:mid], depth+1)
+	right := o.build(ids[mid+1:], depth+1)
+	o.nodes[k].left, o.nodes[k].right = left, right
+	return k
+}
+
+// inRadius collects the points within radius in the subtree k
+func (o *KdTree) inRadius(k int, p la.Vector, radius, sqRadius float64, ids *[]int) {
+	if k < 0 {
+		return
+	}
+	node := o.nodes[k]
+	if kdSqDist(o.Points[node.id], p) <= sqRadius {
+		*ids = append(*ids, node.id)
+	}
+	δ := p[node.axis] - o.Points[node.id][node.axis]
+	if δ <= radius {
+		o.inRadius(node.left, p, radius, sqRadius, ids)
+	}
+	if δ >= -radius {
+		o.inRadius(node.right, p, radius, sqRadius, ids)
+	}
+}
+
+// nearest finds the nearest point in the subtree k
+func (o *KdTree) nearest(k int, p la.Vector, id *int, sqDist *float64) {
+	if k < 0 {
+		return
+	}
+	node := o.nodes[k]
+	if d := kdSqDist(o.Points[node.id], p); d < *sqDist {
+		*id, *sqDist = node.id, d
+	}
+	δ := p[node.axis] - o.Points[node.id][node.axis]
+	first, second := node.left, node.right
+	if δ > 0 {
+		first, second = node.right, node.left
+	}
+	o.nearest(first, p, id, sqDist)
+	if δ*δ < *sqDist {
+		o.nearest(second, p, id, sqDist)
+	}
+}
+
+// kdSqDist computes the squared distance between a and b
+func kdSqDist(a, b la.Vector) (d float64) {
+	for k := 0; k < len(a); k++ {
+		d += (a[k] - b[k]) * (a[k] - b[k])
+	}
+	return
+}
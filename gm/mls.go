@@ -0,0 +1,188 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gm
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// MLS implements the moving-least-squares approximation of scattered (noisy) data. At each query
+// point p, a polynomial is fitted to the neighbors within radius R using weighted least squares:
+//
+//   min_c Σ_i w(‖p[i] - p‖) ⋅ (b(p[i] - p) ⋅ c - f[i])²    ⇒    s(p) = c[0]
+//
+//   w(d) = (1 - d/R)⁴ ⋅ (4d/R + 1)    (Wendland weight; w(R) = 0)
+//
+//  where b are the monomials up to PolyDegree centered at p; e.g. for PolyDegree = 2 in 2D:
+//  b(x,y) = [1, x, y, x², xy, y²]
+//
+//  NOTE: if the number of neighbors is insufficient for PolyDegree, the degree is reduced. NaN is
+//        returned if there are no neighbors within R
+//
+//  Reference:
+//    [1] Lancaster P and Salkauskas K (1981) Surfaces generated by moving least squares methods.
+//        Mathematics of Computation, 37(155):141-158
+type MLS struct {
+	Points     []la.Vector // [npts][ndim] data points (not copied)
+	Values     la.Vector   // [npts] values at points (not copied)
+	Radius     float64     // radius of support R
+	PolyDegree int         // degree of local polynomial: 0, 1 or 2
+	tree       *KdTree     // k-d tree with points
+}
+
+// NewMLS returns a new moving-least-squares approximation
+//  points     -- [npts][ndim] scattered points
+//  values     -- [npts] (noisy) values at points
+//  radius     -- radius of support (neighbors within radius are used)
+//  polyDegree -- degree of local polynomial: 0, 1 or 2
+func NewMLS(points []la.Vector, values la.Vector, radius float64, polyDegree int) (o *MLS) {
+	if len(points) != len(values) {
+		chk.Panic("number of values must be equal to the number of points. %d != %d\n", len(values), len(points))
+	}
+	if radius <= 0 {
+		chk.Panic("radius must be positive. radius = %g is invalid\n", radius)
+	}
+	if polyDegree < 0 || polyDegree > 2 {
+		chk.Panic("polyDegree must be 0, 1 or 2. polyDegree = %d is invalid\n", polyDegree)
+	}
+	o = &MLS{Points: points, Values: values, Radius: radius, PolyDegree: polyDegree}
+	o.tree = NewKdTree(points)
+	return
+}
+
+// Eval computes the approximated value @ p
+func (o *MLS) Eval(p la.Vector) float64 {
+
+	// neighbors and weights
+	ids := o.tree.InRadius(p, o.Radius)
+	if len(ids) == 0 {
+		return math.NaN()
+	}
+	ws := make([]float64, len(ids))
+	for k, i := range ids {
+		d := math.Sqrt(kdSqDist(o.Points[i], p)) / o.Radius
+		ws[k] = math.Pow(1.0-d, 4) * (4.0*d + 1.0)
+	}
+
+	// weighted least squares, reducing the degree if the system is singular
+	dx := la.NewVector(len(p))
+	for deg := o.PolyDegree; deg > 0; deg-- {
+		nb := mlsNbasis(len(p), deg)
+		if len(ids) < nb {
+			continue
+		}
+		A := la.NewMatrix(nb, nb)
+		b := la.NewVector(nb)
+		c := la.NewVector(nb)
+		bas := la.NewVector(nb)
+		for k, i := range ids {
+			for j := 0; j < len(p); j++ {
+				dx[j] = (o.Points[i][j] - p[j]) / o.Radius // scaled for better conditioning
+			}
+			mlsBasis(bas, dx, deg)
+			for r := 0; r < nb; r++ {
+				b[r] += ws[k] * bas[r] * o.Values[i]
+				for s := 0; s < nb; s++ {
+					A.Add(r, s, ws[k]*bas[r]*bas[s])
+				}
+			}
+		}
+		if mlsSolve(c, A, b) {
+			return c[0]
+		}
+	}
+
+	// degree 0: weighted average (Shepard)
+	var sumw, sumwf float64
+	for k, i := range ids {
+		sumw += ws[k]
+		sumwf += ws[k] * o.Values[i]
+	}
+	if sumw > 0 {
+		return sumwf / sumw
+	}
+	return o.Values[ids[0]] // all neighbors exactly at R
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// mlsNbasis returns the number of monomials up to degree deg in ndim dimensions
+func mlsNbasis(ndim, deg int) int {
+	switch deg {
+	case 0:
+		return 1
+	case 1:
+		return 1 + ndim
+	}
+	return 1 + ndim + ndim*(ndim+1)/2
+}
+
+// mlsBasis computes the monomials up to degree deg @ x
+func mlsBasis(bas, x la.Vector, deg int) {
+	bas[0] = 1
+	if deg < 1 {
+		return
+	}
+	n := 1
+	for j := 0; j < len(x); j++ {
+		bas[n] = x[j]
+		n++
+	}
+	if deg < 2 {
+		return
+	}
+	for j := 0; j < len(x); j++ {
+		for l := j; l < len(x); l++ {
+			bas[n] = x[j] * x[l]
+			n++
+		}
+	}
+}
+
+// mlsSolve solves the (symmetric positive semi-definite) moment system; returns false if singular
+//  NOTE: the Cholesky factorisation is computed here (instead of la.Cholesky, which panics) in
+//        order to stop at the first pivot that is too small; thus, Eval can reduce the degree
+func mlsSolve(c la.Vector, A *la.Matrix, b la.Vector) bool {
+	amax := 0.0
+	for i := 0; i < A.M; i++ {
+		amax = math.Max(amax, A.Get(i, i))
+	}
+	L := la.NewMatrix(A.M, A.M)
+	for j := 0; j < A.M; j++ { // A = L⋅Lᵀ
+		d := A.Get(j, j)
+		for k := 0; k < j; k++ {
+			d -= L.Get(j, k) * L.Get(j, k)
+		}
+		if !(d >= 1e-12*amax) || amax == 0 { // also catches NaN
+			return false
+		}
+		ljj := math.Sqrt(d)
+		L.Set(j, j, ljj)
+		for i := j + 1; i < A.M; i++ {
+			sum := A.Get(i, j)
+			for k := 0; k < j; k++ {
+				sum -= L.Get(i, k) * L.Get(j, k)
+			}
+			L.Set(i, j, sum/ljj)
+		}
+	}
+	for i := 0; i < A.M; i++ { // L⋅y = b with y stored in c
+		c[i] = b[i]
+		for k := 0; k < i; k++ {
+			c[i] -= L.Get(i, k) * c[k]
+		}
+		c[i] /= L.Get(i, i)
+	}
+	for i := A.M - 1; i >= 0; i-- { // Lᵀ⋅c = y
+		for k := i + 1; k < A.M; k++ {
+			c[i] -= L.Get(k, i) * c[k]
+		}
+		c[i] /= L.Get(i, i)
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gm
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/rnd"
+)
+
+func Test_kdtree01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("kdtree01. small k-d tree")
+
+	points := []la.Vector{{2, 3}, {5, 4}, {9, 6}, {4, 7}, {8, 1}, {7, 2}}
+	o := NewKdTree(points)
+	chk.Int(tst, "ndim", o.Ndim, 2)
+
+	id, dist := o.Nearest(la.Vector{9, 2})
+	chk.Int(tst, "nearest(9,2)", id, 4)
+	chk.Float64(tst, "dist", 1e-15, dist, math.Sqrt2)
+
+	ids := o.InRadius(la.Vector{5, 4}, 3) // (2,3) and (4,7) are at √10 > 3
+	sort.Ints(ids)
+	io.Pforan("ids = %v\n", ids)
+	chk.Ints(tst, "InRadius((5,4),3)", ids, []int{1, 5})
+
+	// empty tree
+	e := NewKdTree(nil)
+	id, _ = e.Nearest(la.Vector{0, 0})
+	chk.Int(tst, "empty: nearest", id, -1)
+	if len(e.InRadius(la.Vector{0, 0}, 1)) != 0 {
+		tst.Errorf("empty tree should have no neighbors\n")
+	}
+}
+
+func Test_kdtree02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("kdtree02. k-d tree versus brute force")
+
+	rnd.Init(1234)
+	points := make([]la.Vector, 500)
+	for i := range points {
+		points[i] = la.NewVector(3)
+		rnd.Float64s(points[i], -1, 1)
+	}
+	points[10][0], points[11][0] = points[9][0], points[9][0] // repeated coordinates
+	o := NewKdTree(points)
+
+	q := la.NewVector(3)
+	for trial := 0; trial < 20; trial++ {
+		rnd.Float64s(q, -1, 1)
+		r := 0.4
+
+		// brute force
+		var correct []int
+		imin, dmin := -1, math.Inf(1)
+		for i, p := range points {
+			d := math.Sqrt(kdSqDist(p, q))
+			if d <= r {
+				correct = append(correct, i)
+			}
+			if d < dmin {
+				imin, dmin = i, d
+			}
+		}
+
+		// k-d tree
+		ids := o.InRadius(q, r)
+		sort.Ints(ids)
+		chk.Ints(tst, io.Sf("InRadius: trial %d", trial), ids, correct)
+		id, dist := o.Nearest(q)
+		chk.Int(tst, io.Sf("Nearest: trial %d", trial), id, imin)
+		chk.Float64(tst, io.Sf("dist: trial %d", trial), 1e-15, dist, dmin)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/rnd"
+	"github.com/cpmech/gosl/utl"
+)
+
+func Test_mls01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("mls01. moving least squares: polynomials are reproduced")
+
+	// quadratic function
+	f := func(p la.Vector) float64 { return 1 + p[0] - 2*p[1] + p[0]*p[0] + 3*p[0]*p[1] - p[1]*p[1] }
+
+	// scattered points
+	rnd.Init(111)
+	points := make([]la.Vector, 100)
+	values := la.NewVector(len(points))
+	for i := range points {
+		points[i] = la.NewVector(2)
+		rnd.Float64s(points[i], 0, 1)
+		values[i] = f(points[i])
+	}
+
+	// degree 2 reproduces the quadratic exactly
+	o := NewMLS(points, values, 0.35, 2)
+	for _, x := range utl.LinSpace(0.1, 0.9, 5) {
+		for _, y := range utl.LinSpace(0.1, 0.9, 5) {
+			p := la.Vector{x, y}
+			chk.Float64(tst, io.Sf("s(%.1f,%.1f)", x, y), 1e-12, o.Eval(p), f(p))
+		}
+	}
+
+	// no neighbors
+	if !math.IsNaN(o.Eval(la.Vector{5, 5})) {
+		tst.Errorf("query without neighbors should return NaN\n")
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	NewMLS(points, values, 0.3, 3)
+}
+
+func Test_mls02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("mls02. moving least squares: noisy data")
+
+	// smooth surface
+	f := func(p la.Vector) float64 { return math.Sin(math.Pi*p[0]) * math.Cos(math.Pi*p[1]) }
+
+	// noisy scattered samples
+	rnd.Init(222)
+	σ := 0.1
+	points := make([]la.Vector, 800)
+	values := la.NewVector(len(points))
+	for i := range points {
+		points[i] = la.NewVector(2)
+		rnd.Float64s(points[i], 0, 1)
+		values[i] = f(points[i]) + rnd.Normal(0, σ)
+	}
+
+	// rms errors at data points (interior)
+	for _, deg := range []int{0, 1, 2} {
+		o := NewMLS(points, values, 0.25, deg)
+		var sumNoise, sumFit float64
+		n := 0
+		for i, p := range points {
+			if p[0] < 0.1 || p[0] > 0.9 || p[1] < 0.1 || p[1] > 0.9 {
+				continue
+			}
+			exact := f(p)
+			sumNoise += math.Pow(values[i]-exact, 2)
+			sumFit += math.Pow(o.Eval(p)-exact, 2)
+			n++
+		}
+		rmsNoise := math.Sqrt(sumNoise / float64(n))
+		rmsFit := math.Sqrt(sumFit / float64(n))
+		io.Pforan("degree %d: rms(noise) = %.4f  rms(fit) = %.4f\n", deg, rmsNoise, rmsFit)
+		if rmsFit > 0.5*rmsNoise {
+			tst.Errorf("degree %d: fit should reduce the noise: rms(fit) = %g, rms(noise) = %g\n", deg, rmsFit, rmsNoise)
+		}
+	}
+}
+
+func Test_mls03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("mls03. moving least squares: collinear points (singular moment matrix)")
+
+	// points on y = 0 and f = 1 + 2x; the degrees 2 and 1 are singular in 2D
+	points := make([]la.Vector, 10)
+	values := la.NewVector(len(points))
+	for i := range points {
+		x := float64(i) / 9.0
+		points[i] = la.Vector{x, 0}
+		values[i] = 1 + 2*x
+	}
+
+	// the points are symmetric about x = 0.5; thus the weighted average (degree 0) is exact
+	for _, deg := range []int{0, 1, 2} {
+		o := NewMLS(points, values, 0.5, deg)
+		res := o.Eval(la.Vector{0.5, 0})
+		io.Pforan("degree %d: s(0.5,0) = %v\n", deg, res)
+		chk.Float64(tst, io.Sf("degree %d: s(0.5,0)", deg), 1e-14, res, 2)
+	}
+}
@@ -47,10 +47,11 @@ func VecDot(u, v Vector) (res float64) {
 
 // VecAdd adds the scaled components of two vectors
 //   res := α⋅u + β⋅v   ⇒   result[i] := α⋅u[i] + β⋅v[i]
+//  NOTE: res may be the same as u or v
 func VecAdd(res Vector, α float64, u Vector, β float64, v Vector) {
 	n := len(u)
 	cutoff := 150
-	if β == 1 && n > cutoff {
+	if β == 1 && n > cutoff && &res[0] != &u[0] { // res must not be overwritten before using u
 		copy(res, v)
 		oblas.Daxpy(n, α, u, 1, res, 1)
 		return
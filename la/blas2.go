@@ -60,6 +60,17 @@ func VecVecTrMul(a *Matrix, α float64, u, v Vector) {
 	oblas.Dger(a.M, a.N, α, u, 1, v, 1, a.Data, utl.Imax(a.M, a.N))
 }
 
+// Outer returns the outer product of two vectors in a new matrix
+//
+//   a = u⋅vᵀ    ⇒    aij = ui * vj
+//
+//  NOTE: a has dimensions len(u) × len(v); the lengths are checked by Rank1Update
+func Outer(u, v Vector) (a *Matrix) {
+	a = NewMatrix(len(u), len(v))
+	a.Rank1Update(1, u, v)
+	return
+}
+
 // MatVecMulAdd returns the matrix-vector multiplication with addition
 //
 //   v += α⋅a⋅u    ⇒    vi += α * aij * uj
@@ -68,6 +79,22 @@ func MatVecMulAdd(v Vector, α float64, a *Matrix, u Vector) {
 	oblas.Dgemv(false, a.M, a.N, α, a.Data, a.M, u, 1, 1.0, v, 1)
 }
 
+// MatViewVecMul returns the (block) matrix-vector multiplication using a view
+//
+//   v = α⋅a⋅u    ⇒    vi = α * aij * uj
+//
+func MatViewVecMul(v Vector, α float64, a *MatrixView, u Vector) {
+	oblas.Dgemv(false, a.M, a.N, α, a.Data, a.Ld, u, 1, 0.0, v, 1)
+}
+
+// MatViewTrVecMul returns the transpose(block matrix)-vector multiplication using a view
+//
+//   v = α⋅aᵀ⋅u    ⇒    vi = α * aji * uj = α * uj * aji
+//
+func MatViewTrVecMul(v Vector, α float64, a *MatrixView, u Vector) {
+	oblas.Dgemv(true, a.M, a.N, α, a.Data, a.Ld, u, 1, 0.0, v, 1)
+}
+
 // complex /////////////////////////////////////////////////////////////////////////////////////////
 
 // MatVecMulC returns the matrix-vector multiplication (complex version)
@@ -120,3 +120,23 @@ func MatAdd(res *Matrix, α float64, a *Matrix, β float64, b *Matrix) {
 	}
 	return
 }
+
+// SyrkUpper returns the symmetric rank-k update (Gram matrix) computed by BLAS dsyrk
+//
+//  c := α⋅xᵀ⋅x    ⇒    cij := α * xki * xkj    with c = [x.N][x.N]
+//
+//  NOTE: only the upper triangle of c is computed (half of the work of MatTrMatMul). The lower
+//        triangle is filled by symmetry if symmetrize==true; otherwise it is left with zeros
+func SyrkUpper(α float64, x *Matrix, symmetrize bool) (c *Matrix) {
+	n := x.N
+	c = NewMatrix(n, n)
+	oblas.Dsyrk(true, true, n, x.M, α, x.Data, x.M, 0.0, c.Data, n)
+	if symmetrize {
+		for j := 0; j < n; j++ {
+			for i := j + 1; i < n; i++ {
+				c.Data[i+j*n] = c.Data[j+i*n]
+			}
+		}
+	}
+	return
+}
@@ -6,6 +6,8 @@ package la
 
 import (
 	"math"
+	"runtime"
+	"sync"
 
 	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/la/oblas"
@@ -26,6 +28,27 @@ func DenSolve(x Vector, A *Matrix, b Vector, preserveA bool) {
 	oblas.Dgesv(A.M, 1, a.Data, A.M, ipiv, x, A.M)
 }
 
+// DenSolveMat solves dense linear systems with many right-hand sides using LAPACK (OpenBLAS)
+//
+//   Given:  A ⋅ X = B    find X   such that   X = A⁻¹ ⋅ B
+//
+//  where each column of B[n][nrhs] is a right-hand side. A is factorised only once and all
+//  columns are solved by a single call to LAPACK
+//
+func DenSolveMat(X, A, B *Matrix, preserveA bool) {
+	if A.M != A.N || B.M != A.M || X.M != B.M || X.N != B.N {
+		chk.Panic("A must be square and X and B must be [%d][nrhs]. A=(%d,%d), X=(%d,%d), B=(%d,%d) are invalid\n", A.M, A.M, A.N, X.M, X.N, B.M, B.N)
+	}
+	a := A
+	if preserveA {
+		a = NewMatrix(A.M, A.N)
+		copy(a.Data, A.Data)
+	}
+	copy(X.Data, B.Data)
+	ipiv := make([]int32, A.M)
+	oblas.Dgesv(A.M, B.N, a.Data, A.M, ipiv, X.Data, A.M)
+}
+
 // Cholesky returns the Cholesky decomposition of a symmetric positive-definite matrix
 //
 //   a = L * trans(L)
@@ -49,6 +72,69 @@ func Cholesky(L, a *Matrix) {
 	}
 }
 
+// CholeskyUpdate updates the Cholesky factor L (in place) after a rank-1 modification
+//
+//   a + x⋅xᵀ = L̄ ⋅ trans(L̄)    where    a = L ⋅ trans(L)
+//
+//  The update applies a sequence of Givens rotations and requires O(n²) operations instead of the
+//  O(n³) operations of a new factorisation. NOTE: x is not modified
+//
+func CholeskyUpdate(L *Matrix, x Vector) {
+	n := L.M
+	if len(x) != n {
+		chk.Panic("len(x) must be equal to the dimension of L. %d != %d\n", len(x), n)
+	}
+	w := x.GetCopy()
+	for k := 0; k < n; k++ {
+		lkk := L.Get(k, k)
+		r := math.Hypot(lkk, w[k])
+		c, s := r/lkk, w[k]/lkk
+		L.Set(k, k, r)
+		for i := k + 1; i < n; i++ {
+			lik := (L.Get(i, k) + s*w[i]) / c
+			L.Set(i, k, lik)
+			w[i] = c*w[i] - s*lik
+		}
+	}
+}
+
+// CholeskyDowndate updates the Cholesky factor L (in place) after a rank-1 modification
+//
+//   a - x⋅xᵀ = L̄ ⋅ trans(L̄)    where    a = L ⋅ trans(L)
+//
+//  The downdate applies a sequence of hyperbolic rotations and requires O(n²) operations.
+//  An error is returned if a - x⋅xᵀ is not positive-definite; i.e. if ‖p‖ ≥ 1 with L⋅p = x.
+//  In this case, L is not modified. NOTE: x is not modified
+//
+func CholeskyDowndate(L *Matrix, x Vector) (err error) {
+
+	// check
+	n := L.M
+	if len(x) != n {
+		return chk.Err("len(x) must be equal to the dimension of L. %d != %d", len(x), n)
+	}
+	p := NewVector(n)
+	CholeskySolveL(p, L, x) // solve L*p = x
+	if 1.0-VecDot(p, p) <= 0 {
+		return chk.Err("Cholesky downdate failed due to non positive-definite matrix")
+	}
+
+	// hyperbolic rotations
+	w := x.GetCopy()
+	for k := 0; k < n; k++ {
+		lkk := L.Get(k, k)
+		r := math.Sqrt((lkk - w[k]) * (lkk + w[k]))
+		c, s := r/lkk, w[k]/lkk
+		L.Set(k, k, r)
+		for i := k + 1; i < n; i++ {
+			lik := (L.Get(i, k) - s*w[i]) / c
+			L.Set(i, k, lik)
+			w[i] = c*w[i] - s*lik
+		}
+	}
+	return
+}
+
 // SolveRealLinSysSPD solves a linear system with real numbres and a Symmetric-Positive-Definite (SPD) matrix
 //
 //        x := inv(a) * b
@@ -60,23 +146,8 @@ func SolveRealLinSysSPD(x Vector, a *Matrix, b Vector) {
 	L := NewMatrix(a.M, a.M)
 	Cholesky(L, a)
 
-	// solve L*y = b storing y in x
-	for i := 0; i < a.M; i++ {
-		bmsum := b[i]
-		for k := 0; k < i; k++ {
-			bmsum -= L.Get(i, k) * x[k]
-		}
-		x[i] = bmsum / L.Get(i, i)
-	}
-
-	// solve trans(L)*x = y with y==x
-	for i := a.M - 1; i >= 0; i-- {
-		bmsum := x[i]
-		for k := i + 1; k < a.M; k++ {
-			bmsum -= L.Get(k, i) * x[k]
-		}
-		x[i] = bmsum / L.Get(i, i)
-	}
+	// solve
+	CholeskySolve(x, L, b)
 }
 
 // SolveTwoRealLinSysSPD solves two linear systems with real numbres and Symmetric-Positive-Definite (SPD) matrices
@@ -114,3 +185,92 @@ func SolveTwoRealLinSysSPD(x, X Vector, a *Matrix, b, B Vector) {
 		X[i] = Bmsum / L.Get(i, i)
 	}
 }
+
+// CholeskySolve solves a linear system using the Cholesky factor L computed by Cholesky
+//
+//        x := inv(L * trans(L)) * b
+//
+//   NOTE: x and b may be the same vector
+func CholeskySolve(x Vector, L *Matrix, b Vector) {
+
+	// solve L*y = b storing y in x
+	CholeskySolveL(x, L, b)
+
+	// solve trans(L)*x = y with y==x
+	for i := L.M - 1; i >= 0; i-- {
+		bmsum := x[i]
+		for k := i + 1; k < L.M; k++ {
+			bmsum -= L.Get(k, i) * x[k]
+		}
+		x[i] = bmsum / L.Get(i, i)
+	}
+}
+
+// CholeskySolveL solves the lower triangular system with the Cholesky factor L computed by
+// Cholesky (forward substitution)
+//
+//        x := inv(L) * b
+//
+//   NOTE: x and b may be the same vector
+func CholeskySolveL(x Vector, L *Matrix, b Vector) {
+	for i := 0; i < L.M; i++ {
+		bmsum := b[i]
+		for k := 0; k < i; k++ {
+			bmsum -= L.Get(i, k) * x[k]
+		}
+		x[i] = bmsum / L.Get(i, i)
+	}
+}
+
+// CholeskySolveMat solves linear systems with many right-hand sides using the Cholesky factor L
+// computed by Cholesky
+//
+//        X := inv(L * trans(L)) * B
+//
+//   where each column of B[n][nrhs] is a right-hand side
+func CholeskySolveMat(X, L, B *Matrix) {
+	CholeskySolveMatPar(X, L, B, 1)
+}
+
+// CholeskySolveMatPar solves linear systems with many right-hand sides using the Cholesky factor
+// L computed by Cholesky. The columns of B are distributed among nworkers goroutines
+//
+//        X := inv(L * trans(L)) * B
+//
+//  INPUT:
+//   L        -- Cholesky factor [n][n] (not modified; shared by all goroutines)
+//   B        -- right-hand sides; each column is one right-hand side [n][nrhs]
+//   nworkers -- number of goroutines; use 0 for runtime.NumCPU()
+//
+//  OUTPUT:
+//   X -- solutions; each column is one solution [n][nrhs]. NOTE: X and B may be the same matrix
+//
+func CholeskySolveMatPar(X, L, B *Matrix, nworkers int) {
+	n, nrhs := L.M, B.N
+	if L.N != n || B.M != n || X.M != n || X.N != nrhs {
+		chk.Panic("L must be square and X and B must be [%d][nrhs]. L=(%d,%d), X=(%d,%d), B=(%d,%d) are invalid\n", n, L.M, L.N, X.M, X.N, B.M, B.N)
+	}
+	if nworkers < 1 {
+		nworkers = runtime.NumCPU()
+	}
+	if nworkers > nrhs {
+		nworkers = nrhs
+	}
+	if nworkers < 2 { // avoid goroutines
+		for j := 0; j < nrhs; j++ {
+			CholeskySolve(X.Col(j), L, B.Col(j))
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < nworkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for j := w; j < nrhs; j += nworkers {
+				CholeskySolve(X.Col(j), L, B.Col(j))
+			}
+		}(w)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,296 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// PowerIteration estimates the dominant eigenpair (largest |λ|) of a square linear operator
+//
+//   v ← A⋅v / ‖A⋅v‖    and    λ = vᵀ⋅A⋅v
+//
+//  INPUT:
+//   op    -- square linear operator (matrix-free)
+//   tol   -- tolerance on the residual: ‖A⋅v - λ⋅v‖ ≤ tol⋅|λ|
+//   maxit -- maximum number of iterations
+//
+//  OUTPUT:
+//   lambda -- dominant eigenvalue
+//   v      -- corresponding eigenvector (normalised; ‖v‖ = 1)
+//   err    -- error if the tolerance is not achieved
+//
+//  NOTE: the convergence rate is |λ₂/λ₁|; thus the dominant eigenvalue must be well separated
+//
+func PowerIteration(op LinearOperator, tol float64, maxit int) (lambda float64, v Vector, err error) {
+
+	// check
+	n, err := checkEigIter(op)
+	if err != nil {
+		return
+	}
+
+	// iterations
+	v = eigIterStart(n)
+	w := NewVector(n)
+	var res float64
+	for it := 0; it < maxit; it++ {
+		op.Apply(w, v)
+		lambda = VecDot(v, w)
+		res = eigIterResidual(w, lambda, v)
+		wnorm := w.Norm()
+		if wnorm == 0 {
+			return 0, v, nil // v is in the null space of A and all eigenvalues are zero
+		}
+		if res <= tol*math.Abs(lambda) {
+			return
+		}
+		v.Apply(1.0/wnorm, w)
+	}
+	err = chk.Err("power iteration did not converge after %d iterations. ‖A⋅v - λ⋅v‖ = %g", maxit, res)
+	return
+}
+
+// InversePowerIteration estimates the eigenpair with the smallest |λ| of a square matrix A by
+// applying the power iteration to A⁻¹
+//
+//   v ← A⁻¹⋅v / ‖A⁻¹⋅v‖    and    λ = 1 / (vᵀ⋅A⁻¹⋅v)
+//
+//  INPUT:
+//   solve -- function returning the solution w of A⋅w = x; e.g. with a factorised matrix or a
+//            Krylov solver. NOTE: x must not be modified
+//   n     -- dimension of A
+//   tol   -- tolerance on the residual: ‖A⁻¹⋅v - μ⋅v‖ ≤ tol⋅|μ| with μ = 1/λ
+//   maxit -- maximum number of iterations
+//
+//  OUTPUT:
+//   lambda -- eigenvalue with the smallest magnitude
+//   v      -- corresponding eigenvector (normalised; ‖v‖ = 1)
+//   err    -- error if the tolerance is not achieved
+//
+func InversePowerIteration(solve func(x Vector) Vector, n int, tol float64, maxit int) (lambda float64, v Vector, err error) {
+
+	// check
+	if n < 1 {
+		return 0, nil, chk.Err("dimension of matrix must be at least 1. n=%d is invalid", n)
+	}
+
+	// iterations
+	v = eigIterStart(n)
+	var μ, res float64
+	for it := 0; it < maxit; it++ {
+		w := solve(v)
+		if len(w) != n {
+			return 0, v, chk.Err("solve must return a vector of length %d. %d is invalid", n, len(w))
+		}
+		μ = VecDot(v, w)
+		res = eigIterResidual(w, μ, v)
+		if μ == 0 {
+			return 0, v, chk.Err("inverse power iteration failed: vᵀ⋅A⁻¹⋅v = 0")
+		}
+		if res <= tol*math.Abs(μ) {
+			return 1.0 / μ, v, nil
+		}
+		v.Apply(1.0/w.Norm(), w)
+	}
+	lambda = 1.0 / μ
+	err = chk.Err("inverse power iteration did not converge after %d iterations. ‖A⁻¹⋅v - μ⋅v‖ = %g", maxit, res)
+	return
+}
+
+// RayleighQuotientIteration refines an eigenpair of a square matrix A starting from a rough
+// approximation v0 of the eigenvector. Each iteration computes
+//
+//   (A - σ⋅I)⋅w = v    v ← w / ‖w‖    and    σ = vᵀ⋅A⋅v
+//
+//  INPUT:
+//   A     -- square matrix
+//   v0    -- initial guess of the eigenvector (not modified)
+//   tol   -- tolerance on the residual: ‖A⋅v - λ⋅v‖ ≤ tol⋅|λ|
+//   maxit -- maximum number of iterations
+//
+//  OUTPUT:
+//   lambda -- eigenvalue (the one nearest to the Rayleigh quotient of v0)
+//   v      -- corresponding eigenvector (normalised; ‖v‖ = 1)
+//   err    -- error if the tolerance is not achieved
+//
+//  NOTE: (1) the convergence is cubic for symmetric matrices and quadratic otherwise
+//        (2) A - σ⋅I becomes nearly singular as σ approaches λ; this is harmless because the
+//            (large) error in w lies in the direction of the eigenvector. Zero pivots are then
+//            replaced by ϵ⋅‖A‖ in the LU factorisation
+//
+func RayleighQuotientIteration(A *Matrix, v0 Vector, tol float64, maxit int) (lambda float64, v Vector, err error) {
+
+	// check
+	n, err := checkEigIter(NewMatrixOp(A))
+	if err != nil {
+		return
+	}
+	if len(v0) != n {
+		return 0, nil, chk.Err("length of initial vector must be equal to %d. %d is invalid", n, len(v0))
+	}
+	v = v0.GetCopy()
+	vnorm := v.Norm()
+	if vnorm == 0 {
+		return 0, nil, chk.Err("initial vector must not be zero")
+	}
+	v.Apply(1.0/vnorm, v)
+
+	// iterations
+	S := NewMatrix(n, n)
+	w := NewVector(n)
+	piv := make([]int, n)
+	ϵ := math.Max(A.NormFrob(), 1) * (math.Nextafter(1, 2) - 1.0)
+	var res float64
+	for it := 0; it <= maxit; it++ {
+
+		// Rayleigh quotient and residual
+		MatVecMul(w, 1, A, v)
+		lambda = VecDot(v, w)
+		res = eigIterResidual(w, lambda, v)
+		if res <= tol*math.Abs(lambda) || res == 0 {
+			return
+		}
+		if it == maxit {
+			break
+		}
+
+		// solve (A - σ⋅I)⋅w = v
+		copy(S.Data, A.Data)
+		for i := 0; i < n; i++ {
+			S.Add(i, i, -lambda)
+		}
+		luFactorPivotFix(S, piv, ϵ)
+		luSolve(w, S, piv, v)
+		v.Apply(1.0/w.Norm(), w)
+	}
+	err = chk.Err("Rayleigh quotient iteration did not converge after %d iterations. ‖A⋅v - λ⋅v‖ = %g", maxit, res)
+	return
+}
+
+// Deflate returns a copy of A with the contribution of the eigenpair (λ,v) removed (Hotelling
+// deflation); thus, a subsequent power iteration finds the next dominant eigenvalue
+//
+//   B = A - λ ⋅ v ⋅ vᵀ / (vᵀ⋅v)
+//
+//  For a symmetric A, the eigenvalues of B are those of A with λ replaced by 0; the eigenvectors
+//  are the same. v does not need to be normalised
+//
+//  NOTE: (1) A must be symmetric; otherwise the other eigenvalues are changed as well
+//        (2) the errors in (λ,v) are carried over to B; hence, after repeated deflations, the
+//            accuracy of the computed eigenpairs deteriorates. Only a few eigenpairs should be
+//            computed in this way and each one may be refined with RayleighQuotientIteration
+//            using the original matrix
+//        (3) B is dense even if A is sparse
+//
+func Deflate(A *Matrix, lambda float64, v Vector) (B *Matrix) {
+	if A.M != A.N || len(v) != A.M {
+		chk.Panic("matrix must be square and len(v) must be equal to the dimension of A. %d×%d and len(v)=%d are invalid\n", A.M, A.N, len(v))
+	}
+	vv := VecDot(v, v)
+	if vv == 0 {
+		chk.Panic("eigenvector must not be zero\n")
+	}
+	B = A.GetCopy()
+	α := lambda / vv
+	for j := 0; j < A.N; j++ {
+		for i := 0; i < A.M; i++ {
+			B.Add(i, j, -α*v[i]*v[j])
+		}
+	}
+	return
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// checkEigIter checks that the operator of an eigenvalue iteration is square and not empty
+func checkEigIter(op LinearOperator) (n int, err error) {
+	m, n := op.Dim()
+	if m != n || n < 1 {
+		return 0, chk.Err("operator must be square and not empty. %d×%d is invalid", m, n)
+	}
+	return
+}
+
+// eigIterStart returns the (normalised) starting vector of eigenvalue iterations. The components
+// are all different, thus the vector is unlikely to be orthogonal to the sought eigenvector
+func eigIterStart(n int) (v Vector) {
+	v = NewVector(n)
+	for i := 0; i < n; i++ {
+		v[i] = 1.0 + 1.0/float64(i+1)
+	}
+	v.Apply(1.0/v.Norm(), v)
+	return
+}
+
+// eigIterResidual computes ‖w - λ⋅v‖
+func eigIterResidual(w Vector, λ float64, v Vector) float64 {
+	var sum float64
+	for i := 0; i < len(v); i++ {
+		d := w[i] - λ*v[i]
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+// luFactorPivotFix computes the LU factorisation with partial pivoting of a (overwritten); i.e.
+// P⋅a = L⋅U. Pivots smaller than ϵ in magnitude are replaced by ±ϵ
+func luFactorPivotFix(a *Matrix, piv []int, ϵ float64) {
+	n := a.M
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a.Get(i, k)) > math.Abs(a.Get(p, k)) {
+				p = i
+			}
+		}
+		piv[k] = p
+		if p != k {
+			for j := 0; j < n; j++ {
+				akj := a.Get(k, j)
+				a.Set(k, j, a.Get(p, j))
+				a.Set(p, j, akj)
+			}
+		}
+		if math.Abs(a.Get(k, k)) < ϵ {
+			if math.Signbit(a.Get(k, k)) {
+				a.Set(k, k, -ϵ)
+			} else {
+				a.Set(k, k, ϵ)
+			}
+		}
+		for i := k + 1; i < n; i++ {
+			lik := a.Get(i, k) / a.Get(k, k)
+			a.Set(i, k, lik)
+			for j := k + 1; j < n; j++ {
+				a.Add(i, j, -lik*a.Get(k, j))
+			}
+		}
+	}
+}
+
+// luSolve solves a⋅x = b using the factorisation computed by luFactorPivotFix
+//  NOTE: luFactorPivotFix swaps whole rows (including the multipliers of L); thus, all row
+//        interchanges must be applied to b before the forward substitution; i.e. L⋅y = P⋅b
+func luSolve(x Vector, a *Matrix, piv []int, b Vector) {
+	n := a.M
+	copy(x, b)
+	for k := 0; k < n; k++ {
+		x[k], x[piv[k]] = x[piv[k]], x[k]
+	}
+	for k := 0; k < n; k++ {
+		for i := k + 1; i < n; i++ {
+			x[i] -= a.Get(i, k) * x[k]
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			x[i] -= a.Get(i, j) * x[j]
+		}
+		x[i] /= a.Get(i, i)
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// KrylovOpts holds options for the Krylov (iterative) solvers of linear systems
+type KrylovOpts struct {
+	Tol     float64 // tolerance on the relative residual: ‖b - A⋅x‖ ≤ Tol⋅‖b‖ [default = 1e-10]
+	MaxIt   int     // maximum number of iterations [default = 10⋅n]
+	Restart int     // dimension of the Krylov subspace before restarting GMRES [default = min(n,30)]
+}
+
+// defaults returns a copy of the options with default values set
+func (o *KrylovOpts) defaults(n int) (opt KrylovOpts) {
+	if o != nil {
+		opt = *o
+	}
+	if opt.Tol <= 0 {
+		opt.Tol = 1e-10
+	}
+	if opt.MaxIt < 1 {
+		opt.MaxIt = 10 * n
+	}
+	if opt.Restart < 1 {
+		opt.Restart = 30
+	}
+	if opt.Restart > n {
+		opt.Restart = n
+	}
+	return
+}
+
+// SolveCG solves A⋅x = b using the conjugate gradient method
+//
+//  INPUT:
+//   A   -- symmetric positive-definite linear operator
+//   x   -- initial guess
+//   b   -- right-hand side
+//   opt -- options [may be nil ⇒ use default values]
+//
+//  OUTPUT:
+//   x   -- solution
+//   nit -- number of iterations
+//   err -- error if the tolerance is not achieved
+//
+func SolveCG(A LinearOperator, x, b Vector, opt *KrylovOpts) (nit int, err error) {
+
+	// check
+	n, err := checkKrylov(A, x, b)
+	if err != nil {
+		return
+	}
+	o := opt.defaults(n)
+
+	// initial residual r := b - A⋅x
+	r, p, Ap := NewVector(n), NewVector(n), NewVector(n)
+	A.Apply(r, x)
+	VecAdd(r, 1, b, -1, r)
+	tol := o.Tol * b.Norm()
+	copy(p, r)
+	rr := VecDot(r, r)
+
+	// iterations
+	for nit = 0; nit < o.MaxIt; nit++ {
+		if math.Sqrt(rr) <= tol {
+			return
+		}
+		A.Apply(Ap, p)
+		pAp := VecDot(p, Ap)
+		if pAp <= 0 {
+			return nit, chk.Err("CG failed: operator is not positive-definite (pᵀ⋅A⋅p = %g)", pAp)
+		}
+		α := rr / pAp
+		VecAdd(x, 1, x, α, p)
+		VecAdd(r, 1, r, -α, Ap)
+		rrNew := VecDot(r, r)
+		VecAdd(p, 1, r, rrNew/rr, p)
+		rr = rrNew
+	}
+	if math.Sqrt(rr) <= tol {
+		return
+	}
+	return nit, chk.Err("CG did not converge after %d iterations. ‖r‖ = %g", nit, math.Sqrt(rr))
+}
+
+// SolveBiCGStab solves A⋅x = b using the biconjugate gradient stabilized method
+//
+//  INPUT:
+//   A   -- square (possibly nonsymmetric) linear operator
+//   x   -- initial guess
+//   b   -- right-hand side
+//   opt -- options [may be nil ⇒ use default values]
+//
+//  OUTPUT:
+//   x   -- solution
+//   nit -- number of iterations
+//   err -- error if the tolerance is not achieved or a breakdown occurs
+//
+//  Reference:
+//    [1] van der Vorst HA (1992) Bi-CGSTAB: a fast and smoothly converging variant of Bi-CG for
+//        the solution of nonsymmetric linear systems. SIAM J. Sci. Stat. Comput., 13(2):631-644
+//
+func SolveBiCGStab(A LinearOperator, x, b Vector, opt *KrylovOpts) (nit int, err error) {
+
+	// check
+	n, err := checkKrylov(A, x, b)
+	if err != nil {
+		return
+	}
+	o := opt.defaults(n)
+
+	// initial residual r := b - A⋅x
+	r, r0, p, v := NewVector(n), NewVector(n), NewVector(n), NewVector(n)
+	s, t := NewVector(n), NewVector(n)
+	A.Apply(r, x)
+	VecAdd(r, 1, b, -1, r)
+	copy(r0, r)
+	tol := o.Tol * b.Norm()
+	ρ, α, ω := 1.0, 1.0, 1.0
+
+	// iterations
+	for nit = 0; nit < o.MaxIt; nit++ {
+		if r.Norm() <= tol {
+			return
+		}
+		ρNew := VecDot(r0, r)
+		if ρNew == 0 {
+			return nit, chk.Err("BiCGStab failed: breakdown with ρ = 0")
+		}
+		β := (ρNew / ρ) * (α / ω)
+		ρ = ρNew
+		for i := 0; i < n; i++ { // p := r + β⋅(p - ω⋅v)
+			p[i] = r[i] + β*(p[i]-ω*v[i])
+		}
+		A.Apply(v, p)
+		α = ρ / VecDot(r0, v)
+		VecAdd(s, 1, r, -α, v) // s := r - α⋅v
+		if s.Norm() <= tol {
+			VecAdd(x, 1, x, α, p)
+			copy(r, s)
+			nit++
+			return
+		}
+		A.Apply(t, s)
+		tt := VecDot(t, t)
+		if tt == 0 {
+			return nit, chk.Err("BiCGStab failed: breakdown with tᵀ⋅t = 0")
+		}
+		ω = VecDot(t, s) / tt
+		if ω == 0 {
+			return nit, chk.Err("BiCGStab failed: breakdown with ω = 0")
+		}
+		for i := 0; i < n; i++ {
+			x[i] += α*p[i] + ω*s[i]
+			r[i] = s[i] - ω*t[i]
+		}
+	}
+	if r.Norm() <= tol {
+		return
+	}
+	return nit, chk.Err("BiCGStab did not converge after %d iterations. ‖r‖ = %g", nit, r.Norm())
+}
+
+// SolveGMRES solves A⋅x = b using the restarted generalized minimal residual method GMRES(m)
+//
+//  INPUT:
+//   A   -- square (possibly nonsymmetric) linear operator
+//   x   -- initial guess
+//   b   -- right-hand side
+//   opt -- options [may be nil ⇒ use default values]; Restart is the dimension m
+//
+//  OUTPUT:
+//   x   -- solution
+//   nit -- number of iterations (products by A in the Arnoldi process)
+//   err -- error if the tolerance is not achieved
+//
+//  Reference:
+//    [1] Saad Y and Schultz MH (1986) GMRES: a generalized minimal residual algorithm for solving
+//        nonsymmetric linear systems. SIAM J. Sci. Stat. Comput., 7(3):856-869
+//
+func SolveGMRES(A LinearOperator, x, b Vector, opt *KrylovOpts) (nit int, err error) {
+
+	// check
+	n, err := checkKrylov(A, x, b)
+	if err != nil {
+		return
+	}
+	o := opt.defaults(n)
+
+	// workspace
+	m := o.Restart
+	r := NewVector(n)
+	v := make([]Vector, m+1)
+	h := make([][]float64, m+1)
+	for i := 0; i <= m; i++ {
+		v[i] = NewVector(n)
+		h[i] = make([]float64, m)
+	}
+	cs, sn := make([]float64, m), make([]float64, m)
+	g, y := make([]float64, m+1), make([]float64, m)
+	tol := o.Tol * b.Norm()
+
+	// restarts
+	for {
+
+		// residual r := b - A⋅x
+		A.Apply(r, x)
+		VecAdd(r, 1, b, -1, r)
+		β := r.Norm()
+		if β <= tol {
+			return
+		}
+		if nit >= o.MaxIt {
+			return nit, chk.Err("GMRES did not converge after %d iterations. ‖r‖ = %g", nit, β)
+		}
+
+		// start Arnoldi process
+		v[0].Apply(1.0/β, r)
+		for i := range g {
+			g[i] = 0
+		}
+		g[0] = β
+
+		// Arnoldi iterations
+		k := 0
+		for k < m && nit < o.MaxIt {
+			nit++
+
+			// new direction (modified Gram-Schmidt)
+			w := v[k+1]
+			A.Apply(w, v[k])
+			for i := 0; i <= k; i++ {
+				h[i][k] = VecDot(w, v[i])
+				VecAdd(w, 1, w, -h[i][k], v[i])
+			}
+			h[k+1][k] = w.Norm()
+			if h[k+1][k] > 0 {
+				w.Apply(1.0/h[k+1][k], w)
+			}
+
+			// apply previous Givens rotations to the new column
+			for i := 0; i < k; i++ {
+				t := cs[i]*h[i][k] + sn[i]*h[i+1][k]
+				h[i+1][k] = -sn[i]*h[i][k] + cs[i]*h[i+1][k]
+				h[i][k] = t
+			}
+
+			// new Givens rotation
+			d := math.Hypot(h[k][k], h[k+1][k])
+			if d == 0 {
+				cs[k], sn[k] = 1, 0
+			} else {
+				cs[k], sn[k] = h[k][k]/d, h[k+1][k]/d
+			}
+			h[k][k] = d
+			h[k+1][k] = 0
+			g[k+1] = -sn[k] * g[k]
+			g[k] = cs[k] * g[k]
+			k++
+
+			// check convergence (or breakdown)
+			if math.Abs(g[k]) <= tol || d == 0 {
+				break
+			}
+		}
+
+		// solve upper triangular system H⋅y = g and update x
+		for i := k - 1; i >= 0; i-- {
+			y[i] = g[i]
+			for j := i + 1; j < k; j++ {
+				y[i] -= h[i][j] * y[j]
+			}
+			if h[i][i] != 0 {
+				y[i] /= h[i][i]
+			}
+		}
+		for i := 0; i < k; i++ {
+			VecAdd(x, 1, x, y[i], v[i])
+		}
+	}
+}
+
+// checkKrylov checks the dimensions of the linear system
+func checkKrylov(A LinearOperator, x, b Vector) (n int, err error) {
+	m, n := A.Dim()
+	if m != n {
+		return 0, chk.Err("operator must be square. %d×%d is invalid", m, n)
+	}
+	if len(x) != n || len(b) != n {
+		return 0, chk.Err("lengths of x and b must be equal to %d. len(x)=%d and len(b)=%d are invalid", n, len(x), len(b))
+	}
+	return
+}
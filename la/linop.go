@@ -0,0 +1,64 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+// LinearOperator defines a (matrix-free) linear operator A; i.e. anything that can compute the
+// product of a matrix by a vector without having the matrix assembled
+//
+//   y := A ⋅ x    with y[m] and x[n]
+//
+type LinearOperator interface {
+	Apply(y, x Vector) // computes y := A ⋅ x
+	Dim() (m, n int)   // returns the dimensions of A
+}
+
+// LinOpFunc implements a LinearOperator using a function computing the matrix-vector product
+type LinOpFunc struct {
+	M, N int               // dimensions
+	F    func(y, x Vector) // computes y := A ⋅ x
+}
+
+// NewLinOpFunc returns a new LinearOperator defined by the function f computing y := A ⋅ x
+func NewLinOpFunc(m, n int, f func(y, x Vector)) *LinOpFunc {
+	return &LinOpFunc{M: m, N: n, F: f}
+}
+
+// Apply computes y := A ⋅ x
+func (o *LinOpFunc) Apply(y, x Vector) { o.F(y, x) }
+
+// Dim returns the dimensions of A
+func (o *LinOpFunc) Dim() (m, n int) { return o.M, o.N }
+
+// CCMatrixOp wraps a column-compressed sparse matrix as a LinearOperator
+type CCMatrixOp struct {
+	A *CCMatrix
+}
+
+// NewCCMatrixOp returns a LinearOperator wrapping the sparse matrix A (not copied)
+func NewCCMatrixOp(A *CCMatrix) *CCMatrixOp {
+	return &CCMatrixOp{A}
+}
+
+// Apply computes y := A ⋅ x
+func (o *CCMatrixOp) Apply(y, x Vector) { SpMatVecMul(y, 1, o.A, x) }
+
+// Dim returns the dimensions of A
+func (o *CCMatrixOp) Dim() (m, n int) { return o.A.m, o.A.n }
+
+// MatrixOp wraps a dense matrix as a LinearOperator
+type MatrixOp struct {
+	A *Matrix
+}
+
+// NewMatrixOp returns a LinearOperator wrapping the dense matrix A (not copied)
+func NewMatrixOp(A *Matrix) *MatrixOp {
+	return &MatrixOp{A}
+}
+
+// Apply computes y := A ⋅ x
+func (o *MatrixOp) Apply(y, x Vector) { MatVecMul(y, 1, o.A, x) }
+
+// Dim returns the dimensions of A
+func (o *MatrixOp) Dim() (m, n int) { return o.A.M, o.A.N }
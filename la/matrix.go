@@ -231,6 +231,25 @@ func (o *Matrix) Col(j int) Vector {
 	return o.Data[j*o.M : (j+1)*o.M]
 }
 
+// Row returns row i of this matrix. Since the internal data are in col-major format, the
+// components of a row are not contiguous unless the matrix has a single row; hence a copy is
+// returned if M > 1 and a view (aliasing the internal data) is returned if M == 1
+// NOTE: use RowIsView to find whether modifications of the returned vector propagate or not
+func (o *Matrix) Row(i int) Vector {
+	if i < 0 || i >= o.M {
+		chk.Panic("row index must be in [0, %d). i=%d is invalid\n", o.M, i)
+	}
+	if o.RowIsView() {
+		return o.Data[:o.N]
+	}
+	return o.GetRow(i)
+}
+
+// RowIsView returns whether Row returns views (M == 1) or copies (M > 1). Col always returns views
+func (o *Matrix) RowIsView() bool {
+	return o.M == 1
+}
+
 // GetRow returns row i of this matrix
 func (o *Matrix) GetRow(i int) (row Vector) {
 	row = make([]float64, o.N)
@@ -304,6 +323,19 @@ func (o Matrix) Apply(α float64, another *Matrix) {
 	}
 }
 
+// Rank1Update adds the rank-1 matrix α⋅u⋅vᵀ to this matrix (BLAS dger)
+//  this += α⋅u⋅vᵀ   ⇒   this[i][j] += α * u[i] * v[j]
+//  NOTE: len(u) must be equal to M and len(v) must be equal to N
+func (o *Matrix) Rank1Update(α float64, u, v Vector) {
+	if len(u) != o.M {
+		chk.Panic("length of vector u must be equal to %d. u_(%d × 1). a_(%d × %d)\n", o.M, len(u), o.M, o.N)
+	}
+	if len(v) != o.N {
+		chk.Panic("length of vector v must be equal to %d. v_(%d × 1). a_(%d × %d)\n", o.N, len(v), o.M, o.N)
+	}
+	oblas.Dger(o.M, o.N, α, u, 1, v, 1, o.Data, o.M)
+}
+
 // Det computes the determinant of matrix using the LU factorization
 //   NOTE: this method may fail due to overflow...
 func (o *Matrix) Det() (det float64) {
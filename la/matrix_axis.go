@@ -0,0 +1,142 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/utl"
+)
+
+// ApplyAxis applies a reduction function to each row (axis=0) or each column (axis=1) of a matrix
+//
+//   axis = 0 ⇒ res[i] = f(row i)  with len(res) = m.M
+//   axis = 1 ⇒ res[j] = f(col j)  with len(res) = m.N
+//
+//  NOTE: v is a workspace vector holding a copy of the row or column; thus f may modify v but
+//        must not keep a reference to it
+func ApplyAxis(m *Matrix, axis int, f func(v Vector) float64) (res Vector) {
+	switch axis {
+	case 0:
+		res = NewVector(m.M)
+		v := NewVector(m.N)
+		for i := 0; i < m.M; i++ {
+			m.rowInto(v, i)
+			res[i] = f(v)
+		}
+	case 1:
+		res = NewVector(m.N)
+		v := NewVector(m.M)
+		for j := 0; j < m.N; j++ {
+			copy(v, m.Col(j))
+			res[j] = f(v)
+		}
+	default:
+		chk.Panic("axis must be 0 (rows) or 1 (columns). axis=%d is invalid\n", axis)
+	}
+	return
+}
+
+// ApplyAxisVec applies a vector-valued function to each row (axis=0) or each column (axis=1) of
+// a matrix. The results are stored in the corresponding rows or columns of a new matrix
+//
+//   axis = 0 ⇒ res(i,:) = f(row i)  with res = [m.M][len(f)]
+//   axis = 1 ⇒ res(:,j) = f(col j)  with res = [len(f)][m.N]
+//
+//  NOTE: (1) f must return vectors with the same length for all rows or columns
+//        (2) v is a workspace vector holding a copy of the row or column; thus f may modify v
+//            but must not keep a reference to it
+func ApplyAxisVec(m *Matrix, axis int, f func(v Vector) Vector) (res *Matrix) {
+	switch axis {
+	case 0:
+		v := NewVector(m.N)
+		for i := 0; i < m.M; i++ {
+			m.rowInto(v, i)
+			r := f(v)
+			if res == nil {
+				res = NewMatrix(m.M, len(r))
+			}
+			if len(r) != res.N {
+				chk.Panic("f must return vectors with the same length. len(f(row %d)) = %d is invalid; %d is required\n", i, len(r), res.N)
+			}
+			for j := 0; j < res.N; j++ {
+				res.Set(i, j, r[j])
+			}
+		}
+	case 1:
+		v := NewVector(m.M)
+		for j := 0; j < m.N; j++ {
+			copy(v, m.Col(j))
+			r := f(v)
+			if res == nil {
+				res = NewMatrix(len(r), m.N)
+			}
+			if len(r) != res.M {
+				chk.Panic("f must return vectors with the same length. len(f(col %d)) = %d is invalid; %d is required\n", j, len(r), res.M)
+			}
+			copy(res.Col(j), r)
+		}
+	default:
+		chk.Panic("axis must be 0 (rows) or 1 (columns). axis=%d is invalid\n", axis)
+	}
+	return
+}
+
+// MinAxis returns the minimum of each row (axis=0) or each column (axis=1) of a matrix
+//  NOTE: NaN values are ignored; rows or columns full of NaN give NaN
+func MinAxis(m *Matrix, axis int) (res Vector) {
+	res, _ = extremaAxis(m, axis, utl.ArgMin)
+	return
+}
+
+// MaxAxis returns the maximum of each row (axis=0) or each column (axis=1) of a matrix
+//  NOTE: NaN values are ignored; rows or columns full of NaN give NaN
+func MaxAxis(m *Matrix, axis int) (res Vector) {
+	res, _ = extremaAxis(m, axis, utl.ArgMax)
+	return
+}
+
+// ArgMinAxis returns the indices of the minimum of each row (axis=0) or each column (axis=1)
+// of a matrix; i.e. the column index (axis=0) or the row index (axis=1) of the minimum
+//  NOTE: NaN values are ignored; rows or columns full of NaN give -1
+func ArgMinAxis(m *Matrix, axis int) (idx []int) {
+	_, idx = extremaAxis(m, axis, utl.ArgMin)
+	return
+}
+
+// ArgMaxAxis returns the indices of the maximum of each row (axis=0) or each column (axis=1)
+// of a matrix; i.e. the column index (axis=0) or the row index (axis=1) of the maximum
+//  NOTE: NaN values are ignored; rows or columns full of NaN give -1
+func ArgMaxAxis(m *Matrix, axis int) (idx []int) {
+	_, idx = extremaAxis(m, axis, utl.ArgMax)
+	return
+}
+
+// extremaAxis computes the extrema of rows or columns using argfcn = utl.ArgMin or utl.ArgMax
+func extremaAxis(m *Matrix, axis int, argfcn func(v []float64) (float64, int)) (vals Vector, idx []int) {
+	switch axis {
+	case 0:
+		vals, idx = NewVector(m.M), make([]int, m.M)
+		v := NewVector(m.N)
+		for i := 0; i < m.M; i++ {
+			m.rowInto(v, i)
+			vals[i], idx[i] = argfcn(v)
+		}
+	case 1:
+		vals, idx = NewVector(m.N), make([]int, m.N)
+		for j := 0; j < m.N; j++ {
+			vals[j], idx[j] = argfcn(m.Col(j))
+		}
+	default:
+		chk.Panic("axis must be 0 (rows) or 1 (columns). axis=%d is invalid\n", axis)
+	}
+	return
+}
+
+// rowInto copies row i of this matrix into v
+func (o *Matrix) rowInto(v Vector, i int) {
+	for j := 0; j < o.N; j++ {
+		v[j] = o.Data[i+j*o.M]
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import "github.com/cpmech/gosl/chk"
+
+// MatrixView implements a view of a block (submatrix) of a column-major Matrix
+//
+//  NOTE: the view ALIASES the storage of the parent matrix; i.e. no copies are made, modifications
+//        through the view change the parent (and vice-versa). The columns of the view are
+//        contiguous but separated by the leading dimension Ld (number of rows of the parent)
+//
+//  Example:
+//              _            _
+//             |  0  4  8 12  |
+//        A =  |  1 [5  9]13  |    ⇒    V = A.Slice(1, 3, 1, 3) = | 5  9 |
+//             |  2 [6 10]14  |                                   | 6 10 |
+//             |_ 3  7 11 15 _|
+//
+//     V.Data[i+j*Ld] = A[r0+i][c0+j]
+//
+type MatrixView struct {
+	M, N int       // dimensions of the view
+	Ld   int       // leading dimension; i.e. number of rows of the parent matrix
+	Data []float64 // parent data starting at A[r0][c0] (aliased)
+}
+
+// Slice returns a view of the block A[r0:r1][c0:c1] of this matrix; i.e. rows r0,…,r1-1 and
+// columns c0,…,c1-1
+//  NOTE: the view aliases the storage of this matrix (no copies are made)
+func (o *Matrix) Slice(r0, r1, c0, c1 int) (view *MatrixView) {
+	if r0 < 0 || r1 > o.M || r0 >= r1 || c0 < 0 || c1 > o.N || c0 >= c1 {
+		chk.Panic("block [%d:%d][%d:%d] is invalid for a %d×%d matrix\n", r0, r1, c0, c1, o.M, o.N)
+	}
+	view = new(MatrixView)
+	view.M, view.N, view.Ld = r1-r0, c1-c0, o.M
+	start := r0 + c0*o.M
+	view.Data = o.Data[start : start+(view.N-1)*o.M+view.M]
+	return
+}
+
+// Set sets value of the view; i.e. A[r0+i][c0+j] = val
+func (o *MatrixView) Set(i, j int, val float64) {
+	o.Data[i+j*o.Ld] = val
+}
+
+// Get gets value of the view; i.e. A[r0+i][c0+j]
+func (o *MatrixView) Get(i, j int) float64 {
+	return o.Data[i+j*o.Ld]
+}
+
+// Add adds value to (i,j) location of the view
+func (o *MatrixView) Add(i, j int, val float64) {
+	o.Data[i+j*o.Ld] += val
+}
+
+// Fill fills the block with a single number
+func (o *MatrixView) Fill(val float64) {
+	for j := 0; j < o.N; j++ {
+		for i := 0; i < o.M; i++ {
+			o.Data[i+j*o.Ld] = val
+		}
+	}
+}
+
+// Col accesses column j of the view. No copies are made
+//  NOTE: this method can be used to modify the parent matrix
+func (o *MatrixView) Col(j int) Vector {
+	return o.Data[j*o.Ld : j*o.Ld+o.M]
+}
+
+// GetCopy returns a copy of the block as a new Matrix (not aliased)
+func (o *MatrixView) GetCopy() (clone *Matrix) {
+	clone = NewMatrix(o.M, o.N)
+	for j := 0; j < o.N; j++ {
+		copy(clone.Data[j*o.M:(j+1)*o.M], o.Col(j))
+	}
+	return
+}
+
+// GetDeep2 returns nested slice representation of the block
+func (o *MatrixView) GetDeep2() (M [][]float64) {
+	M = make([][]float64, o.M)
+	for i := 0; i < o.M; i++ {
+		M[i] = make([]float64, o.N)
+		for j := 0; j < o.N; j++ {
+			M[i][j] = o.Data[i+j*o.Ld]
+		}
+	}
+	return
+}
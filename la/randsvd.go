@@ -0,0 +1,189 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/rnd"
+	"github.com/cpmech/gosl/utl"
+)
+
+// TruncatedSVD computes the truncated singular value decomposition of A keeping the k largest
+// singular values, using a randomized algorithm
+//
+//   A ≈ U ⋅ diag(s) ⋅ Vt    with U[m][k], s[k] and Vt[k][n]
+//
+//  See TruncatedSVDEst for details
+func TruncatedSVD(A *Matrix, k int) (U *Matrix, s Vector, Vt *Matrix, err error) {
+	U, s, Vt, _, err = TruncatedSVDEst(A, k)
+	return
+}
+
+// TruncatedSVDEst computes the truncated singular value decomposition of A keeping the k largest
+// singular values, using a randomized algorithm, and estimates the approximation error
+//
+//   A ≈ U ⋅ diag(s) ⋅ Vt    with U[m][k], s[k] and Vt[k][n]
+//
+//  The algorithm is [1]:
+//   (1) Y = A⋅Ω where Ω[n][k+p] is a Gaussian random matrix and p = min(10, min(m,n)-k) is the
+//       oversampling; followed by two power (subspace) iterations to improve the accuracy
+//   (2) Q = orthonormal basis of the range of Y
+//   (3) B = Qᵀ⋅A is small [k+p][n]; its SVD B = Ũ⋅Σ⋅Vᵀ is computed by MatSvd
+//   (4) U = Q⋅Ũ
+//
+//  INPUT:
+//   A -- matrix [m][n]
+//   k -- number of singular values; 1 ≤ k ≤ min(m,n)
+//
+//  OUTPUT:
+//   U      -- left singular vectors [m][k]
+//   s      -- singular values in descending order [k]
+//   Vt     -- transposed right singular vectors [k][n]
+//   errEst -- estimate of the approximation error in the Frobenius norm; i.e.
+//             errEst ≈ ‖A - U⋅diag(s)⋅Vt‖_F = sqrt(‖A‖²_F - Σ s²)
+//   err    -- error if k is invalid
+//
+//  NOTE: (1) the algorithm is much faster than the full SVD for k ≪ min(m,n)
+//        (2) a fixed seed is used; thus the results are reproducible
+//        (3) B⋅Bᵀ is not formed; thus small singular values are not degraded by squaring the
+//            condition number of B
+//
+//  Reference:
+//   [1] Halko N, Martinsson PG and Tropp JA (2011) Finding structure with randomness:
+//       probabilistic algorithms for constructing approximate matrix decompositions. SIAM
+//       Review, 53(2):217-288
+//
+func TruncatedSVDEst(A *Matrix, k int) (U *Matrix, s Vector, Vt *Matrix, errEst float64, err error) {
+
+	// check
+	m, n := A.M, A.N
+	if k < 1 || k > utl.Imin(m, n) {
+		err = chk.Err("number of singular values must be in [1, %d]. k=%d is invalid", utl.Imin(m, n), k)
+		return
+	}
+	l := k + utl.Imin(10, utl.Imin(m, n)-k)
+
+	// range finder and power iterations: Q = orth(A⋅orth(Aᵀ⋅Q))
+	Q := RandomizedRangeFinder(NewMatrixOp(A), k, l-k, rnd.NewRNG(1234))
+	Z := NewMatrix(n, l)
+	for it := 0; it < 2; it++ {
+		MatTrMatMul(Z, 1, A, Q)
+		orthonormalizeCols(Z)
+		MatMatMul(Q, 1, A, Z)
+		orthonormalizeCols(Q)
+	}
+
+	// small matrix B = Qᵀ⋅A and its SVD
+	B := NewMatrix(l, n)
+	MatTrMatMul(B, 1, Q, A)
+	σ := NewVector(l)
+	Ub := NewMatrix(l, l)
+	Vb := NewMatrix(n, n)
+	MatSvd(σ, Ub, Vb, B, false)
+
+	// results
+	U = NewMatrix(m, k)
+	s = NewVector(k)
+	Vt = NewMatrix(k, n)
+	for c := 0; c < k; c++ {
+		s[c] = σ[c]
+		MatVecMul(U.Col(c), 1, Q, Ub.Col(c)) // U = Q⋅Ũ
+		for j := 0; j < n; j++ {
+			Vt.Set(c, j, Vb.Get(c, j))
+		}
+	}
+
+	// error estimate
+	nrm := A.NormFrob()
+	sum := nrm * nrm
+	for c := 0; c < k; c++ {
+		sum -= s[c] * s[c]
+	}
+	errEst = math.Sqrt(math.Max(sum, 0))
+	return
+}
+
+// RandomizedRangeFinder computes an orthonormal basis Q approximating the range of a linear
+// operator A; i.e. A ≈ Q⋅Qᵀ⋅A. The algorithm (matrix-free) is [1]:
+//
+//   Y = A ⋅ Ω    and    Q = orth(Y)
+//
+//  where Ω[n][k+p] is a Gaussian random matrix. The basis can be used for randomized SVD and
+//  Nyström approximations. See also RandomizedLowRank
+//
+//  INPUT:
+//   op           -- linear operator [m][n]
+//   k            -- target rank; 1 ≤ k
+//   oversampling -- number of extra samples p ≥ 0 (e.g. 5 or 10); k+p ≤ min(m,n)
+//   rng          -- random numbers generator [may be nil ⇒ use the global generator of rnd]
+//
+//  OUTPUT:
+//   Q -- orthonormal basis [m][k+p]
+//
+//  NOTE: (1) the expected error ‖A - Q⋅Qᵀ⋅A‖ is close to σ_{k+1}; i.e. the optimal error
+//        (2) columns of Q corresponding to linearly dependent samples (e.g. if rank(A) < k+p)
+//            are set to zero
+//
+//  Reference:
+//   [1] Halko N, Martinsson PG and Tropp JA (2011) Finding structure with randomness:
+//       probabilistic algorithms for constructing approximate matrix decompositions. SIAM
+//       Review, 53(2):217-288
+//
+func RandomizedRangeFinder(op LinearOperator, k, oversampling int, rng *rnd.RNG) (Q *Matrix) {
+	m, n := op.Dim()
+	l := k + oversampling
+	if k < 1 || oversampling < 0 || l > utl.Imin(m, n) {
+		chk.Panic("k ≥ 1 and oversampling ≥ 0 with k+oversampling ≤ %d are required. k=%d and oversampling=%d are invalid\n", utl.Imin(m, n), k, oversampling)
+	}
+	Q = NewMatrix(m, l)
+	ω := NewVector(n)
+	for j := 0; j < l; j++ {
+		for i := 0; i < n; i++ {
+			ω[i] = rng.Normal(0, 1)
+		}
+		op.Apply(Q.Col(j), ω)
+	}
+	orthonormalizeCols(Q)
+	return
+}
+
+// RandomizedLowRank computes the randomized low-rank approximation of a dense matrix
+//
+//   A ≈ Q ⋅ B    with Q = RandomizedRangeFinder(A, k, oversampling, rng) and B = Qᵀ⋅A
+//
+//  OUTPUT:
+//   Q -- orthonormal basis [m][k+oversampling]
+//   B -- small matrix [k+oversampling][n]
+//
+func RandomizedLowRank(A *Matrix, k, oversampling int, rng *rnd.RNG) (Q, B *Matrix) {
+	Q = RandomizedRangeFinder(NewMatrixOp(A), k, oversampling, rng)
+	B = NewMatrix(Q.N, A.N)
+	MatTrMatMul(B, 1, Q, A)
+	return
+}
+
+// orthonormalizeCols orthonormalizes the columns of Y (in place) using the modified Gram-Schmidt
+// method with reorthogonalisation. Columns that are (numerically) linearly dependent on the
+// previous ones are set to zero
+func orthonormalizeCols(Y *Matrix) {
+	for j := 0; j < Y.N; j++ {
+		yj := Y.Col(j)
+		nrm0 := yj.Norm()
+		for pass := 0; pass < 2; pass++ {
+			for i := 0; i < j; i++ {
+				yi := Y.Col(i)
+				VecAdd(yj, 1, yj, -VecDot(yi, yj), yi)
+			}
+		}
+		nrm := yj.Norm()
+		if nrm <= 1e-12*nrm0 || nrm == 0 {
+			yj.Fill(0)
+			continue
+		}
+		yj.Apply(1.0/nrm, yj)
+	}
+}
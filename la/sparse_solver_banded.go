@@ -0,0 +1,302 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/mpi"
+	"github.com/cpmech/gosl/utl"
+)
+
+// Banded implements a (direct) solver for banded linear systems using LU decomposition with
+// partial pivoting. The lower (kl) and upper (ku) bandwidths are found from the triplet; i.e.
+//
+//    A[i][j] = 0   if   i - j > kl   or   j - i > ku
+//
+//  The factors are stored column-wise with 2⋅kl+ku+1 entries for each column (as in LAPACK's
+//  dgbtrf) in order to accommodate the fill-in caused by row interchanges
+type Banded struct {
+	t           *Triplet  // matrix
+	n           int       // dimension
+	kl, ku      int       // lower and upper bandwidths
+	ld          int       // leading dimension of ab = 2⋅kl + ku + 1
+	ab          []float64 // banded storage of LU factors
+	piv         []int     // pivots
+	initialised bool      // Init was called
+	factorised  bool      // Fact was called
+}
+
+// Init initialises solver
+//  NOTE: symmetric, verbose, ordering, scaling and comm are ignored
+func (o *Banded) Init(t *Triplet, symmetric, verbose bool, ordering, scaling string, dummy *mpi.Communicator) {
+	if o.initialised {
+		chk.Panic("solver must be initialised just once\n")
+	}
+	if t.pos == 0 {
+		chk.Panic("triplet must have at least one item for initialisation\n")
+	}
+	if t.m != t.n {
+		chk.Panic("banded solver requires a square matrix. %d×%d is invalid\n", t.m, t.n)
+	}
+	o.t = t
+	o.n = t.n
+	o.piv = make([]int, o.n)
+	o.initialised = true
+}
+
+// Free frees memory
+func (o *Banded) Free() {
+}
+
+// Bandwidths returns the lower (kl) and upper (ku) bandwidths found by Fact
+func (o *Banded) Bandwidths() (kl, ku int) {
+	return o.kl, o.ku
+}
+
+// Fact performs the factorisation
+func (o *Banded) Fact() {
+
+	// check
+	if !o.initialised {
+		chk.Panic("linear solver must be initialised first\n")
+	}
+	o.factorised = false
+
+	// bandwidths
+	o.kl, o.ku = 0, 0
+	for k := 0; k < o.t.pos; k++ {
+		o.kl = utl.Imax(o.kl, o.t.i[k]-o.t.j[k])
+		o.ku = utl.Imax(o.ku, o.t.j[k]-o.t.i[k])
+	}
+	o.ld = 2*o.kl + o.ku + 1
+	if len(o.ab) < o.ld*o.n {
+		o.ab = make([]float64, o.ld*o.n)
+	}
+	for k := 0; k < o.ld*o.n; k++ {
+		o.ab[k] = 0
+	}
+
+	// set banded matrix (adding repeated entries)
+	for k := 0; k < o.t.pos; k++ {
+		o.ab[o.idx(o.t.i[k], o.t.j[k])] += o.t.x[k]
+	}
+
+	// LU decomposition with partial pivoting
+	n, kl, kv := o.n, o.kl, o.kl+o.ku
+	for k := 0; k < n; k++ {
+		imax := utl.Imin(n-1, k+kl)
+		jmax := utl.Imin(n-1, k+kv)
+		p := k
+		for i := k + 1; i <= imax; i++ {
+			if math.Abs(o.ab[o.idx(i, k)]) > math.Abs(o.ab[o.idx(p, k)]) {
+				p = i
+			}
+		}
+		o.piv[k] = p
+		if o.ab[o.idx(p, k)] == 0 {
+			chk.Panic("banded matrix is singular (zero pivot at column %d)\n", k)
+		}
+		if p != k {
+			for j := k; j <= jmax; j++ {
+				pk, pp := o.idx(k, j), o.idx(p, j)
+				o.ab[pk], o.ab[pp] = o.ab[pp], o.ab[pk]
+			}
+		}
+		akk := o.ab[o.idx(k, k)]
+		for i := k + 1; i <= imax; i++ {
+			l := o.ab[o.idx(i, k)] / akk
+			o.ab[o.idx(i, k)] = l
+			if l != 0 {
+				for j := k + 1; j <= jmax; j++ {
+					o.ab[o.idx(i, j)] -= l * o.ab[o.idx(k, j)]
+				}
+			}
+		}
+	}
+	o.factorised = true
+}
+
+// Solve solves the linear system
+//  NOTE: x and b may be the same vector
+func (o *Banded) Solve(x, b Vector, dummy bool) {
+
+	// check
+	if !o.factorised {
+		chk.Panic("factorisation must be performed first\n")
+	}
+
+	// forward substitution: L⋅y = P⋅b
+	n, kl, kv := o.n, o.kl, o.kl+o.ku
+	copy(x, b)
+	for k := 0; k < n; k++ {
+		if p := o.piv[k]; p != k {
+			x[k], x[p] = x[p], x[k]
+		}
+		imax := utl.Imin(n-1, k+kl)
+		for i := k + 1; i <= imax; i++ {
+			x[i] -= o.ab[o.idx(i, k)] * x[k]
+		}
+	}
+
+	// backward substitution: U⋅x = y
+	for k := n - 1; k >= 0; k-- {
+		jmax := utl.Imin(n-1, k+kv)
+		for j := k + 1; j <= jmax; j++ {
+			x[k] -= o.ab[o.idx(k, j)] * x[j]
+		}
+		x[k] /= o.ab[o.idx(k, k)]
+	}
+}
+
+// idx returns the index of A[i][j] in the banded storage
+func (o *Banded) idx(i, j int) int {
+	return o.kl + o.ku + i - j + j*o.ld
+}
+
+// complex /////////////////////////////////////////////////////////////////////////////////////////
+
+// BandedC implements a (direct) solver for banded linear systems (complex version)
+type BandedC struct {
+	t           *TripletC    // matrix
+	n           int          // dimension
+	kl, ku      int          // lower and upper bandwidths
+	ld          int          // leading dimension of ab = 2⋅kl + ku + 1
+	ab          []complex128 // banded storage of LU factors
+	piv         []int        // pivots
+	initialised bool         // Init was called
+	factorised  bool         // Fact was called
+}
+
+// Init initialises solver
+//  NOTE: symmetric, verbose, ordering, scaling and comm are ignored
+func (o *BandedC) Init(t *TripletC, symmetric, verbose bool, ordering, scaling string, dummy *mpi.Communicator) {
+	if o.initialised {
+		chk.Panic("solver must be initialised just once\n")
+	}
+	if t.pos == 0 {
+		chk.Panic("triplet must have at least one item for initialisation\n")
+	}
+	if t.m != t.n {
+		chk.Panic("banded solver requires a square matrix. %d×%d is invalid\n", t.m, t.n)
+	}
+	o.t = t
+	o.n = t.n
+	o.piv = make([]int, o.n)
+	o.initialised = true
+}
+
+// Free frees memory
+func (o *BandedC) Free() {
+}
+
+// Fact performs the factorisation
+func (o *BandedC) Fact() {
+
+	// check
+	if !o.initialised {
+		chk.Panic("linear solver must be initialised first\n")
+	}
+	o.factorised = false
+
+	// bandwidths
+	o.kl, o.ku = 0, 0
+	for k := 0; k < o.t.pos; k++ {
+		o.kl = utl.Imax(o.kl, o.t.i[k]-o.t.j[k])
+		o.ku = utl.Imax(o.ku, o.t.j[k]-o.t.i[k])
+	}
+	o.ld = 2*o.kl + o.ku + 1
+	if len(o.ab) < o.ld*o.n {
+		o.ab = make([]complex128, o.ld*o.n)
+	}
+	for k := 0; k < o.ld*o.n; k++ {
+		o.ab[k] = 0
+	}
+
+	// set banded matrix (adding repeated entries)
+	for k := 0; k < o.t.pos; k++ {
+		o.ab[o.idx(o.t.i[k], o.t.j[k])] += o.t.x[k]
+	}
+
+	// LU decomposition with partial pivoting
+	n, kl, kv := o.n, o.kl, o.kl+o.ku
+	for k := 0; k < n; k++ {
+		imax := utl.Imin(n-1, k+kl)
+		jmax := utl.Imin(n-1, k+kv)
+		p := k
+		for i := k + 1; i <= imax; i++ {
+			if cmplx.Abs(o.ab[o.idx(i, k)]) > cmplx.Abs(o.ab[o.idx(p, k)]) {
+				p = i
+			}
+		}
+		o.piv[k] = p
+		if o.ab[o.idx(p, k)] == 0 {
+			chk.Panic("banded matrix is singular (zero pivot at column %d)\n", k)
+		}
+		if p != k {
+			for j := k; j <= jmax; j++ {
+				pk, pp := o.idx(k, j), o.idx(p, j)
+				o.ab[pk], o.ab[pp] = o.ab[pp], o.ab[pk]
+			}
+		}
+		akk := o.ab[o.idx(k, k)]
+		for i := k + 1; i <= imax; i++ {
+			l := o.ab[o.idx(i, k)] / akk
+			o.ab[o.idx(i, k)] = l
+			if l != 0 {
+				for j := k + 1; j <= jmax; j++ {
+					o.ab[o.idx(i, j)] -= l * o.ab[o.idx(k, j)]
+				}
+			}
+		}
+	}
+	o.factorised = true
+}
+
+// Solve solves the linear system
+//  NOTE: x and b may be the same vector
+func (o *BandedC) Solve(x, b VectorC, dummy bool) {
+
+	// check
+	if !o.factorised {
+		chk.Panic("factorisation must be performed first\n")
+	}
+
+	// forward substitution: L⋅y = P⋅b
+	n, kl, kv := o.n, o.kl, o.kl+o.ku
+	copy(x, b)
+	for k := 0; k < n; k++ {
+		if p := o.piv[k]; p != k {
+			x[k], x[p] = x[p], x[k]
+		}
+		imax := utl.Imin(n-1, k+kl)
+		for i := k + 1; i <= imax; i++ {
+			x[i] -= o.ab[o.idx(i, k)] * x[k]
+		}
+	}
+
+	// backward substitution: U⋅x = y
+	for k := n - 1; k >= 0; k-- {
+		jmax := utl.Imin(n-1, k+kv)
+		for j := k + 1; j <= jmax; j++ {
+			x[k] -= o.ab[o.idx(k, j)] * x[j]
+		}
+		x[k] /= o.ab[o.idx(k, k)]
+	}
+}
+
+// idx returns the index of A[i][j] in the banded storage
+func (o *BandedC) idx(i, j int) int {
+	return o.kl + o.ku + i - j + j*o.ld
+}
+
+// add solvers to database /////////////////////////////////////////////////////////////////////////
+
+func init() {
+	spSolverDB["banded"] = func() SparseSolver { return new(Banded) }
+	spSolverDBc["banded"] = func() SparseSolverC { return new(BandedC) }
+}
@@ -70,5 +70,13 @@ func TestBlas1tst02(tst *testing.T) {
 		VecAdd(w[:n], 1, u[:n], 1, v[:n])
 		chk.Array(tst, io.Sf("n=%3d: w:=u-v", n), 1e-15, w[:n], wref[:n])
 		chk.Float64(tst, "u⋅v", 1e-15, VecDot(u, v), dot)
+
+		// res aliasing u or v
+		copy(w, u)
+		VecAdd(w[:n], 1, w[:n], 1, v[:n])
+		chk.Array(tst, io.Sf("n=%3d: u:=u+v", n), 1e-15, w[:n], wref[:n])
+		copy(w, v)
+		VecAdd(w[:n], 1, u[:n], 1, w[:n])
+		chk.Array(tst, io.Sf("n=%3d: v:=u+v", n), 1e-15, w[:n], wref[:n])
 	}
 }
@@ -152,3 +152,62 @@ func TestBlas2tst03(tst *testing.T) {
 	MatTrVecMul(y, 0.5, a, v)
 	chk.Array(tst, "0.5⋅aᵀ⋅v", 1e-17, y, []float64{12, 7, 5, 9.5, 1})
 }
+
+func TestBlas2tst04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Blas2tst04. (real) outer product and rank-1 update")
+
+	u := NewVectorSlice([]float64{1, -2, 3, 0.5})
+	v := NewVectorSlice([]float64{4, 5, -6})
+
+	// outer product
+	a := Outer(u, v)
+	chk.Int(tst, "m", a.M, 4)
+	chk.Int(tst, "n", a.N, 3)
+	for i := 0; i < len(u); i++ {
+		for j := 0; j < len(v); j++ {
+			chk.Float64(tst, "u⋅vᵀ", 1e-15, a.Get(i, j), u[i]*v[j])
+		}
+	}
+
+	// rank-1 update
+	b := NewMatrixDeep2([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+		{0, 1, 0},
+	})
+	c := b.GetCopy()
+	α := -0.5
+	b.Rank1Update(α, u, v)
+	for i := 0; i < len(u); i++ {
+		for j := 0; j < len(v); j++ {
+			chk.Float64(tst, "b + α⋅u⋅vᵀ", 1e-15, b.Get(i, j), c.Get(i, j)+α*u[i]*v[j])
+		}
+	}
+
+	// BFGS-like update of a larger matrix
+	n := 12
+	w := NewVectorMapped(n, func(i int) float64 { return float64(i) - 5.5 })
+	h := NewMatrix(n, n)
+	h.SetDiag(1)
+	h.Rank1Update(0.1, w, w)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			δ := 0.0
+			if i == j {
+				δ = 1.0
+			}
+			chk.Float64(tst, "h", 1e-14, h.Get(i, j), δ+0.1*w[i]*w[j])
+		}
+	}
+
+	// errors: lengths of u and v must match the dimensions of the matrix
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		b.Rank1Update(1, v, u)
+	}()
+	defer chk.RecoverTstPanicIsOK(tst)
+	b.Rank1Update(1, u, u)
+}
@@ -130,3 +130,47 @@ func TestBlas3tst03(tst *testing.T) {
 	MatAdd(c, 10, a, 20, b)
 	chk.Deep2(tst, "c := 10⋅a + 20⋅b", 1e-15, c.GetDeep2(), cref)
 }
+
+func TestBlas3tst04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Blas3tst04. (real) SyrkUpper. Gram matrix")
+
+	// data
+	x := NewMatrixDeep2([][]float64{
+		{1, 2, 3},
+		{-1, 0, 4},
+		{2, 5, -3},
+		{0.5, 1, 1},
+	})
+
+	// reference: general multiplication
+	cref := NewMatrix(3, 3)
+	MatTrMatMul(cref, 2, x, x)
+
+	// symmetrized
+	c := SyrkUpper(2, x, true)
+	chk.Deep2(tst, "c := 2⋅xᵀ⋅x", 1e-15, c.GetDeep2(), cref.GetDeep2())
+
+	// upper triangle only
+	c = SyrkUpper(2, x, false)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if j >= i {
+				chk.Float64(tst, "upper(c)", 1e-15, c.Get(i, j), cref.Get(i, j))
+			} else {
+				chk.Float64(tst, "lower(c)", 1e-17, c.Get(i, j), 0)
+			}
+		}
+	}
+
+	// larger matrix
+	y := NewMatrix(40, 25)
+	for k := 0; k < len(y.Data); k++ {
+		y.Data[k] = float64(k%7) - 3.0 + 0.1*float64(k%11)
+	}
+	dref := NewMatrix(25, 25)
+	MatTrMatMul(dref, 0.5, y, y)
+	d := SyrkUpper(0.5, y, true)
+	chk.Deep2(tst, "d := 0.5⋅yᵀ⋅y", 1e-13, d.GetDeep2(), dref.GetDeep2())
+}
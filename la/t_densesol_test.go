@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
 )
 
 func calcLLt(L *Matrix) (LLt *Matrix) {
@@ -68,6 +69,16 @@ func TestCholesky01(tst *testing.T) {
 		{+3, 3, 0},
 		{-1, 1, 3},
 	})
+
+	// L⋅x = b and L⋅Lᵀ⋅x = b
+	b := NewVectorSlice([]float64{5, 6, 6})
+	x := NewVector(3)
+	CholeskySolveL(x, L, b)
+	chk.Array(tst, "inv(L)⋅b", 1e-15, x, []float64{1, 1, 2})
+	CholeskySolve(x, L, b)
+	r := NewVector(3)
+	MatVecMul(r, 1, a, x)
+	chk.Array(tst, "a⋅x = b", 1e-14, r, b)
 }
 
 func TestCholesky02(tst *testing.T) {
@@ -147,3 +158,171 @@ func TestSPDsolve02(tst *testing.T) {
 	})
 	chk.Array(tst, "X = inv(a) * B", 1e-13, X, []float64{0, 4, 7, -1, 8})
 }
+
+func TestCholesky03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Cholesky 03. rank-1 update and downdate")
+
+	a := NewMatrixDeep2([][]float64{
+		{2, 1, 1, 3, 2},
+		{1, 2, 2, 1, 1},
+		{1, 2, 9, 1, 5},
+		{3, 1, 1, 7, 1},
+		{2, 1, 5, 1, 8},
+	})
+	L := NewMatrix(5, 5)
+	Cholesky(L, a)
+	L0 := L.GetCopy()
+
+	// update: a + x⋅xᵀ
+	x := []float64{1, -2, 0.5, 3, -1}
+	ap := a.GetCopy()
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			ap.Add(i, j, x[i]*x[j])
+		}
+	}
+	Lp := NewMatrix(5, 5)
+	Cholesky(Lp, ap)
+	CholeskyUpdate(L, x)
+	chk.Deep2(tst, "updated L", 1e-14, L.GetDeep2(), Lp.GetDeep2())
+	chk.Array(tst, "x (unmodified)", 1e-17, x, []float64{1, -2, 0.5, 3, -1})
+
+	// downdate: (a + x⋅xᵀ) - x⋅xᵀ = a
+	err := CholeskyDowndate(L, x)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Deep2(tst, "downdated L", 1e-14, L.GetDeep2(), L0.GetDeep2())
+	chk.Deep2(tst, "a = LLt", 1e-13, calcLLt(L).GetDeep2(), a.GetDeep2())
+
+	// downdate resulting in non positive-definite matrix
+	L1 := L.GetCopy()
+	err = CholeskyDowndate(L, []float64{3, 0, 0, 0, 0}) // a[0][0] - 9 < 0
+	if err == nil {
+		tst.Errorf("CholeskyDowndate should have failed\n")
+		return
+	}
+	chk.Deep2(tst, "L (unmodified)", 1e-17, L.GetDeep2(), L1.GetDeep2())
+	err = CholeskyDowndate(L, []float64{1, 2})
+	if err == nil {
+		tst.Errorf("CholeskyDowndate should have failed with wrong x\n")
+	}
+}
+
+func TestCholesky04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Cholesky 04. sequence of updates")
+
+	// a = I + Σ xₖ⋅xₖᵀ built incrementally and compared with a fresh factorisation
+	n := 8
+	a := NewMatrix(n, n)
+	a.SetDiag(1)
+	L := NewMatrix(n, n)
+	L.SetDiag(1)
+	x := NewVector(n)
+	for k := 0; k < 20; k++ {
+		for i := 0; i < n; i++ {
+			x[i] = math.Sin(float64(3*k+i+1)) * float64(i%3+1)
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				a.Add(i, j, x[i]*x[j])
+			}
+		}
+		CholeskyUpdate(L, x)
+	}
+	Lref := NewMatrix(n, n)
+	Cholesky(Lref, a)
+	chk.Deep2(tst, "L", 1e-13, L.GetDeep2(), Lref.GetDeep2())
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	CholeskyUpdate(L, []float64{1})
+}
+
+func TestDenSolve02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("DenSolve02. many right-hand sides")
+
+	a := NewMatrixDeep2([][]float64{
+		{2, 1, 1, 3, 2},
+		{1, 2, 2, 1, 1},
+		{1, 2, 9, 1, 5},
+		{3, 1, 1, 7, 1},
+		{2, 1, 5, 1, 8},
+	})
+	nrhs := 7
+	B := NewMatrix(5, nrhs)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < nrhs; j++ {
+			B.Set(i, j, math.Cos(float64(i*nrhs+j)))
+		}
+	}
+
+	// batched solve
+	X := NewMatrix(5, nrhs)
+	DenSolveMat(X, a, B, true)
+
+	// compare with per-column solves
+	x := NewVector(5)
+	for j := 0; j < nrhs; j++ {
+		DenSolve(x, a, B.GetCol(j), true)
+		chk.Array(tst, io.Sf("x%d", j), 1e-13, X.GetCol(j), x)
+		TestSolverResidual(tst, a, X.GetCol(j), B.GetCol(j), 1e-13)
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	DenSolveMat(NewMatrix(5, 2), a, B, true)
+}
+
+func TestSPDsolve03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("TestSPDsolve 03. many right-hand sides")
+
+	a := NewMatrixDeep2([][]float64{
+		{2, 1, 1, 3, 2},
+		{1, 2, 2, 1, 1},
+		{1, 2, 9, 1, 5},
+		{3, 1, 1, 7, 1},
+		{2, 1, 5, 1, 8},
+	})
+	L := NewMatrix(5, 5)
+	Cholesky(L, a)
+	nrhs := 101
+	B := NewMatrix(5, nrhs)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < nrhs; j++ {
+			B.Set(i, j, math.Sin(float64(i+2*j)))
+		}
+	}
+
+	// serial and parallel solves
+	X := NewMatrix(5, nrhs)
+	Y := NewMatrix(5, nrhs)
+	CholeskySolveMat(X, L, B)
+	CholeskySolveMatPar(Y, L, B, 4)
+	chk.Array(tst, "X(par) = X(ser)", 1e-17, Y.Data, X.Data)
+
+	// compare with per-column solves
+	x := NewVector(5)
+	for j := 0; j < nrhs; j++ {
+		SolveRealLinSysSPD(x, a, B.GetCol(j))
+		chk.Array(tst, io.Sf("x%d", j), 1e-13, X.GetCol(j), x)
+	}
+
+	// in-place solve with all CPUs
+	Z := B.GetCopy()
+	CholeskySolveMatPar(Z, L, Z, 0)
+	chk.Array(tst, "X(in-place)", 1e-17, Z.Data, X.Data)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	CholeskySolveMat(NewMatrix(4, nrhs), L, B)
+}
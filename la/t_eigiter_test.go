@@ -0,0 +1,277 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestEigIter01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("EigIter01. power iteration")
+
+	// symmetric matrix with well-separated dominant eigenvalue
+	A := NewMatrixDeep2([][]float64{
+		{10, 1, 0, 0},
+		{1, 4, 1, 0},
+		{0, 1, 3, 1},
+		{0, 0, 1, 1},
+	})
+
+	// reference: Jacobi
+	Q := NewMatrix(4, 4)
+	ev := NewVector(4)
+	Jacobi(Q, ev, A.GetCopy())
+	λmax, imax := ev.ArgMax()
+	io.Pforan("eigenvalues (Jacobi) = %v\n", ev)
+
+	// power iteration
+	lambda, v, err := PowerIteration(NewMatrixOp(A), 1e-12, 1000)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("λ = %v\n", lambda)
+	io.Pforan("v = %v\n", v)
+	chk.Float64(tst, "λ", 1e-12, lambda, λmax)
+	chk.Float64(tst, "‖v‖", 1e-15, v.Norm(), 1)
+	q := Q.GetCol(imax)
+	if VecDot(q, v) < 0 {
+		q.Apply(-1, q)
+	}
+	chk.Array(tst, "v", 1e-11, v, q)
+
+	// negative dominant eigenvalue
+	B := A.GetCopy()
+	B.Apply(-1, A)
+	lambda, _, err = PowerIteration(NewMatrixOp(B), 1e-12, 1000)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "λ(-A)", 1e-12, lambda, -λmax)
+
+	// not converged
+	_, _, err = PowerIteration(NewMatrixOp(A), 1e-12, 2)
+	io.Pforan("err = %v\n", err)
+	if err == nil {
+		tst.Errorf("power iteration should have failed to converge\n")
+	}
+
+	// non-square operator
+	_, _, err = PowerIteration(NewLinOpFunc(2, 3, func(y, x Vector) {}), 1e-12, 10)
+	if err == nil {
+		tst.Errorf("power iteration should have failed with non-square operator\n")
+	}
+}
+
+func TestEigIter02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("EigIter02. inverse power iteration")
+
+	// 1D Laplacian: λ_k = 2 - 2 cos(kπ/(n+1))
+	n := 20
+	A := laplacian1d(n, 1, 0)
+	solve := func(x Vector) (w Vector) {
+		w = NewVector(n)
+		_, err := SolveCG(A, w, x, &KrylovOpts{Tol: 1e-14})
+		if err != nil {
+			tst.Errorf("%v\n", err)
+		}
+		return
+	}
+
+	// smallest eigenpair
+	lambda, v, err := InversePowerIteration(solve, n, 1e-10, 100)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	θ := math.Pi / float64(n+1)
+	io.Pforan("λ = %v\n", lambda)
+	chk.Float64(tst, "λmin", 1e-12, lambda, 2-2*math.Cos(θ))
+
+	// eigenvector: v_i ∝ sin((i+1)θ)
+	q := NewVector(n)
+	for i := 0; i < n; i++ {
+		q[i] = math.Sin(float64(i+1) * θ)
+	}
+	q.Apply(1.0/q.Norm(), q)
+	if VecDot(q, v) < 0 {
+		q.Apply(-1, q)
+	}
+	chk.Array(tst, "v", 1e-9, v, q)
+
+	// errors
+	_, _, err = InversePowerIteration(solve, 0, 1e-10, 100)
+	if err == nil {
+		tst.Errorf("inverse power iteration should have failed with n=0\n")
+	}
+	_, _, err = InversePowerIteration(func(x Vector) Vector { return x[:1] }, n, 1e-10, 100)
+	if err == nil {
+		tst.Errorf("inverse power iteration should have failed with wrong solve\n")
+	}
+}
+
+func TestEigIter03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("EigIter03. Rayleigh quotient iteration")
+
+	// matrix and reference eigenpairs
+	A := NewMatrixDeep2([][]float64{
+		{10, 1, 0, 0},
+		{1, 4, 1, 0},
+		{0, 1, 3, 1},
+		{0, 0, 1, 1},
+	})
+	Q := NewMatrix(4, 4)
+	ev := NewVector(4)
+	Jacobi(Q, ev, A.GetCopy())
+
+	// refine each eigenpair from a rough guess
+	for k := 0; k < 4; k++ {
+		q := Q.GetCol(k)
+		v0 := q.GetCopy()
+		for i := 0; i < 4; i++ {
+			v0[i] += 0.15 * math.Cos(float64(3*i+k))
+		}
+		lambda, v, err := RayleighQuotientIteration(A, v0, 1e-14, 10)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		io.Pforan("λ%d = %23.15e  (Jacobi: %23.15e)\n", k, lambda, ev[k])
+		chk.Float64(tst, "λ", 1e-13, lambda, ev[k])
+		if VecDot(q, v) < 0 {
+			q.Apply(-1, q)
+		}
+		chk.Array(tst, "v", 1e-13, v, q)
+	}
+
+	// initial vector is an exact eigenvector ⇒ singular shifted system
+	B := NewMatrixDeep2([][]float64{
+		{2, 0, 0},
+		{0, 5, 0},
+		{0, 0, 7},
+	})
+	lambda, v, err := RayleighQuotientIteration(B, []float64{0, 1, 0}, 1e-14, 10)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "λ(diag)", 1e-15, lambda, 5)
+	chk.Array(tst, "v(diag)", 1e-15, v, []float64{0, 1, 0})
+	lambda, v, err = RayleighQuotientIteration(B, []float64{0.1, 1, 0.1}, 1e-14, 10)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "λ(diag)", 1e-14, lambda, 5)
+	if v[1] < 0 {
+		v.Apply(-1, v)
+	}
+	chk.Array(tst, "v(diag)", 1e-14, v, []float64{0, 1, 0})
+
+	// errors
+	_, _, err = RayleighQuotientIteration(B, []float64{0, 0, 0}, 1e-14, 10)
+	if err == nil {
+		tst.Errorf("Rayleigh quotient iteration should have failed with zero initial vector\n")
+	}
+	_, _, err = RayleighQuotientIteration(B, []float64{1, 1}, 1e-14, 10)
+	if err == nil {
+		tst.Errorf("Rayleigh quotient iteration should have failed with wrong initial vector\n")
+	}
+	_, _, err = RayleighQuotientIteration(NewMatrix(2, 3), []float64{1, 1, 1}, 1e-14, 10)
+	if err == nil {
+		tst.Errorf("Rayleigh quotient iteration should have failed with non-square matrix\n")
+	}
+}
+
+func TestEigIter04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("EigIter04. power iteration with deflation")
+
+	// symmetric matrix with well-separated eigenvalues
+	A := NewMatrixDeep2([][]float64{
+		{20, 2, 1, 0, 0},
+		{2, 12, 1, 1, 0},
+		{1, 1, 7, 1, 1},
+		{0, 1, 1, 3, 1},
+		{0, 0, 1, 1, 1},
+	})
+
+	// reference: Jacobi (sorted in descending order)
+	Q := NewMatrix(5, 5)
+	ev := NewVector(5)
+	Jacobi(Q, ev, A.GetCopy())
+	sort.Sort(sort.Reverse(sort.Float64Slice(ev)))
+	io.Pforan("eigenvalues (Jacobi) = %v\n", ev)
+
+	// top-3 eigenvalues
+	B := A
+	for k := 0; k < 3; k++ {
+		lambda, v, err := PowerIteration(NewMatrixOp(B), 1e-13, 2000)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		io.Pforan("λ%d = %v\n", k, lambda)
+		chk.Float64(tst, io.Sf("λ%d", k), 1e-11, lambda, ev[k])
+
+		// v is also an eigenvector of the original matrix
+		Av, λv := NewVector(5), NewVector(5)
+		MatVecMul(Av, 1, A, v)
+		λv.Apply(lambda, v)
+		chk.Array(tst, io.Sf("A⋅v%d", k), 1e-10, Av, λv)
+		B = Deflate(B, lambda, v)
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	Deflate(A, 1, NewVector(5))
+}
+
+func TestEigIter05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("EigIter05. LU solve with pivoting used by Rayleigh quotient iteration")
+
+	rng := rand.New(rand.NewSource(1234))
+	for n := 2; n <= 7; n++ {
+		for trial := 0; trial < 20; trial++ {
+			A := NewMatrix(n, n)
+			b := NewVector(n)
+			for i := 0; i < n; i++ {
+				b[i] = rng.Float64()*2 - 1
+				for j := 0; j < n; j++ {
+					A.Set(i, j, rng.Float64()*2-1)
+				}
+			}
+			LU := A.GetCopy()
+			piv := make([]int, n)
+			luFactorPivotFix(LU, piv, 1e-300)
+			x := NewVector(n)
+			luSolve(x, LU, piv, b)
+			res := NewVector(n)
+			MatVecMul(res, 1, A, x)
+			VecAdd(res, 1, res, -1, b) // res := A⋅x - b
+			scale := 1.0 + x.Norm()
+			if res.Norm() > 1e-12*scale {
+				tst.Errorf("n=%d, trial=%d: |A⋅x-b| = %g is too large\n", n, trial, res.Norm())
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+// laplacian1d returns the matrix-free operator of the 1D Laplacian with Dirichlet boundaries
+//
+//   (A⋅x)[i] = (1 + c) x[i] - x[i-1] - c x[i+1] + σ x[i]
+//
+//  c = 1 ⇒ symmetric (-1, 2, -1); c ≠ 1 ⇒ nonsymmetric (convection-like)
+func laplacian1d(n int, c, σ float64) LinearOperator {
+	return NewLinOpFunc(n, n, func(y, x Vector) {
+		for i := 0; i < n; i++ {
+			y[i] = (1 + c + σ) * x[i]
+			if i > 0 {
+				y[i] -= x[i-1]
+			}
+			if i < n-1 {
+				y[i] -= c * x[i+1]
+			}
+		}
+	})
+}
+
+func TestKrylov01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Krylov01. matrix-free symmetric system")
+
+	// exact solution and right-hand side
+	n := 50
+	A := laplacian1d(n, 1, 0)
+	xref := NewVector(n)
+	for i := 0; i < n; i++ {
+		xref[i] = float64(i%7) - 3.0
+	}
+	b := NewVector(n)
+	A.Apply(b, xref)
+
+	// solvers
+	for _, name := range []string{"CG", "BiCGStab", "GMRES"} {
+		x := NewVector(n)
+		var nit int
+		var err error
+		switch name {
+		case "CG":
+			nit, err = SolveCG(A, x, b, &KrylovOpts{Tol: 1e-13})
+		case "BiCGStab":
+			nit, err = SolveBiCGStab(A, x, b, &KrylovOpts{Tol: 1e-13})
+		case "GMRES":
+			nit, err = SolveGMRES(A, x, b, &KrylovOpts{Tol: 1e-13, Restart: 50})
+		}
+		if err != nil {
+			tst.Errorf("%s failed: %v\n", name, err)
+			continue
+		}
+		io.Pforan("%-8s: nit = %d\n", name, nit)
+		chk.Array(tst, name, 1e-8, x, xref)
+	}
+
+	// CG converges in at most n iterations (exact arithmetic)
+	x := NewVector(n)
+	nit, _ := SolveCG(A, x, b, nil)
+	if nit > n+5 {
+		tst.Errorf("CG should converge in about n iterations. nit = %d\n", nit)
+	}
+
+	// errors
+	_, err := SolveCG(A, NewVector(3), b, nil)
+	if err == nil {
+		tst.Errorf("SolveCG should have failed with wrong dimensions\n")
+	}
+	_, err = SolveCG(NewLinOpFunc(2, 2, func(y, x Vector) { y[0], y[1] = -x[0], -x[1] }), NewVector(2), []float64{1, 1}, nil)
+	if err == nil {
+		tst.Errorf("SolveCG should have failed with negative-definite operator\n")
+	}
+}
+
+func TestKrylov02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Krylov02. matrix-free nonsymmetric system")
+
+	n := 80
+	A := laplacian1d(n, 0.3, 0.1)
+	xref := NewVector(n)
+	for i := 0; i < n; i++ {
+		xref[i] = 1.0 / float64(1+i)
+	}
+	b := NewVector(n)
+	A.Apply(b, xref)
+
+	// BiCGStab
+	x := NewVector(n)
+	nit, err := SolveBiCGStab(A, x, b, nil)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("BiCGStab  : nit = %d\n", nit)
+	chk.Array(tst, "BiCGStab", 1e-8, x, xref)
+
+	// restarted GMRES
+	x.Fill(0)
+	nit, err = SolveGMRES(A, x, b, &KrylovOpts{Restart: 10, Tol: 1e-12})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("GMRES(10) : nit = %d\n", nit)
+	chk.Array(tst, "GMRES(10)", 1e-10, x, xref)
+
+	// too few iterations
+	x.Fill(0)
+	_, err = SolveGMRES(A, x, b, &KrylovOpts{Restart: 5, MaxIt: 5})
+	io.Pforan("err = %v\n", err)
+	if err == nil {
+		tst.Errorf("SolveGMRES should have failed with MaxIt = 5\n")
+	}
+}
+
+func TestKrylov03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Krylov03. sparse and dense matrices as operators")
+
+	// same matrix as in SpSolver01
+	T := new(Triplet)
+	T.Init(5, 5, 13)
+	T.Put(0, 0, +1.0)
+	T.Put(0, 0, +1.0)
+	T.Put(1, 0, +3.0)
+	T.Put(0, 1, +3.0)
+	T.Put(2, 1, -1.0)
+	T.Put(4, 1, +4.0)
+	T.Put(1, 2, +4.0)
+	T.Put(2, 2, -3.0)
+	T.Put(3, 2, +1.0)
+	T.Put(4, 2, +2.0)
+	T.Put(2, 3, +2.0)
+	T.Put(1, 4, +6.0)
+	T.Put(4, 4, +1.0)
+	b := []float64{8.0, 45.0, -3.0, 3.0, 19.0}
+
+	// sparse
+	op := NewCCMatrixOp(T.ToMatrix(nil))
+	m, n := op.Dim()
+	chk.Ints(tst, "dim", []int{m, n}, []int{5, 5})
+	x := NewVector(5)
+	_, err := SolveGMRES(op, x, b, nil)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Array(tst, "x (sparse)", 1e-9, x, []float64{1, 2, 3, 4, 5})
+
+	// dense
+	x.Fill(0)
+	_, err = SolveBiCGStab(NewMatrixOp(T.ToDense()), x, b, &KrylovOpts{Tol: 1e-13})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Array(tst, "x (dense)", 1e-9, x, []float64{1, 2, 3, 4, 5})
+}
@@ -0,0 +1,113 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestMatAxis01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("MatAxis01. apply function along rows and columns")
+
+	a := NewMatrixDeep2([][]float64{
+		{1, -2, 3, 4},
+		{5, 6, -7, 8},
+		{9, 0, 1, -2},
+	})
+
+	// max of each row
+	rmax := ApplyAxis(a, 0, func(v Vector) float64 { return v.Max() })
+	io.Pforan("rmax = %v\n", rmax)
+	chk.Array(tst, "max(rows)", 1e-17, rmax, []float64{4, 8, 9})
+
+	// norm of each column
+	cnrm := ApplyAxis(a, 1, func(v Vector) float64 { return v.Norm() })
+	io.Pforan("cnrm = %v\n", cnrm)
+	chk.Array(tst, "norm(cols)", 1e-15, cnrm, []float64{
+		math.Sqrt(107), math.Sqrt(40), math.Sqrt(59), math.Sqrt(84),
+	})
+
+	// f may modify the workspace without changing the matrix
+	ApplyAxis(a, 1, func(v Vector) float64 { v.Fill(0); return 0 })
+	chk.Deep2(tst, "a(unchanged)", 1e-17, a.GetDeep2(), [][]float64{
+		{1, -2, 3, 4},
+		{5, 6, -7, 8},
+		{9, 0, 1, -2},
+	})
+
+	// min and max of each row
+	mm := ApplyAxisVec(a, 0, func(v Vector) Vector {
+		min, max := v.MinMax()
+		return []float64{min, max}
+	})
+	io.Pf("minmax(rows) =\n%v\n", mm.Print("%4g"))
+	chk.Deep2(tst, "minmax(rows)", 1e-17, mm.GetDeep2(), [][]float64{
+		{-2, 4},
+		{-7, 8},
+		{-2, 9},
+	})
+
+	// cumulative sum of each column
+	cs := ApplyAxisVec(a, 1, func(v Vector) Vector {
+		for i := 1; i < len(v); i++ {
+			v[i] += v[i-1]
+		}
+		return v.GetCopy()
+	})
+	io.Pf("cumsum(cols) =\n%v\n", cs.Print("%4g"))
+	chk.Deep2(tst, "cumsum(cols)", 1e-17, cs.GetDeep2(), [][]float64{
+		{1, -2, 3, 4},
+		{6, 4, -4, 12},
+		{15, 4, -3, 10},
+	})
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	ApplyAxis(a, 2, func(v Vector) float64 { return 0 })
+}
+
+func TestMatAxis02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("MatAxis02. min, max, argmin and argmax along rows and columns")
+
+	nan := math.NaN()
+	a := NewMatrixDeep2([][]float64{
+		{1, -2, 3, 4},
+		{5, nan, -7, 8},
+		{9, 0, 1, -2},
+	})
+
+	// rows
+	chk.Array(tst, "min(rows)", 1e-17, MinAxis(a, 0), []float64{-2, -7, -2})
+	chk.Array(tst, "max(rows)", 1e-17, MaxAxis(a, 0), []float64{4, 8, 9})
+	chk.Ints(tst, "argmin(rows)", ArgMinAxis(a, 0), []int{1, 2, 3})
+	chk.Ints(tst, "argmax(rows)", ArgMaxAxis(a, 0), []int{3, 3, 0})
+
+	// columns (NaN is ignored)
+	chk.Array(tst, "min(cols)", 1e-17, MinAxis(a, 1), []float64{1, -2, -7, -2})
+	chk.Array(tst, "max(cols)", 1e-17, MaxAxis(a, 1), []float64{9, 0, 3, 8})
+	chk.Ints(tst, "argmin(cols)", ArgMinAxis(a, 1), []int{0, 0, 1, 2})
+	chk.Ints(tst, "argmax(cols)", ArgMaxAxis(a, 1), []int{2, 2, 0, 1})
+
+	// column full of NaN
+	b := NewMatrixDeep2([][]float64{
+		{1, nan},
+		{2, nan},
+	})
+	mx := MaxAxis(b, 1)
+	io.Pforan("max(cols) = %v\n", mx)
+	chk.Float64(tst, "max(col0)", 1e-17, mx[0], 2)
+	if !math.IsNaN(mx[1]) {
+		tst.Errorf("max of column full of NaN should be NaN\n")
+	}
+	chk.Ints(tst, "argmax(cols)", ArgMaxAxis(b, 1), []int{1, -1})
+}
@@ -471,3 +471,51 @@ func TestMatrix06(tst *testing.T) {
 	}()
 	A.GetColReal(0, true)
 }
+
+func TestMatrix07(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Matrix07. (real) Row and Col")
+
+	a := NewMatrixDeep2([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+
+	// iterate over rows and columns
+	rows := [][]float64{{1, 2, 3}, {4, 5, 6}}
+	cols := [][]float64{{1, 4}, {2, 5}, {3, 6}}
+	for i := 0; i < a.M; i++ {
+		chk.Array(tst, io.Sf("row %d", i), 1e-17, a.Row(i), rows[i])
+	}
+	for j := 0; j < a.N; j++ {
+		chk.Array(tst, io.Sf("col %d", j), 1e-17, a.Col(j), cols[j])
+	}
+
+	// columns are views
+	a.Col(1)[1] = -5
+	chk.Float64(tst, "a(1,1)", 1e-17, a.Get(1, 1), -5)
+
+	// rows are copies if M > 1
+	if a.RowIsView() {
+		tst.Errorf("rows of a 2×3 matrix cannot be views\n")
+	}
+	a.Row(0)[0] = -1
+	chk.Float64(tst, "a(0,0)", 1e-17, a.Get(0, 0), 1)
+
+	// rows are views if M == 1
+	b := NewMatrixDeep2([][]float64{{1, 2, 3}})
+	if !b.RowIsView() {
+		tst.Errorf("row of a 1×3 matrix must be a view\n")
+	}
+	b.Row(0)[2] = -3
+	chk.Float64(tst, "b(0,2)", 1e-17, b.Get(0, 2), -3)
+
+	// out-of-range index (including the M == 1 case)
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		a.Row(2)
+	}()
+	defer chk.RecoverTstPanicIsOK(tst)
+	b.Row(1)
+}
@@ -0,0 +1,104 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestMatView01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("MatView01. submatrix view")
+
+	a := NewMatrixDeep2([][]float64{
+		{0, 4, 8, 12},
+		{1, 5, 9, 13},
+		{2, 6, 10, 14},
+		{3, 7, 11, 15},
+	})
+
+	// view
+	v := a.Slice(1, 3, 1, 3)
+	chk.Int(tst, "m", v.M, 2)
+	chk.Int(tst, "n", v.N, 2)
+	chk.Int(tst, "ld", v.Ld, 4)
+	chk.Deep2(tst, "v", 1e-17, v.GetDeep2(), [][]float64{
+		{5, 9},
+		{6, 10},
+	})
+	chk.Array(tst, "v.Col(1)", 1e-17, v.Col(1), []float64{9, 10})
+
+	// writes through the view modify the parent
+	v.Set(0, 1, -9)
+	v.Add(1, 0, 100)
+	v.Col(0)[0] = -5
+	io.Pf("a =\n%v\n", a.Print("%4g"))
+	chk.Deep2(tst, "a", 1e-17, a.GetDeep2(), [][]float64{
+		{0, 4, 8, 12},
+		{1, -5, -9, 13},
+		{2, 106, 10, 14},
+		{3, 7, 11, 15},
+	})
+
+	// writes to the parent are seen by the view
+	a.Set(2, 2, 77)
+	chk.Float64(tst, "v(1,1)", 1e-17, v.Get(1, 1), 77)
+
+	// copy is not aliased
+	c := v.GetCopy()
+	c.Set(0, 0, 123)
+	chk.Float64(tst, "v(0,0)", 1e-17, v.Get(0, 0), -5)
+
+	// fill block
+	a.Slice(2, 4, 0, 4).Fill(0)
+	chk.Deep2(tst, "a", 1e-17, a.GetDeep2(), [][]float64{
+		{0, 4, 8, 12},
+		{1, -5, -9, 13},
+		{0, 0, 0, 0},
+		{0, 0, 0, 0},
+	})
+
+	// invalid block
+	defer chk.RecoverTstPanicIsOK(tst)
+	a.Slice(0, 5, 0, 1)
+}
+
+func TestMatView02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("MatView02. matrix-vector product with submatrix view")
+
+	a := NewMatrix(12, 10)
+	for i := 0; i < a.M; i++ {
+		for j := 0; j < a.N; j++ {
+			a.Set(i, j, float64(i+1)-0.5*float64(j*j)+0.1*float64(i*j))
+		}
+	}
+
+	// view and extracted copy
+	v := a.Slice(2, 11, 3, 9)
+	b := v.GetCopy()
+	chk.Deep2(tst, "copy", 1e-17, b.GetDeep2(), v.GetDeep2())
+
+	// v = α⋅a⋅u
+	u := NewVectorMapped(v.N, func(i int) float64 { return 1.0 - float64(i)/3.0 })
+	r1 := NewVector(v.M)
+	r2 := NewVector(v.M)
+	MatViewVecMul(r1, 2.5, v, u)
+	MatVecMul(r2, 2.5, b, u)
+	chk.Array(tst, "a⋅u", 1e-13, r1, r2)
+
+	// v = α⋅aᵀ⋅w
+	w := NewVectorMapped(v.M, func(i int) float64 { return float64(i) - 4.0 })
+	s1 := NewVector(v.N)
+	s2 := NewVector(v.N)
+	MatViewTrVecMul(s1, -1.5, v, w)
+	MatTrVecMul(s2, -1.5, b, w)
+	chk.Array(tst, "aᵀ⋅w", 1e-13, s1, s2)
+}
@@ -0,0 +1,217 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/rnd"
+)
+
+// lowRankPlusNoise returns A = Σ σ_i⋅u_i⋅v_iᵀ + noise⋅E with orthonormal u_i and v_i (columns of
+// Ut and Vr) and E with standard normal entries
+func lowRankPlusNoise(m, n int, σ []float64, noise float64, rng *rnd.RNG) (A, Ut, Vr *Matrix) {
+	r := len(σ)
+	Ut, Vr = NewMatrix(m, r), NewMatrix(n, r)
+	for i := 0; i < len(Ut.Data); i++ {
+		Ut.Data[i] = rng.Normal(0, 1)
+	}
+	for i := 0; i < len(Vr.Data); i++ {
+		Vr.Data[i] = rng.Normal(0, 1)
+	}
+	orthonormalizeCols(Ut)
+	orthonormalizeCols(Vr)
+	A = NewMatrix(m, n)
+	for c := 0; c < r; c++ {
+		A.Rank1Update(σ[c], Ut.Col(c), Vr.Col(c))
+	}
+	for i := 0; i < len(A.Data); i++ {
+		A.Data[i] += noise * rng.Normal(0, 1)
+	}
+	return
+}
+
+// reconstructionError computes ‖A - U⋅diag(s)⋅Vt‖_F
+func reconstructionError(A, U *Matrix, s Vector, Vt *Matrix) float64 {
+	R := A.GetCopy()
+	for c := 0; c < len(s); c++ {
+		R.Rank1Update(-s[c], U.Col(c), Vt.GetRow(c))
+	}
+	return R.NormFrob()
+}
+
+func TestRandSvd01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("RandSvd01. truncated SVD of low-rank matrix")
+
+	// exactly low-rank matrix
+	rng := rnd.NewRNG(13)
+	σ := []float64{10, 5, 2}
+	A, Ut, Vr := lowRankPlusNoise(60, 40, σ, 0, rng)
+
+	// truncated SVD
+	U, s, Vt, errEst, err := TruncatedSVDEst(A, 3)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("s = %v  errEst = %v\n", s, errEst)
+	chk.Array(tst, "s", 1e-12, s, σ)
+	chk.Float64(tst, "‖A - U⋅S⋅Vt‖", 1e-11, reconstructionError(A, U, s, Vt), 0)
+	chk.Float64(tst, "errEst", 1e-6, errEst, 0)
+
+	// singular vectors (up to sign)
+	for c := 0; c < 3; c++ {
+		chk.Float64(tst, io.Sf("|u%d⋅U%d|", c, c), 1e-12, math.Abs(VecDot(Ut.Col(c), U.Col(c))), 1)
+		chk.Float64(tst, io.Sf("|v%d⋅V%d|", c, c), 1e-12, math.Abs(VecDot(Vr.Col(c), Vt.GetRow(c))), 1)
+	}
+
+	// errors
+	_, _, _, err = TruncatedSVD(A, 0)
+	if err == nil {
+		tst.Errorf("TruncatedSVD should have failed with k=0\n")
+	}
+	_, _, _, err = TruncatedSVD(A, 41)
+	if err == nil {
+		tst.Errorf("TruncatedSVD should have failed with k > min(m,n)\n")
+	}
+}
+
+func TestRandSvd02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("RandSvd02. truncated SVD of low-rank plus noise matrix")
+
+	// low-rank plus noise
+	rng := rnd.NewRNG(31)
+	σ := []float64{50, 20, 10, 5}
+	noise := 1e-3
+	A, Ut, Vr := lowRankPlusNoise(80, 50, σ, noise, rng)
+
+	// dominant components
+	U, s, Vt, errEst, err := TruncatedSVDEst(A, 4)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("s = %v\n", s)
+	chk.Array(tst, "s", 1e-2, s, σ)
+	for c := 0; c < 4; c++ {
+		chk.Float64(tst, io.Sf("|u%d⋅U%d|", c, c), 1e-5, math.Abs(VecDot(Ut.Col(c), U.Col(c))), 1)
+		chk.Float64(tst, io.Sf("|v%d⋅V%d|", c, c), 1e-5, math.Abs(VecDot(Vr.Col(c), Vt.GetRow(c))), 1)
+	}
+
+	// error estimate ≈ actual error ≈ norm of noise
+	errAct := reconstructionError(A, U, s, Vt)
+	io.Pforan("errEst = %v  errAct = %v  noise = %v\n", errEst, errAct, noise*math.Sqrt(80*50))
+	chk.Float64(tst, "errEst", 1e-6, errEst, errAct)
+	if errAct > noise*math.Sqrt(80*50) {
+		tst.Errorf("approximation error is larger than the noise\n")
+	}
+}
+
+func TestRandSvd03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("RandSvd03. randomized range finder")
+
+	// matrix-free low-rank operator: A⋅x = Ut⋅diag(σ)⋅Vrᵀ⋅x
+	rng := rnd.NewRNG(7)
+	m, n := 70, 50
+	σ := []float64{8, 4, 2, 1}
+	_, Ut, Vr := lowRankPlusNoise(m, n, σ, 0, rng)
+	op := NewLinOpFunc(m, n, func(y, x Vector) {
+		c := NewVector(len(σ))
+		MatTrVecMul(c, 1, Vr, x)
+		for i := 0; i < len(σ); i++ {
+			c[i] *= σ[i]
+		}
+		MatVecMul(y, 1, Ut, c)
+	})
+
+	// basis
+	Q := RandomizedRangeFinder(op, 4, 5, rng)
+	chk.Int(tst, "Q.M", Q.M, m)
+	chk.Int(tst, "Q.N", Q.N, 9)
+
+	// the basis captures the range: ‖(I - Q⋅Qᵀ)⋅u_i‖ = 0
+	c := NewVector(Q.N)
+	p := NewVector(m)
+	for i := 0; i < len(σ); i++ {
+		u := Ut.Col(i)
+		MatTrVecMul(c, 1, Q, u)
+		MatVecMul(p, 1, Q, c)
+		io.Pforan("‖(I - Q⋅Qᵀ)⋅u%d‖ = %v\n", i, p.NormDiff(u))
+		chk.Float64(tst, io.Sf("‖(I - Q⋅Qᵀ)⋅u%d‖", i), 1e-13, p.NormDiff(u), 0)
+	}
+
+	// the extra columns are zero because rank(A) = 4
+	nonzero := 0
+	for j := 0; j < Q.N; j++ {
+		if Q.Col(j).Norm() > 0 {
+			nonzero++
+		}
+	}
+	chk.Int(tst, "number of nonzero columns", nonzero, 4)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	RandomizedRangeFinder(op, 48, 5, rng)
+}
+
+func TestRandSvd04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("RandSvd04. randomized low-rank approximation")
+
+	// low-rank plus noise with decaying spectrum
+	rng := rnd.NewRNG(21)
+	σ := []float64{100, 50, 25, 12, 6, 3}
+	noise := 1e-4
+	A, _, _ := lowRankPlusNoise(60, 60, σ, noise, rng)
+
+	// approximation with rank 6 ⇒ error ≈ ‖noise‖
+	Q, B := RandomizedLowRank(A, 6, 5, rng)
+	R := A.GetCopy()
+	MatMatMulAdd(R, -1, Q, B)
+	io.Pforan("‖A - Q⋅B‖ = %v  (‖noise‖ ≈ %v)\n", R.NormFrob(), noise*60)
+	if R.NormFrob() > 1.5*noise*60 {
+		tst.Errorf("approximation error is larger than the noise\n")
+	}
+
+	// approximation with rank 3 (no oversampling) ⇒ error ≈ sqrt(12² + 6² + 3²)
+	Q, B = RandomizedLowRank(A, 3, 0, rng)
+	R = A.GetCopy()
+	MatMatMulAdd(R, -1, Q, B)
+	io.Pforan("‖A - Q⋅B‖ = %v  (optimal = %v)\n", R.NormFrob(), math.Sqrt(12*12+6*6+3*3))
+	if R.NormFrob() > 2*math.Sqrt(12*12+6*6+3*3) {
+		tst.Errorf("approximation error is too large\n")
+	}
+}
+
+func TestRandSvd05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("RandSvd05. small singular values")
+
+	// singular values spanning 12 orders of magnitude
+	rng := rnd.NewRNG(7)
+	σ := []float64{1, 1e-6, 1e-12}
+	A, _, Vr := lowRankPlusNoise(50, 30, σ, 0, rng)
+	_, s, Vt, _, err := TruncatedSVDEst(A, 3)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("s = %v\n", s)
+	for c := 0; c < 3; c++ {
+		chk.Float64(tst, io.Sf("s%d/σ%d", c, c), 1e-4, s[c]/σ[c], 1)
+		chk.Float64(tst, io.Sf("|v%d⋅V%d|", c, c), 1e-4, math.Abs(VecDot(Vr.Col(c), Vt.GetRow(c))), 1)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+func TestBanded01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Banded01. real")
+
+	// same matrix as in SpSolver01: kl = 3, ku = 3
+	A := new(Triplet)
+	A.Init(5, 5, 13)
+	A.Put(0, 0, +1.0) // << duplicated
+	A.Put(0, 0, +1.0) // << duplicated
+	A.Put(1, 0, +3.0)
+	A.Put(0, 1, +3.0)
+	A.Put(2, 1, -1.0)
+	A.Put(4, 1, +4.0)
+	A.Put(1, 2, +4.0)
+	A.Put(2, 2, -3.0)
+	A.Put(3, 2, +1.0)
+	A.Put(4, 2, +2.0)
+	A.Put(2, 3, +2.0)
+	A.Put(1, 4, +6.0)
+	A.Put(4, 4, +1.0)
+	b := []float64{8.0, 45.0, -3.0, 3.0, 19.0}
+	TestSpSolver(tst, "banded", false, A, b, []float64{1, 2, 3, 4, 5}, 1e-14, 1e-13, false, false, nil)
+
+	// tridiagonal matrix with zero diagonal entries ⇒ pivoting is required
+	n := 6
+	T := new(Triplet)
+	T.Init(n, n, 3*n)
+	xCorrect := NewVector(n)
+	for i := 0; i < n; i++ {
+		xCorrect[i] = float64(i + 1)
+		if i%2 == 1 {
+			T.Put(i, i, 2)
+		}
+		if i > 0 {
+			T.Put(i, i-1, 1)
+		}
+		if i < n-1 {
+			T.Put(i, i+1, -1)
+		}
+	}
+	bb := NewVector(n)
+	SpMatVecMul(bb, 1, T.ToMatrix(nil), xCorrect)
+	TestSpSolver(tst, "banded", false, T, bb, xCorrect, 1e-14, 1e-13, false, false, nil)
+
+	// bandwidths
+	o := NewSparseSolver("banded").(*Banded)
+	o.Init(T, false, false, "", "", nil)
+	o.Fact()
+	kl, ku := o.Bandwidths()
+	chk.Int(tst, "kl", kl, 1)
+	chk.Int(tst, "ku", ku, 1)
+}
+
+func TestBanded02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Banded02. complex")
+
+	// same matrix as in SpSolver02
+	A := new(TripletC)
+	A.Init(5, 5, 16)
+	A.Put(0, 0, 19.73+0.00i)
+	A.Put(1, 0, +0.00-0.51i)
+	A.Put(0, 1, 12.11-1.00i)
+	A.Put(1, 1, 32.30+7.00i)
+	A.Put(2, 1, +0.00-0.51i)
+	A.Put(0, 2, +0.00+5.0i)
+	A.Put(1, 2, 23.07+0.0i)
+	A.Put(2, 2, 70.00+7.3i)
+	A.Put(3, 2, +1.00+1.1i)
+	A.Put(1, 3, +0.00+1.000i)
+	A.Put(2, 3, +3.95+0.000i)
+	A.Put(3, 3, 50.17+0.000i)
+	A.Put(4, 3, +0.00-9.351i)
+	A.Put(2, 4, 19.00+31.83i)
+	A.Put(3, 4, 45.51+0.00i)
+	A.Put(4, 4, 55.00+0.00i)
+	b := []complex128{77.38 + 8.82i, 157.48 + 19.8i, 1175.62 + 20.69i, 912.12 - 801.75i, 550.00 - 1060.4i}
+	xCorrect := []complex128{3.3 - 1.00i, 1.0 + 0.17i, 5.5 + 0.00i, 9.0 + 0.00i, 10.0 - 17.75i}
+	TestSpSolverC(tst, "banded", false, A, b, xCorrect, 1e-3, 1e-12, false, false, nil)
+}
@@ -0,0 +1,123 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/rnd"
+	"github.com/cpmech/gosl/utl"
+)
+
+// blurProblem returns an ill-posed deconvolution problem: A is the discretisation of a Gaussian
+// blurring kernel on [0,1], xtrue is a smooth signal and b = A⋅xtrue + noise
+func blurProblem(n int, width, noise float64, rng *rnd.RNG) (A *Matrix, b, xtrue Vector) {
+	h := 1.0 / float64(n)
+	A = NewMatrix(n, n)
+	xtrue = NewVector(n)
+	for i := 0; i < n; i++ {
+		si := (float64(i) + 0.5) * h
+		xtrue[i] = math.Exp(-math.Pow((si-0.3)/0.1, 2)) + 0.5*math.Exp(-math.Pow((si-0.7)/0.08, 2))
+		for j := 0; j < n; j++ {
+			tj := (float64(j) + 0.5) * h
+			A.Set(i, j, h*math.Exp(-0.5*math.Pow((si-tj)/width, 2))/(width*math.Sqrt(2*math.Pi)))
+		}
+	}
+	b = NewVector(n)
+	MatVecMul(b, 1, A, xtrue)
+	for i := 0; i < n; i++ {
+		b[i] += noise * rng.Normal(0, 1)
+	}
+	return
+}
+
+func TestTikhonov01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Tikhonov01. regularized least squares")
+
+	// overdetermined well-posed problem: λ = 0 gives the least squares solution
+	A := NewMatrixDeep2([][]float64{
+		{1, 1},
+		{1, 2},
+		{1, 3},
+		{1, 4},
+	})
+	b := []float64{6, 5, 7, 10}
+	x := RegularizedLeastSquares(A, b, 0)
+	io.Pforan("x = %v\n", x)
+	chk.Array(tst, "x(λ=0)", 1e-14, x, []float64{3.5, 1.4})
+
+	// λ > 0 ⇒ (Aᵀ⋅A + λ²⋅I)⋅x = Aᵀ⋅b
+	λ := 0.7
+	x = RegularizedLeastSquares(A, b, λ)
+	AtA := NewMatrix(2, 2)
+	MatTrMatMul(AtA, 1, A, A)
+	lhs, rhs := NewVector(2), NewVector(2)
+	MatVecMul(lhs, 1, AtA, x)
+	VecAdd(lhs, 1, lhs, λ*λ, x)
+	MatTrVecMul(rhs, 1, A, b)
+	chk.Array(tst, "normal equations", 1e-13, lhs, rhs)
+
+	// rank-deficient matrix
+	B := NewMatrixDeep2([][]float64{
+		{1, 2},
+		{2, 4},
+		{3, 6},
+	})
+	x = RegularizedLeastSquares(B, []float64{1, 2, 3}, 1e-3)
+	io.Pforan("x(rank-deficient) = %v\n", x)
+	chk.Array(tst, "x(rank-deficient)", 1e-6, x, []float64{0.2, 0.4}) // minimum norm solution
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	RegularizedLeastSquares(A, b, -1)
+}
+
+func TestTikhonov02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Tikhonov02. L-curve")
+
+	// ill-posed problem
+	n := 40
+	A, b, xtrue := blurProblem(n, 0.05, 1e-3, rnd.NewRNG(17))
+
+	// candidate values
+	lambdas := utl.LinSpace(-7, 1, 33)
+	for i := 0; i < len(lambdas); i++ {
+		lambdas[i] = math.Pow(10, lambdas[i])
+	}
+
+	// L-curve
+	best := LCurve(A, b, lambdas)
+	io.Pforan("best λ = %v\n", best)
+
+	// errors of solutions
+	xerr := func(λ float64) float64 {
+		return RegularizedLeastSquares(A, b, λ).NormDiff(xtrue) / xtrue.Norm()
+	}
+	errBest, errSmall, errLarge := xerr(best), xerr(lambdas[0]), xerr(lambdas[len(lambdas)-1])
+	io.Pforan("relative error: best = %v  λmin = %v  λmax = %v\n", errBest, errSmall, errLarge)
+	if errBest > 0.2 {
+		tst.Errorf("error of L-curve solution is too large\n")
+	}
+	if errBest > errSmall/10 || errBest > errLarge/2 {
+		tst.Errorf("L-curve solution should be better than the under- and over-regularized ones\n")
+	}
+
+	// the residual at the corner is of the order of the noise
+	r := NewVector(n)
+	MatVecMul(r, 1, A, RegularizedLeastSquares(A, b, best))
+	io.Pforan("‖A⋅x - b‖ = %v  (‖noise‖ ≈ %v)\n", r.NormDiff(b), 1e-3*math.Sqrt(float64(n)))
+	chk.Float64(tst, "log10(‖A⋅x - b‖ / ‖noise‖)", 0.5, math.Log10(r.NormDiff(b)/(1e-3*math.Sqrt(float64(n)))), 0)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	LCurve(A, b, []float64{1, 2})
+}
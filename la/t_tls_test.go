@@ -0,0 +1,137 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/rnd"
+)
+
+func TestTLS01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("TLS01. total least squares")
+
+	// consistent system ⇒ exact solution
+	A := NewMatrixDeep2([][]float64{
+		{1, 2},
+		{3, 1},
+		{0, 4},
+		{2, 2},
+	})
+	xref := []float64{0.5, -1.5}
+	b := NewVector(4)
+	MatVecMul(b, 1, A, xref)
+	x, err := TotalLeastSquares(A, b)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Array(tst, "x(consistent)", 1e-13, x, xref)
+
+	// errors
+	_, err = TotalLeastSquares(A, []float64{1, 2})
+	if err == nil {
+		tst.Errorf("TotalLeastSquares should have failed with wrong b\n")
+	}
+	_, err = TotalLeastSquares(NewMatrix(2, 2), []float64{1, 2})
+	if err == nil {
+		tst.Errorf("TotalLeastSquares should have failed with too few rows\n")
+	}
+	_, err = TotalLeastSquares(NewMatrix(3, 1), []float64{0, 0, 0})
+	io.Pforan("err = %v\n", err)
+	if err == nil {
+		tst.Errorf("TotalLeastSquares should have failed with non-unique solution\n")
+	}
+}
+
+func TestTLS02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("TLS02. errors-in-variables: TLS versus OLS")
+
+	// data y = 2⋅x with noise in x and y (same variance)
+	rng := rnd.NewRNG(11)
+	m := 2000
+	slope := 2.0
+	σ := 0.3
+	A := NewMatrix(m, 1)
+	b := NewVector(m)
+	for i := 0; i < m; i++ {
+		xtrue := rng.Normal(0, 1)
+		A.Set(i, 0, xtrue+rng.Normal(0, σ))
+		b[i] = slope*xtrue + rng.Normal(0, σ)
+	}
+
+	// solutions
+	xtls, err := TotalLeastSquares(A, b)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	xols := RegularizedLeastSquares(A, b, 0)
+	io.Pforan("slope: TLS = %v  OLS = %v  (true = %v)\n", xtls[0], xols[0], slope)
+
+	// OLS is biased towards zero (attenuation) by the factor 1/(1+σ²)
+	chk.Float64(tst, "OLS slope", 0.05, xols[0], slope/(1+σ*σ))
+	chk.Float64(tst, "TLS slope", 0.05, xtls[0], slope)
+	if math.Abs(xtls[0]-slope) > math.Abs(xols[0]-slope) {
+		tst.Errorf("TLS should recover the slope better than OLS\n")
+	}
+}
+
+func TestTLS03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("TLS03. ill-conditioned A")
+
+	// nearly collinear columns: cond(A) ≈ 1e8 ⇒ cond(Aᵀ⋅A) ≈ 1e16
+	m := 6
+	A := NewMatrix(m, 2)
+	for i := 0; i < m; i++ {
+		t := float64(i) / float64(m-1)
+		A.Set(i, 0, 1+t)
+		A.Set(i, 1, 1+t+1e-8*math.Cos(math.Pi*t))
+	}
+	xref := []float64{1, 2}
+	b := NewVector(m)
+	MatVecMul(b, 1, A, xref)
+	x, err := TotalLeastSquares(A, b)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("x = %v\n", x)
+	chk.Array(tst, "x(ill-conditioned)", 1e-6, x, xref)
+
+	// [x -1] is the right singular vector of C = [A b] of the smallest singular value
+	C := NewMatrixDeep2([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 10},
+		{1, 0, 1},
+	})
+	A = NewMatrixDeep2([][]float64{{1, 2}, {4, 5}, {7, 8}, {1, 0}})
+	x, err = TotalLeastSquares(A, C.Col(2))
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	σ := NewVector(3)
+	U, Vt := NewMatrix(4, 4), NewMatrix(3, 3)
+	MatSvd(σ, U, Vt, C, true)
+	z := Vector{x[0], x[1], -1}
+	CtC := NewMatrix(3, 3)
+	MatTrMatMul(CtC, 1, C, C)
+	w, σ2z := NewVector(3), NewVector(3)
+	MatVecMul(w, 1, CtC, z)
+	σ2z.Apply(σ[2]*σ[2], z)
+	io.Pforan("σ = %v\n", σ)
+	chk.Array(tst, "Cᵀ⋅C⋅z = σmin²⋅z", 1e-12, w, σ2z)
+}
@@ -106,3 +106,71 @@ func TestVector03(tst *testing.T) {
 
 	chk.ArrayC(tst, "b:=complex(aR,aI)", 1e-17, b, []complex128{1 - 1i, 2 - 2i, 3 - 3i})
 }
+
+func TestVector04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Vector04. ArgMin and ArgMax")
+
+	v := NewVectorSlice([]float64{3, math.NaN(), -1, 7, 7, -1})
+	min, imin := v.ArgMin()
+	max, imax := v.ArgMax()
+	io.Pforan("min = %v @ %d  max = %v @ %d\n", min, imin, max, imax)
+	chk.Float64(tst, "min", 1e-17, min, -1)
+	chk.Float64(tst, "max", 1e-17, max, 7)
+	chk.Int(tst, "imin (first occurrence)", imin, 2)
+	chk.Int(tst, "imax (first occurrence)", imax, 3)
+
+	w := NewVectorSlice([]float64{math.NaN(), math.NaN()})
+	min, imin = w.ArgMin()
+	if !math.IsNaN(min) {
+		tst.Errorf("min of vector full of NaN should be NaN\n")
+	}
+	chk.Int(tst, "imin(NaN)", imin, -1)
+}
+
+func TestVector05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Vector05. pairwise and compensated summation")
+
+	// one large value followed by many small values: Σ = 1 + n⋅1e-16
+	n := 1000000
+	v := NewVector(n + 1)
+	v[0] = 1
+	for i := 1; i <= n; i++ {
+		v[i] = 1e-16
+	}
+	ref := 1.0 + float64(n)*1e-16
+
+	// naive summation
+	naive := 0.0
+	for i := 0; i < len(v); i++ {
+		naive += v[i]
+	}
+	pairwise := v.SumPairwise()
+	kahan := v.SumKahan()
+	io.Pforan("naive    = %.17f  error = %.3e\n", naive, math.Abs(naive-ref))
+	io.Pforan("pairwise = %.17f  error = %.3e\n", pairwise, math.Abs(pairwise-ref))
+	io.Pforan("kahan    = %.17f  error = %.3e\n", kahan, math.Abs(kahan-ref))
+	if math.Abs(naive-ref) < 1e-11 {
+		tst.Errorf("naive summation should lose the small values\n")
+	}
+	chk.Float64(tst, "pairwise", 1e-13, pairwise, ref)
+	chk.Float64(tst, "kahan", 1e-15, kahan, ref)
+	chk.Float64(tst, "accum", 1e-13, v.Accum(), ref)
+
+	// small vectors
+	w := NewVectorSlice([]float64{1, 2, 3, 4.5})
+	chk.Float64(tst, "pairwise(w)", 1e-17, w.SumPairwise(), 10.5)
+	chk.Float64(tst, "kahan(w)", 1e-17, w.SumKahan(), 10.5)
+
+	// cancellation
+	u := NewVectorSlice([]float64{1, 1e100, 1, -1e100})
+	chk.Float64(tst, "kahan(u)", 1e-17, u.SumKahan(), 2)
+
+	// root-mean-square of large vectors
+	z := NewVectorMapped(1000, func(i int) float64 { return 3 })
+	chk.Float64(tst, "rms(z)", 1e-15, z.Rms(), 3)
+	chk.Float64(tst, "‖z-0‖", 1e-13, z.NormDiff(NewVector(1000)), 3*math.Sqrt(1000))
+}
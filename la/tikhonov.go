@@ -0,0 +1,192 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/utl"
+)
+
+// RegularizedLeastSquares solves the Tikhonov-regularized least squares problem
+//
+//   x = argmin ‖A⋅x - b‖² + λ²⋅‖x‖²    ⇒    (Aᵀ⋅A + λ²⋅I)⋅x = Aᵀ⋅b
+//
+//  The problem is solved as the equivalent (augmented) least squares problem
+//
+//         | A  |       | b |
+//   min ‖ |    | ⋅ x - |   | ‖²
+//         | λI |       | 0 |
+//
+//  using the Householder QR factorisation; thus the condition number of Aᵀ⋅A is avoided
+//
+//  INPUT:
+//   A      -- matrix [m][n]
+//   b      -- right-hand side [m]
+//   lambda -- regularization parameter λ ≥ 0. NOTE: λ > 0 is required if rank(A) < n
+//
+//  OUTPUT:
+//   x -- solution [n]
+//
+//  NOTE: λ² (not λ) multiplies ‖x‖², as in the L-curve literature [see LCurve]. stat.Ridge uses
+//        the convention (Xᵀ⋅X + λ⋅I); thus RegularizedLeastSquares(A, b, λ) is equivalent to
+//        stat.Ridge(A, b, λ²)
+//
+func RegularizedLeastSquares(A *Matrix, b Vector, lambda float64) (x Vector) {
+	if len(b) != A.M {
+		chk.Panic("len(b) must be equal to the number of rows of A. %d != %d\n", len(b), A.M)
+	}
+	if lambda < 0 {
+		chk.Panic("regularization parameter must be non-negative. λ=%g is invalid\n", lambda)
+	}
+	m, n := A.M, A.N
+	C := NewMatrix(m+n, n)
+	for j := 0; j < n; j++ {
+		copy(C.Data[j*(m+n):j*(m+n)+m], A.Col(j))
+		C.Set(m+j, j, lambda)
+	}
+	d := NewVector(m + n)
+	copy(d, b)
+	return leastSquaresQR(C, d)
+}
+
+// LCurve selects the regularization parameter of RegularizedLeastSquares using the L-curve
+// criterion [1]. The L-curve is the (log-log) plot of the norm of the solution versus the norm of
+// the residual:
+//
+//   ρ(λ) = log ‖A⋅x_λ - b‖    and    η(λ) = log ‖x_λ‖
+//
+//  The selected λ corresponds to the corner of the L-curve; i.e. the point of maximum curvature:
+//
+//         ρ'⋅η'' - ρ''⋅η'
+//   κ = ───────────────────    with (') = d/d(log λ)
+//        (ρ'² + η'²)^(3/2)
+//
+//  INPUT:
+//   A       -- matrix [m][n]
+//   b       -- right-hand side [m]
+//   lambdas -- candidate values (positive); at least 3. NOTE: a logarithmically spaced sequence
+//              covering several orders of magnitude works best; e.g. 1e-8, 1e-7, ..., 1e1
+//
+//  OUTPUT:
+//   bestLambda -- λ at the corner of the L-curve
+//
+//  NOTE: the curvature is computed with finite differences; hence the first and last lambdas
+//        (after sorting) are never selected
+//
+//  Reference:
+//   [1] Hansen PC and O'Leary DP (1993) The use of the L-curve in the regularization of discrete
+//       ill-posed problems. SIAM Journal on Scientific Computing, 14(6):1487-1503
+//
+func LCurve(A *Matrix, b, lambdas Vector) (bestLambda float64) {
+	_, _, κ, λs := lcurvePoints(A, b, lambdas)
+	_, imax := utl.ArgMax(κ)
+	return λs[imax]
+}
+
+// lcurvePoints computes the points (ρ,η) and curvatures κ of the L-curve with sorted λs
+//  NOTE: κ[0] and κ[len-1] are set to -∞
+func lcurvePoints(A *Matrix, b, lambdas Vector) (ρ, η, κ, λs Vector) {
+
+	// check
+	nl := len(lambdas)
+	if nl < 3 {
+		chk.Panic("at least 3 candidate values of λ are required. %d is invalid\n", nl)
+	}
+	λs = lambdas.GetCopy()
+	sort.Float64s(λs)
+	if λs[0] <= 0 {
+		chk.Panic("candidate values of λ must be positive. λ=%g is invalid\n", λs[0])
+	}
+
+	// points on the L-curve
+	ρ, η, κ = NewVector(nl), NewVector(nl), NewVector(nl)
+	t := NewVector(nl)
+	r := NewVector(A.M)
+	for k := 0; k < nl; k++ {
+		x := RegularizedLeastSquares(A, b, λs[k])
+		MatVecMul(r, 1, A, x)
+		t[k] = math.Log(λs[k])
+		ρ[k] = math.Log(r.NormDiff(b))
+		η[k] = math.Log(x.Norm())
+	}
+
+	// curvature using three-point (non-uniform) finite differences
+	κ[0], κ[nl-1] = math.Inf(-1), math.Inf(-1)
+	for k := 1; k < nl-1; k++ {
+		dρ, ddρ := fdiff3(t[k-1], t[k], t[k+1], ρ[k-1], ρ[k], ρ[k+1])
+		dη, ddη := fdiff3(t[k-1], t[k], t[k+1], η[k-1], η[k], η[k+1])
+		den := math.Pow(dρ*dρ+dη*dη, 1.5)
+		if den == 0 {
+			κ[k] = math.Inf(-1)
+			continue
+		}
+		κ[k] = (dρ*ddη - ddρ*dη) / den
+	}
+	return
+}
+
+// fdiff3 computes the first and second derivatives at x1 of the parabola through (x0,y0), (x1,y1)
+// and (x2,y2)
+func fdiff3(x0, x1, x2, y0, y1, y2 float64) (d, dd float64) {
+	h0, h1 := x1-x0, x2-x1
+	d = (-h1/(h0*(h0+h1)))*y0 + ((h1-h0)/(h0*h1))*y1 + (h0/(h1*(h0+h1)))*y2
+	dd = 2.0 * (y0/(h0*(h0+h1)) - y1/(h0*h1) + y2/(h1*(h0+h1)))
+	return
+}
+
+// leastSquaresQR solves min ‖C⋅x - d‖ with C[m][n] (m ≥ n) using the Householder QR factorisation
+//  NOTE: C and d are modified
+func leastSquaresQR(C *Matrix, d Vector) (x Vector) {
+
+	// R = Qᵀ⋅C and Qᵀ⋅d
+	householderQR(C, d)
+
+	// back substitution R⋅x = Qᵀ⋅d
+	n := C.N
+	x = NewVector(n)
+	for i := n - 1; i >= 0; i-- {
+		sum := d[i]
+		for j := i + 1; j < n; j++ {
+			sum -= C.Get(i, j) * x[j]
+		}
+		x[i] = sum / C.Get(i, i)
+	}
+	return
+}
+
+// householderQR computes (in place) the triangular factor R of the QR factorisation C = Q⋅R with
+// C[m][n] (m ≥ n) using Householder reflections and applies Qᵀ to d if d is not nil
+//  NOTE: on exit, the upper triangle of C holds R; the entries below the diagonal are modified
+func householderQR(C *Matrix, d Vector) {
+	m, n := C.M, C.N
+	for k := 0; k < n; k++ {
+
+		// Householder vector of column k (below the diagonal)
+		ck := C.Col(k)[k:]
+		α := ck.Norm()
+		if α == 0 {
+			continue
+		}
+		if ck[0] > 0 {
+			α = -α
+		}
+		ck[0] -= α // v = c - α⋅e₁ stored in ck
+		vv := VecDot(ck, ck)
+
+		// apply H = I - 2⋅v⋅vᵀ/(vᵀ⋅v) to the remaining columns and to d
+		for j := k + 1; j < n; j++ {
+			cj := C.Col(j)[k:]
+			VecAdd(cj, 1, cj, -2.0*VecDot(ck, cj)/vv, ck)
+		}
+		if d != nil {
+			dk := d[k:m]
+			VecAdd(dk, 1, dk, -2.0*VecDot(ck, dk)/vv, ck)
+		}
+		C.Set(k, k, α) // diagonal of R
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package la
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+)
+
+// TotalLeastSquares solves the total least squares (errors-in-variables) problem
+//
+//   min ‖[ΔA Δb]‖_F    subject to    (A + ΔA)⋅x = b + Δb
+//
+//  i.e. both A and b are assumed to have errors (orthogonal regression). The solution is obtained
+//  from the right singular vector v of the augmented matrix C = [A b] corresponding to the
+//  smallest singular value [1]:
+//
+//   x = -v[0:n] / v[n]
+//
+//  INPUT:
+//   A -- matrix [m][n] with m ≥ n+1
+//   b -- right-hand side [m]
+//
+//  OUTPUT:
+//   x   -- solution [n]
+//   err -- error if the dimensions are invalid or the problem has no (unique) solution; e.g.
+//          if v[n] = 0 or if the smallest singular value is repeated
+//
+//  NOTE: (1) C is reduced to the triangular factor R[n+1][n+1] of C = Q⋅R (Householder), which has
+//            the same singular values and right singular vectors as C, and the SVD of R is computed
+//            by MatSvd; i.e. Cᵀ⋅C is not formed and the accuracy of v is not degraded by squaring
+//            the condition number of C
+//        (2) the columns of A and b should be scaled such that the errors have the same variance
+//
+//  Reference:
+//   [1] Golub GH and Van Loan CF (1980) An analysis of the total least squares problem. SIAM
+//       Journal on Numerical Analysis, 17(6):883-893
+//
+func TotalLeastSquares(A *Matrix, b Vector) (x Vector, err error) {
+
+	// check
+	m, n := A.M, A.N
+	if len(b) != m {
+		return nil, chk.Err("len(b) must be equal to the number of rows of A. %d != %d", len(b), m)
+	}
+	if m < n+1 {
+		return nil, chk.Err("number of rows of A must be at least %d. m=%d is invalid", n+1, m)
+	}
+
+	// C = [A b]
+	C := NewMatrix(m, n+1)
+	copy(C.Data, A.Data)
+	copy(C.Col(n), b)
+
+	// C = Q⋅R
+	householderQR(C, nil)
+	R := NewMatrix(n+1, n+1)
+	for j := 0; j < n+1; j++ {
+		for i := 0; i <= j; i++ {
+			R.Set(i, j, C.Get(i, j))
+		}
+	}
+
+	// smallest singular value and corresponding right singular vector (last row of Vt)
+	σ := NewVector(n + 1)
+	U := NewMatrix(n+1, n+1)
+	Vt := NewMatrix(n+1, n+1)
+	MatSvd(σ, U, Vt, R, false)
+	if n > 0 && σ[n-1]-σ[n] <= 1e-14*σ[0] {
+		return nil, chk.Err("TLS solution is not unique: the smallest singular value of [A b] is repeated")
+	}
+	v := Vt.GetRow(n)
+	if math.Abs(v[n]) < 1e-14 {
+		return nil, chk.Err("TLS solution does not exist: last component of singular vector is zero")
+	}
+
+	// solution
+	x = NewVector(n)
+	for i := 0; i < n; i++ {
+		x[i] = -v[i] / v[n]
+	}
+	return
+}
@@ -7,6 +7,8 @@ package la
 import (
 	"math"
 	"math/cmplx"
+
+	"github.com/cpmech/gosl/utl"
 )
 
 // Vector defines the vector type for real numbers simply as a slice of float64
@@ -80,13 +82,50 @@ func (o Vector) GetUnit() (unit Vector) {
 
 // Accum sum/accumulates all components in a vector
 //  sum := Σ_i v[i]
+//  NOTE: pairwise summation is used for large vectors (see SumPairwise)
 func (o Vector) Accum() (sum float64) {
+	if len(o) > pairwiseBlock {
+		return o.SumPairwise()
+	}
 	for i := 0; i < len(o); i++ {
 		sum += o[i]
 	}
 	return
 }
 
+// SumPairwise returns the sum of all components using pairwise (cascade) summation
+//  sum := Σ_i v[i]
+//  The vector is recursively split in halves and the partial sums are added. The rounding error
+//  grows as O(ε⋅log n) instead of O(ε⋅n) of the naive (sequential) summation
+func (o Vector) SumPairwise() (sum float64) {
+	if len(o) <= pairwiseBlock {
+		for i := 0; i < len(o); i++ {
+			sum += o[i]
+		}
+		return
+	}
+	m := len(o) / 2
+	return o[:m].SumPairwise() + o[m:].SumPairwise()
+}
+
+// SumKahan returns the sum of all components using compensated summation
+//  sum := Σ_i v[i]
+//  The Kahan-Babuška (Neumaier) algorithm is used; i.e. the rounding error of each addition is
+//  accumulated in a separate variable. The error is O(ε) independently of n
+func (o Vector) SumKahan() (sum float64) {
+	var c, t float64
+	for i := 0; i < len(o); i++ {
+		t = sum + o[i]
+		if math.Abs(sum) >= math.Abs(o[i]) {
+			c += (sum - t) + o[i]
+		} else {
+			c += (o[i] - t) + sum
+		}
+		sum = t
+	}
+	return sum + c
+}
+
 // Norm returns the Euclidean norm of a vector:
 //  nrm := ‖v‖
 func (o Vector) Norm() (nrm float64) {
@@ -101,8 +140,12 @@ func (o Vector) Norm() (nrm float64) {
 //           \/    N   ———— \         /
 //
 func (o Vector) Rms() (rms float64) {
-	for i := 0; i < len(o); i++ {
-		rms += o[i] * o[i]
+	if len(o) > pairwiseBlock {
+		rms = sumTermsPairwise(0, len(o), func(i int) float64 { return o[i] * o[i] })
+	} else {
+		for i := 0; i < len(o); i++ {
+			rms += o[i] * o[i]
+		}
 	}
 	rms = math.Sqrt(rms / float64(len(o)))
 	return
@@ -111,8 +154,12 @@ func (o Vector) Rms() (rms float64) {
 // NormDiff returns the Euclidean norm of the difference:
 //  nrm := ||u - v||
 func (o Vector) NormDiff(v Vector) (nrm float64) {
-	for i := 0; i < len(v); i++ {
-		nrm += (o[i] - v[i]) * (o[i] - v[i])
+	if len(v) > pairwiseBlock {
+		nrm = sumTermsPairwise(0, len(v), func(i int) float64 { return (o[i] - v[i]) * (o[i] - v[i]) })
+	} else {
+		for i := 0; i < len(v); i++ {
+			nrm += (o[i] - v[i]) * (o[i] - v[i])
+		}
 	}
 	nrm = math.Sqrt(nrm)
 	return
@@ -154,6 +201,18 @@ func (o Vector) MinMax() (min, max float64) {
 	return
 }
 
+// ArgMin returns the minimum component of a vector and its index
+//  NOTE: NaN components are ignored; if all components are NaN, min=NaN and idx=-1
+func (o Vector) ArgMin() (min float64, idx int) {
+	return utl.ArgMin(o)
+}
+
+// ArgMax returns the maximum component of a vector and its index
+//  NOTE: NaN components are ignored; if all components are NaN, max=NaN and idx=-1
+func (o Vector) ArgMax() (max float64, idx int) {
+	return utl.ArgMax(o)
+}
+
 // Largest returns the largest component |u[i]| of this vector, normalised by den
 //   largest := |u[i]| / den
 func (o Vector) Largest(den float64) (largest float64) {
@@ -167,6 +226,21 @@ func (o Vector) Largest(den float64) (largest float64) {
 	return largest / den
 }
 
+// pairwiseBlock is the length of the blocks summed sequentially by the pairwise summation
+const pairwiseBlock = 128
+
+// sumTermsPairwise returns Σ term(i) for i in [lo,hi) using pairwise summation
+func sumTermsPairwise(lo, hi int, term func(i int) float64) (sum float64) {
+	if hi-lo <= pairwiseBlock {
+		for i := lo; i < hi; i++ {
+			sum += term(i)
+		}
+		return
+	}
+	m := lo + (hi-lo)/2
+	return sumTermsPairwise(lo, m, term) + sumTermsPairwise(m, hi, term)
+}
+
 // complex /////////////////////////////////////////////////////////////////////////////////////////
 
 // VectorC defines the vector type for complex numbers simply as a slice of complex128
@@ -47,6 +47,7 @@ func NewBracket(ffcn fun.Ss) (o *Bracket) {
 	o.MaxIt = 500
 	o.ffcn = ffcn
 	o.gold = (1.0 + math.Sqrt(5.0)) / 2.0
+	o.glimit = 100.0
 	o.tiny = math.Sqrt(MACHEPS)
 	return
 }
@@ -59,11 +60,23 @@ func NewBracket(ffcn fun.Ss) (o *Bracket) {
 //
 //  Returns also the function values at the three points, fa, fb, and fc
 //
+//  NOTE: this function panics if the minimum cannot be bracketed. See TryMin
+//
 func (o *Bracket) Min(a0, b0 float64) (a, b, c, fa, fb, fc float64) {
+	a, b, c, fa, fb, fc, err := o.TryMin(a0, b0)
+	if err != nil {
+		chk.Panic("%v\n", err)
+	}
+	return
+}
+
+// TryMin brackets minimum, returning an error instead of panicking. See Min
+func (o *Bracket) TryMin(a0, b0 float64) (a, b, c, fa, fb, fc float64, err error) {
 
 	// check
 	if a0 == b0 {
-		chk.Panic("a0=%g must be different than b0=%g\n", a0, b0)
+		err = chk.Err("a0=%g must be different than b0=%g", a0, b0)
+		return
 	}
 
 	// sort output
@@ -155,7 +168,8 @@ func (o *Bracket) Min(a0, b0 float64) (a, b, c, fa, fb, fc float64) {
 			if fu < fc {
 				aux = u + o.gold*(u-c)
 				shft3(&b, &c, &u, aux)
-				shft3(&fb, &fc, &fu, fu) // TODO: check why [1] calls ffcn(u) again here
+				shft3(&fb, &fc, &fu, o.ffcn(u)) // f at the new (magnified) u
+				o.NumFeval++
 			}
 
 			// limit parabolic u to maximum allowed value
@@ -177,6 +191,25 @@ func (o *Bracket) Min(a0, b0 float64) (a, b, c, fa, fb, fc float64) {
 	}
 
 	// check
-	chk.Panic("fail to converge after %d iterations", o.NumIter)
+	err = chk.Err("fail to converge after %d iterations", o.NumIter)
+	return
+}
+
+// BracketMin brackets a minimum of f by searching downhill from the initial points a and b,
+// expanding the interval by the golden ratio (and parabolic extrapolation) [1]
+//
+//  INPUT:
+//   f -- function
+//   a -- first initial point
+//   b -- second initial point; b ≠ a
+//
+//  OUTPUT:
+//   xa, xb, xc -- points such that xa < xb < xc and f(xb) ≤ f(xa) and f(xb) ≤ f(xc)
+//   err        -- error if a == b or the minimum cannot be bracketed (e.g. f is unbounded below)
+//
+//  NOTE: a Bracket object can be used to access the function values and statistics
+//
+func BracketMin(f fun.Ss, a, b float64) (xa, xb, xc float64, err error) {
+	xa, xb, xc, _, _, _, err = NewBracket(f).TryMin(a, b)
 	return
 }
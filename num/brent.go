@@ -192,6 +192,15 @@ func (o *Brent) Root(xa, xb float64) (res float64) {
 	return
 }
 
+// FindRoot solves f(x) = 0 for x in [a, b] with f(a) * f(b) < 0; thus Brent implements the
+// RootFinder interface
+//  NOTE: this method replaces the function given to NewBrent and the tolerance Tol
+func (o *Brent) FindRoot(f func(x float64) float64, a, b, tol float64) (res float64, err error) {
+	defer recoverRootFinder(&err, "Brent's method")
+	o.ffcn, o.Tol = f, tol
+	return o.Root(a, b), nil
+}
+
 // Min finds the minimum of f(x) in [xa, xb]
 //
 //  Based on ZEROIN C math library: http://www.netlib.org/c/
@@ -0,0 +1,48 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import "github.com/cpmech/gosl/chk"
+
+// ContourIntegral integrates a complex function along a parametrized contour in the complex plane
+//
+//   INPUT:
+//     f     -- complex function f(z)
+//     path  -- contour z(t)
+//     dpath -- derivative of contour dz/dt
+//     t0    -- initial value of the parameter t
+//     t1    -- final value of the parameter t
+//     tol   -- tolerance
+//
+//   OUTPUT:              t1
+//             res = ∮ f(z) dz = ∫  f(z(t)) ⋅ z'(t) dt
+//                 C         t0
+//
+//   NOTE: (1) the real and imaginary parts are integrated with the adaptive Gauss quadrature
+//             (IntegratorGauss) on the real parameter t. The values of f(z(t))⋅z'(t) are cached;
+//             thus the integrand is evaluated once at the nodes shared by both quadratures
+//         (2) the contour must not pass through singularities of f; e.g. for residue calculations
+//
+func ContourIntegral(f func(z complex128) complex128, path, dpath func(t float64) complex128, t0, t1, tol float64) (res complex128, err error) {
+	cache := make(map[float64]complex128)
+	g := func(t float64) complex128 {
+		if v, ok := cache[t]; ok {
+			return v
+		}
+		v := f(path(t)) * dpath(t)
+		cache[t] = v
+		return v
+	}
+	var integ IntegratorGauss
+	re, err := integ.Integrate(func(t float64) float64 { return real(g(t)) }, t0, t1, tol)
+	if err != nil {
+		return complex(re, 0), chk.Err("cannot integrate real part: %v", err)
+	}
+	im, err := integ.Integrate(func(t float64) float64 { return imag(g(t)) }, t0, t1, tol)
+	if err != nil {
+		return complex(re, im), chk.Err("cannot integrate imaginary part: %v", err)
+	}
+	return complex(re, im), nil
+}
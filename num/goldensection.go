@@ -0,0 +1,80 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+)
+
+// GoldenSection finds a minimum of f using the golden section search, given a bracket (a, b, c)
+//
+//  The bracket is reduced by the golden ratio at each iteration; i.e. the width of the interval
+//  containing the minimum is multiplied by 0.618 (linear convergence). Thus, the convergence is
+//  guaranteed, even for non-smooth unimodal functions
+//
+//  INPUT:
+//   f   -- function
+//   a   -- left (or right) point of the bracket
+//   b   -- middle point such that f(b) ≤ f(a) and f(b) ≤ f(c); e.g. computed with BracketMin
+//   c   -- right (or left) point of the bracket
+//   tol -- tolerance: the search stops when |c - a| ≤ tol⋅(1 + |x1| + |x2|) where x1 and x2 are
+//          the interior points. NOTE: tol ≤ 0 ⇒ tol = sqrt(MACHEPS); and tol ≥ MACHEPS
+//
+//  OUTPUT:
+//   xmin -- location of the minimum
+//   fmin -- f(xmin)
+//
+//  Reference:
+//   [1] Press WH, Teukolsky SA, Vetterling WT, Fnannery BP (2007) Numerical Recipes:
+//       The Art of Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+//
+func GoldenSection(f fun.Ss, a, b, c, tol float64) (xmin, fmin float64) {
+
+	// check
+	if !((a < b && b < c) || (c < b && b < a)) {
+		chk.Panic("b must be between a and c. a=%g, b=%g and c=%g are invalid\n", a, b, c)
+	}
+	fb := f(b)
+	if fb > f(a) || fb > f(c) {
+		chk.Panic("f(b) must not be greater than f(a) and f(c). (a,b,c)=(%g,%g,%g) is not a bracket\n", a, b, c)
+	}
+	if tol <= 0 {
+		tol = math.Sqrt(MACHEPS)
+	}
+	tol = math.Max(tol, MACHEPS)
+
+	// golden ratios
+	r := (math.Sqrt(5.0) - 1.0) / 2.0 // 0.618...
+	q := 1.0 - r                      // 0.382...
+
+	// initial interior points: x1 and x2 such that the new point is placed in the larger segment
+	x0, x3 := a, c
+	var x1, x2, f1, f2 float64
+	if math.Abs(c-b) > math.Abs(b-a) {
+		x1, x2 = b, b+q*(c-b)
+		f1, f2 = fb, f(x2)
+	} else {
+		x1, x2 = b-q*(b-a), b
+		f1, f2 = f(x1), fb
+	}
+
+	// reduce bracket
+	for math.Abs(x3-x0) > tol*(1.0+math.Abs(x1)+math.Abs(x2)) {
+		if f2 < f1 {
+			x0, x1, x2 = x1, x2, r*x2+q*x3
+			f1, f2 = f2, f(x2)
+		} else {
+			x3, x2, x1 = x2, x1, r*x1+q*x0
+			f2, f1 = f1, f(x1)
+		}
+	}
+	if f1 < f2 {
+		return x1, f1
+	}
+	return x2, f2
+}
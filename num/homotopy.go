@@ -0,0 +1,79 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// Homotopy solves F(x,λ=1) = 0 by continuation; i.e. by solving the sequence of problems
+//
+//    F(x, λₖ) = 0   with   λₖ = k / steps,   k = 1 … steps
+//
+//  where F(x,0) = 0 is an easy problem. Each solution is used as the initial guess for the next
+//  problem, which is solved by NlSolver (Newton's method with numerical Jacobian).
+//
+//  Example: the (global) Newton homotopy with a hard target G(x) = 0 is:
+//
+//    F(x,λ) = G(x) - (1 - λ)⋅G(x0)
+//
+//  INPUT:
+//   Ffcn  -- F(x,λ) function
+//   x0    -- initial guess; i.e. solution of F(x,0) = 0 [not modified]
+//   steps -- number of continuation steps (≥ 1)
+//
+//  OUTPUT:
+//   x   -- solution of F(x,1) = 0
+//   err -- error if Newton's method failed for any λₖ
+func Homotopy(Ffcn func(x la.Vector, lambda float64) la.Vector, x0 la.Vector, steps int) (x la.Vector, err error) {
+
+	// check
+	if steps < 1 {
+		return nil, chk.Err("number of steps must be at least 1. steps = %d is invalid", steps)
+	}
+	if len(x0) < 1 {
+		return nil, chk.Err("initial guess must not be empty")
+	}
+
+	// nonlinear solver
+	var λ float64
+	neq := len(x0)
+	ffcn := func(fx, xx la.Vector) {
+		copy(fx, Ffcn(xx, λ))
+	}
+	var nls NlSolver
+	nls.Init(neq, ffcn, nil, nil, false, true, map[string]float64{"chkConv": 1})
+	defer nls.Free()
+
+	// continuation
+	x = x0.GetCopy()
+	for k := 1; k <= steps; k++ {
+		λ = float64(k) / float64(steps)
+		err = homotopyStep(&nls, x)
+		if err != nil {
+			return x, chk.Err("continuation failed with λ = %g (step %d of %d):\n%v", λ, k, steps, err)
+		}
+	}
+	return
+}
+
+// homotopyStep runs NlSolver and converts failures (panics) into errors
+func homotopyStep(nls *NlSolver, x la.Vector) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = chk.Err("%v", e)
+		}
+	}()
+	nls.Solve(x, true)
+	for _, v := range x {
+		if math.IsNaN(v) {
+			return chk.Err("solution contains NaN")
+		}
+	}
+	return
+}
@@ -0,0 +1,362 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/num/qpck"
+)
+
+// Integrator defines the interface of quadrature routines computing
+//
+//           b
+//    res = ∫  f(x) dx    with tolerance tol
+//          a
+//
+//  NOTE: an error is returned if the requested tolerance cannot be achieved
+type Integrator interface {
+	Integrate(f func(x float64) float64, a, b, tol float64) (res float64, err error)
+}
+
+// IntegratorTrapz implements the Integrator interface using the trapezoidal rule with refinement
+type IntegratorTrapz struct{}
+
+// IntegratorSimpson implements the Integrator interface using Simpson's rule with refinement
+type IntegratorSimpson struct{}
+
+// QuadAdaptiveOpts holds options of adaptive quadratures
+//
+//  NOTE: the interval of integration is split at the break points before the adaptive bisections;
+//        thus, known kinks, discontinuities or singularities of piecewise-smooth integrands do not
+//        have to be located by refinement. Points outside (a, b) are ignored. The tolerance is
+//        distributed among the segments proportionally to their lengths
+type QuadAdaptiveOpts struct {
+	MaxSubdiv   int       // maximum number of bisections of subintervals (total) [default = 0 ⇒ no limit]
+	BreakPoints []float64 // known break/singularity points [may be nil]
+}
+
+// IntegratorGauss implements the Integrator interface using the ten-point Gauss-Legendre rule
+// with adaptive bisection of intervals
+//
+//  NOTE: with Parallel = true, the subintervals of each level of bisection are evaluated
+//        concurrently; thus f must be safe for concurrent use. This is advantageous if f is
+//        expensive (e.g. it runs a simulation). The subintervals are refined and summed in the
+//        same order as in the serial version; hence the results are identical
+type IntegratorGauss struct {
+	QuadAdaptiveOpts      // options: max number of subdivisions and break points
+	MaxDepth         int  // maximum number of bisections of each initial segment [default = 50]
+	Parallel         bool // evaluate subintervals concurrently
+	NumWorkers       int  // number of goroutines if Parallel; use 0 for runtime.NumCPU()
+}
+
+// IntegratorTanhSinh implements the Integrator interface using the tanh-sinh (double exponential)
+// quadrature. The integrand is never evaluated at a or b; thus endpoint singularities are handled
+//
+//    x = c + d⋅tanh(π/2⋅sinh(t))   with   c = (a+b)/2   and   d = (b-a)/2
+//
+//  Reference:
+//    [1] Takahasi H and Mori M (1974) Double exponential formulas for numerical integration.
+//        Publications of the Research Institute for Mathematical Sciences, 9(3):721-741
+type IntegratorTanhSinh struct {
+	MaxLevel int // maximum number of halvings of the step size [default = 10]
+}
+
+// IntegratorQuadpack implements the Integrator interface using the QUADPACK routine AGSE
+type IntegratorQuadpack struct {
+	Fid int // index of goroutine (to avoid race problems)
+}
+
+// Integrate integrates f from a to b
+func (o IntegratorTrapz) Integrate(f func(x float64) float64, a, b, tol float64) (res float64, err error) {
+	defer recoverIntegrator(&err, "trapezoidal rule")
+	var T ElementaryTrapz
+	T.Init(f, a, b, tol)
+	return T.Integrate(), nil
+}
+
+// Integrate integrates f from a to b
+func (o IntegratorSimpson) Integrate(f func(x float64) float64, a, b, tol float64) (res float64, err error) {
+	defer recoverIntegrator(&err, "Simpson's rule")
+	var S ElementarySimpson
+	S.Init(f, a, b, tol)
+	return S.Integrate(), nil
+}
+
+// Integrate integrates f from a to b
+func (o IntegratorGauss) Integrate(f func(x float64) float64, a, b, tol float64) (res float64, err error) {
+	maxDepth := o.MaxDepth
+	if maxDepth < 1 {
+		maxDepth = 50
+	}
+	for _, x := range o.BreakPoints {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return 0, chk.Err("break points must be finite. %g is invalid", x)
+		}
+	}
+
+	// segments with tolerances proportional to their lengths
+	pts := gaussSegments(a, b, o.BreakPoints)
+	nseg := len(pts) - 1
+	nodes := make([]gaussNode, nseg)
+	for i := 0; i < nseg; i++ {
+		nodes[i] = gaussNode{a: pts[i], b: pts[i+1], tol: tol, depth: maxDepth, kids: -1}
+		if nseg > 1 {
+			nodes[i].tol = tol * (pts[i+1] - pts[i]) / (b - a)
+		}
+	}
+	evalTasks(nseg, o.Parallel, o.NumWorkers, func(k int) {
+		nodes[k].whole = QuadGaussL10(nodes[k].a, nodes[k].b, f)
+	})
+	whole := 0.0
+	for i := 0; i < nseg; i++ {
+		whole += nodes[i].whole
+	}
+	for i := 0; i < nseg; i++ {
+		nodes[i].scale = math.Abs(whole)
+	}
+
+	// bisections
+	ok := true
+	res = adaptGauss(nodes, o.MaxSubdiv, &ok, func(nodes []gaussNode, level []int) {
+		evalTasks(2*len(level), o.Parallel, o.NumWorkers, func(k int) {
+			n := &nodes[level[k/2]]
+			m := (n.a + n.b) / 2.0
+			if k%2 == 0 {
+				n.left = QuadGaussL10(n.a, m, f)
+			} else {
+				n.right = QuadGaussL10(m, n.b, f)
+			}
+		})
+	})
+	if !ok {
+		return res, chk.Err("adaptive Gauss quadrature did not converge with tol=%g, MaxDepth=%d and MaxSubdiv=%d", tol, maxDepth, o.MaxSubdiv)
+	}
+	return
+}
+
+// Integrate integrates f from a to b
+func (o IntegratorTanhSinh) Integrate(f func(x float64) float64, a, b, tol float64) (res float64, err error) {
+
+	// constants
+	maxLevel := o.MaxLevel
+	if maxLevel < 1 {
+		maxLevel = 10
+	}
+	d := (b - a) / 2.0
+	if d == 0 {
+		return 0, nil
+	}
+	tmax := 3.5 // u = π/2⋅sinh(tmax) ≈ 26 thus 1-tanh(u) ≈ 1e-23
+
+	// sum of f(x(t))⋅x'(t) for t = ±k⋅h; skipping points at the endpoints (round-off)
+	term := func(t float64) (sum float64) {
+		u := math.Pi / 2.0 * math.Sinh(t)
+		ch := math.Cosh(u)
+		w := d * math.Pi / 2.0 * math.Cosh(t) / (ch * ch)
+		δ := 2.0 * d / (1.0 + math.Exp(2.0*u)) // distance to endpoint = d⋅(1 - tanh(u))
+		if xb := b - δ; δ != 0 && xb != b {
+			sum += w * f(xb)
+		}
+		if t > 0 {
+			if xa := a + δ; δ != 0 && xa != a {
+				sum += w * f(xa)
+			}
+		}
+		return
+	}
+
+	// level 0
+	h := 1.0
+	sum := term(0)
+	for t := h; t <= tmax; t += h {
+		sum += term(t)
+	}
+	res = h * sum
+
+	// refinements
+	for level := 1; level <= maxLevel; level++ {
+		h /= 2.0
+		for t := h; t <= tmax; t += 2.0 * h {
+			sum += term(t)
+		}
+		old := res
+		res = h * sum
+		if math.IsNaN(res) || math.IsInf(res, 0) {
+			return res, chk.Err("tanh-sinh quadrature failed: integral is not finite")
+		}
+		if level > 2 && math.Abs(res-old) <= tol*math.Max(1.0, math.Abs(res)) {
+			return
+		}
+	}
+	return res, chk.Err("tanh-sinh quadrature did not converge with tol=%g and MaxLevel=%d", tol, maxLevel)
+}
+
+// Integrate integrates f from a to b
+func (o IntegratorQuadpack) Integrate(f func(x float64) float64, a, b, tol float64) (res float64, err error) {
+	defer recoverIntegrator(&err, "QUADPACK")
+	res, _, _, _ = qpck.Agse(int32(o.Fid), f, a, b, tol, tol, nil, nil, nil, nil, nil)
+	return
+}
+
+// AutoIntegrate integrates f from a to b selecting the quadrature method according to the
+// behaviour of f at the endpoints: the tanh-sinh quadrature is used if f is not finite or seems
+// to be singular at a or b; otherwise the adaptive Gauss quadrature is used
+func AutoIntegrate(f func(x float64) float64, a, b, tol float64) (res float64, err error) {
+	if math.IsInf(a, 0) || math.IsInf(b, 0) || math.IsNaN(a) || math.IsNaN(b) {
+		return 0, chk.Err("limits of integration must be finite. a=%g and b=%g are invalid", a, b)
+	}
+	if a == b {
+		return 0, nil
+	}
+	if endpointSingular(f, a, b-a) || endpointSingular(f, b, a-b) {
+		return IntegratorTanhSinh{}.Integrate(f, a, b, tol)
+	}
+	return IntegratorGauss{}.Integrate(f, a, b, tol)
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// gaussNode holds a subinterval of the adaptive Gauss quadrature
+type gaussNode struct {
+	a, b        float64 // limits
+	whole       float64 // estimate over [a, b]
+	tol, scale  float64 // tolerance and scale
+	depth       int     // remaining number of bisections
+	left, right float64 // estimates over [a, m] and [m, b]
+	kids        int     // index of the first of the two children; -1 if leaf
+}
+
+// adaptGauss performs the bisections of the adaptive Gauss quadrature level by level, starting
+// from the nroots first nodes. The function halves computes the estimates left and right of the
+// nodes of one level; e.g. concurrently or in batch. Nevertheless, the subintervals are bisected
+// and summed in the same order; thus, the results do not depend on how halves are computed
+func adaptGauss(nodes []gaussNode, maxSubdiv int, ok *bool, halves func(nodes []gaussNode, level []int)) (res float64) {
+	nroots := len(nodes)
+	level := make([]int, nroots)
+	for i := 0; i < nroots; i++ {
+		level[i] = i
+	}
+	nsubdiv := 0
+	for len(level) > 0 {
+
+		// evaluate halves
+		halves(nodes, level)
+
+		// check convergence and bisect
+		var next []int
+		for _, i := range level {
+			n := nodes[i]
+			m := (n.a + n.b) / 2.0
+			sum := n.left + n.right
+			scale := math.Max(n.scale, math.Abs(sum))
+			if math.Abs(sum-n.whole) <= n.tol*math.Max(1.0, scale) {
+				continue
+			}
+			if n.depth == 0 || m == n.a || m == n.b || math.IsNaN(sum) || math.IsInf(sum, 0) {
+				*ok = false
+				continue
+			}
+			if maxSubdiv > 0 && nsubdiv == maxSubdiv {
+				*ok = false
+				continue
+			}
+			nsubdiv++
+			nodes[i].kids = len(nodes)
+			nodes = append(nodes,
+				gaussNode{a: n.a, b: m, whole: n.left, tol: n.tol / 2.0, scale: scale, depth: n.depth - 1, kids: -1},
+				gaussNode{a: m, b: n.b, whole: n.right, tol: n.tol / 2.0, scale: scale, depth: n.depth - 1, kids: -1},
+			)
+			next = append(next, len(nodes)-2, len(nodes)-1)
+		}
+		level = next
+	}
+
+	// sum leaves
+	for i := 0; i < nroots; i++ {
+		res += sumGaussNodes(nodes, i)
+	}
+	return
+}
+
+// sumGaussNodes sums the estimates of the leaves of the tree of subintervals starting at node i
+func sumGaussNodes(nodes []gaussNode, i int) float64 {
+	if nodes[i].kids < 0 {
+		return nodes[i].left + nodes[i].right
+	}
+	return sumGaussNodes(nodes, nodes[i].kids) + sumGaussNodes(nodes, nodes[i].kids+1)
+}
+
+// evalTasks calls task(k) for k in [0, ntasks) serially or concurrently using nworkers
+func evalTasks(ntasks int, parallel bool, nworkers int, task func(k int)) {
+	if !parallel {
+		for k := 0; k < ntasks; k++ {
+			task(k)
+		}
+		return
+	}
+	if nworkers < 1 {
+		nworkers = runtime.NumCPU()
+	}
+	if nworkers > ntasks {
+		nworkers = ntasks
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < nworkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for k := w; k < ntasks; k += nworkers {
+				task(k)
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+// gaussSegments returns the limits of the segments of [a, b] split at the break points within
+// (a, b), sorted in the direction from a to b; repeated points are skipped
+func gaussSegments(a, b float64, breakPoints []float64) (pts []float64) {
+	lo, hi := math.Min(a, b), math.Max(a, b)
+	var inner []float64
+	for _, x := range breakPoints {
+		if x > lo && x < hi {
+			inner = append(inner, x)
+		}
+	}
+	sort.Float64s(inner)
+	if a > b {
+		sort.Sort(sort.Reverse(sort.Float64Slice(inner)))
+	}
+	pts = []float64{a}
+	for _, x := range inner {
+		if x != pts[len(pts)-1] {
+			pts = append(pts, x)
+		}
+	}
+	return append(pts, b)
+}
+
+// endpointSingular checks whether f is not finite or grows rapidly @ x towards x+h
+func endpointSingular(f func(x float64) float64, x, h float64) bool {
+	fx := f(x)
+	if math.IsNaN(fx) || math.IsInf(fx, 0) {
+		return true
+	}
+	f1, f2 := f(x+1e-8*h), f(x+1e-4*h)
+	slope := math.Abs(f1-f2) / (1e-4 * math.Abs(h))
+	return slope > 1e6*(1.0+math.Abs(f2))
+}
+
+// recoverIntegrator converts panics of quadrature routines into errors
+func recoverIntegrator(err *error, method string) {
+	if e := recover(); e != nil {
+		*err = chk.Err("%s failed: %v", method, e)
+	}
+}
@@ -49,6 +49,53 @@ func Jacobian(J *la.Triplet, ffcn fun.Vv, x, fx, w []float64) {
 	return
 }
 
+// JacobianBanded computes the (sparse) Jacobian matrix of a function with banded derivative
+//
+//   The Jacobian must satisfy:  dfi/dxj = 0   if   i - j > ml   or   j - i > mu
+//
+//   Columns that are (ml+mu+1) apart do not share rows and are perturbed simultaneously [1].
+//   Thus, only ml+mu+1 evaluations of f are required, regardless of the number of equations, and
+//   only the entries within the band are stored into J
+//
+//  INPUT:
+//      ffcn   : f(x) function
+//      x      : station where dfdx has to be calculated
+//      fx     : f @ x
+//      w      : workspace with size == n == len(x)
+//      ml, mu : lower and upper bandwidths
+//  RETURNS:
+//      J : dfdx @ x [must be pre-allocated or with J.Max() == 0 ⇒ allocated here]
+//
+//  Reference:
+//    [1] Curtis AR, Powell MJD, Reid JK (1974) On the estimation of sparse Jacobian matrices,
+//        IMA Journal of Applied Mathematics, 13(1):117-119
+func JacobianBanded(J *la.Triplet, ffcn fun.Vv, x, fx, w []float64, ml, mu int) {
+	ndim := len(x)
+	if J.Max() == 0 {
+		J.Init(ndim, ndim, ndim*(ml+mu+1))
+	}
+	J.Start()
+	ngroups := utl.Imin(ml+mu+1, ndim)
+	xsafe := make([]float64, ndim)
+	delta := make([]float64, ndim)
+	copy(xsafe, x)
+	for g := 0; g < ngroups; g++ {
+		for col := g; col < ndim; col += ngroups {
+			delta[col] = math.Sqrt(MACHEPS * utl.Max(1e-5, math.Abs(xsafe[col])))
+			x[col] = xsafe[col] + delta[col]
+		}
+		ffcn(w, x) // w := f(x+δx[group])
+		for col := g; col < ndim; col += ngroups {
+			rmin, rmax := utl.Imax(0, col-mu), utl.Imin(ndim-1, col+ml)
+			for row := rmin; row <= rmax; row++ {
+				J.Put(row, col, (w[row]-fx[row])/delta[col])
+			}
+			x[col] = xsafe[col]
+		}
+	}
+	return
+}
+
 // CompareJac compares Jacobian matrix (e.g. for testing)
 func CompareJac(tst *testing.T, ffcn fun.Vv, Jfcn fun.Tv, x []float64, tol float64) {
 
@@ -0,0 +1,199 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// NKOpts holds options for the Newton-Krylov solver
+type NKOpts struct {
+	MaxIt    int     // maximum number of Newton iterations [default = 50]
+	Ftol     float64 // tolerance on the residual: ‖F(x)‖∞ < Ftol [default = 1e-9]
+	Eta      float64 // forcing term: the linear solution satisfies ‖F + J⋅δx‖ ≤ η⋅‖F‖ [default = 1e-4]
+	Restart  int     // dimension of the Krylov subspace before restarting GMRES [default = min(n,30)]
+	MaxLinIt int     // maximum number of GMRES iterations at each Newton iteration [default = 10⋅n]
+	MaxLsIt  int     // maximum number of backtracking steps in the line search [default = 20]
+	Verbose  bool    // show messages
+}
+
+// NewtonKrylov implements the Jacobian-free Newton-Krylov method to solve large nonlinear
+// systems of equations F(x) = 0
+//
+//   The Newton correction is computed by (restarted) GMRES (la.SolveGMRES) which only requires
+//   the product of the Jacobian matrix by a vector. This product is approximated by the directional derivative:
+//
+//              F(x + ε⋅v) - F(x)
+//     J ⋅ v ≈ ———————————————————
+//                      ε
+//
+//   The update x ← x + λ⋅δx is globalised by backtracking on ‖F‖.
+//
+//   Reference:
+//    [1] Knoll DA and Keyes DE (2004) Jacobian-free Newton-Krylov methods: a survey of
+//        approaches and applications. Journal of Computational Physics, 193:357-397
+type NewtonKrylov struct {
+
+	// input
+	n    int    // number of equations
+	Ffcn fun.Vv // F(x) function
+	opt  NKOpts // options
+
+	// stat data
+	It     int // number of Newton iterations from the last call to Solve
+	NFeval int // number of calls to Ffcn (function evaluations)
+	NLinIt int // total number of GMRES iterations
+
+	// workspace
+	fx   la.Vector     // F(x)
+	mf   la.Vector     // -F(x): right-hand side of the linear system
+	dx   la.Vector     // Newton correction δx
+	xp   la.Vector     // perturbed or trial x
+	fp   la.Vector     // F(xp)
+	nrm  float64       // ‖x‖ used for computing ε
+	kopt la.KrylovOpts // options for GMRES
+}
+
+// NewNewtonKrylov returns a new Newton-Krylov solver
+//  n    -- number of equations
+//  Ffcn -- F(x) function
+//  opt  -- options [may be nil ⇒ use default values]
+func NewNewtonKrylov(n int, Ffcn fun.Vv, opt *NKOpts) (o *NewtonKrylov, err error) {
+
+	// check
+	if n < 1 {
+		return nil, chk.Err("number of equations must be at least 1. n = %d is invalid", n)
+	}
+	if Ffcn == nil {
+		return nil, chk.Err("function F(x) must not be nil")
+	}
+
+	// options
+	o = new(NewtonKrylov)
+	o.n = n
+	o.Ffcn = Ffcn
+	if opt != nil {
+		o.opt = *opt
+	}
+	if o.opt.MaxIt < 1 {
+		o.opt.MaxIt = 50
+	}
+	if o.opt.Ftol <= 0 {
+		o.opt.Ftol = 1e-9
+	}
+	if o.opt.Eta <= 0 {
+		o.opt.Eta = 1e-4
+	}
+	if o.opt.Eta >= 1 {
+		return nil, chk.Err("forcing term must be smaller than 1. Eta = %g is invalid", o.opt.Eta)
+	}
+	if o.opt.Restart < 1 {
+		o.opt.Restart = 30
+	}
+	if o.opt.Restart > n {
+		o.opt.Restart = n
+	}
+	if o.opt.MaxLinIt < 1 {
+		o.opt.MaxLinIt = 10 * n
+	}
+	if o.opt.MaxLsIt < 1 {
+		o.opt.MaxLsIt = 20
+	}
+
+	o.kopt = la.KrylovOpts{Tol: o.opt.Eta, MaxIt: o.opt.MaxLinIt, Restart: o.opt.Restart}
+
+	// workspace
+	o.fx = la.NewVector(n)
+	o.mf = la.NewVector(n)
+	o.dx = la.NewVector(n)
+	o.xp = la.NewVector(n)
+	o.fp = la.NewVector(n)
+	return
+}
+
+// Solve solves F(x) = 0
+//  x -- initial values on input and solution on output
+func (o *NewtonKrylov) Solve(x la.Vector) (err error) {
+
+	// check
+	if len(x) != o.n {
+		return chk.Err("length of x must be equal to %d. %d is invalid", o.n, len(x))
+	}
+
+	// initial residual
+	o.It, o.NFeval, o.NLinIt = 0, 1, 0
+	o.Ffcn(o.fx, x)
+	fnrm := o.fx.Norm()
+
+	// iterations
+	for o.It = 0; o.It < o.opt.MaxIt; o.It++ {
+
+		// check convergence
+		fmax := o.fx.Largest(1)
+		if o.opt.Verbose {
+			io.Pf("%4d : ‖F‖∞ = %23.15e  nlinit = %d\n", o.It, fmax, o.NLinIt)
+		}
+		if fmax < o.opt.Ftol {
+			return
+		}
+
+		// solve J⋅δx = -F with GMRES until ‖F + J⋅δx‖ ≤ η⋅‖F‖, starting from δx = 0. NOTE: the
+		// (inexact) correction is used by the line search even if the tolerance is not achieved
+		o.nrm = x.Norm()
+		o.mf.Apply(-1, o.fx)
+		o.dx.Fill(0)
+		J := la.NewLinOpFunc(o.n, o.n, func(Jv, v la.Vector) { o.jacVec(Jv, x, v) })
+		nit, _ := la.SolveGMRES(J, o.dx, o.mf, &o.kopt)
+		o.NLinIt += nit
+
+		// backtracking line search on ‖F‖
+		λ := 1.0
+		ok := false
+		for k := 0; k < o.opt.MaxLsIt; k++ {
+			la.VecAdd(o.xp, 1, x, λ, o.dx) // xp := x + λ⋅δx
+			o.Ffcn(o.fp, o.xp)
+			o.NFeval++
+			fpnrm := o.fp.Norm()
+			if fpnrm <= (1.0-1e-4*λ)*fnrm {
+				ok = true
+				fnrm = fpnrm
+				break
+			}
+			λ /= 2.0
+		}
+		if !ok {
+			return chk.Err("line search failed after %d steps (Newton iteration %d)", o.opt.MaxLsIt, o.It)
+		}
+		copy(x, o.xp)
+		copy(o.fx, o.fp)
+	}
+
+	// check convergence
+	if o.fx.Largest(1) < o.opt.Ftol {
+		return
+	}
+	return chk.Err("Newton-Krylov did not converge after %d iterations. ‖F‖∞ = %g", o.opt.MaxIt, o.fx.Largest(1))
+}
+
+// jacVec computes J⋅v using the directional derivative of F @ x
+func (o *NewtonKrylov) jacVec(Jv, x, v la.Vector) {
+	vnrm := v.Norm()
+	if vnrm == 0 {
+		Jv.Fill(0)
+		return
+	}
+	ε := math.Sqrt(MACHEPS) * (1.0 + o.nrm) / vnrm
+	la.VecAdd(o.xp, 1, x, ε, v) // xp := x + ε⋅v
+	o.Ffcn(o.fp, o.xp)
+	o.NFeval++
+	for i := 0; i < o.n; i++ {
+		Jv[i] = (o.fp[i] - o.fx[i]) / ε
+	}
+}
@@ -12,6 +12,12 @@ import (
 	"github.com/cpmech/gosl/utl"
 )
 
+// positions and weights of the ten-point Gauss-Legendre rule (positive half)
+var (
+	gaussL10x = []float64{0.1488743389816312, 0.4333953941292472, 0.6794095682990244, 0.8650633666889845, 0.9739065285171717}
+	gaussL10w = []float64{0.2955242247147529, 0.2692667193099963, 0.2190863625159821, 0.1494513491505806, 0.0666713443086881}
+)
+
 // QuadGaussL10 approximates the integral of the function f(x) between a and b, by ten-point
 // Gauss-Legendre integration. The function is evaluated exactly ten times at interior points
 // in the range of integration. See page 180 of [1].
@@ -21,8 +27,7 @@ import (
 func QuadGaussL10(a, b float64, f fun.Ss) (res float64) {
 
 	// constants
-	x := []float64{0.1488743389816312, 0.4333953941292472, 0.6794095682990244, 0.8650633666889845, 0.9739065285171717}
-	w := []float64{0.2955242247147529, 0.2692667193099963, 0.2190863625159821, 0.1494513491505806, 0.0666713443086881}
+	x, w := gaussL10x, gaussL10w
 
 	// auxiliary variables
 	xm := 0.5 * (b + a)
@@ -0,0 +1,100 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// QuadVec performs the adaptive Gauss quadrature (see IntegratorGauss) with a vectorized integrand.
+// The integrand receives the nodes of all ten-point Gauss-Legendre panels of one level of
+// bisection at once and returns all values; thus batch evaluations (e.g. SIMD/BLAS) can be
+// exploited
+//
+//   INPUT:
+//     f   -- vectorized integrand: returns ys[k] = f(xs[k]) with len(ys) = len(xs)
+//     a   -- lower limit of integration
+//     b   -- upper limit of integration
+//     tol -- tolerance
+//
+//   OUTPUT:          b
+//             res = ∫  f(x) dx
+//                   a
+//
+//   NOTE: (1) the results are identical to the results of IntegratorGauss{}.Integrate with the
+//             corresponding scalar function
+//         (2) use VecIntegrand to convert a scalar function into a vectorized function
+//
+func QuadVec(f func(xs la.Vector) la.Vector, a, b, tol float64) (res float64, err error) {
+	defer recoverIntegrator(&err, "vectorized adaptive Gauss quadrature")
+	maxDepth := 50
+	nodes := []gaussNode{{a: a, b: b, tol: tol, depth: maxDepth, kids: -1}}
+	nodes[0].whole = quadGaussL10Vec(f, []float64{a, b})[0]
+	nodes[0].scale = math.Abs(nodes[0].whole)
+	ok := true
+	res = adaptGauss(nodes, 0, &ok, func(nodes []gaussNode, level []int) {
+		lims := make([]float64, 0, 4*len(level))
+		for _, i := range level {
+			m := (nodes[i].a + nodes[i].b) / 2.0
+			lims = append(lims, nodes[i].a, m, m, nodes[i].b)
+		}
+		vals := quadGaussL10Vec(f, lims)
+		for k, i := range level {
+			nodes[i].left, nodes[i].right = vals[2*k], vals[2*k+1]
+		}
+	})
+	if !ok {
+		return res, chk.Err("vectorized adaptive Gauss quadrature did not converge with tol=%g and MaxDepth=%d", tol, maxDepth)
+	}
+	return
+}
+
+// VecIntegrand converts a scalar integrand into a vectorized integrand for QuadVec; i.e. the
+// scalar function is called for each node
+func VecIntegrand(f func(x float64) float64) func(xs la.Vector) la.Vector {
+	return func(xs la.Vector) la.Vector {
+		return la.NewVectorMapped(len(xs), func(k int) float64 { return f(xs[k]) })
+	}
+}
+
+// quadGaussL10Vec computes the ten-point Gauss-Legendre estimates of npanels panels with a single
+// call to the vectorized integrand. The operations are the same as in QuadGaussL10
+//   lims -- [2⋅npanels] limits of panels: a0, b0, a1, b1, ...
+func quadGaussL10Vec(f func(xs la.Vector) la.Vector, lims []float64) (res []float64) {
+
+	// nodes
+	npanels := len(lims) / 2
+	xs := la.NewVector(10 * npanels)
+	for p := 0; p < npanels; p++ {
+		xm := 0.5 * (lims[2*p+1] + lims[2*p])
+		xr := 0.5 * (lims[2*p+1] - lims[2*p])
+		for j := 0; j < 5; j++ {
+			dx := xr * gaussL10x[j]
+			xs[10*p+2*j] = xm + dx
+			xs[10*p+2*j+1] = xm - dx
+		}
+	}
+
+	// values
+	ys := f(xs)
+	if len(ys) != len(xs) {
+		chk.Panic("vectorized integrand must return %d values. %d is invalid\n", len(xs), len(ys))
+	}
+
+	// sums
+	res = make([]float64, npanels)
+	for p := 0; p < npanels; p++ {
+		xr := 0.5 * (lims[2*p+1] - lims[2*p])
+		s := 0.0
+		for j := 0; j < 5; j++ {
+			s += gaussL10w[j] * (ys[10*p+2*j] + ys[10*p+2*j+1])
+		}
+		res[p] = s * xr
+	}
+	return
+}
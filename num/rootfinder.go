@@ -0,0 +1,338 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/utl"
+)
+
+// RootFinder defines the interface of scalar root finders solving f(x) = 0 for x in [a, b]
+//
+//  NOTE: (1) the bracketing methods (Brent, bisection, ITP) require f(a) * f(b) < 0
+//        (2) the open methods (Newton, Halley, secant) use [a, b] to find the initial guess and
+//            as a safeguard if the root is bracketed
+//        (3) tol is the absolute tolerance on x
+type RootFinder interface {
+	FindRoot(f func(x float64) float64, a, b, tol float64) (res float64, err error)
+}
+
+// factory
+var rootfinderallocators = make(map[string]func() RootFinder)
+
+// RegisterRootFinder adds a root finder to the factory such that it can be selected by name
+func RegisterRootFinder(name string, allocator func() RootFinder) {
+	rootfinderallocators[name] = allocator
+}
+
+// GetRootFinder returns a new root finder from the factory
+//  name -- "brent", "bisection", "itp", "newton", "halley", "secant" or a registered name
+func GetRootFinder(name string) (o RootFinder, err error) {
+	allocator, ok := rootfinderallocators[name]
+	if !ok {
+		return nil, chk.Err("cannot find %q root finder. available: %v", name, RootFinderNames())
+	}
+	return allocator(), nil
+}
+
+// RootFinderNames returns the (sorted) names of the root finders in the factory
+func RootFinderNames() (names []string) {
+	for name := range rootfinderallocators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+// add root finders to factory
+func init() {
+	RegisterRootFinder("brent", func() RootFinder { return NewBrent(nil, nil) })
+	RegisterRootFinder("bisection", func() RootFinder { return &RootBisection{MaxIt: 200} })
+	RegisterRootFinder("itp", func() RootFinder { return &RootITP{MaxIt: 200} })
+	RegisterRootFinder("newton", func() RootFinder { return &RootNewton{MaxIt: 100} })
+	RegisterRootFinder("halley", func() RootFinder { return &RootHalley{MaxIt: 100} })
+	RegisterRootFinder("secant", func() RootFinder { return &RootSecant{MaxIt: 100} })
+}
+
+// RootBisection implements the bisection method
+type RootBisection struct {
+	MaxIt   int // max iterations
+	NumIter int // number of iterations from last call to FindRoot
+}
+
+// RootITP implements the ITP (interpolate, truncate and project) method which attains the
+// superlinear convergence of the regula falsi while keeping the worst case of the bisection
+//
+//  Reference:
+//    [1] Oliveira IFD and Takahashi RHC (2020) An enhancement of the bisection method average
+//        performance preserving minmax optimality. ACM Transactions on Mathematical Software,
+//        47(1):5
+type RootITP struct {
+	MaxIt   int     // max iterations
+	K1      float64 // κ1 truncation constant [default = 0.2/(b-a)]
+	K2      float64 // κ2 truncation exponent [default = 2]
+	N0      int     // slack of iterations with respect to the bisection [default = 1 if N0 ≤ 0]
+	NumIter int     // number of iterations from last call to FindRoot
+}
+
+// RootNewton implements Newton's method safeguarded by bisection
+type RootNewton struct {
+	MaxIt   int                     // max iterations
+	Dfcn    func(x float64) float64 // df/dx [optional; computed numerically if nil]
+	NumIter int                     // number of iterations from last call to FindRoot
+}
+
+// RootHalley implements Halley's (third order) method safeguarded by bisection
+type RootHalley struct {
+	MaxIt   int                     // max iterations
+	Dfcn    func(x float64) float64 // df/dx [optional; computed numerically if nil]
+	D2fcn   func(x float64) float64 // d²f/dx² [optional; computed numerically if nil]
+	NumIter int                     // number of iterations from last call to FindRoot
+}
+
+// RootSecant implements the secant method starting with x0 = a and x1 = b
+type RootSecant struct {
+	MaxIt   int // max iterations
+	NumIter int // number of iterations from last call to FindRoot
+}
+
+// FindRoot solves f(x) = 0 for x in [a, b]
+func (o *RootBisection) FindRoot(f func(x float64) float64, a, b, tol float64) (res float64, err error) {
+	fa, fb, err := checkRootBracket(f, a, b)
+	if err != nil {
+		return
+	}
+	if fa == 0 {
+		return a, nil
+	}
+	if fb == 0 {
+		return b, nil
+	}
+	for o.NumIter = 0; o.NumIter < o.MaxIt; o.NumIter++ {
+		m := (a + b) / 2.0
+		if math.Abs(b-a) <= 2.0*tol || m == a || m == b {
+			return m, nil
+		}
+		fm := f(m)
+		if fm == 0 {
+			return m, nil
+		}
+		if math.Signbit(fm) == math.Signbit(fa) {
+			a, fa = m, fm
+		} else {
+			b = m
+		}
+	}
+	return (a + b) / 2.0, chk.Err("bisection did not converge after %d iterations", o.NumIter)
+}
+
+// FindRoot solves f(x) = 0 for x in [a, b]
+func (o *RootITP) FindRoot(f func(x float64) float64, a, b, tol float64) (res float64, err error) {
+
+	// check
+	if !(tol > 0) {
+		return 0, chk.Err("ITP requires tol > 0. tol=%g is invalid", tol)
+	}
+	fa, fb, err := checkRootBracket(f, a, b)
+	if err != nil {
+		return
+	}
+	if fa == 0 {
+		return a, nil
+	}
+	if fb == 0 {
+		return b, nil
+	}
+	if a > b {
+		a, b, fa, fb = b, a, fb, fa
+	}
+	σf := 1.0 // make f(a) < 0 < f(b)
+	if fa > 0 {
+		σf, fa, fb = -1, -fa, -fb
+	}
+
+	// constants
+	κ1, κ2, n0 := o.K1, o.K2, o.N0
+	if κ1 <= 0 {
+		κ1 = 0.2 / (b - a)
+	}
+	if κ2 < 1 {
+		κ2 = 2
+	}
+	if n0 <= 0 {
+		n0 = 1
+	}
+	ε := tol
+	nhalf := int(math.Ceil(math.Log2((b - a) / (2.0 * ε))))
+	nmax := utl.Imax(nhalf, 0) + n0
+
+	// iterations
+	for o.NumIter = 0; o.NumIter < o.MaxIt; o.NumIter++ {
+		if b-a <= 2.0*ε {
+			return (a + b) / 2.0, nil
+		}
+
+		// interpolation
+		xhalf := (a + b) / 2.0
+		r := ε*math.Pow(2, float64(nmax-o.NumIter)) - (b-a)/2.0
+		δ := κ1 * math.Pow(b-a, κ2)
+		xf := (fb*a - fa*b) / (fb - fa)
+
+		// truncation
+		σ := 1.0
+		if xhalf-xf < 0 {
+			σ = -1.0
+		}
+		xt := xhalf
+		if δ <= math.Abs(xhalf-xf) {
+			xt = xf + σ*δ
+		}
+
+		// projection
+		x := xhalf - σ*r
+		if math.Abs(xt-xhalf) <= r {
+			x = xt
+		}
+
+		// update interval
+		fx := σf * f(x)
+		switch {
+		case fx > 0:
+			b, fb = x, fx
+		case fx < 0:
+			a, fa = x, fx
+		default:
+			return x, nil
+		}
+	}
+	return (a + b) / 2.0, chk.Err("ITP method did not converge after %d iterations", o.NumIter)
+}
+
+// FindRoot solves f(x) = 0 for x in [a, b]
+func (o *RootNewton) FindRoot(f func(x float64) float64, a, b, tol float64) (res float64, err error) {
+	step := func(x, fx float64) float64 {
+		return -fx / evalDeriv(o.Dfcn, f, x, b-a)
+	}
+	return safeguardedNewton(f, a, b, tol, o.MaxIt, &o.NumIter, step, "Newton's method")
+}
+
+// FindRoot solves f(x) = 0 for x in [a, b]
+func (o *RootHalley) FindRoot(f func(x float64) float64, a, b, tol float64) (res float64, err error) {
+	step := func(x, fx float64) float64 {
+		d1 := evalDeriv(o.Dfcn, f, x, b-a)
+		var d2 float64
+		if o.D2fcn != nil {
+			d2 = o.D2fcn(x)
+		} else {
+			d2 = SecondDerivCen5(x, 1e-3*math.Max(math.Abs(b-a), 1e-8), f)
+		}
+		return -2.0 * fx * d1 / (2.0*d1*d1 - fx*d2)
+	}
+	return safeguardedNewton(f, a, b, tol, o.MaxIt, &o.NumIter, step, "Halley's method")
+}
+
+// FindRoot solves f(x) = 0 with the initial values x0 = a and x1 = b
+func (o *RootSecant) FindRoot(f func(x float64) float64, a, b, tol float64) (res float64, err error) {
+	x0, x1 := a, b
+	f0, f1 := f(x0), f(x1)
+	for o.NumIter = 0; o.NumIter < o.MaxIt; o.NumIter++ {
+		if f1 == 0 {
+			return x1, nil
+		}
+		if f1 == f0 {
+			return x1, chk.Err("secant method failed: f(x0) == f(x1) = %g with x0=%g and x1=%g", f1, x0, x1)
+		}
+		x2 := x1 - f1*(x1-x0)/(f1-f0)
+		if math.IsNaN(x2) || math.IsInf(x2, 0) {
+			return x1, chk.Err("secant method diverged")
+		}
+		x0, f0 = x1, f1
+		x1, f1 = x2, f(x2)
+		if math.Abs(x1-x0) <= tol {
+			return x1, nil
+		}
+	}
+	return x1, chk.Err("secant method did not converge after %d iterations", o.NumIter)
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// checkRootBracket checks that the root is bracketed by [a, b]
+func checkRootBracket(f func(x float64) float64, a, b float64) (fa, fb float64, err error) {
+	fa, fb = f(a), f(b)
+	if math.Signbit(fa) == math.Signbit(fb) && fa != 0 && fb != 0 {
+		err = chk.Err("root must be bracketed: a=%g, b=%g, f(a)=%g, f(b)=%g => f(a) * f(b) > 0", a, b, fa, fb)
+	}
+	return
+}
+
+// evalDeriv computes df/dx using dfcn or numerically if dfcn == nil
+func evalDeriv(dfcn, f func(x float64) float64, x, length float64) float64 {
+	if dfcn != nil {
+		return dfcn(x)
+	}
+	return DerivCen5(x, 1e-3*math.Max(math.Abs(length), 1e-8), f)
+}
+
+// safeguardedNewton runs Newton-like iterations x ← x + step(x, f(x)) starting at the midpoint of
+// [a, b]. If the root is bracketed, bisection is used whenever the step falls outside the bracket
+func safeguardedNewton(f func(x float64) float64, a, b, tol float64, maxIt int, numIter *int, step func(x, fx float64) float64, method string) (res float64, err error) {
+
+	// bracket
+	fa, fb := f(a), f(b)
+	if fa == 0 {
+		return a, nil
+	}
+	if fb == 0 {
+		return b, nil
+	}
+	bracketed := math.Signbit(fa) != math.Signbit(fb)
+	lo, hi, flo := math.Min(a, b), math.Max(a, b), fa
+	if a > b {
+		flo = fb
+	}
+
+	// iterations
+	x := (a + b) / 2.0
+	for *numIter = 0; *numIter < maxIt; *numIter++ {
+		fx := f(x)
+		if fx == 0 {
+			return x, nil
+		}
+		if bracketed {
+			if math.Signbit(fx) == math.Signbit(flo) {
+				lo, flo = x, fx
+			} else {
+				hi = x
+			}
+		}
+		dx := step(x, fx)
+		if math.Abs(dx) <= tol {
+			return x + dx, nil
+		}
+		xnew := x + dx
+		if bracketed && (math.IsNaN(xnew) || xnew <= lo || xnew >= hi) {
+			xnew = (lo + hi) / 2.0 // bisection
+		}
+		if math.IsNaN(xnew) || math.IsInf(xnew, 0) {
+			return x, chk.Err("%s diverged", method)
+		}
+		dx, x = xnew-x, xnew
+		if math.Abs(dx) <= tol || (bracketed && hi-lo <= tol) {
+			return x, nil
+		}
+	}
+	return x, chk.Err("%s did not converge after %d iterations", method, *numIter)
+}
+
+// recoverRootFinder converts panics of root finders into errors
+func recoverRootFinder(err *error, method string) {
+	if e := recover(); e != nil {
+		*err = chk.Err("%s failed: %v", method, e)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// benchSlowIntegrand simulates an expensive integrand (e.g. running a simulation)
+func benchSlowIntegrand(x float64) float64 {
+	time.Sleep(20 * time.Microsecond)
+	return math.Sin(1.0 / (x + 0.05))
+}
+
+func BenchmarkIntegratorGaussSerial(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		IntegratorGauss{}.Integrate(benchSlowIntegrand, 0, 1, 1e-10)
+	}
+}
+
+func BenchmarkIntegratorGaussParallel(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		IntegratorGauss{Parallel: true, NumWorkers: 8}.Integrate(benchSlowIntegrand, 0, 1, 1e-10)
+	}
+}
@@ -140,3 +140,36 @@ func TestBracket02(tst *testing.T) {
 		plt.Save("/tmp/gosl/num", "bracket02")
 	}
 }
+
+func TestBracket03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Bracket03. BracketMin from poor starting pairs")
+
+	// unimodal function with minimum @ x = 50
+	f := func(x float64) float64 { return math.Cosh((x-50)/10) + 3 }
+	for _, ab := range [][]float64{{0, 0.1}, {0.1, 0}, {100, 99.9}, {-1000, -999}, {49, 51}} {
+		xa, xb, xc, err := BracketMin(f, ab[0], ab[1])
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		io.Pforan("a=%g b=%g ⇒ xa=%g xb=%g xc=%g\n", ab[0], ab[1], xa, xb, xc)
+		checkBracket(tst, xa, xb, xc, f(xa), f(xb), f(xc))
+		if xa > 50 || xc < 50 {
+			tst.Errorf("minimum @ x=50 should be bracketed by [%g, %g]\n", xa, xc)
+			return
+		}
+	}
+
+	// errors
+	_, _, _, err := BracketMin(f, 1, 1)
+	if err == nil {
+		tst.Errorf("BracketMin should have failed with a == b\n")
+	}
+	_, _, _, err = BracketMin(func(x float64) float64 { return -x }, 0, 1)
+	io.Pforan("err = %v\n", err)
+	if err == nil {
+		tst.Errorf("BracketMin should have failed with unbounded function\n")
+	}
+}
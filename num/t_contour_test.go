@@ -0,0 +1,74 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_contour01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("contour01. integrals around the unit circle")
+
+	// unit circle
+	circle := func(t float64) complex128 { return cmplx.Exp(complex(0, t)) }
+	dcircle := func(t float64) complex128 { return complex(0, 1) * cmplx.Exp(complex(0, t)) }
+
+	tests := []struct {
+		name string
+		f    func(z complex128) complex128
+		ana  complex128
+	}{
+		{"1/z", func(z complex128) complex128 { return 1 / z }, complex(0, 2*math.Pi)},
+		{"z²", func(z complex128) complex128 { return z * z }, 0},
+		{"exp(z)/z²", func(z complex128) complex128 { return cmplx.Exp(z) / (z * z) }, complex(0, 2*math.Pi)}, // residue = 1
+		{"1/(z-2)", func(z complex128) complex128 { return 1 / (z - 2) }, 0},                                  // pole outside
+	}
+	for _, t := range tests {
+		nfeval := 0
+		f := func(z complex128) complex128 {
+			nfeval++
+			return t.f(z)
+		}
+		res, err := ContourIntegral(f, circle, dcircle, 0, 2*math.Pi, 1e-12)
+		if err != nil {
+			tst.Errorf("%s: %v\n", t.name, err)
+			return
+		}
+		io.Pforan("%10s: res = %v  nfeval = %d\n", t.name, res, nfeval)
+		chk.Complex128(tst, t.name, 1e-12, res, t.ana)
+	}
+}
+
+func Test_contour02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("contour02. straight segment and errors")
+
+	// ∫ z dz from 0 to 1+i = (1+i)²/2 = i
+	end := complex(1, 1)
+	segment := func(t float64) complex128 { return complex(t, 0) * end }
+	dsegment := func(t float64) complex128 { return end }
+	res, err := ContourIntegral(func(z complex128) complex128 { return z }, segment, dsegment, 0, 1, 1e-12)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Complex128(tst, "∫ z dz", 1e-15, res, complex(0, 1))
+
+	// contour through singularity
+	_, err = ContourIntegral(func(z complex128) complex128 { return 1 / cmplx.Sqrt(z) }, segment, dsegment, 0, 1, 1e-12)
+	if err == nil {
+		tst.Errorf("contour through singularity should cause an error\n")
+	} else {
+		io.Pf("error: %v\n", err)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestGoldenSection01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GoldenSection01. smooth function: compare with Brent")
+
+	// function (see Brent03)
+	ffcn := func(x float64) float64 { return x*x*x - 2.0*x - 5.0 }
+	xcor := math.Sqrt(2.0 / 3.0)
+
+	// golden section
+	xa, xb, xc, err := BracketMin(ffcn, 0, 0.1)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	xmin, fmin := GoldenSection(ffcn, xa, xb, xc, 1e-10)
+	io.Pforan("golden: x = %v  f = %v\n", xmin, fmin)
+
+	// Brent
+	o := NewBrent(ffcn, nil)
+	xbre := o.Min(-1.4, 1.4)
+	io.Pforan("brent:  x = %v  f = %v\n", xbre, ffcn(xbre))
+	chk.Float64(tst, "x(golden) = x(brent)", 1e-7, xmin, xbre)
+	chk.Float64(tst, "x", 1e-7, xmin, xcor)
+	chk.Float64(tst, "f", 1e-15, fmin, ffcn(xcor))
+
+	// reversed bracket and default tolerance
+	xmin, _ = GoldenSection(ffcn, xc, xb, xa, 0)
+	chk.Float64(tst, "x(reversed)", 1e-7, xmin, xcor)
+}
+
+func TestGoldenSection02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GoldenSection02. non-smooth unimodal function")
+
+	// f has a kink @ the minimum
+	neval := 0
+	ffcn := func(x float64) float64 {
+		neval++
+		if x < 0.7 {
+			return 3 * (0.7 - x)
+		}
+		return math.Sqrt(x - 0.7)
+	}
+	xmin, fmin := GoldenSection(ffcn, -2, 0.5, 3, 1e-12)
+	io.Pforan("x = %v  f = %v  neval = %v\n", xmin, fmin, neval)
+	chk.Float64(tst, "x", 1e-11, xmin, 0.7)
+	if fmin > 1e-5 {
+		tst.Errorf("fmin=%g should be close to zero\n", fmin)
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	GoldenSection(ffcn, 1, 2, 3, 1e-12) // not a bracket: f(b) > f(a)
+}
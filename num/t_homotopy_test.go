@@ -0,0 +1,61 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestHomotopy01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Homotopy01. Newton homotopy with arctan functions")
+
+	// target: G(x) = 0 with solution x = [1, -1]
+	G := func(x la.Vector) la.Vector {
+		return la.NewVectorSlice([]float64{
+			math.Atan(x[0]-1) + 0.1*(x[1]+1),
+			math.Atan(x[1]+1) - 0.1*(x[0]-1),
+		})
+	}
+	x0 := la.NewVectorSlice([]float64{5, -6})
+
+	// direct Newton's method diverges
+	var nls NlSolver
+	nls.Init(2, func(fx, x la.Vector) { copy(fx, G(x)) }, nil, nil, false, true, map[string]float64{"chkConv": 1})
+	defer nls.Free()
+	err := homotopyStep(&nls, x0.GetCopy())
+	io.Pforan("direct Newton: %v\n", err)
+	if err == nil {
+		tst.Errorf("direct Newton's method should fail\n")
+		return
+	}
+
+	// continuation
+	g0 := G(x0)
+	F := func(x la.Vector, λ float64) la.Vector {
+		g := G(x)
+		la.VecAdd(g, 1, g, λ-1, g0) // g := G(x) - (1-λ)⋅G(x0)
+		return g
+	}
+	x, err := Homotopy(F, x0, 10)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("x = %v\n", x)
+	chk.Array(tst, "x", 1e-10, x, []float64{1, -1})
+	chk.Array(tst, "x0 (unchanged)", 1e-17, x0, []float64{5, -6})
+
+	// errors
+	if _, err = Homotopy(F, x0, 0); err == nil {
+		tst.Errorf("steps = 0 should cause an error\n")
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_integrator01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("integrator01. same integral through the Integrator interface")
+
+	f := func(x float64) float64 { return math.Sqrt(1.0 + math.Pow(math.Sin(x), 3.0)) }
+	Acor := 1.08268158558
+
+	integrators := map[string]Integrator{
+		"trapz":    IntegratorTrapz{},
+		"simpson":  IntegratorSimpson{},
+		"gauss":    IntegratorGauss{},
+		"tanhsinh": IntegratorTanhSinh{},
+	}
+	for _, name := range []string{"trapz", "simpson", "gauss", "tanhsinh"} {
+		A, err := integrators[name].Integrate(f, 0, 1, 1e-11)
+		if err != nil {
+			tst.Errorf("%s: %v\n", name, err)
+			return
+		}
+		io.Pforan("%8s: A = %v\n", name, A)
+		chk.Float64(tst, name, 1e-10, A, Acor)
+	}
+
+	// reversed limits
+	A, err := IntegratorGauss{}.Integrate(math.Sin, math.Pi, 0, 1e-12)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "gauss: reversed", 1e-14, A, -2)
+	A, err = IntegratorTanhSinh{}.Integrate(math.Sin, math.Pi, 0, 1e-12)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "tanhsinh: reversed", 1e-14, A, -2)
+
+	// errors
+	if _, err = (IntegratorGauss{MaxDepth: 2}).Integrate(func(x float64) float64 { return 1 / math.Sqrt(x) }, 0, 1, 1e-12); err == nil {
+		tst.Errorf("gauss with small MaxDepth should fail for singular integrand\n")
+	}
+	if _, err = (IntegratorTrapz{}).Integrate(func(x float64) float64 { return math.Sin(1 / (x + 1e-3)) }, 0, 1, 1e-15); err == nil {
+		tst.Errorf("trapz should fail on highly oscillatory integrand with tight tolerance\n")
+	} else {
+		io.Pf("error: %v\n", err)
+	}
+}
+
+func Test_integrator02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("integrator02. AutoIntegrate")
+
+	tests := []struct {
+		name string
+		f    func(x float64) float64
+		a, b float64
+		ana  float64
+		tol  float64
+	}{
+		{"sin", math.Sin, 0, math.Pi, 2, 1e-14},
+		{"1/√x", func(x float64) float64 { return 1 / math.Sqrt(x) }, 0, 1, 2, 1e-11},
+		{"log(x)", math.Log, 0, 1, -1, 1e-14},
+		{"exp(-x²)", func(x float64) float64 { return math.Exp(-x * x) }, -2, 2, math.Sqrt(math.Pi) * math.Erf(2), 1e-14},
+
+		// NOTE: the accuracy is limited by round-off because 1-x² loses precision near x=±1
+		{"1/√(1-x²)", func(x float64) float64 { return 1 / math.Sqrt(1-x*x) }, -1, 1, math.Pi, 1e-7},
+	}
+	for _, t := range tests {
+		A, err := AutoIntegrate(t.f, t.a, t.b, t.tol)
+		if err != nil {
+			tst.Errorf("%s: %v\n", t.name, err)
+			return
+		}
+		io.Pforan("%10s: A = %23.15e  error = %.2e\n", t.name, A, math.Abs(A-t.ana))
+		chk.Float64(tst, t.name, t.tol, A, t.ana)
+	}
+
+	// errors
+	if _, err := AutoIntegrate(math.Sin, 0, math.Inf(1), 1e-8); err == nil {
+		tst.Errorf("infinite limits should cause an error\n")
+	}
+}
+
+func Test_integrator03Quadpack(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("integrator03Quadpack. QUADPACK through the Integrator interface")
+
+	var integ Integrator = IntegratorQuadpack{}
+	A, err := integ.Integrate(func(x float64) float64 { return math.Log(x) / math.Sqrt(x) }, 0, 1, 1e-10)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("A = %v\n", A)
+	chk.Float64(tst, "A", 1e-10, A, -4)
+}
+
+func Test_integrator04Parallel(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("integrator04Parallel. adaptive Gauss quadrature: serial and parallel")
+
+	tests := []struct {
+		name string
+		f    func(x float64) float64
+		a, b float64
+		tol  float64
+	}{
+		{"sin", math.Sin, 0, math.Pi, 1e-14},
+		{"√(1+sin³x)", func(x float64) float64 { return math.Sqrt(1.0 + math.Pow(math.Sin(x), 3.0)) }, 0, 1, 1e-12},
+		{"sin(1/x)", func(x float64) float64 { return math.Sin(1.0 / x) }, 0.01, 1, 1e-10},
+		{"|x-1/3|", func(x float64) float64 { return math.Abs(x - 1.0/3.0) }, 0, 1, 1e-13},
+		{"1/√x", func(x float64) float64 { return 1 / math.Sqrt(x) }, 0, 1, 1e-12}, // fails with MaxDepth=50
+	}
+	for _, t := range tests {
+		Aser, errSer := IntegratorGauss{}.Integrate(t.f, t.a, t.b, t.tol)
+		io.Pforan("%12s: A = %23.15e  err = %v\n", t.name, Aser, errSer)
+		for _, nworkers := range []int{0, 1, 2, 3, 8} {
+			Apar, errPar := IntegratorGauss{Parallel: true, NumWorkers: nworkers}.Integrate(t.f, t.a, t.b, t.tol)
+			if Apar != Aser {
+				tst.Errorf("%s: parallel result with %d workers is not identical to serial result: %v != %v\n", t.name, nworkers, Apar, Aser)
+			}
+			if (errPar == nil) != (errSer == nil) {
+				tst.Errorf("%s: parallel error with %d workers is not consistent with serial error: %v != %v\n", t.name, nworkers, errPar, errSer)
+			}
+		}
+	}
+}
+
+func Test_integrator05BreakPoints(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("integrator05BreakPoints. adaptive Gauss quadrature with break points")
+
+	// integrand with kink at x = 0.3
+	g := func(x float64) float64 { return math.Abs(x-0.3) * math.Exp(x) }
+	nfeval := 0
+	f := func(x float64) float64 {
+		nfeval++
+		return g(x)
+	}
+	ana := 2.0*math.Exp(0.3) - 1.3 - 0.3*math.E // ∫ |x-0.3|⋅exp(x) dx from 0 to 1
+
+	// blind refinement
+	A, err := IntegratorGauss{}.Integrate(f, 0, 1, 1e-13)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	nblind := nfeval
+	io.Pforan("without break point: A = %23.15e  error = %.2e  nfeval = %d\n", A, math.Abs(A-ana), nblind)
+	chk.Float64(tst, "A (blind)", 1e-13, A, ana)
+
+	// with break point
+	nfeval = 0
+	opts := QuadAdaptiveOpts{BreakPoints: []float64{0.3}}
+	A, err = IntegratorGauss{QuadAdaptiveOpts: opts}.Integrate(f, 0, 1, 1e-13)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("with break point:    A = %23.15e  error = %.2e  nfeval = %d\n", A, math.Abs(A-ana), nfeval)
+	chk.Float64(tst, "A (break point)", 1e-15, A, ana)
+	if nfeval*10 > nblind {
+		tst.Errorf("break point should reduce the number of evaluations significantly: %d vs %d\n", nfeval, nblind)
+	}
+
+	// reversed limits, repeated points and points outside the interval
+	opts.BreakPoints = []float64{2, 0.3, -1, 0.3, 1}
+	A, err = IntegratorGauss{QuadAdaptiveOpts: opts}.Integrate(f, 1, 0, 1e-13)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "A (reversed)", 1e-15, A, -ana)
+	chk.Array(tst, "segments", 1e-17, gaussSegments(1, 0, opts.BreakPoints), []float64{1, 0.3, 0})
+
+	// parallel (g is safe for concurrent use)
+	Apar, err := IntegratorGauss{QuadAdaptiveOpts: opts, Parallel: true}.Integrate(g, 1, 0, 1e-13)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	if Apar != A {
+		tst.Errorf("parallel result must be identical to serial result: %v != %v\n", Apar, A)
+	}
+
+	// max number of subdivisions
+	if _, err = (IntegratorGauss{QuadAdaptiveOpts: QuadAdaptiveOpts{MaxSubdiv: 10}}).Integrate(f, 0, 1, 1e-13); err == nil {
+		tst.Errorf("blind refinement with MaxSubdiv=10 should fail\n")
+	} else {
+		io.Pf("error: %v\n", err)
+	}
+	opts.MaxSubdiv = 10
+	if _, err = (IntegratorGauss{QuadAdaptiveOpts: opts}).Integrate(f, 0, 1, 1e-13); err != nil {
+		tst.Errorf("refinement with break point and MaxSubdiv=10 should succeed: %v\n", err)
+	}
+
+	// invalid break point
+	opts.BreakPoints = []float64{math.NaN()}
+	if _, err = (IntegratorGauss{QuadAdaptiveOpts: opts}).Integrate(f, 0, 1, 1e-13); err == nil {
+		tst.Errorf("NaN break point should cause an error\n")
+	}
+}
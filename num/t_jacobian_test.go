@@ -108,3 +108,45 @@ func TestJacobian03(tst *testing.T) {
 	x := []float64{0.5, 0.5}
 	CompareJacDense(tst, ffcn, Jfcn, x, 1e-7)
 }
+
+func TestJacobian04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("TestJacobian 04 (banded)")
+
+	// f with ml = 2 and mu = 1
+	n := 9
+	nfeval := 0
+	ffcn := func(fx, x la.Vector) {
+		nfeval++
+		for i := 0; i < n; i++ {
+			fx[i] = 2.0 * x[i] * x[i]
+			if i > 1 {
+				fx[i] += math.Sin(x[i-2])
+			}
+			if i < n-1 {
+				fx[i] -= x[i] * x[i+1]
+			}
+		}
+	}
+	x := la.NewVector(n)
+	for i := 0; i < n; i++ {
+		x[i] = 0.1 * float64(i+1)
+	}
+	x0 := x.GetCopy()
+	fx := la.NewVector(n)
+	w := la.NewVector(n)
+	ffcn(fx, x)
+
+	// full and banded Jacobians
+	var Jfull, Jband la.Triplet
+	nfeval = 0
+	Jacobian(&Jfull, ffcn, x, fx, w)
+	chk.Int(tst, "nfeval(full)", nfeval, n)
+	nfeval = 0
+	JacobianBanded(&Jband, ffcn, x, fx, w, 2, 1)
+	chk.Int(tst, "nfeval(banded)", nfeval, 4)
+	chk.Int(tst, "max(banded)", Jband.Max(), n*4)
+	chk.Deep2(tst, "J", 1e-17, Jband.ToDense().GetDeep2(), Jfull.ToDense().GetDeep2())
+	chk.Array(tst, "x (unchanged)", 1e-17, x, x0)
+}
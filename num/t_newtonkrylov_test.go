@@ -0,0 +1,107 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestNewtonKrylov01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("NewtonKrylov01. small system")
+
+	// F(x) = [x0² + x1² - 4, x0 - x1]  ⇒  x = [√2, √2]
+	ffcn := func(fx, x la.Vector) {
+		fx[0] = x[0]*x[0] + x[1]*x[1] - 4.0
+		fx[1] = x[0] - x[1]
+	}
+	nk, err := NewNewtonKrylov(2, ffcn, &NKOpts{Verbose: chk.Verbose})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	x := la.NewVectorSlice([]float64{1, 2})
+	err = nk.Solve(x)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("It = %d  NFeval = %d  NLinIt = %d\n", nk.It, nk.NFeval, nk.NLinIt)
+	chk.Array(tst, "x", 1e-10, x, []float64{math.Sqrt2, math.Sqrt2})
+
+	// errors
+	if _, err = NewNewtonKrylov(0, ffcn, nil); err == nil {
+		tst.Errorf("n = 0 should cause an error\n")
+	}
+	if _, err = NewNewtonKrylov(2, nil, nil); err == nil {
+		tst.Errorf("nil function should cause an error\n")
+	}
+	if err = nk.Solve(la.NewVector(3)); err == nil {
+		tst.Errorf("wrong size of x should cause an error\n")
+	}
+}
+
+func TestNewtonKrylov02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("NewtonKrylov02. nonlinear Poisson equation")
+
+	// problem: -∇²u + u³ = f in [0,1]² with u = 0 on the boundary
+	// exact solution: u = sin(πx)⋅sin(πy)  ⇒  f = 2π²⋅u + u³
+	m := 31 // number of interior nodes along each direction
+	h := 1.0 / float64(m+1)
+	n := m * m
+	uana := la.NewVector(n)
+	fsrc := la.NewVector(n)
+	for j := 0; j < m; j++ {
+		for i := 0; i < m; i++ {
+			u := math.Sin(math.Pi*float64(i+1)*h) * math.Sin(math.Pi*float64(j+1)*h)
+			uana[i+j*m] = u
+			fsrc[i+j*m] = 2.0*math.Pi*math.Pi*u + u*u*u
+		}
+	}
+	get := func(u la.Vector, i, j int) float64 {
+		if i < 0 || j < 0 || i >= m || j >= m {
+			return 0
+		}
+		return u[i+j*m]
+	}
+	ffcn := func(fx, u la.Vector) {
+		for j := 0; j < m; j++ {
+			for i := 0; i < m; i++ {
+				I := i + j*m
+				lap := (get(u, i-1, j) + get(u, i+1, j) + get(u, i, j-1) + get(u, i, j+1) - 4.0*u[I]) / (h * h)
+				fx[I] = -lap + u[I]*u[I]*u[I] - fsrc[I]
+			}
+		}
+	}
+
+	// solve
+	nk, err := NewNewtonKrylov(n, ffcn, &NKOpts{Ftol: 1e-8, Restart: 50, Verbose: chk.Verbose})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	u := la.NewVector(n)
+	err = nk.Solve(u)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	emax := la.VecMaxDiff(u, uana)
+	io.Pforan("n = %d  It = %d  NFeval = %d  NLinIt = %d  max(error) = %g\n", n, nk.It, nk.NFeval, nk.NLinIt, emax)
+	if nk.It > 10 {
+		tst.Errorf("Newton-Krylov should converge in a few iterations: It = %d\n", nk.It)
+	}
+	if emax > 1e-3 {
+		tst.Errorf("error is too large: %g\n", emax)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func Test_quadVec01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("quadVec01. vectorized integrand versus scalar adaptive Gauss quadrature")
+
+	// vectorized integrand: f(x) = exp(-x²)⋅cos(5x)
+	ncalls, nvals := 0, 0
+	fvec := func(xs la.Vector) la.Vector {
+		ncalls++
+		nvals += len(xs)
+		ys := la.NewVector(len(xs))
+		for k, x := range xs {
+			ys[k] = math.Exp(-x*x) * math.Cos(5*x)
+		}
+		return ys
+	}
+	fsca := func(x float64) float64 { return math.Exp(-x*x) * math.Cos(5*x) }
+	ana := math.Sqrt(math.Pi) * math.Exp(-25.0/4.0) // ∫ from -∞ to ∞
+
+	// compare
+	Asca, err := IntegratorGauss{}.Integrate(fsca, -10, 10, 1e-13)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	Avec, err := QuadVec(fvec, -10, 10, 1e-13)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("scalar:     A = %23.15e\n", Asca)
+	io.Pforan("vectorized: A = %23.15e  ncalls = %d  nvals = %d\n", Avec, ncalls, nvals)
+	if Avec != Asca {
+		tst.Errorf("vectorized result must be identical to scalar result: %v != %v\n", Avec, Asca)
+	}
+	chk.Float64(tst, "A", 1e-13, Avec, ana)
+	if ncalls*10 > nvals {
+		tst.Errorf("vectorized integrand should receive many nodes per call\n")
+	}
+
+	// scalar function through VecIntegrand
+	Aconv, err := QuadVec(VecIntegrand(fsca), -10, 10, 1e-13)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	if Aconv != Asca {
+		tst.Errorf("result with VecIntegrand must be identical to scalar result: %v != %v\n", Aconv, Asca)
+	}
+
+	// reversed limits
+	A, err := QuadVec(VecIntegrand(math.Sin), math.Pi, 0, 1e-12)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "reversed", 1e-14, A, -2)
+
+	// errors
+	if _, err = QuadVec(VecIntegrand(func(x float64) float64 { return 1 / math.Sqrt(x) }), 0, 1, 1e-12); err == nil {
+		tst.Errorf("singular integrand should cause an error\n")
+	}
+	if _, err = QuadVec(func(xs la.Vector) la.Vector { return xs[:1] }, 0, 1, 1e-12); err == nil {
+		tst.Errorf("wrong number of values should cause an error\n")
+	} else {
+		io.Pf("error: %v\n", err)
+	}
+}
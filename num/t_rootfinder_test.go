@@ -0,0 +1,117 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func Test_rootfinder01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("rootfinder01. root finders through the RootFinder interface")
+
+	// f(x) = x³ - 2x - 5 (Wallis' example)
+	f := func(x float64) float64 { return x*x*x - 2*x - 5 }
+	xcor := 2.0945514815423265
+
+	names := RootFinderNames()
+	io.Pforan("names = %v\n", names)
+	chk.Strings(tst, "names", names, []string{"bisection", "brent", "halley", "itp", "newton", "secant"})
+	for _, name := range names {
+		solver, err := GetRootFinder(name)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		x, err := solver.FindRoot(f, 2, 3, 1e-12)
+		if err != nil {
+			tst.Errorf("%s: %v\n", name, err)
+			return
+		}
+		io.Pf("%10s: x = %.16f\n", name, x)
+		chk.Float64(tst, name, 1e-11, x, xcor)
+	}
+
+	// analytical derivatives
+	newton := &RootNewton{MaxIt: 20, Dfcn: func(x float64) float64 { return 3*x*x - 2 }}
+	x, err := newton.FindRoot(f, 2, 3, 1e-14)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("newton: NumIter = %d\n", newton.NumIter)
+	chk.Float64(tst, "newton(analytical)", 1e-14, x, xcor)
+	halley := &RootHalley{MaxIt: 20, Dfcn: newton.Dfcn, D2fcn: func(x float64) float64 { return 6 * x }}
+	x, err = halley.FindRoot(f, 2, 3, 1e-14)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("halley: NumIter = %d\n", halley.NumIter)
+	chk.Float64(tst, "halley(analytical)", 1e-14, x, xcor)
+	if halley.NumIter > newton.NumIter {
+		tst.Errorf("Halley's method should not require more iterations than Newton's method\n")
+	}
+
+	// ITP requires fewer iterations than bisection
+	bis, itp := &RootBisection{MaxIt: 200}, &RootITP{MaxIt: 200}
+	bis.FindRoot(math.Cos, 0, 3, 1e-12)
+	x, _ = itp.FindRoot(math.Cos, 3, 0, 1e-12) // reversed interval with f(a) < 0
+	io.Pforan("bisection: NumIter = %d  itp: NumIter = %d\n", bis.NumIter, itp.NumIter)
+	chk.Float64(tst, "itp: cos", 1e-12, x, math.Pi/2)
+	if itp.NumIter >= bis.NumIter {
+		tst.Errorf("ITP should require fewer iterations than bisection\n")
+	}
+
+	// the zero value of N0 gives the default slack n0 = 1
+	itp1 := &RootITP{MaxIt: 200, N0: 1}
+	x1, _ := itp1.FindRoot(math.Cos, 3, 0, 1e-12)
+	chk.Float64(tst, "itp: N0 = 0 ⇒ n0 = 1", 1e-17, x, x1)
+	chk.Int(tst, "itp: N0 = 0 ⇒ n0 = 1: NumIter", itp.NumIter, itp1.NumIter)
+}
+
+func Test_rootfinder02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("rootfinder02. root finder errors and registry")
+
+	f := func(x float64) float64 { return x*x + 1 }
+	for _, name := range []string{"brent", "bisection", "itp"} {
+		solver, _ := GetRootFinder(name)
+		if _, err := solver.FindRoot(f, -1, 1, 1e-10); err == nil {
+			tst.Errorf("%s: root not bracketed should cause an error\n", name)
+		} else {
+			io.Pf("%10s: %v\n", name, err)
+		}
+	}
+	for _, tol := range []float64{0, -1e-10, math.NaN()} {
+		if _, err := (&RootITP{MaxIt: 200}).FindRoot(math.Cos, 0, 3, tol); err == nil {
+			tst.Errorf("itp: tol=%g should cause an error\n", tol)
+		}
+	}
+	if _, err := (&RootNewton{MaxIt: 30}).FindRoot(f, -1, 2, 1e-10); err == nil {
+		tst.Errorf("newton: function without roots should cause an error\n")
+	}
+	if _, err := GetRootFinder("unknown"); err == nil {
+		tst.Errorf("unknown root finder should cause an error\n")
+	}
+
+	// register a new root finder
+	RegisterRootFinder("bisection-tight", func() RootFinder { return &RootBisection{MaxIt: 5} })
+	solver, err := GetRootFinder("bisection-tight")
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	if _, err = solver.FindRoot(math.Sin, 3, 4, 1e-12); err == nil {
+		tst.Errorf("bisection with MaxIt=5 should fail to converge\n")
+	}
+	delete(rootfinderallocators, "bisection-tight")
+}
@@ -145,13 +145,22 @@ func (o *BwEuler) Step(x0 float64, y0 la.Vector) {
 
 			// numerical Jacobian
 			if o.jac == nil { // numerical
-				num.Jacobian(o.dfdy, func(fy, yy la.Vector) {
-					o.fcn(fy, h, x0, yy)
-				}, y0, o.work.f[0], o.dr) // dr works here as workspace variable
+				if o.conf.jacBand {
+					num.JacobianBanded(o.dfdy, func(fy, yy la.Vector) {
+						o.fcn(fy, h, x0, yy)
+					}, y0, o.work.f[0], o.dr, o.conf.jacMl, o.conf.jacMu) // dr works here as workspace variable
+				} else {
+					num.Jacobian(o.dfdy, func(fy, yy la.Vector) {
+						o.fcn(fy, h, x0, yy)
+					}, y0, o.work.f[0], o.dr) // dr works here as workspace variable
+				}
 
 				// analytical Jacobian
 			} else {
 				o.jac(o.dfdy, h, x0, y0)
+				if o.conf.checkJac && o.stat.Njeval <= o.conf.checkJacNmax {
+					checkJacobian(o.dfdy, o.fcn, h, x0, y0, o.conf.checkJacTol)
+				}
 			}
 
 			// initialise drdy matrix
@@ -170,12 +179,16 @@ func (o *BwEuler) Step(x0 float64, y0 la.Vector) {
 
 			// perform factorisation
 			o.stat.Ndecomp++
+			o.stat.swLinsol.Start()
 			o.ls.Fact()
+			o.stat.swLinsol.Stop()
 		}
 
 		// solve linear system
 		o.stat.Nlinsol++
+		o.stat.swLinsol.Start()
 		o.ls.Solve(o.dr, o.r, false) // dr := inv(drdy) * residual
+		o.stat.swLinsol.Stop()
 
 		// update y
 		for i := 0; i < o.ndim; i++ {
@@ -0,0 +1,49 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ode
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/num"
+)
+
+// checkJacobian compares the analytical Jacobian (dfdy) with a finite-differences one computed
+// with fcn @ (h,x,y). The worst-offending entry is reported (by panicking) if
+//
+//    |Jana[i][j] - Jnum[i][j]| > tol ⋅ (1 + |Jnum[i][j]|)
+//
+func checkJacobian(dfdy *la.Triplet, fcn Func, h, x float64, y la.Vector, tol float64) {
+
+	// numerical Jacobian
+	ndim := len(y)
+	yy := y.GetCopy()
+	fy := la.NewVector(ndim)
+	w := la.NewVector(ndim)
+	fcn(fy, h, x, yy)
+	jnum := new(la.Triplet)
+	num.Jacobian(jnum, func(fyy, yyy la.Vector) {
+		fcn(fyy, h, x, yyy)
+	}, yy, fy, w)
+
+	// find worst entry
+	Jana := dfdy.ToDense()
+	Jnum := jnum.ToDense()
+	imax, jmax, emax := 0, 0, 0.0
+	for i := 0; i < ndim; i++ {
+		for j := 0; j < ndim; j++ {
+			e := math.Abs(Jana.Get(i, j)-Jnum.Get(i, j)) / (1.0 + math.Abs(Jnum.Get(i, j)))
+			if e > emax {
+				imax, jmax, emax = i, j, e
+			}
+		}
+	}
+	if emax > tol {
+		chk.Panic("analytical Jacobian is incorrect @ x=%g. worst entry: dfdy[%d][%d] = %g (analytical) but %g (numerical). scaled difference = %g > tol = %g\n",
+			x, imax, jmax, Jana.Get(imax, jmax), Jnum.Get(imax, jmax), emax, tol)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"math"
 
 	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
 	"github.com/cpmech/gosl/mpi"
 	"github.com/cpmech/gosl/utl"
 )
@@ -17,6 +18,7 @@ type Config struct {
 
 	// parameters
 	Hmin       float64 // minimum H allowed
+	Hmax       float64 // maximum H allowed (variable steps only) [0 ⇒ no limit]
 	IniH       float64 // initial H
 	NmaxIt     int     // max num iterations (allowed)
 	NmaxSS     int     // max num substeps
@@ -36,6 +38,8 @@ type Config struct {
 	Verbose    bool    // show messages, e.g. during iterations
 	ZeroTrial  bool    // always start iterations with zero trial values (instead of collocation interpolation)
 	StabBeta   float64 // Lund stabilisation coefficient β
+	ProjTol    float64 // tolerance on max|g(y)| of the projection onto constraints (see SetProjection) [default = 1e-10]
+	ProjNmaxIt int     // max number of iterations of the projection onto constraints [default = 5]
 
 	// stiffness detection
 	StiffNstp  int     // number of steps to check stiff situation. 0 ⇒ no check. [default = 1]
@@ -51,6 +55,15 @@ type Config struct {
 	denseOut  bool      // perform dense output is active
 	denseNstp int       // number of dense steps
 
+	// invariants
+	invNames []string     // names of invariants
+	invFcns  []InvariantF // functions computing the invariants
+
+	// projection onto constraints
+	projNcons int    // number of constraints
+	projG     fun.Vv // constraints g(y) = 0 [may be nil ⇒ no projection]
+	projDgdy  fun.Mv // Jacobian of constraints dg/dy
+
 	// linear solver
 	Symmetric bool   // assume symmetric matrix
 	LsVerbose bool   // show linear solver messages
@@ -78,6 +91,19 @@ type Config struct {
 	fixed       bool    // use fixed steps
 	fixedH      float64 // value of fixed stepsize
 	fixedNsteps int     // number of fixed steps
+
+	// Jacobian verification
+	checkJac     bool    // compare analytical Jacobian with numerical one
+	checkJacTol  float64 // tolerance for the Jacobian verification
+	checkJacNmax int     // number of Jacobian evaluations to be verified
+
+	// timing
+	timing bool // measure time spent in fcn, Jacobian and linear solvers
+
+	// banded Jacobian
+	jacBand bool // the Jacobian is banded
+	jacMl   int  // lower bandwidth of Jacobian
+	jacMu   int  // upper bandwidth of Jacobian
 }
 
 // NewConfig returns a new [default] set of configuration parameters
@@ -110,6 +136,8 @@ func NewConfig(method string, lsKind string, comm *mpi.Communicator) (o *Config)
 	o.CteTg = false
 	o.UseRmsNorm = true
 	o.Verbose = false
+	o.ProjTol = 1e-10
+	o.ProjNmaxIt = 5
 
 	// stiffness detection
 	o.StiffNstp = 0
@@ -144,7 +172,7 @@ func NewConfig(method string, lsKind string, comm *mpi.Communicator) (o *Config)
 	case "dopri5":
 		o.StabBeta = 0.04
 		o.stabBetaM = 0.75
-	case "dopri8":
+	case "dopri8", "dop853":
 		o.stabBetaM = 0.2
 	}
 	return
@@ -191,6 +219,62 @@ func (o *Config) SetFixedH(dxApprox, xf float64) {
 	}
 }
 
+// SetStepLimits sets the minimum and maximum stepsizes
+//  hmin -- minimum stepsize; use 0 to keep the current value
+//  hmax -- maximum stepsize (variable steps only); use 0 for no limit
+//  NOTE: hmax is useful to respect stability limits of explicit methods; e.g. the CFL condition
+func (o *Config) SetStepLimits(hmin, hmax float64) {
+	if hmax > 0 && hmin > hmax {
+		chk.Panic("hmin=%v must not be greater than hmax=%v\n", hmin, hmax)
+	}
+	if hmin > 0 {
+		o.Hmin = hmin
+	}
+	o.Hmax = hmax
+}
+
+// SetCheckJac activates the verification of the analytical Jacobian (JacF) by comparing it
+// against a finite-differences one during the first few Jacobian evaluations
+//  check -- activate verification
+//  tol   -- tolerance on the scaled difference |Jana-Jnum|/(1+|Jnum|); use 0 for default [1e-5]
+//  NOTE: the solver panics with the worst-offending entry if the Jacobians disagree
+func (o *Config) SetCheckJac(check bool, tol float64) {
+	if tol <= 0 {
+		tol = 1e-5
+	}
+	o.checkJac = check
+	o.checkJacTol = tol
+	o.checkJacNmax = 3
+}
+
+// SetTiming activates the measurement of the wall-clock time spent in the fcn, Jacobian and
+// linear solvers; the results are reported in Stat (Tfcn, Tjac, Tlinsol and Ttotal)
+//  NOTE: this function must be called before NewSolver
+func (o *Config) SetTiming(timing bool) {
+	o.timing = timing
+}
+
+// SetJacBand sets the lower (ml) and upper (mu) bandwidths of the Jacobian matrix; i.e.
+//
+//    df_i/dy_j = 0   if   i - j > ml   or   j - i > mu
+//
+//  This function also selects the "banded" linear solver. The numerical Jacobian (if used) then
+//  requires only ml+mu+1 calls to fcn and stores the entries within the band only
+//  NOTE: (1) this function must be called before NewSolver
+//        (2) an analytical Jacobian must not have entries outside the band
+//        (3) the banded mode is not available in MPI distributed runs
+func (o *Config) SetJacBand(ml, mu int) {
+	if ml < 0 || mu < 0 {
+		chk.Panic("bandwidths must be non-negative. ml=%d, mu=%d are invalid\n", ml, mu)
+	}
+	if o.distr {
+		chk.Panic("banded Jacobian is not available in MPI distributed runs\n")
+	}
+	o.jacBand = true
+	o.jacMl, o.jacMu = ml, mu
+	o.lsKind = "banded"
+}
+
 // SetStepOut activates output of (variable) steps
 //  save -- save all values
 //  out  -- function to be during step output [may be nil]
@@ -210,3 +294,43 @@ func (o *Config) SetDenseOut(save bool, dxOut, xf float64, out DenseOutF) {
 		o.denseDx = dxOut
 	}
 }
+
+// SetInvariant adds (or replaces) a conserved quantity (e.g. the energy) to be evaluated at each
+// accepted step (including the initial point). The values are recorded in Output.Invariants
+//  name -- name of invariant; e.g. "energy"
+//  fn   -- function computing the invariant
+//  NOTE: (1) this function must be called before NewSolver
+//        (2) use Output.InvariantDrift to quantify the numerical quality of the solution
+func (o *Config) SetInvariant(name string, fn InvariantF) {
+	if fn == nil {
+		chk.Panic("function of invariant %q must not be nil\n", name)
+	}
+	for i, n := range o.invNames {
+		if n == name {
+			o.invFcns[i] = fn
+			return
+		}
+	}
+	o.invNames = append(o.invNames, name)
+	o.invFcns = append(o.invFcns, fn)
+}
+
+// SetProjection activates the projection of y onto the constraint manifold g(y) = 0 after each
+// accepted step (post-stabilization). A few Gauss-Newton iterations computing the smallest
+// correction of y are performed; see ProjTol and ProjNmaxIt
+//  ncons -- number of constraints; i.e. len(g)
+//  g     -- constraints function g(y) [ncons]
+//  dgdy  -- Jacobian of constraints dg/dy [ncons][ndim]. NOTE: dg/dy must have full rank
+//  NOTE: (1) this function must be called before NewSolver
+//        (2) the dense output is computed without the projection
+func (o *Config) SetProjection(ncons int, g fun.Vv, dgdy fun.Mv) {
+	if ncons < 1 {
+		chk.Panic("number of constraints must be at least 1. ncons=%d is invalid\n", ncons)
+	}
+	if g == nil || dgdy == nil {
+		chk.Panic("constraints function g and its Jacobian dgdy must not be nil\n")
+	}
+	o.projNcons = ncons
+	o.projG = g
+	o.projDgdy = dgdy
+}
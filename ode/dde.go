@@ -0,0 +1,164 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ode
+
+import (
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// DdeF defines the right-hand side of delay differential equations (DDEs)
+//
+//    f := dy/dx = f(x, y(x), y(x-τ))
+//
+type DdeF func(f la.Vector, x float64, y, ylag la.Vector)
+
+// DdeHistF defines the history function; i.e. y(x) for x ≤ 0
+type DdeHistF func(x float64) la.Vector
+
+// DdeSolution holds the solution of a DDE problem computed by SolveDDE
+type DdeSolution struct {
+	Y    la.Vector // final {y} @ x=xf
+	Stat *Stat     // statistics (accumulated over all intervals of length τ)
+
+	// internal
+	history DdeHistF      // y(x) for x ≤ 0
+	erk     *ExplicitRK   // method providing the dense output function
+	xs      []float64     // x at the beginning of each accepted step [nsteps]
+	hs      []float64     // stepsize of each accepted step [nsteps]
+	dos     [][]la.Vector // dense output coefficients of each accepted step [nsteps][ncoef][ndim]
+}
+
+// SolveDDE solves delay differential equations with a constant delay τ
+//
+//    dy/dx = f(x, y(x), y(x-τ))   with   y(x) = history(x)  for  x ≤ 0
+//
+//  The method of steps is employed; i.e. the problem is solved on the intervals [k⋅τ, (k+1)⋅τ]
+//  where the derivative of y may be discontinuous at the interval boundaries. The steps are
+//  limited by τ and the dense output interpolant of each accepted step is stored in order to
+//  supply y(x-τ) to f.
+//
+//  INPUT:
+//   method  -- explicit method with dense output; e.g. "dopri5" or "dopri8"
+//   fcn     -- function f(x, y(x), y(x-τ))
+//   history -- y(x) for x ≤ 0; y(0) is the initial value
+//   tau     -- delay τ > 0
+//   xf      -- final x
+//   tol     -- absolute and relative tolerances; use 0 for default [default = 1e-4]
+//
+//  OUTPUT:
+//   sol -- solution; call sol.Eval to compute y at any x ≤ xf
+//   err -- error if the input is invalid or the solution has failed
+//
+func SolveDDE(method string, fcn DdeF, history DdeHistF, tau, xf, tol float64) (sol *DdeSolution, err error) {
+
+	// catch errors
+	defer func() {
+		if e := recover(); e != nil {
+			err = chk.Err("%v", e)
+		}
+	}()
+
+	// check
+	if fcn == nil || history == nil {
+		return nil, chk.Err("functions fcn and history must not be nil")
+	}
+	if tau <= 0 {
+		return nil, chk.Err("delay τ must be positive. τ = %g is invalid", tau)
+	}
+	if xf < 0 {
+		return nil, chk.Err("xf=%v must be greater than x=0", xf)
+	}
+
+	// initial values
+	y0 := history(0)
+	ndim := len(y0)
+	if ndim < 1 {
+		return nil, chk.Err("history function must return a vector with at least one component")
+	}
+	sol = &DdeSolution{history: history}
+	sol.Y = y0.GetCopy()
+	sol.Stat = NewStat("", false)
+
+	// configuration
+	conf := NewConfig(method, "", nil)
+	if tol > 0 {
+		conf.SetTol(tol)
+	}
+	conf.SetStepLimits(0, tau)
+	conf.denseOut = true // compute the dense output coefficients without saving values
+
+	// save dense output coefficients of each accepted step
+	conf.SetStepOut(false, func(istep int, h, x float64, y la.Vector) (stop bool) {
+		if istep == 0 {
+			return
+		}
+		do := make([]la.Vector, len(sol.erk.do))
+		for i := 0; i < len(sol.erk.do); i++ {
+			do[i] = sol.erk.do[i].GetCopy()
+		}
+		sol.xs = append(sol.xs, x-h)
+		sol.hs = append(sol.hs, h)
+		sol.dos = append(sol.dos, do)
+		return
+	})
+
+	// solver with y(x-τ) taken from the history
+	ylag := la.NewVector(ndim)
+	f := func(dydx la.Vector, h, x float64, y la.Vector) {
+		sol.Eval(ylag, x-tau)
+		fcn(dydx, x, y, ylag)
+	}
+	solver := NewSolver(ndim, conf, f, nil, nil)
+	defer solver.Free()
+
+	// method
+	erk, ok := solver.rkm.(*ExplicitRK)
+	if !ok || !erk.Embedded || erk.dfunA == nil {
+		return nil, chk.Err("method %q cannot be used for DDEs. an embedded explicit method with dense output is required", method)
+	}
+	sol.erk = erk
+
+	// solve on each interval [k⋅τ, (k+1)⋅τ]
+	var xa, xb float64
+	for k := 0; xa < xf; k++ {
+		xb = utl.Min(float64(k+1)*tau, xf)
+		solver.Solve(sol.Y, xa, xb)
+		sol.Stat.Nfeval += solver.Stat.Nfeval
+		sol.Stat.Nsteps += solver.Stat.Nsteps
+		sol.Stat.Naccepted += solver.Stat.Naccepted
+		sol.Stat.Nrejected += solver.Stat.Nrejected
+		sol.Stat.Hopt = solver.Stat.Hopt
+		xa = xb
+	}
+	return
+}
+
+// Eval computes y(x) using the history function (x ≤ 0) or the dense output (0 < x ≤ xf)
+//  NOTE: x must not be greater than the last computed x
+func (o *DdeSolution) Eval(yout la.Vector, x float64) {
+
+	// history
+	if x <= 0 {
+		copy(yout, o.history(x))
+		return
+	}
+
+	// find step containing x
+	n := len(o.xs)
+	k := sort.Search(n, func(i int) bool { return o.xs[i]+o.hs[i] >= x })
+	if k == n {
+		chk.Panic("cannot compute y(x) with x=%g beyond the computed steps\n", x)
+	}
+
+	// dense output
+	do := o.erk.do
+	o.erk.do = o.dos[k]
+	o.erk.dfunB(yout, o.hs[k], o.xs[k]+o.hs[k], nil, x)
+	o.erk.do = do
+}
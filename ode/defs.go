@@ -67,5 +67,17 @@ type StepOutF func(istep int, h, x float64, y la.Vector) (stop bool)
 //
 type DenseOutF func(istep int, h, x float64, y la.Vector, xout float64, yout la.Vector) (stop bool)
 
+// InvariantF defines a function computing a quantity that should be conserved along the solution;
+// e.g. the energy or the momentum of a physical system
+//
+//   INPUT:
+//     x -- current x
+//     y -- current {y}
+//
+//   OUTPUT:
+//     the value of the invariant @ (x, {y})
+//
+type InvariantF func(x float64, y la.Vector) float64
+
 // YanaF defines a function to be used when computing analytical solutions
 type YanaF func(res []float64, x float64)
@@ -28,6 +28,7 @@ import (
 //     verner6    -- 6(5) Verner 6(5) ⇒ q = 5
 //     fehlberg7  -- 7(8) Fehlberg 7(8) ⇒ q = 7
 //     dopri8     -- 8(5,3) Dormand-Prince 8 order with 5,3 estimator
+//     dop853     -- same as dopri8 (name of Hairer's code)
 //  where p(q) means method of p-order with embedded estimator of q-order
 //
 //  References:
@@ -185,11 +186,12 @@ func (o *ExplicitRK) Step(xa float64, ya la.Vector) {
 	v := o.work.v
 
 	// compute k0 (otherwise, use k0 saved in Accept)
-	if (o.work.first || !o.FSAL) && !o.work.reject {
+	if (o.work.first || !o.FSAL || o.work.proj) && !o.work.reject {
 		u0 := xa + h*o.C[0]
 		o.stat.Nfeval++
 		o.fcn(k[0], h, u0, ya) // k0 := f(ui,vi)
 	}
+	o.work.proj = false
 
 	// compute ki
 	var ui float64
@@ -490,4 +492,5 @@ func init() {
 	rkmDB["verner6"] = func() rkmethod { return newERK("verner6") }
 	rkmDB["fehlberg7"] = func() rkmethod { return newERK("fehlberg7") }
 	rkmDB["dopri8"] = func() rkmethod { return newERK("dopri8") }
+	rkmDB["dop853"] = func() rkmethod { return newERK("dopri8") }
 }
@@ -4,7 +4,13 @@
 
 package ode
 
-import "github.com/cpmech/gosl/la"
+import (
+	"runtime"
+	"sync"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
 
 // Solve solves ODE problem using standard parameters
 //
@@ -77,3 +83,88 @@ func Radau5simple(fcn Func, jac JacF, y la.Vector, xf, tol float64) {
 	numJac := jac == nil
 	Solve("radau5", fcn, jac, y, xf, 0, tol, tol, numJac, false, false, false)
 }
+
+// SolveEnsemble solves an ensemble of ODE problems sharing the same fcn but with different initial
+// values. The trajectories are distributed among nworkers goroutines, each one with its own solver
+//
+//  INPUT:
+//   method   -- the method
+//   fcn      -- function d{y}/dx := {f}(h=dx, x, {y}) [must be safe for concurrent use]
+//   Y0       -- initial values @ x=0; each column is {y} of one trajectory [ndim][ntraj]
+//   xf       -- final x
+//   tol      -- absolute and relative tolerances; use 0 for default [default = 1e-4]
+//   nworkers -- number of goroutines; use 0 for runtime.NumCPU()
+//
+//  OUTPUT:
+//   Yf    -- final values; each column is {y} of one trajectory [ndim][ntraj]
+//   stats -- statistics of each trajectory [ntraj]
+//   err   -- error if the input is invalid or any solution has failed
+//
+//  NOTE: the Jacobian is computed numerically (implicit methods only)
+//
+func SolveEnsemble(method string, fcn Func, Y0 *la.Matrix, xf, tol float64, nworkers int) (Yf *la.Matrix, stats []*Stat, err error) {
+
+	// check
+	if Y0 == nil || Y0.M < 1 || Y0.N < 1 {
+		return nil, nil, chk.Err("matrix of initial values must have at least one row and one column")
+	}
+	if nworkers < 1 {
+		nworkers = runtime.NumCPU()
+	}
+	ndim, ntraj := Y0.M, Y0.N
+	if nworkers > ntraj {
+		nworkers = ntraj
+	}
+
+	// configuration
+	conf := NewConfig(method, "", nil)
+	if tol > 0 {
+		conf.SetTol(tol)
+	}
+
+	// results
+	Yf = la.NewMatrix(ndim, ntraj)
+	Y0.CopyInto(Yf, 1)
+	stats = make([]*Stat, ntraj)
+	errs := make([]error, ntraj)
+
+	// solve (column j of a column-major matrix is contiguous)
+	solveTraj := func(sol *Solver, j int) {
+		defer func() {
+			if e := recover(); e != nil {
+				errs[j] = chk.Err("trajectory %d failed: %v", j, e)
+			}
+		}()
+		sol.Solve(Yf.Data[j*ndim:(j+1)*ndim], 0, xf)
+	}
+	base := NewSolver(ndim, conf, fcn, nil, nil)
+	defer base.Free()
+	var wg sync.WaitGroup
+	for w := 0; w < nworkers; w++ {
+		sol := base
+		if w > 0 {
+			sol = base.Clone()
+		}
+		wg.Add(1)
+		go func(w int, sol *Solver) {
+			defer wg.Done()
+			if w > 0 {
+				defer sol.Free()
+			}
+			for j := w; j < ntraj; j += nworkers {
+				solveTraj(sol, j)
+				st := *sol.Stat
+				stats[j] = &st
+			}
+		}(w, sol)
+	}
+	wg.Wait()
+
+	// errors
+	for _, e := range errs {
+		if e != nil {
+			return Yf, stats, e
+		}
+	}
+	return
+}
@@ -15,7 +15,9 @@
 package ode
 
 import (
+	"context"
 	"math"
+	"time"
 
 	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/io"
@@ -32,15 +34,21 @@ type Solver struct {
 	Stat *Stat   // statistics
 
 	// problem definition
-	ndim int  // size of y
-	fcn  Func // dy/dx := f(x,y)
-	jac  JacF // Jacobian: df/dy
+	ndim int         // size of y
+	fcn  Func        // dy/dx := f(x,y) [may be wrapped; e.g. for timing]
+	jac  JacF        // Jacobian: df/dy [may be wrapped; e.g. for timing]
+	ufcn Func        // fcn given by user
+	ujac JacF        // jac given by user
+	mmat *la.Triplet // "mass" matrix [may be nil]
 
 	// method, info and workspace
 	rkm       rkmethod // Runge-Kutta method
 	FixedOnly bool     // method can only be used with fixed steps
 	Implicit  bool     // method is implicit
 	work      *rkwork  // Runge-Kutta workspace
+
+	// projection onto constraints
+	proj *projector // [may be nil]
 }
 
 // NewSolver returns a new ODE structure with default values and allocated slices
@@ -64,8 +72,9 @@ func NewSolver(ndim int, conf *Config, fcn Func, jac JacF, M *la.Triplet) (o *So
 
 	// problem definition
 	o.ndim = ndim
-	o.fcn = fcn
-	o.jac = jac
+	o.ufcn = fcn
+	o.ujac = jac
+	o.mmat = M
 
 	// allocate method
 	o.rkm = newRKmethod(o.conf.method)
@@ -80,14 +89,70 @@ func NewSolver(ndim int, conf *Config, fcn Func, jac JacF, M *la.Triplet) (o *So
 	// workspace
 	o.work = newRKwork(nstg, o.ndim)
 
+	// projection onto constraints
+	if o.conf.projG != nil {
+		o.proj = newProjector(o.ndim, o.conf)
+	}
+
 	// initialise method
-	o.rkm.Init(ndim, o.conf, o.work, o.Stat, fcn, jac, M)
+	o.initMethod()
+	return
+}
+
+// Clone returns a new solver sharing the configuration (and functions) of this solver, but with
+// its own workspace, output, statistics and linear solvers. Thus, clones can be run concurrently
+//  NOTE: (1) the configuration must not be modified while the clones are running
+//        (2) the output functions (e.g. given to SetStepOut) are shared by all clones
+//        (3) use SetFunc to give each clone its own fcn (e.g. with different parameters)
+//        (4) remember to call Free() on each clone
+func (o *Solver) Clone() (c *Solver) {
+	return NewSolver(o.ndim, o.conf, o.ufcn, o.ujac, o.mmat)
+}
+
+// SetFunc replaces the functions defining the problem
+//  fcn -- f(x,y) = dy/dx function
+//  jac -- Jacobian: df/dy function [may be nil ⇒ use numerical Jacobian, if necessary]
+//  NOTE: the method is re-initialised; thus this function must not be called during Solve
+func (o *Solver) SetFunc(fcn Func, jac JacF) {
+	o.ufcn = fcn
+	o.ujac = jac
+	o.rkm.Free()
+	o.rkm = newRKmethod(o.conf.method)
+	o.initMethod()
+}
+
+// initMethod sets fcn and jac (with timing, if requested) and initialises the RK method
+func (o *Solver) initMethod() {
+
+	// functions
+	o.fcn = o.ufcn
+	o.jac = o.ujac
+
+	// timing
+	if o.conf.timing {
+		o.Stat.initTiming()
+		fcn, jac := o.ufcn, o.ujac
+		o.fcn = func(f la.Vector, h, x float64, y la.Vector) {
+			o.Stat.swFcn.Start()
+			fcn(f, h, x, y)
+			o.Stat.swFcn.Stop()
+		}
+		if jac != nil {
+			o.jac = func(dfdy *la.Triplet, h, x float64, y la.Vector) {
+				o.Stat.swJac.Start()
+				jac(dfdy, h, x, y)
+				o.Stat.swJac.Stop()
+			}
+		}
+	}
+
+	// initialise method
+	o.rkm.Init(o.ndim, o.conf, o.work, o.Stat, o.fcn, o.jac, o.mmat)
 
 	// connect dense output function
 	if o.Out != nil {
 		o.Out.dout = o.rkm.DenseOut
 	}
-	return
 }
 
 // Free releases allocated memory (e.g. by the linear solvers)
@@ -99,6 +164,23 @@ func (o *Solver) Free() {
 
 // Solve solves dy/dx = f(x,y) from x to xf with initial y given in y
 func (o *Solver) Solve(y la.Vector, x, xf float64) {
+	o.solve(context.Background(), y, x, xf)
+}
+
+// SolveWithContext solves dy/dx = f(x,y) from x to xf with initial y given in y and stops if the
+// context is cancelled. The context is checked before each (sub)step
+//
+//  OUTPUT:
+//   y   -- modified y vector with final {y} or {y} at the last accepted step if err != nil
+//   err -- ctx.Err() (e.g. context.Canceled or context.DeadlineExceeded) if the context is
+//          cancelled before reaching xf
+//
+func (o *Solver) SolveWithContext(ctx context.Context, y la.Vector, x, xf float64) (err error) {
+	return o.solve(ctx, y, x, xf)
+}
+
+// solve implements Solve and SolveWithContext
+func (o *Solver) solve(ctx context.Context, y la.Vector, x, xf float64) (err error) {
 
 	// check
 	if xf < x {
@@ -114,10 +196,17 @@ func (o *Solver) Solve(y la.Vector, x, xf float64) {
 		o.work.h = o.conf.fixedH
 	} else {
 		o.work.h = utl.Min(o.work.h, o.conf.IniH)
+		if o.conf.Hmax > 0 {
+			o.work.h = utl.Min(o.work.h, o.conf.Hmax)
+		}
 	}
 
 	// stat and output
 	o.Stat.Reset()
+	if o.conf.timing {
+		t0 := time.Now()
+		defer func() { o.Stat.collectTiming(time.Since(t0)) }()
+	}
 	o.Stat.Hopt = o.work.h
 	if o.Out != nil {
 		stop := o.Out.execute(0, false, o.work.rs, o.work.h, x, y)
@@ -132,12 +221,30 @@ func (o *Solver) Solve(y la.Vector, x, xf float64) {
 	// first scaling variable
 	la.VecScaleAbs(o.work.scal, o.conf.atol, o.conf.rtol, y) // scal = atol + rtol * abs(y)
 
-	// make sure that final x is equal to xf in the end
-	defer func() {
-		if math.Abs(x-xf) > 1e-15 {
-			chk.Panic("internal error: x must be equal to xf in the end. x-xf=%v\n", x-xf)
+	// integrate and make sure that final x is equal to xf in the end. NOTE: the check is not
+	// deferred; thus panics raised during the integration propagate with their stack trace
+	x, err = o.integrate(ctx, y, x, xf)
+	if err == nil && math.Abs(x-xf) > 1e-15 {
+		chk.Panic("internal error: x must be equal to xf in the end. x-xf=%v\n", x-xf)
+	}
+	return
+}
+
+// integrate performs the (fixed or variable) steps from x0 to xf; returning the final x
+func (o *Solver) integrate(ctx context.Context, y la.Vector, x0, xf float64) (x float64, err error) {
+	x = x0
+
+	// cancellation
+	done := ctx.Done() // nil if the context can never be cancelled
+	cancelled := func() bool {
+		select {
+		case <-done:
+			err = ctx.Err()
+			return true
+		default:
+			return false
 		}
-	}()
+	}
 
 	// fixed steps //////////////////////////////
 	if o.conf.fixed {
@@ -147,6 +254,9 @@ func (o *Solver) Solve(y la.Vector, x, xf float64) {
 			io.Pf("y = %v\n", y)
 		}
 		for n := 0; n < o.conf.fixedNsteps; n++ {
+			if done != nil && cancelled() {
+				return
+			}
 			if o.Implicit && o.jac == nil { // f0 for numerical Jacobian
 				o.Stat.Nfeval++
 				o.fcn(o.work.f0, o.work.h, x, y)
@@ -156,6 +266,7 @@ func (o *Solver) Solve(y la.Vector, x, xf float64) {
 			o.work.first = false
 			x = float64(n+1) * o.work.h
 			o.rkm.Accept(y, x)
+			o.project(y)
 			if o.Out != nil {
 				stop := o.Out.execute(istep, false, o.work.rs, o.work.h, x, y)
 				if stop {
@@ -204,6 +315,11 @@ func (o *Solver) Solve(y la.Vector, x, xf float64) {
 			// total number of substeps
 			o.Stat.Nsteps++
 
+			// cancelled
+			if done != nil && cancelled() {
+				return
+			}
+
 			// error: did not converge
 			if iss == o.conf.NmaxSS {
 				failed = true
@@ -256,6 +372,7 @@ func (o *Solver) Solve(y la.Vector, x, xf float64) {
 				// update x and y
 				dxnew = o.rkm.Accept(y, x)
 				x += o.work.h
+				o.project(y)
 
 				// output
 				if o.Out != nil {
@@ -284,6 +401,9 @@ func (o *Solver) Solve(y la.Vector, x, xf float64) {
 
 				// check new step size
 				dxnew = utl.Min(dxnew, dxmax)
+				if o.conf.Hmax > 0 {
+					dxnew = utl.Min(dxnew, o.conf.Hmax)
+				}
 				if o.work.reject { // do not alow h to grow if previous was a reject
 					dxnew = utl.Min(o.work.h, dxnew)
 				}
@@ -348,4 +468,15 @@ func (o *Solver) Solve(y la.Vector, x, xf float64) {
 			break
 		}
 	}
+	return
+}
+
+// project projects y onto the constraint manifold (if SetProjection was called)
+func (o *Solver) project(y la.Vector) {
+	if o.proj == nil {
+		return
+	}
+	if o.proj.project(y) > 0 {
+		o.work.proj = true // f(x,y) of FSAL methods must be recomputed
+	}
 }
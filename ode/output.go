@@ -5,6 +5,9 @@
 package ode
 
 import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/la"
 	"github.com/cpmech/gosl/utl"
 )
@@ -33,6 +36,9 @@ type Output struct {
 	xout      float64     // current x of dense output
 	yout      la.Vector   // current y of dense output (used if denseF != nil only)
 
+	// invariants
+	Invariants map[string][]float64 // values of invariants @ accepted steps (incl. the initial point) [name][nsteps]
+
 	// from RK method
 	dout func(yout la.Vector, h, x float64, y la.Vector, xout float64) // function to calculate dense values of y
 }
@@ -64,6 +70,9 @@ func newOutput(ndim int, conf *Config) (o *Output) {
 	if o.conf.denseF != nil {
 		o.yout = la.NewVector(ndim)
 	}
+	if len(o.conf.invNames) > 0 {
+		o.Invariants = make(map[string][]float64)
+	}
 	return
 }
 
@@ -88,6 +97,14 @@ func (o *Output) execute(istep int, last bool, ρs, h, x float64, y []float64) (
 		o.StepIdx++
 	}
 
+	// invariants
+	for k, name := range o.conf.invNames {
+		if istep == 0 {
+			o.Invariants[name] = o.Invariants[name][:0] // new solution
+		}
+		o.Invariants[name] = append(o.Invariants[name], o.conf.invFcns[k](x, y))
+	}
+
 	// dense output using function
 	var xo float64
 	if o.conf.denseF != nil {
@@ -267,3 +284,63 @@ func (o *Output) GetDenseYtableT() (Y [][]float64) {
 	}
 	return
 }
+
+// time series ////////////////////////////////////////////////////////////////////////////////////
+
+// Times returns a copy of all x values (abscissae) from the dense output data, if any, or from the
+// (accepted) steps output otherwise
+func (o *Output) Times() (xs la.Vector) {
+	if o.DenseIdx > 0 {
+		xs = la.NewVector(o.DenseIdx)
+		copy(xs, o.DenseX)
+		return
+	}
+	xs = la.NewVector(o.StepIdx)
+	copy(xs, o.StepX)
+	return
+}
+
+// Component returns the time series of the i-th component of y; i.e. the x values (see Times) and
+// the corresponding y[i] values from the dense output data, if any, or from the (accepted) steps
+// output otherwise
+//  i -- index of y component
+//  use to plot time series; e.g.:
+//     xs, y0 := o.Component(0)
+//     plt.Plot(xs, y0, &plt.A{L:"y0"})
+func (o *Output) Component(i int) (xs, yi la.Vector) {
+	if i < 0 || i >= o.ndim {
+		chk.Panic("index of component must be in [0, %d). i=%d is invalid\n", o.ndim, i)
+	}
+	xs = o.Times()
+	if o.DenseIdx > 0 {
+		yi = o.GetDenseY(i)
+	} else {
+		yi = o.GetStepY(i)
+	}
+	if yi == nil {
+		yi = la.NewVector(0)
+	}
+	return
+}
+
+// invariants /////////////////////////////////////////////////////////////////////////////////////
+
+// GetInvariant returns the values of the invariant named name @ all accepted steps (including the
+// initial point)
+func (o *Output) GetInvariant(name string) (I []float64) {
+	I, ok := o.Invariants[name]
+	if !ok {
+		chk.Panic("cannot find invariant named %q. make sure to call conf.SetInvariant\n", name)
+	}
+	return
+}
+
+// InvariantDrift returns the maximum deviation of the invariant named name from its initial value;
+// i.e. max |I(x) - I(x0)|
+func (o *Output) InvariantDrift(name string) (drift float64) {
+	I := o.GetInvariant(name)
+	for _, v := range I {
+		drift = utl.Max(drift, math.Abs(v-I[0]))
+	}
+	return
+}
@@ -0,0 +1,64 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ode
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// projector projects y onto the constraint manifold g(y) = 0 (post-stabilization). The correction
+// Δy with minimum norm ‖Δy‖ satisfying the linearised constraints g + G⋅Δy = 0 is computed at each
+// (Gauss-)Newton iteration:
+//
+//   Δy = -Gᵀ⋅(G⋅Gᵀ)⁻¹⋅g    with    G = dg/dy
+//
+//   Reference:
+//   [1] Hairer E, Lubich C, Wanner G (2006) Geometric Numerical Integration. Springer Series in
+//       Computational Mathematics, Vol. 31, 2nd Edition, Berlin, Germany, 644 p. Section IV.4
+//
+type projector struct {
+	conf *Config    // configuration
+	g    la.Vector  // [ncons] constraints g(y)
+	G    *la.Matrix // [ncons][ndim] Jacobian dg/dy
+	GGt  *la.Matrix // [ncons][ncons] G⋅Gᵀ
+	w    la.Vector  // [ncons] w = (G⋅Gᵀ)⁻¹⋅g
+	dy   la.Vector  // [ndim] correction Δy
+}
+
+// newProjector returns a new projector
+func newProjector(ndim int, conf *Config) (o *projector) {
+	o = new(projector)
+	o.conf = conf
+	o.g = la.NewVector(conf.projNcons)
+	o.G = la.NewMatrix(conf.projNcons, ndim)
+	o.GGt = la.NewMatrix(conf.projNcons, conf.projNcons)
+	o.w = la.NewVector(conf.projNcons)
+	o.dy = la.NewVector(ndim)
+	return
+}
+
+// project projects y onto the constraint manifold
+//  Output:
+//   nit -- number of iterations (0 if y already satisfies the constraints)
+//   y   -- [modify input] projected y
+func (o *projector) project(y la.Vector) (nit int) {
+	for nit = 0; nit <= o.conf.ProjNmaxIt; nit++ {
+		o.conf.projG(o.g, y)
+		if o.g.Largest(1) <= o.conf.ProjTol {
+			return
+		}
+		if nit == o.conf.ProjNmaxIt {
+			break
+		}
+		o.conf.projDgdy(o.G, y)
+		la.MatMatTrMul(o.GGt, 1, o.G, o.G)     // GGt := G⋅Gᵀ
+		la.SolveRealLinSysSPD(o.w, o.GGt, o.g) // w := (G⋅Gᵀ)⁻¹⋅g
+		la.MatTrVecMul(o.dy, -1, o.G, o.w)     // Δy := -Gᵀ⋅w
+		la.VecAdd(y, 1, y, 1, o.dy)            // y := y + Δy
+	}
+	chk.Panic("projection onto constraints did not converge after %d iterations. max|g| = %g\n", o.conf.ProjNmaxIt, o.g.Largest(1))
+	return
+}
@@ -236,6 +236,10 @@ func (o *Radau5) Step(x0 float64, y0 la.Vector) {
 					num.JacobianMpi(o.conf.comm, o.dfdy, func(fy, yy la.Vector) {
 						o.fcn(fy, h, x0, yy)
 					}, y0, o.work.f0, o.w[0], true) // w works here as workspace variable
+				} else if o.conf.jacBand {
+					num.JacobianBanded(o.dfdy, func(fy, yy la.Vector) {
+						o.fcn(fy, h, x0, yy)
+					}, y0, o.work.f0, o.w[0], o.conf.jacMl, o.conf.jacMu) // w works here as workspace variable
 				} else {
 					num.Jacobian(o.dfdy, func(fy, yy la.Vector) {
 						o.fcn(fy, h, x0, yy)
@@ -245,6 +249,9 @@ func (o *Radau5) Step(x0 float64, y0 la.Vector) {
 				// analytical Jacobian
 			} else {
 				o.jac(o.dfdy, h, x0, y0)
+				if o.conf.checkJac && o.stat.Njeval <= o.conf.checkJacNmax {
+					checkJacobian(o.dfdy, o.fcn, h, x0, y0, o.conf.checkJacTol)
+				}
 			}
 
 			// set flag
@@ -270,8 +277,10 @@ func (o *Radau5) Step(x0 float64, y0 la.Vector) {
 
 		// perform factorisation
 		o.stat.Ndecomp++
+		o.stat.swLinsol.Start()
 		o.lsR.Fact()
 		o.lsC.Fact()
+		o.stat.swLinsol.Stop()
 	}
 
 	// update u[i]
@@ -352,6 +361,7 @@ func (o *Radau5) Step(x0 float64, y0 la.Vector) {
 
 		// solve linear system
 		o.stat.Nlinsol++
+		o.stat.swLinsol.Start()
 		if !o.conf.distr && o.conf.GoChan {
 			wg := new(sync.WaitGroup)
 			wg.Add(2)
@@ -372,6 +382,7 @@ func (o *Radau5) Step(x0 float64, y0 la.Vector) {
 			o.lsC.Solve(o.dw12, o.v12, false)
 			o.dw12.SplitRealImag(o.dw[1], o.dw[2])
 		}
+		o.stat.swLinsol.Stop()
 
 		// update w and z
 		for m := 0; m < o.ndim; m++ {
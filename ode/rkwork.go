@@ -21,6 +21,7 @@ type rkwork struct {
 	h     float64   // current stepsize
 	hPrev float64   // previous stepsize
 	first bool      // first step
+	proj  bool      // y has been projected onto constraints after the last step (see Solver.project)
 	f0    la.Vector // f(x,y) before step
 	scal  la.Vector // scal = Atol + Rtol*abs(y)
 
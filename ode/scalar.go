@@ -0,0 +1,67 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ode
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// SolveScalar solves the scalar ODE problem dy/dx = f(x,y) from x=0 to xf
+//
+//  INPUT:
+//   method -- the method; e.g. dopri5, dopri8 or radau5 (with numerical Jacobian)
+//   f      -- function dy/dx := f(x, y)
+//   y0     -- initial y @ x=0
+//   xf     -- final x
+//   tol    -- absolute and relative tolerances; use 0 for default [default = 1e-4]
+//
+//  OUTPUT:
+//   yf   -- y @ x=xf
+//   stat -- statistics
+//   err  -- error if the input is invalid or the solution has failed
+//
+//  NOTE: this is a convenience wrapper around Solver with ndim = 1; thus the step size control
+//        and the results are the same as those of Solve. Methods with fixed steps only (e.g. rk4)
+//        cannot be used
+//
+func SolveScalar(method string, f func(x, y float64) float64, y0, xf, tol float64) (yf float64, stat *Stat, err error) {
+
+	// catch errors
+	defer func() {
+		if e := recover(); e != nil {
+			err = chk.Err("%v", e)
+		}
+	}()
+
+	// check
+	if f == nil {
+		return y0, nil, chk.Err("function f(x,y) must not be nil")
+	}
+	if xf < 0 {
+		return y0, nil, chk.Err("xf=%v must be greater than x=0", xf)
+	}
+
+	// configuration
+	conf := NewConfig(method, "", nil)
+	if tol > 0 {
+		conf.SetTol(tol)
+	}
+
+	// solver
+	fcn := func(dydx la.Vector, h, x float64, y la.Vector) {
+		dydx[0] = f(x, y[0])
+	}
+	sol := NewSolver(1, conf, fcn, nil, nil)
+	defer sol.Free()
+	if sol.FixedOnly {
+		return y0, nil, chk.Err("method %q can only be used with fixed steps. SolveScalar requires an embedded method", method)
+	}
+
+	// solve
+	y := la.Vector{y0}
+	sol.Solve(y, 0, xf)
+	return y[0], sol.Stat, nil
+}
@@ -4,7 +4,12 @@
 
 package ode
 
-import "github.com/cpmech/gosl/io"
+import (
+	"time"
+
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/utl"
+)
 
 // Stat holds statistics and output data
 type Stat struct {
@@ -19,6 +24,17 @@ type Stat struct {
 	Hopt      float64 // optimal step size at the end
 	LsKind    string  // kind of linear solver used
 	Implicit  bool    // method is implicit
+
+	// timing (see Config.SetTiming)
+	Tfcn    time.Duration // time spent in fcn evaluations (including those of numerical Jacobians)
+	Tjac    time.Duration // time spent in the analytical Jacobian function
+	Tlinsol time.Duration // time spent in linear solvers (factorisations and solutions)
+	Ttotal  time.Duration // total time spent in Solve
+
+	// stopwatches [nil if timing is not active]
+	swFcn    *utl.Stopwatch
+	swJac    *utl.Stopwatch
+	swLinsol *utl.Stopwatch
 }
 
 // NewStat returns a new structure
@@ -39,6 +55,31 @@ func (o *Stat) Reset() {
 	o.Ndecomp = 0
 	o.Nlinsol = 0
 	o.Nitmax = 0
+	o.Tfcn = 0
+	o.Tjac = 0
+	o.Tlinsol = 0
+	o.Ttotal = 0
+	o.swFcn.Reset()
+	o.swJac.Reset()
+	o.swLinsol.Reset()
+}
+
+// initTiming allocates the stopwatches
+func (o *Stat) initTiming() {
+	o.swFcn = new(utl.Stopwatch)
+	o.swJac = new(utl.Stopwatch)
+	o.swLinsol = new(utl.Stopwatch)
+}
+
+// collectTiming copies the accumulated times from the stopwatches
+func (o *Stat) collectTiming(total time.Duration) {
+	if o.swFcn == nil {
+		return
+	}
+	o.Tfcn = o.swFcn.Elapsed()
+	o.Tjac = o.swJac.Elapsed()
+	o.Tlinsol = o.swLinsol.Elapsed()
+	o.Ttotal = total
 }
 
 // Print prints information about the solution process
@@ -59,4 +100,12 @@ func (o *Stat) Print(extra bool) {
 		io.Pf("optimal step size Hopt    = %g\n", o.Hopt)
 		io.Pf("kind of linear solver     = %q\n", o.LsKind)
 	}
+	if o.swFcn != nil {
+		io.Pf("time spent in fcn         = %v\n", o.Tfcn)
+		if o.Implicit {
+			io.Pf("time spent in Jacobian    = %v\n", o.Tjac)
+			io.Pf("time spent in linsolver   = %v\n", o.Tlinsol)
+		}
+		io.Pf("total time                = %v\n", o.Ttotal)
+	}
 }
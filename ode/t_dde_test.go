@@ -0,0 +1,112 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ode
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/plt"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestDde01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Dde01. oscillatory solution: y' = -y(x-π/2)")
+
+	// y = cos(x) ⇒ y(x-π/2) = sin(x) = -y'
+	fcn := func(f la.Vector, x float64, y, ylag la.Vector) {
+		f[0] = -ylag[0]
+	}
+	history := func(x float64) la.Vector {
+		return []float64{math.Cos(x)}
+	}
+	tau, xf := math.Pi/2.0, 4.0*math.Pi
+
+	// solve
+	for _, method := range []string{"dopri5", "dopri8"} {
+		sol, err := SolveDDE(method, fcn, history, tau, xf, 1e-10)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		io.Pforan("%s: y(xf) = %v  nfeval = %d  naccepted = %d\n", method, sol.Y, sol.Stat.Nfeval, sol.Stat.Naccepted)
+		chk.Float64(tst, method+": y(xf)", 1e-8, sol.Y[0], math.Cos(xf))
+
+		// dense output
+		y := la.NewVector(1)
+		X := utl.LinSpace(-1, xf, 41)
+		Y := make([]float64, len(X))
+		for i, x := range X {
+			sol.Eval(y, x)
+			Y[i] = y[0]
+			chk.AnaNum(tst, io.Sf("y(%.3f)", x), 1e-7, y[0], math.Cos(x), chk.Verbose)
+		}
+
+		// plot
+		if chk.Verbose && method == "dopri5" {
+			plt.Reset(true, nil)
+			xx := utl.LinSpace(-1, xf, 201)
+			plt.Plot(xx, utl.GetMapped(xx, math.Cos), &plt.A{C: "k", L: "cos(x)", NoClip: true})
+			plt.Plot(X, Y, &plt.A{C: "r", M: ".", Ls: "none", L: method, NoClip: true})
+			plt.Gll("$x$", "$y$", nil)
+			plt.Save("/tmp/gosl/ode", "dde01")
+		}
+	}
+}
+
+func TestDde02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Dde02. discontinuous derivatives: y' = -y(x-1)")
+
+	// with y = 1 for x ≤ 0, the solution is piecewise polynomial:
+	//  y = Σ_{k=0}^{n} (-1)ᵏ (x-k+1)ᵏ / k!   for   n-1 ≤ x ≤ n
+	yana := func(x float64) (res float64) {
+		fact := 1.0
+		for k := 0; float64(k-1) <= x; k++ {
+			if k > 0 {
+				fact *= float64(k)
+			}
+			res += math.Pow(-1, float64(k)) * math.Pow(x-float64(k)+1, float64(k)) / fact
+		}
+		return
+	}
+	fcn := func(f la.Vector, x float64, y, ylag la.Vector) {
+		f[0] = -ylag[0]
+	}
+	history := func(x float64) la.Vector {
+		return []float64{1}
+	}
+
+	// solve
+	sol, err := SolveDDE("dopri5", fcn, history, 1, 5, 1e-10)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("y(xf) = %v  nfeval = %d  naccepted = %d\n", sol.Y, sol.Stat.Nfeval, sol.Stat.Naccepted)
+	chk.Float64(tst, "y(xf)", 1e-9, sol.Y[0], yana(5))
+	y := la.NewVector(1)
+	for _, x := range []float64{0.5, 1, 1.5, 2, 2.7, 3, 3.3, 4.5} {
+		sol.Eval(y, x)
+		chk.AnaNum(tst, io.Sf("y(%g)", x), 1e-9, y[0], yana(x), chk.Verbose)
+	}
+
+	// errors
+	if _, err = SolveDDE("dopri5", fcn, history, 0, 5, 0); err == nil {
+		tst.Errorf("zero delay should cause an error\n")
+	}
+	if _, err = SolveDDE("radau5", fcn, history, 1, 5, 0); err == nil {
+		tst.Errorf("implicit method should cause an error\n")
+	}
+	if _, err = SolveDDE("fehlberg4", fcn, history, 1, 5, 0); err == nil {
+		tst.Errorf("method without dense output should cause an error\n")
+	}
+}
@@ -5,6 +5,7 @@
 package ode
 
 import (
+	"math"
 	"testing"
 
 	"github.com/cpmech/gosl/chk"
@@ -106,3 +107,55 @@ func TestDoPri802(tst *testing.T) {
 		plt.Save("/tmp/gosl/ode", "dopri802")
 	}
 }
+
+func TestDoPri803(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("DoPri803. DOP853. convergence and dense output")
+
+	// harmonic oscillator: y0 = sin(x), y1 = cos(x)
+	fcn := func(f la.Vector, h, x float64, y la.Vector) {
+		f[0] = y[1]
+		f[1] = -y[0]
+	}
+	xf := math.Pi
+
+	// fixed steps: error must decrease as h⁸
+	var errPrev float64
+	for k, nsteps := range []int{4, 8, 16} {
+		conf := NewConfig("dop853", "", nil)
+		conf.SetFixedH(xf/float64(nsteps), xf)
+		sol := NewSolver(2, conf, fcn, nil, nil)
+		y := la.NewVectorSlice([]float64{0, 1})
+		sol.Solve(y, 0, xf)
+		sol.Free()
+		err := math.Max(math.Abs(y[0]-math.Sin(xf)), math.Abs(y[1]-math.Cos(xf)))
+		if k > 0 {
+			order := math.Log2(errPrev / err)
+			io.Pforan("nsteps = %2d  error = %.3e  order = %.4f\n", nsteps, err, order)
+			if order < 7.8 {
+				tst.Errorf("observed order of convergence is too low: %g\n", order)
+			}
+		}
+		errPrev = err
+	}
+
+	// tight tolerance with dense output
+	conf := NewConfig("dop853", "", nil)
+	conf.SetTol(1e-13)
+	conf.SetDenseOut(true, 0.25, xf, nil)
+	sol := NewSolver(2, conf, fcn, nil, nil)
+	defer sol.Free()
+	y := la.NewVectorSlice([]float64{0, 1})
+	sol.Solve(y, 0, xf)
+	sol.Stat.Print(false)
+	chk.Float64(tst, "y0(xf)", 1e-12, y[0], math.Sin(xf))
+	chk.Float64(tst, "y1(xf)", 1e-12, y[1], math.Cos(xf))
+	X := sol.Out.GetDenseX()
+	Y0 := sol.Out.GetDenseY(0)
+	Y1 := sol.Out.GetDenseY(1)
+	for i, x := range X {
+		chk.AnaNum(tst, io.Sf("y0(%.2f)", x), 1e-10, Y0[i], math.Sin(x), chk.Verbose)
+		chk.AnaNum(tst, io.Sf("y1(%.2f)", x), 1e-10, Y1[i], math.Cos(x), chk.Verbose)
+	}
+}
@@ -5,6 +5,7 @@
 package ode
 
 import (
+	"math"
 	"testing"
 
 	"github.com/cpmech/gosl/chk"
@@ -71,3 +72,150 @@ func TestHL01(tst *testing.T) {
 		plt.Save("/tmp/gosl/ode", "hl01")
 	}
 }
+
+func TestHL03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("HL03. ensemble of initial values")
+
+	// Van der Pol oscillator with ε = 1
+	p := ProbVanDerPol(1, false)
+
+	// initial values
+	ntraj := 7
+	Y0 := la.NewMatrix(p.Ndim, ntraj)
+	for j := 0; j < ntraj; j++ {
+		Y0.Set(0, j, 0.5+0.25*float64(j))
+		Y0.Set(1, j, -0.1*float64(j))
+	}
+
+	// solve ensembles and individual problems
+	for _, method := range []string{"dopri5", "radau5"} {
+		tol := 1e-8
+		Yf, stats, err := SolveEnsemble(method, p.Fcn, Y0, p.Xf, tol, 3)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		chk.Int(tst, "number of stats", len(stats), ntraj)
+		for j := 0; j < ntraj; j++ {
+			y := la.NewVectorSlice(Y0.GetCol(j))
+			stat, _ := Solve(method, p.Fcn, nil, y, p.Xf, 0, tol, tol, true, false, false, false)
+			chk.Array(tst, io.Sf("%s: y%d", method, j), 1e-15, Yf.GetCol(j), y)
+			chk.Int(tst, io.Sf("%s: Nsteps%d", method, j), stats[j].Nsteps, stat.Nsteps)
+		}
+		chk.Array(tst, "Y0 (unchanged)", 1e-15, Y0.GetCol(ntraj-1), []float64{2, -0.6})
+	}
+
+	// errors
+	if _, _, err := SolveEnsemble("dopri5", p.Fcn, nil, 1, 0, 0); err == nil {
+		tst.Errorf("nil Y0 should cause an error\n")
+	}
+	bad := func(f la.Vector, h, x float64, y la.Vector) {
+		chk.Panic("bad function\n")
+	}
+	if _, _, err := SolveEnsemble("dopri5", bad, Y0, 1, 0, 0); err == nil {
+		tst.Errorf("failing fcn should cause an error\n")
+	}
+}
+
+func TestHL04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("HL04. scalar problems")
+
+	// dy/dx = y⋅cos(x) - x  with  y(0) = 1
+	f := func(x, y float64) float64 { return y*math.Cos(x) - x }
+	fcn := func(dydx la.Vector, h, x float64, y la.Vector) {
+		dydx[0] = f(x, y[0])
+	}
+	xf, tol := 2.0, 1e-8
+
+	// compare with general solver
+	for _, method := range []string{"dopri5", "dopri8", "fehlberg4", "radau5"} {
+		yf, stat, err := SolveScalar(method, f, 1, xf, tol)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		y := la.NewVectorSlice([]float64{1})
+		numJac := method == "radau5"
+		ref, _ := Solve(method, fcn, nil, y, xf, 0, tol, tol, numJac, false, false, false)
+		io.Pforan("%10s: yf = %23.15e  nsteps = %3d  nfeval = %4d (general: %4d)\n", method, yf, stat.Nsteps, stat.Nfeval, ref.Nfeval)
+		chk.Float64(tst, method+": yf", 1e-14, yf, y[0])
+		chk.Int(tst, method+": Naccepted", stat.Naccepted, ref.Naccepted)
+		chk.Int(tst, method+": Nrejected", stat.Nrejected, ref.Nrejected)
+	}
+
+	// exponential decay
+	yf, _, err := SolveScalar("dopri8", func(x, y float64) float64 { return -y }, 1, 3, 1e-10)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "exp(-3)", 1e-9, yf, math.Exp(-3))
+
+	// errors
+	if _, _, err = SolveScalar("rk4", f, 1, xf, tol); err == nil {
+		tst.Errorf("fixed-steps method should cause an error\n")
+	}
+	if _, _, err = SolveScalar("unknown", f, 1, xf, tol); err == nil {
+		tst.Errorf("unknown method should cause an error\n")
+	}
+}
+
+func TestHL05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("HL05. time series of components")
+
+	// harmonic oscillator and exponential decay: y = {cos(x), -sin(x), exp(-x)}
+	fcn := func(f la.Vector, dx, x float64, y la.Vector) {
+		f[0] = y[1]
+		f[1] = -y[0]
+		f[2] = -y[2]
+	}
+	y := la.NewVectorSlice([]float64{1, 0, 1})
+	xf, dx := 1.0, 0.125
+
+	// dense output
+	_, out := Solve("dopri5", fcn, nil, y.GetCopy(), xf, dx, 1e-10, 1e-10, false, false, true, true)
+	table := out.GetDenseYtable()
+	xs := out.Times()
+	io.Pforan("xs = %v\n", xs)
+	chk.Array(tst, "xs(dense)", 1e-15, xs, []float64{0, 0.125, 0.25, 0.375, 0.5, 0.625, 0.75, 0.875, 1})
+	for i := 0; i < 3; i++ {
+		xi, yi := out.Component(i)
+		chk.Array(tst, io.Sf("x%d(dense)", i), 1e-15, xi, xs)
+		chk.Int(tst, io.Sf("len(y%d)", i), len(yi), len(table))
+		for j := 0; j < len(table); j++ {
+			chk.Float64(tst, io.Sf("y%d[%d](dense)", i, j), 1e-15, yi[j], table[j][i])
+		}
+	}
+
+	// compare with analytical solution
+	_, y1 := out.Component(1)
+	_, y2 := out.Component(2)
+	for j, x := range xs {
+		chk.Float64(tst, io.Sf("y1(%g)", x), 1e-9, y1[j], -math.Sin(x))
+		chk.Float64(tst, io.Sf("y2(%g)", x), 1e-9, y2[j], math.Exp(-x))
+	}
+
+	// copies
+	xs[0] = 123
+	chk.Float64(tst, "DenseX[0] (unchanged)", 1e-15, out.DenseX[0], 0)
+
+	// step output only
+	_, out = Solve("dopri5", fcn, nil, y.GetCopy(), xf, 0, 1e-6, 1e-6, false, false, true, false)
+	table = out.GetStepYtable()
+	xs, y1 = out.Component(1)
+	chk.Array(tst, "xs(step)", 1e-15, xs, out.GetStepX())
+	chk.Int(tst, "len(y1)", len(y1), len(table))
+	for j := 0; j < len(table); j++ {
+		chk.Float64(tst, io.Sf("y1[%d](step)", j), 1e-15, y1[j], table[j][1])
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	out.Component(3)
+}
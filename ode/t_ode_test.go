@@ -5,11 +5,15 @@
 package ode
 
 import (
+	"context"
+	"math"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
 	"github.com/cpmech/gosl/plt"
 )
 
@@ -275,3 +279,274 @@ func TestOde04(tst *testing.T) {
 		plt.Save("/tmp/gosl/ode", "ode4")
 	}
 }
+
+func TestOde05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ode05: parameter sweep with cloned solvers")
+
+	// problem: dy/dx = -k⋅y  ⇒  y = exp(-k⋅x)
+	xf := 1.0
+	problem := func(k float64) (fcn Func, jac JacF) {
+		fcn = func(f la.Vector, h, x float64, y la.Vector) {
+			f[0] = -k * y[0]
+		}
+		jac = func(dfdy *la.Triplet, h, x float64, y la.Vector) {
+			if dfdy.Max() == 0 {
+				dfdy.Init(1, 1, 1)
+			}
+			dfdy.Start()
+			dfdy.Put(0, 0, -k)
+		}
+		return
+	}
+
+	// run clones concurrently
+	for _, method := range []string{"dopri5", "radau5"} {
+		conf := NewConfig(method, "", nil)
+		conf.SetTol(1e-8)
+		fcn, jac := problem(1)
+		base := NewSolver(1, conf, fcn, jac, nil)
+		nk := 16
+		res := make([]float64, nk)
+		var wg sync.WaitGroup
+		for i := 0; i < nk; i++ {
+			sol := base.Clone()
+			sol.SetFunc(problem(float64(i + 1)))
+			wg.Add(1)
+			go func(i int, sol *Solver) {
+				defer wg.Done()
+				defer sol.Free()
+				y := la.NewVectorSlice([]float64{1})
+				sol.Solve(y, 0, xf)
+				res[i] = y[0]
+			}(i, sol)
+		}
+		wg.Wait()
+		base.Free()
+
+		// check
+		for i := 0; i < nk; i++ {
+			k := float64(i + 1)
+			chk.Float64(tst, io.Sf("%s: y(k=%g)", method, k), 1e-6, res[i], math.Exp(-k*xf))
+		}
+	}
+}
+
+func TestOde06(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ode06: cancellation with context")
+
+	// problem: dy/dx = -y  ⇒  y = exp(-x)
+	xf := 10.0
+	neval, nmax := 0, 50
+	var cancel context.CancelFunc
+	fcn := func(f la.Vector, h, x float64, y la.Vector) {
+		neval++
+		if neval == nmax && cancel != nil {
+			cancel()
+		}
+		f[0] = -y[0]
+	}
+
+	// variable and fixed steps
+	for _, method := range []string{"dopri5", "radau5", "rk4"} {
+		conf := NewConfig(method, "", nil)
+		conf.SetTol(1e-10)
+		if method == "rk4" {
+			conf.SetFixedH(0.01, xf)
+		}
+		sol := NewSolver(1, conf, fcn, nil, nil)
+
+		// without cancellation
+		neval, cancel = 0, nil
+		y := la.NewVectorSlice([]float64{1})
+		err := sol.SolveWithContext(context.Background(), y, 0, xf)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		nsteps := sol.Stat.Nsteps
+		io.Pforan("%7s: y(xf) = %v  nsteps = %d\n", method, y[0], nsteps)
+		chk.Float64(tst, method+": y(xf)", 1e-6, y[0], math.Exp(-xf))
+		yref := la.NewVectorSlice([]float64{1})
+		sol.Solve(yref, 0, xf)
+		chk.Array(tst, method+": y(xf) (Solve)", 1e-17, y, yref)
+
+		// cancelled by function
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		neval = 0
+		y[0] = 1
+		err = sol.SolveWithContext(ctx, y, 0, xf)
+		io.Pforan("%7s: err = %v  nsteps = %d  y = %v\n", method, err, sol.Stat.Nsteps, y[0])
+		if err != context.Canceled {
+			tst.Errorf("%s: error should be context.Canceled. err = %v\n", method, err)
+		}
+		if sol.Stat.Nsteps >= nsteps || neval > nmax+10 {
+			tst.Errorf("%s: solver should have stopped promptly\n", method)
+		}
+		cancel()
+
+		// deadline exceeded
+		ctx, cancel = context.WithTimeout(context.Background(), 0)
+		<-ctx.Done()
+		neval = 0
+		y[0] = 1
+		err = sol.SolveWithContext(ctx, y, 0, xf)
+		if err != context.DeadlineExceeded {
+			tst.Errorf("%s: error should be context.DeadlineExceeded. err = %v\n", method, err)
+		}
+		chk.Float64(tst, method+": y (unchanged)", 1e-17, y[0], 1)
+		cancel()
+		sol.Free()
+	}
+}
+
+func TestOde07(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ode07: invariants of Kepler's problem")
+
+	// Kepler's problem: y = {q0, q1, p0, p1} with eccentricity e
+	e := 0.5
+	fcn := func(f la.Vector, h, x float64, y la.Vector) {
+		r3 := math.Pow(y[0]*y[0]+y[1]*y[1], 1.5)
+		f[0] = y[2]
+		f[1] = y[3]
+		f[2] = -y[0] / r3
+		f[3] = -y[1] / r3
+	}
+	energy := func(x float64, y la.Vector) float64 {
+		return (y[2]*y[2]+y[3]*y[3])/2.0 - 1.0/math.Sqrt(y[0]*y[0]+y[1]*y[1])
+	}
+	momentum := func(x float64, y la.Vector) float64 {
+		return y[0]*y[3] - y[1]*y[2]
+	}
+	y0 := la.NewVectorSlice([]float64{1 - e, 0, 0, math.Sqrt((1 + e) / (1 - e))})
+	chk.Float64(tst, "H0", 1e-15, energy(0, y0), -0.5)
+	xf := 64.0 // about 10 periods
+
+	// methods
+	for _, tol := range []float64{1e-6, 1e-9} {
+		for _, method := range []string{"dopri5", "dopri8", "radau5", "rk4"} {
+			conf := NewConfig(method, "", nil)
+			conf.SetTol(tol)
+			conf.NmaxSS = 10000
+			if method == "rk4" {
+				conf.SetFixedH(math.Pow(2, math.Round(math.Log10(tol))), xf) // h = 1/64 or 1/512
+			}
+			conf.SetInvariant("energy", energy)
+			conf.SetInvariant("momentum", momentum)
+			conf.SetInvariant("energy", energy) // replace
+			sol := NewSolver(4, conf, fcn, nil, nil)
+			y := y0.GetCopy()
+			sol.Solve(y, 0, xf)
+
+			// check recorded values
+			nsteps := sol.Stat.Naccepted
+			if conf.fixed {
+				nsteps = sol.Stat.Nsteps
+			}
+			H := sol.Out.GetInvariant("energy")
+			chk.Int(tst, "number of invariants", len(sol.Out.Invariants), 2)
+			chk.Int(tst, method+": len(H)", len(H), nsteps+1)
+			chk.Float64(tst, method+": H[0]", 1e-15, H[0], -0.5)
+			chk.Float64(tst, method+": H[last]", 1e-15, H[len(H)-1], energy(xf, y))
+			drift := 0.0
+			for _, h := range H {
+				drift = math.Max(drift, math.Abs(h+0.5))
+			}
+			dH, dL := sol.Out.InvariantDrift("energy"), sol.Out.InvariantDrift("momentum")
+			chk.Float64(tst, method+": drift(H)", 1e-15, dH, drift)
+			io.Pforan("tol = %g  %6s: nsteps = %5d  drift(H) = %.3e  drift(L) = %.3e\n", tol, method, nsteps, dH, dL)
+			if dH > 1e3*tol || dL > 1e3*tol {
+				tst.Errorf("%s: drift of invariants is too large\n", method)
+			}
+
+			// solve again: values are reset
+			y = y0.GetCopy()
+			sol.Solve(y, 0, xf)
+			chk.Int(tst, method+": len(H) (again)", len(sol.Out.GetInvariant("energy")), nsteps+1)
+			sol.Free()
+		}
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	sol := NewSolver(4, NewConfig("dopri5", "", nil), fcn, nil, nil)
+	defer sol.Free()
+	sol.Out.InvariantDrift("energy")
+}
+
+func TestOde08(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ode08: pendulum in Cartesian coordinates with projection")
+
+	// pendulum with y = {q0, q1, v0, v1}, length L and gravity grav; the Lagrange multiplier λ
+	// is computed from the second derivative of the constraint |q|² = L²
+	L, grav := 1.0, 9.81
+	fcn := func(f la.Vector, h, x float64, y la.Vector) {
+		λ := (y[2]*y[2] + y[3]*y[3] - grav*y[1]) / (y[0]*y[0] + y[1]*y[1])
+		f[0] = y[2]
+		f[1] = y[3]
+		f[2] = -λ * y[0]
+		f[3] = -λ*y[1] - grav
+	}
+
+	// constraints: position and velocity levels
+	g := func(g, y la.Vector) {
+		g[0] = y[0]*y[0] + y[1]*y[1] - L*L
+		g[1] = y[0]*y[2] + y[1]*y[3]
+	}
+	dgdy := func(G *la.Matrix, y la.Vector) {
+		G.Set(0, 0, 2*y[0])
+		G.Set(0, 1, 2*y[1])
+		G.Set(0, 2, 0)
+		G.Set(0, 3, 0)
+		G.Set(1, 0, y[2])
+		G.Set(1, 1, y[3])
+		G.Set(1, 2, y[0])
+		G.Set(1, 3, y[1])
+	}
+	length := func(x float64, y la.Vector) float64 {
+		return math.Sqrt(y[0]*y[0]+y[1]*y[1]) - L
+	}
+
+	// solve with and without projection
+	y0 := la.NewVectorSlice([]float64{L, 0, 0, 0}) // horizontal position at rest
+	xf := 100.0
+	for _, method := range []string{"dopri5", "rk4"} {
+		var drift [2]float64
+		for k, withProj := range []bool{false, true} {
+			conf := NewConfig(method, "", nil)
+			conf.SetTol(1e-6)
+			conf.NmaxSS = 100000
+			if method == "rk4" {
+				conf.SetFixedH(1.0/64.0, xf)
+			}
+			conf.SetInvariant("length", length)
+			if withProj {
+				conf.SetProjection(2, g, dgdy)
+			}
+			sol := NewSolver(4, conf, fcn, nil, nil)
+			y := y0.GetCopy()
+			sol.Solve(y, 0, xf)
+			drift[k] = sol.Out.InvariantDrift("length")
+			io.Pforan("%6s: projection = %5v  nfeval = %6d  drift(length) = %.3e\n", method, withProj, sol.Stat.Nfeval, drift[k])
+			sol.Free()
+		}
+		if drift[0] < 1e-5 {
+			tst.Errorf("%s: drift without projection should be noticeable\n", method)
+		}
+		if drift[1] > 1e-10 {
+			tst.Errorf("%s: constraint must be satisfied with projection\n", method)
+		}
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	NewConfig("dopri5", "", nil).SetProjection(0, g, dgdy)
+}
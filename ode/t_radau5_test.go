@@ -6,7 +6,10 @@ package ode
 
 import (
 	"encoding/json"
+	"math"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/io"
@@ -143,3 +146,151 @@ func readRefData(fn string) (o *refData) {
 	}
 	return
 }
+
+func TestRadau503(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Radau503. verification of analytical Jacobian")
+
+	// correct Jacobian
+	p := ProbVanDerPol(1, false)
+	conf := NewConfig("radau5", "", nil)
+	conf.SetCheckJac(true, 0)
+	sol := NewSolver(p.Ndim, conf, p.Fcn, p.Jac, nil)
+	sol.Solve(p.Y, 0.0, p.Xf)
+	sol.Free()
+	io.Pforan("correct Jacobian: nsteps = %d\n", sol.Stat.Nsteps)
+
+	// wrong Jacobian: dfdy[1][0] has the wrong sign
+	p = ProbVanDerPol(1, false)
+	wrong := func(dfdy *la.Triplet, dx, x float64, y la.Vector) {
+		if dfdy.Max() == 0 {
+			dfdy.Init(2, 2, 4)
+		}
+		dfdy.Start()
+		dfdy.Put(0, 0, 0.0)
+		dfdy.Put(0, 1, 1.0)
+		dfdy.Put(1, 0, 2.0*y[0]*y[1]+1.0)
+		dfdy.Put(1, 1, 1.0-y[0]*y[0])
+	}
+	for _, method := range []string{"radau5", "bweuler"} {
+		msg := ""
+		func() {
+			defer func() {
+				if err := recover(); err != nil {
+					msg = io.Sf("%v", err)
+				}
+			}()
+			conf = NewConfig(method, "", nil)
+			conf.SetCheckJac(true, 1e-6)
+			if method == "bweuler" {
+				conf.SetFixedH(0.1, p.Xf)
+			}
+			sol = NewSolver(p.Ndim, conf, p.Fcn, wrong, nil)
+			defer sol.Free()
+			sol.Solve(p.Y, 0.0, p.Xf)
+		}()
+		io.Pforan("%s: %s\n", method, msg)
+		if msg == "" {
+			tst.Errorf("%s: wrong Jacobian should have been detected\n", method)
+			return
+		}
+		if !strings.Contains(msg, "dfdy[1][0]") {
+			tst.Errorf("%s: worst entry should be dfdy[1][0]\n", method)
+		}
+	}
+}
+
+func TestRadau504(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Radau504. timing")
+
+	// solve with and without analytical Jacobian
+	for _, numJac := range []bool{false, true} {
+		p := ProbVanDerPol(1e-6, false)
+		conf := NewConfig("radau5", "", nil)
+		conf.SetTiming(true)
+		jac := p.Jac
+		if numJac {
+			jac = nil
+		}
+		sol := NewSolver(p.Ndim, conf, p.Fcn, jac, nil)
+		sol.Solve(p.Y, 0.0, p.Xf)
+		sol.Free()
+		if chk.Verbose {
+			sol.Stat.Print(true)
+		}
+
+		// check
+		st := sol.Stat
+		if st.Tfcn <= 0 || st.Tlinsol <= 0 || st.Ttotal <= 0 {
+			tst.Errorf("timing fields should be populated: %v, %v, %v\n", st.Tfcn, st.Tlinsol, st.Ttotal)
+			return
+		}
+		if !numJac && st.Tjac <= 0 {
+			tst.Errorf("time spent in Jacobian should be positive\n")
+			return
+		}
+		sum := st.Tfcn + st.Tjac + st.Tlinsol
+		io.Pforan("numJac = %v: sum = %v  total = %v  ratio = %.3f\n", numJac, sum, st.Ttotal, float64(sum)/float64(st.Ttotal))
+		if sum > st.Ttotal || sum < st.Ttotal/10 {
+			tst.Errorf("sum of times should be (roughly) equal to the total time\n")
+		}
+	}
+}
+
+func TestRadau505(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Radau505. 1D diffusion with banded Jacobian")
+
+	// problem: ∂u/∂t = ∂²u/∂x² in [0,1] with u(0)=u(1)=0 and u(x,0) = sin(πx)
+	n := 200 // number of interior nodes
+	dx := 1.0 / float64(n+1)
+	ncalls := 0
+	fcn := func(f la.Vector, h, t float64, u la.Vector) {
+		ncalls++
+		for i := 0; i < n; i++ {
+			ul, ur := 0.0, 0.0
+			if i > 0 {
+				ul = u[i-1]
+			}
+			if i < n-1 {
+				ur = u[i+1]
+			}
+			f[i] = (ul - 2.0*u[i] + ur) / (dx * dx)
+		}
+	}
+	tf := 0.1
+
+	// solve with full and banded (numerical) Jacobians
+	var res [2]la.Vector
+	var calls [2]int
+	for k, banded := range []bool{false, true} {
+		conf := NewConfig("radau5", "", nil)
+		conf.SetTol(1e-6)
+		if banded {
+			conf.SetJacBand(1, 1)
+		}
+		sol := NewSolver(n, conf, fcn, nil, nil)
+		u := la.NewVector(n)
+		for i := 0; i < n; i++ {
+			u[i] = math.Sin(math.Pi * float64(i+1) * dx)
+		}
+		ncalls = 0
+		t0 := time.Now()
+		sol.Solve(u, 0, tf)
+		io.Pforan("banded = %5v: elapsed time = %v  ncalls = %d  njeval = %d\n", banded, time.Now().Sub(t0), ncalls, sol.Stat.Njeval)
+		sol.Free()
+		res[k], calls[k] = u, ncalls
+	}
+
+	// check
+	chk.Array(tst, "u(banded) = u(full)", 1e-10, res[1], res[0])
+	umid := math.Exp(-math.Pi*math.Pi*tf) * math.Sin(math.Pi*0.5)
+	chk.Float64(tst, "u(x=0.5)", 1e-4, res[1][n/2], umid)
+	if calls[1] >= calls[0] {
+		tst.Errorf("banded Jacobian should require fewer calls to fcn: %d ≥ %d\n", calls[1], calls[0])
+	}
+}
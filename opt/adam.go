@@ -0,0 +1,103 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/la"
+)
+
+// Adam implements the Adam (adaptive moment estimation) stochastic optimizer for objectives given
+// by sums over batches of data (Algorithm 1 of [1]):
+//
+//   m ← β1⋅m + (1 - β1)⋅g         with  g = g(x, batch)
+//   v ← β2⋅v + (1 - β2)⋅g²        (element-wise)
+//   x ← x - α⋅m̂ / (√v̂ + ε)        with  m̂ = m / (1 - β1ᵗ)  and  v̂ = v / (1 - β2ᵗ)
+//
+//   where α is the learning rate and t is the number of updates
+//
+//   NOTE: Check Stochastic to see how to set the number of epochs, learning rate schedule, loss
+//         function and history
+//
+//   REFERENCES:
+//   [1] Kingma DP and Ba J (2015) Adam: A method for stochastic optimization. In: Proceedings of
+//       the 3rd International Conference on Learning Representations (ICLR). arXiv:1412.6980
+//
+type Adam struct {
+
+	// merge properties
+	Stochastic // auxiliary object with configuration and statistics
+
+	// configuration
+	Beta1 float64 // β1: decay rate of the first moment (0 ≤ β1 < 1) [default = 0.9]
+	Beta2 float64 // β2: decay rate of the second moment (0 ≤ β2 < 1) [default = 0.999]
+	Eps   float64 // ε: small number to prevent division by zero [default = 1e-8]
+
+	// internal
+	m la.Vector // first moment
+	v la.Vector // second moment
+}
+
+// NewAdam returns a new Adam optimizer
+//   ndim     -- length of x
+//   nbatches -- number of batches
+//   gfcn     -- stochastic gradient g(x, batch)
+func NewAdam(ndim, nbatches int, gfcn StochasticGrad) (o *Adam) {
+	o = new(Adam)
+	o.initStochastic(ndim, nbatches, gfcn, 0.001)
+	o.Beta1 = 0.9
+	o.Beta2 = 0.999
+	o.Eps = 1e-8
+	o.m = la.NewVector(ndim)
+	o.v = la.NewVector(ndim)
+	return
+}
+
+// Min minimizes the objective function
+//
+//  Input:
+//    x -- [ndim] initial starting point (will be modified)
+//    params -- [may be nil] optional parameters. e.g. "epochs", "alpha". Example:
+//                 params := dbf.NewParams(
+//                     &dbf.P{N: "epochs", V: 100},
+//                     &dbf.P{N: "alpha", V: 0.001},
+//                     &dbf.P{N: "beta1", V: 0.9},
+//                     &dbf.P{N: "beta2", V: 0.999},
+//                     &dbf.P{N: "eps", V: 1e-8},
+//                     &dbf.P{N: "hist", V: 1},
+//                 )
+//
+//  Output:
+//    loss -- running loss of the last epoch (NaN if Loss == nil)
+//    x -- [modify input] final x
+//
+func (o *Adam) Min(x la.Vector, params dbf.Params) (loss float64) {
+
+	// set parameters
+	o.setParams(params)
+	o.Beta1 = params.GetValueOrDefault("beta1", o.Beta1)
+	o.Beta2 = params.GetValueOrDefault("beta2", o.Beta2)
+	o.Eps = params.GetValueOrDefault("eps", o.Eps)
+	if o.Beta1 < 0 || o.Beta1 >= 1 || o.Beta2 < 0 || o.Beta2 >= 1 || o.Eps <= 0 {
+		chk.Panic("coefficients must satisfy 0 ≤ β1 < 1, 0 ≤ β2 < 1 and ε > 0. β1=%g, β2=%g and ε=%g are invalid\n", o.Beta1, o.Beta2, o.Eps)
+	}
+
+	// run
+	o.m.Fill(0)
+	o.v.Fill(0)
+	return o.run(x, func(x, g la.Vector, α float64) {
+		t := float64(o.NumIter + 1)
+		c1 := 1.0 - math.Pow(o.Beta1, t)
+		c2 := 1.0 - math.Pow(o.Beta2, t)
+		for i := 0; i < len(x); i++ {
+			o.m[i] = o.Beta1*o.m[i] + (1.0-o.Beta1)*g[i]
+			o.v[i] = o.Beta2*o.v[i] + (1.0-o.Beta2)*g[i]*g[i]
+			x[i] -= α * (o.m[i] / c1) / (math.Sqrt(o.v[i]/c2) + o.Eps)
+		}
+	})
+}
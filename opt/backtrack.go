@@ -0,0 +1,157 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// Backtrack finds the scalar 'a' that gives a sufficient reduction of f({x}+a⋅{u}) using the
+// backtracking line search (Algorithm 3.1, page 37 of [1]). With φ(a) = f({x}+a⋅{u}), the step
+// is reduced from the initial step a₀ as a ← τ⋅a until the Armijo condition is satisfied:
+//
+//   φ(a) ≤ φ(0) + c⋅a⋅φ'(0)
+//
+//   NOTE: (1) only function evaluations are needed during the search; thus, this method is
+//             cheaper than Wolfe (LineSearch) and HagerZhang if the gradient is expensive
+//         (2) φ(0) and φ'(0) = ∇f(x)⋅u may be given by SetPhi0 before calling Find; e.g. by the
+//             optimizers that have already computed f(x) and ∇f(x). Otherwise, Find computes them
+//             with ffcn and Jfcn
+//
+//   REFERENCES:
+//   [1] Nocedal, J. and Wright, S. (2006) Numerical Optimization.
+//       Springer Series in Operations Research. 2nd Edition. Springer. 664p
+//
+type Backtrack struct {
+
+	// configuration
+	MaxIt  int     // max number of step reductions [default = 50]
+	Alpha0 float64 // a₀: initial step [default = 1]
+	Tau    float64 // τ: reduction factor (0 < τ < 1) [default = 0.5]
+	Coef   float64 // c: Armijo ("sufficient decrease") coefficient (0 < c < 1) [default = 1e-4]
+
+	// statistics
+	NumFeval int // number of calls to Ffcn (function evaluations)
+	NumJeval int // number of calls to Jfcn (Jacobian evaluations)
+	NumIter  int // number of iterations from last call to Find
+
+	// internal
+	ffcn  fun.Sv    // scalar function of vector: y = f({x})
+	Jfcn  fun.Vv    // vector function of vector: {J} = df/d{x} @ {x} [may be nil if SetPhi0 is used]
+	xnew  la.Vector // {xnew} = {x} + a⋅{u}
+	dfdx  la.Vector // derivative df/d{x}
+	phi0  float64   // φ(0) given by SetPhi0
+	dphi0 float64   // φ'(0) given by SetPhi0
+	known bool      // φ(0) and φ'(0) have been given by SetPhi0
+}
+
+// NewBacktrack returns a new Backtrack object
+//   ndim -- length(x)
+//   ffcn -- function y = f({x})
+//   Jfcn -- Jacobian {J} = df/d{x} @ {x} [may be nil if SetPhi0 is always called before Find]
+func NewBacktrack(ndim int, ffcn fun.Sv, Jfcn fun.Vv) (o *Backtrack) {
+	o = new(Backtrack)
+	o.MaxIt = 50
+	o.Alpha0 = 1
+	o.Tau = 0.5
+	o.Coef = 1e-4
+	o.ffcn = ffcn
+	o.Jfcn = Jfcn
+	o.xnew = la.NewVector(ndim)
+	o.dfdx = la.NewVector(ndim)
+	return
+}
+
+// SetParams sets parameters
+//   Example:
+//             o.SetParams(dbf.NewParams(
+//                 &dbf.P{N: "maxitls", V: 50},
+//                 &dbf.P{N: "btalpha", V: 1},
+//                 &dbf.P{N: "bttau", V: 0.5},
+//                 &dbf.P{N: "btcoef", V: 1e-4},
+//             ))
+func (o *Backtrack) SetParams(params dbf.Params) {
+	o.MaxIt = params.GetIntOrDefault("maxitls", o.MaxIt)
+	o.Alpha0 = params.GetValueOrDefault("btalpha", o.Alpha0)
+	o.Tau = params.GetValueOrDefault("bttau", o.Tau)
+	o.Coef = params.GetValueOrDefault("btcoef", o.Coef)
+}
+
+// SetPhi0 sets φ(0) = f(x) and φ'(0) = ∇f(x)⋅u for the next call to Find; thus, Find does not
+// need to evaluate f and ∇f @ x
+func (o *Backtrack) SetPhi0(phi0, dphi0 float64) {
+	o.phi0 = phi0
+	o.dphi0 = dphi0
+	o.known = true
+}
+
+// Find finds the scalar 'a' that gives a sufficient reduction of f({x}+a⋅{u}) (Armijo
+// condition). The call signature is the same as LineSearch.Wolfe
+//
+//  Input:
+//    x -- initial point
+//    u -- direction (must be a descent direction)
+//    useFold -- estimate the initial step from fold; otherwise use a = a₀
+//    fold -- previous f(x) [used if useFold == true]
+//
+//  Output:
+//    a -- scale parameter
+//    f -- f @ a
+//    x -- x + a⋅u  [update input x]
+//
+//  NOTE: this function panics if the coefficients are invalid, if u is not a descent direction
+//        or if the Armijo condition is not satisfied after MaxIt reductions
+//
+func (o *Backtrack) Find(x, u la.Vector, useFold bool, fold float64) (a, f float64) {
+
+	// check
+	if o.Alpha0 <= 0 || o.Tau <= 0 || o.Tau >= 1 || o.Coef <= 0 || o.Coef >= 1 {
+		chk.Panic("coefficients must satisfy a₀ > 0, 0 < τ < 1 and 0 < c < 1. a₀=%g, τ=%g and c=%g are invalid\n", o.Alpha0, o.Tau, o.Coef)
+	}
+
+	// φ(0) and φ'(0)
+	o.NumFeval, o.NumJeval = 0, 0
+	phi0, dphi0 := o.phi0, o.dphi0
+	if !o.known {
+		if o.Jfcn == nil {
+			chk.Panic("Jacobian function is needed if φ(0) and φ'(0) are not given by SetPhi0\n")
+		}
+		o.NumFeval++
+		phi0 = o.ffcn(x)
+		o.NumJeval++
+		o.Jfcn(o.dfdx, x)
+		dphi0 = la.VecDot(o.dfdx, u)
+	}
+	o.known = false
+	if dphi0 >= 0 {
+		chk.Panic("direction must be a descent direction. φ'(0)=%g is invalid\n", dphi0)
+	}
+
+	// initial step
+	a = o.Alpha0
+	if useFold {
+		if a1 := 1.01 * 2 * (phi0 - fold) / dphi0; a1 > 0 {
+			a = utl.Min(a, a1)
+		}
+	}
+
+	// iterations
+	for o.NumIter = 1; o.NumIter <= o.MaxIt; o.NumIter++ {
+		la.VecAdd(o.xnew, 1, x, a, u) // xnew := x + a⋅u
+		o.NumFeval++
+		f = o.ffcn(o.xnew)
+		if f <= phi0+o.Coef*a*dphi0 {
+			copy(x, o.xnew)
+			return
+		}
+		a *= o.Tau
+	}
+	chk.Panic("backtracking line search did not converge after %d iterations. a=%g\n", o.MaxIt, a)
+	return
+}
@@ -0,0 +1,329 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/num"
+	"github.com/cpmech/gosl/rnd"
+	"github.com/cpmech/gosl/stat"
+)
+
+// BayesOpt implements the Bayesian optimization of expensive black-box objective functions within
+// a box. A Gaussian-process (GP) surrogate is fitted to the observations and the next point is
+// the maximizer of the expected improvement (EI) acquisition function:
+//
+//   EI(x) = (f⁺ - μ(x) - ξ) ⋅ Φ(z) + σ(x) ⋅ φ(z)    with    z = (f⁺ - μ(x) - ξ) / σ(x)
+//
+//  where f⁺ is the best observed value and μ, σ are the mean and standard deviation of stat.GP
+//
+//  Usage:
+//     o := NewBayesOpt(lower, upper, nil)
+//     for i := 0; i < neval; i++ {
+//         x := o.Ask()
+//         o.Tell(x, objective(x))
+//     }
+//     xbest, fbest := o.Best()
+//
+//  NOTE: (1) the kernel is evaluated with coordinates normalized to the unit box [0,1]ⁿ and
+//            observations normalized to zero mean and unit variance
+//        (2) the first Ninit points and the candidates of the EI maximization are sampled with
+//            Rng; set Rng = rnd.NewRNG(seed) for reproducible results
+//        (3) if the kernel is stat.KernelScalable, the length scale is selected among Lengths by
+//            maximizing the log marginal likelihood of the observations before each Ask
+//        (4) if the covariance matrix is not positive-definite (e.g. because of repeated points),
+//            a jitter is added to the diagonal, starting from max(10⋅Noise, 1e-10) and growing by
+//            a factor 10; the Noise field is not modified
+//
+//  Reference:
+//    [1] Jones DR, Schonlau M and Welch WJ (1998) Efficient global optimization of expensive
+//        black-box functions. Journal of Global Optimization, 13:455-492
+type BayesOpt struct {
+
+	// configuration
+	Lower     la.Vector   // [ndim] lower bounds
+	Upper     la.Vector   // [ndim] upper bounds
+	Kernel    stat.Kernel // covariance function (in normalized coordinates)
+	Noise     float64     // noise variance (nugget) added to the diagonal of the covariance matrix
+	Xi        float64     // ξ exploration parameter of EI
+	Ninit     int         // number of initial random points
+	Ncand     int         // number of random candidates to start the EI maximization
+	NumStarts int         // number of local maximizations of EI using ConjGrad
+	Lengths   []float64   // candidate length scales for stat.KernelScalable kernels; nil ⇒ do not fit
+	Rng       *rnd.RNG    // random numbers generator; nil ⇒ global generator of rnd [default = nil]
+
+	// observations
+	X []la.Vector // observed points
+	Y []float64   // observed values
+
+	// internal
+	ndim int        // space dimension
+	xs   *la.Matrix // [nobs][ndim] normalized points (rows)
+	ys   la.Vector  // normalized observations
+	ymu  float64    // mean of observations
+	ysd  float64    // standard deviation of observations
+	fmin float64    // best normalized observation
+	gp   *stat.GP   // Gaussian process of the last fit
+}
+
+// bayesMaxJitter is the max number of increases of the jitter added to the covariance matrix
+const bayesMaxJitter = 8
+
+// NewBayesOpt returns a new Bayesian optimizer
+//  lower  -- [ndim] lower bounds
+//  upper  -- [ndim] upper bounds (upper > lower)
+//  kernel -- covariance function; use nil for stat.KernelMatern52{Sigma2: 1, Length: 0.25}
+//  NOTE: the length scale of stat.KernelScalable kernels is fitted by default (see Lengths)
+func NewBayesOpt(lower, upper la.Vector, kernel stat.Kernel) (o *BayesOpt) {
+	if len(lower) < 1 || len(upper) != len(lower) {
+		chk.Panic("lower and upper bounds must have the same length ≥ 1. %d and %d are invalid\n", len(lower), len(upper))
+	}
+	for i := range lower {
+		if !(upper[i] > lower[i]) {
+			chk.Panic("upper bound must be greater than lower bound. upper[%d]=%g and lower[%d]=%g are invalid\n", i, upper[i], i, lower[i])
+		}
+	}
+	if kernel == nil {
+		kernel = stat.KernelMatern52{Sigma2: 1, Length: 0.25}
+	}
+	o = new(BayesOpt)
+	o.Lower, o.Upper, o.Kernel = lower, upper, kernel
+	o.ndim = len(lower)
+	o.Noise = 1e-6
+	o.Xi = 0.01
+	o.Ninit = 2*o.ndim + 1
+	o.Ncand = 500 * o.ndim
+	o.NumStarts = 5
+	if _, ok := kernel.(stat.KernelScalable); ok {
+		o.Lengths = []float64{0.05, 0.075, 0.1, 0.15, 0.2, 0.3, 0.5, 0.75, 1.0}
+	}
+	return
+}
+
+// Ask returns the next point to be evaluated
+func (o *BayesOpt) Ask() (x la.Vector) {
+
+	// initial random points
+	if len(o.X) < o.Ninit || len(o.X) < 2 {
+		x = la.NewVector(o.ndim)
+		for i := range x {
+			x[i] = o.Rng.Float64(o.Lower[i], o.Upper[i])
+		}
+		return
+	}
+
+	// fit surrogate; if the covariance matrix is not (numerically) positive-definite, a growing
+	// jitter is added to its diagonal (Noise is not modified)
+	noise := o.Noise
+	for try := 0; !o.fitBest(noise); try++ {
+		if try == bayesMaxJitter {
+			chk.Panic("cannot fit Gaussian process (covariance matrix is not positive-definite with noise=%g)\n", noise)
+		}
+		noise = math.Max(10.0*noise, 1e-10)
+	}
+
+	// random candidates
+	type cand struct {
+		x  la.Vector
+		ei float64
+	}
+	cands := make([]cand, o.Ncand)
+	for k := range cands {
+		xs := la.NewVector(o.ndim)
+		for i := range xs {
+			xs[i] = o.Rng.Float64(0, 1)
+		}
+		cands[k] = cand{xs, o.ei(xs)}
+	}
+	sort.Slice(cands, func(a, b int) bool { return cands[a].ei > cands[b].ei })
+
+	// local maximization of EI with x̂ = (1 + sin(z)) / 2 ∈ [0,1] for unconstrained z
+	best, eibest := cands[0].x, cands[0].ei
+	xs := la.NewVector(o.ndim)
+	zToX := func(z la.Vector) {
+		for i := range z {
+			xs[i] = (1.0 + math.Sin(z[i])) / 2.0
+		}
+	}
+	prob := &Problem{Ndim: o.ndim}
+	prob.Ffcn = func(z la.Vector) float64 {
+		zToX(z)
+		return -o.ei(xs)
+	}
+	ztmp := la.NewVector(o.ndim)
+	prob.Gfcn = func(g, z la.Vector) {
+		for k := range z {
+			g[k] = num.DerivCen5(z[k], 1e-4, func(zk float64) float64 {
+				copy(ztmp, z)
+				ztmp[k] = zk
+				return prob.Ffcn(ztmp)
+			})
+		}
+	}
+	for s := 0; s < o.NumStarts && s < len(cands); s++ {
+		z := la.NewVector(o.ndim)
+		for i := range z {
+			z[i] = math.Asin(2.0*cands[s].x[i] - 1.0)
+		}
+		if eimax, ok := o.maxEI(prob, z); ok && eimax > eibest {
+			zToX(z)
+			best, eibest = xs.GetCopy(), eimax
+		}
+	}
+	return o.denormalize(best)
+}
+
+// Tell adds the observation y = f(x)
+func (o *BayesOpt) Tell(x la.Vector, y float64) {
+	if len(x) != o.ndim {
+		chk.Panic("length of x must be equal to %d. %d is invalid\n", o.ndim, len(x))
+	}
+	o.X = append(o.X, x.GetCopy())
+	o.Y = append(o.Y, y)
+}
+
+// Best returns the best observed point and value; nil and NaN if there are no observations
+func (o *BayesOpt) Best() (xbest la.Vector, ybest float64) {
+	ybest = math.NaN()
+	for k, y := range o.Y {
+		if xbest == nil || y < ybest {
+			xbest, ybest = o.X[k], y
+		}
+	}
+	return
+}
+
+// Predict computes the mean and standard deviation of the surrogate @ x
+//  NOTE: Ask must have been called (after Ninit observations) to fit the surrogate
+func (o *BayesOpt) Predict(x la.Vector) (μ, σ float64) {
+	if o.gp == nil {
+		chk.Panic("surrogate has not been fitted yet\n")
+	}
+	μ, σ = o.predictNormalized(o.normalize(x))
+	return o.ymu + o.ysd*μ, o.ysd * σ
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// fitBest fits the Gaussian process selecting the length scale with maximum log marginal
+// likelihood if the kernel is stat.KernelScalable; returns false if all fits fail
+//  noise -- noise variance (nugget) added to the diagonal of the covariance matrix
+func (o *BayesOpt) fitBest(noise float64) bool {
+	o.normalizeObservations()
+	scalable, ok := o.Kernel.(stat.KernelScalable)
+	if !ok || len(o.Lengths) == 0 {
+		gp, ok := o.fit(o.Kernel, noise)
+		if ok {
+			o.gp = gp
+		}
+		return ok
+	}
+	lmlBest := math.Inf(-1)
+	found := false
+	for _, length := range o.Lengths {
+		if gp, ok := o.fit(scalable.WithLength(length), noise); ok && gp.LogMarginalLikelihood() > lmlBest {
+			o.gp, lmlBest = gp, gp.LogMarginalLikelihood()
+			found = true
+		}
+	}
+	return found
+}
+
+// normalizeObservations computes the normalized points and observations
+func (o *BayesOpt) normalizeObservations() {
+	n := len(o.X)
+	o.xs = la.NewMatrix(n, o.ndim)
+	for k, x := range o.X {
+		for i, xi := range o.normalize(x) {
+			o.xs.Set(k, i, xi)
+		}
+	}
+	o.ymu, o.ysd = 0, 0
+	for _, y := range o.Y {
+		o.ymu += y
+	}
+	o.ymu /= float64(n)
+	for _, y := range o.Y {
+		o.ysd += (y - o.ymu) * (y - o.ymu)
+	}
+	o.ysd = math.Sqrt(o.ysd / float64(n))
+	if o.ysd < 1e-300 {
+		o.ysd = 1
+	}
+	o.ys = la.NewVector(n)
+	o.fmin = math.Inf(1)
+	for k, y := range o.Y {
+		o.ys[k] = (y - o.ymu) / o.ysd
+		o.fmin = math.Min(o.fmin, o.ys[k])
+	}
+}
+
+// fit fits a Gaussian process with kernel kern to the normalized observations; returns false if
+// the covariance matrix is not (numerically) positive-definite
+func (o *BayesOpt) fit(kern stat.Kernel, noise float64) (gp *stat.GP, ok bool) {
+	defer func() {
+		if err := recover(); err != nil {
+			gp, ok = nil, false
+		}
+	}()
+	gp = stat.NewGP(kern, noise)
+	gp.Fit(o.xs, o.ys)
+	lml := gp.LogMarginalLikelihood()
+	return gp, !math.IsNaN(lml) && !math.IsInf(lml, 0)
+}
+
+// predictNormalized computes the normalized mean and standard deviation @ normalized x
+func (o *BayesOpt) predictNormalized(xs la.Vector) (μ, σ float64) {
+	μ, σ2 := o.gp.PredictPoint(xs)
+	return μ, math.Sqrt(σ2)
+}
+
+// ei computes the expected improvement @ normalized x
+func (o *BayesOpt) ei(xs la.Vector) float64 {
+	μ, σ := o.predictNormalized(xs)
+	imp := o.fmin - μ - o.Xi
+	if σ < 1e-12 {
+		return math.Max(imp, 0)
+	}
+	z := imp / σ
+	Φ := 0.5 * math.Erfc(-z/math.Sqrt2)
+	φ := math.Exp(-z*z/2.0) / math.Sqrt(2.0*math.Pi)
+	return imp*Φ + σ*φ
+}
+
+// maxEI maximizes EI with ConjGrad starting at z; returns false if the minimizer fails
+func (o *BayesOpt) maxEI(prob *Problem, z la.Vector) (eimax float64, ok bool) {
+	defer func() {
+		if e := recover(); e != nil {
+			ok = false
+		}
+	}()
+	solver := NewConjGrad(prob)
+	solver.SetConvParams(100, 1e-10, 1e-10)
+	fmin := solver.Min(z, nil)
+	return -fmin, !math.IsNaN(fmin)
+}
+
+// normalize maps x from the box to the unit box
+func (o *BayesOpt) normalize(x la.Vector) (xs la.Vector) {
+	xs = la.NewVector(o.ndim)
+	for i := range x {
+		xs[i] = (x[i] - o.Lower[i]) / (o.Upper[i] - o.Lower[i])
+	}
+	return
+}
+
+// denormalize maps xs from the unit box to the box
+func (o *BayesOpt) denormalize(xs la.Vector) (x la.Vector) {
+	x = la.NewVector(o.ndim)
+	for i := range xs {
+		x[i] = o.Lower[i] + xs[i]*(o.Upper[i]-o.Lower[i])
+	}
+	return
+}
@@ -0,0 +1,241 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/num"
+)
+
+// BFGS implements the multidimensional minimization by the Broyden-Fletcher-Goldfarb-Shanno
+// quasi-Newton method with a dense approximation H of the inverse Hessian, updated after each
+// step by the rank-two formula (Eq. 6.17, page 140 of [1]):
+//
+//   H ← (I - ρ⋅s⋅yᵀ)⋅H⋅(I - ρ⋅y⋅sᵀ) + ρ⋅s⋅sᵀ    with ρ = 1 / (yᵀ⋅s)
+//
+//   where s = x_{k+1} - x_k and y = ∇f_{k+1} - ∇f_k
+//
+//   NOTE: (1) Check Convergence to see how to set convergence parameters,
+//             max iteration number, or to enable and access history of iterations
+//         (2) H is kept between calls to Min; thus related problems may be solved with warm
+//             restarts. Call ResetHessian to clear the approximation
+//         (3) H requires ndim² storage; use LBFGS for problems with many variables
+//
+//   REFERENCES:
+//   [1] Nocedal, J. and Wright, S. (2006) Numerical Optimization.
+//       Springer Series in Operations Research. 2nd Edition. Springer. 664p
+//
+type BFGS struct {
+
+	// merge properties
+	Convergence // auxiliary object to check convergence
+
+	// configuration
+	LineMethod string // line search method: "wolfe" (LineSearch), "brent", "hz" (HagerZhang) or "backtrack" (Backtrack) [default = "wolfe"]
+
+	// internal
+	H     *la.Matrix // approximation of the inverse Hessian
+	fresh bool       // H is the identity matrix (to be scaled after the first step)
+	g     la.Vector  // gradient
+	s     la.Vector  // step s = x_{k+1} - x_k
+	y     la.Vector  // gradient change y = ∇f_{k+1} - ∇f_k
+	Hy    la.Vector  // H⋅y
+	u     la.Vector  // search direction
+
+	// line search
+	lines *LineSearch     // line search
+	lineb *num.LineSolver // line solver wrapping Brent's method
+	lineh *HagerZhang     // Hager-Zhang line search
+	linea *Backtrack      // backtracking line search (Armijo condition)
+}
+
+// add optimizer to database
+func init() {
+	nlsMakersDB["bfgs"] = func(prob *Problem) NonLinSolver { return NewBFGS(prob) }
+}
+
+// NewBFGS returns a new multidimensional optimizer using the BFGS quasi-Newton method
+func NewBFGS(prob *Problem) (o *BFGS) {
+	o = new(BFGS)
+	o.InitConvergence(prob.Ffcn, prob.Gfcn)
+	o.lines = NewLineSearch(prob.Ndim, o.Ffcn, o.Gfcn)
+	o.lines.Coef2 = 0.9 // recommended for quasi-Newton methods
+	o.lineb = num.NewLineSolver(prob.Ndim, o.Ffcn, o.Gfcn)
+	o.lineh = NewHagerZhang(prob.Ndim, o.Ffcn, o.Gfcn)
+	o.linea = NewBacktrack(prob.Ndim, o.Ffcn, o.Gfcn)
+	o.LineMethod = "wolfe"
+	o.H = la.NewMatrix(prob.Ndim, prob.Ndim)
+	o.g = la.NewVector(prob.Ndim)
+	o.s = la.NewVector(prob.Ndim)
+	o.y = la.NewVector(prob.Ndim)
+	o.Hy = la.NewVector(prob.Ndim)
+	o.u = la.NewVector(prob.Ndim)
+	o.ResetHessian()
+	return
+}
+
+// ResetHessian resets the approximation of the inverse Hessian to the identity matrix; i.e. the
+// next step will be a steepest descent step
+func (o *BFGS) ResetHessian() {
+	o.H.SetDiag(1)
+	o.fresh = true
+}
+
+// Min solves minimization problem
+//
+//  Input:
+//    x -- [ndim] initial starting point (will be modified)
+//    params -- [may be nil] optional parameters. e.g. "maxit". Example:
+//                 params := dbf.NewParams(
+//                     &dbf.P{N: "maxit", V: 1000},
+//                     &dbf.P{N: "maxitls", V: 20},
+//                     &dbf.P{N: "maxitzoom", V: 20},
+//                     &dbf.P{N: "ftol", V: 1e-2},
+//                     &dbf.P{N: "gtol", V: 1e-2},
+//                     &dbf.P{N: "maxfeval", V: 5000},
+//                     &dbf.P{N: "maxduration", V: 1.5},
+//                     &dbf.P{N: "hist", V: 1},
+//                     &dbf.P{N: "verb", V: 1},
+//                 )
+//
+//  Output:
+//    fmin -- f(x@min) minimum f({x}) found
+//    x -- [modify input] position of minimum f({x})
+//
+func (o *BFGS) Min(x la.Vector, params dbf.Params) (fmin float64) {
+
+	// set parameters
+	o.Convergence.SetParams(params)
+	o.lines.SetParams(params)
+	o.lineh.SetParams(params)
+	o.linea.SetParams(params)
+	linesearch, err := selectLineSearch(o.LineMethod, o.lines, o.lineb, o.lineh, o.linea)
+	if err != nil {
+		chk.Panic("%v\n", err)
+	}
+
+	// initializations
+	o.NumFeval, o.NumGeval = 0, 0
+	o.startBudget()
+	fx := o.Ffcn(x) // fx := f(x)
+	o.Gfcn(o.g, x)  // g := df/dx
+	fmin = fx
+
+	// history
+	var λhist float64
+	if o.UseHist {
+		o.InitHist(x)
+	}
+
+	// estimate old f(x) for the first step
+	fold := fx + o.g.Norm()/2.0
+
+	// iterations
+	step := 0.0 // length of the last step (for the diagnostics)
+	for o.NumIter = 0; o.NumIter < o.MaxIt; o.NumIter++ {
+
+		// diagnostics
+		o.logIter(fx, o.g, step)
+
+		// exit point # 0: budget exhausted
+		if o.budgetExceeded() {
+			return
+		}
+
+		// exit point # 1: converged on df/dx (e.g. initial point is the minimum)
+		if o.NumIter == 0 && o.Gconvergence(fx, x, o.g) {
+			o.Status = ConvGtol
+			return
+		}
+
+		// search direction u := -H⋅g; restart with steepest descent if not a descent direction
+		la.MatVecMul(o.u, -1, o.H, o.g)
+		if la.VecDot(o.u, o.g) >= 0 {
+			o.ResetHessian()
+			o.u.Apply(-1, o.g)
+		}
+
+		// line minimization; steepest descent steps are estimated from fold, the others use a = 1
+		copy(o.s, x)
+		copy(o.y, o.g)
+		if o.LineMethod == "backtrack" {
+			o.linea.SetPhi0(fx, la.VecDot(o.g, o.u)) // f and ∇f @ x are known
+		}
+		λhist, fmin = linesearch(x, o.u, o.fresh, fold) // x := x @ min
+
+		if o.logging() {
+			step = math.Abs(λhist) * o.u.Norm()
+		}
+
+		// update fold
+		fold = fx
+
+		// history
+		if o.UseHist {
+			o.uhist.Apply(λhist, o.u)
+			o.Hist.Append(fmin, x, o.uhist)
+		}
+
+		// exit point # 2: converged on f
+		if o.Fconvergence(fx, fmin) {
+			o.Status = ConvFtol
+			return
+		}
+
+		// update fx and gradient
+		fx = fmin
+		o.Gfcn(o.g, x)
+
+		// exit point # 3: converged on df/dx
+		if o.Gconvergence(fx, x, o.g) {
+			o.Status = ConvGtol
+			return
+		}
+
+		// update H; or fall back to steepest descent if the curvature condition sᵀ⋅y > 0 fails
+		la.VecAdd(o.s, 1, x, -1, o.s)   // s := x - xold
+		la.VecAdd(o.y, 1, o.g, -1, o.y) // y := g - gold
+		sy := la.VecDot(o.s, o.y)
+		if sy <= 1e-10*o.s.Norm()*o.y.Norm() {
+			o.ResetHessian()
+			continue
+		}
+		o.update(sy)
+	}
+
+	// did not converge
+	o.Status = ConvMaxIt
+	chk.Panic("fail to converge after %d iterations\n", o.NumIter)
+	return
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// update updates the approximation of the inverse Hessian. Expanding the rank-two formula:
+//
+//   H ← H - ρ⋅(s⋅(H⋅y)ᵀ + (H⋅y)⋅sᵀ) + (ρ²⋅yᵀ⋅H⋅y + ρ)⋅s⋅sᵀ
+//
+//  NOTE: before the first update, H = I is scaled by γ = sᵀ⋅y / yᵀ⋅y (Eq. 6.20, page 143 of [1])
+func (o *BFGS) update(sy float64) {
+	if o.fresh {
+		γ := sy / la.VecDot(o.y, o.y)
+		o.H.SetDiag(γ)
+		o.fresh = false
+	}
+	ρ := 1.0 / sy
+	la.MatVecMul(o.Hy, 1, o.H, o.y)
+	yHy := la.VecDot(o.y, o.Hy)
+	c := ρ*ρ*yHy + ρ
+	n := len(o.s)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			o.H.Add(i, j, -ρ*(o.s[i]*o.Hy[j]+o.Hy[i]*o.s[j])+c*o.s[i]*o.s[j])
+		}
+	}
+}
@@ -5,6 +5,7 @@
 package opt
 
 import (
+	"context"
 	"math"
 
 	"github.com/cpmech/gosl/chk"
@@ -16,12 +17,47 @@ import (
 
 // ConjGrad implements the multidimensional minimization by the Fletcher-Reeves-Polak-Ribiere method.
 //
-//   NOTE: Check Convergence to see how to set convergence parameters,
-//         max iteration number, or to enable and access history of iterations
+//   NOTE: (1) Check Convergence to see how to set convergence parameters,
+//             max iteration number, or to enable and access history of iterations
+//         (2) If the gradient function of the problem is nil, the gradient is computed
+//             numerically using central differences (see JacStep)
+//         (3) Box constraints Lower ≤ x ≤ Upper may be set; in this case, the trial points of the
+//             line search are projected onto the box, the convergence is checked with the
+//             projected gradient, and the components of the direction pointing outwards at active
+//             bounds are set to zero. NOTE: the numerical gradient may evaluate f up to 2⋅JacStep
+//             outside the box
+//         (4) Observer may be set to monitor the iterations and to stop the solver early
+//         (5) MaxFeval and MaxDuration (see Convergence) may be set to limit the number of
+//             function evaluations and the wall-clock time; the solver then returns the current
+//             point without error and BudgetHit tells which budget has been exhausted
+//         (6) Scale may be set to solve the problem in the scaled variables y = x / Scale; i.e.
+//             x = Scale ⊙ y. The objective function and its gradient are transformed as
+//             f̃(y) = f(Scale ⊙ y) and ∇f̃(y) = Scale ⊙ ∇f(x); thus, the convergence test with
+//             Gtol is applied to the scaled gradient Scale ⊙ ∇f and to the scaled variables y
+//             (see Gconvergence). The numerical gradient (JacStep) is also computed w.r.t y.
+//             The results (x, Observer and History) are given in the original units; but the
+//             diagnostics written to LogWriter (see Convergence) refer to the scaled variables
+//         (7) the backtracking line search (LineMethod = "backtrack") only ensures a sufficient
+//             decrease of f; the inexact steps may slow down the convergence of CG methods
+//         (8) Precond may be set to apply M⁻¹, where M ≈ ∇²f is a symmetric positive-definite
+//             preconditioner (e.g. NewJacobiPrecond), to the residual r = -∇f; i.e. z = M⁻¹ r.
+//             The directions are then given by (Section 5.1 of [2]):
+//
+//                 hₖ₊₁ = zₖ₊₁ + γ hₖ     with   h₀ = z₀
+//
+//                         zₖ₊₁ ⋅ rₖ₊₁                          zₖ₊₁ ⋅ (rₖ₊₁ - rₖ)
+//                 γ_FR = ―――――――――――――     and     γ_PR = max(0, ―――――――――――――――――――)
+//                          zₖ ⋅ rₖ                                  zₖ ⋅ rₖ
+//
+//             which reduce to Eqs. 10.8.5 and 10.8.7 of [1] with M = I (Precond = nil). The
+//             convergence test (Gtol) is still applied to the gradient ∇f. NOTE: with Scale, the
+//             preconditioner acts on the scaled gradient Scale ⊙ ∇f
 //
 //   REFERENCES:
 //   [1] Press WH, Teukolsky SA, Vetterling WT, Fnannery BP (2007) Numerical Recipes:
 //       The Art of Scientific Computing. Third Edition. Cambridge University Press. 1235p.
+//   [2] Nocedal, J. and Wright, S. (2006) Numerical Optimization.
+//       Springer Series in Operations Research. 2nd Edition. Springer. 664p
 //
 type ConjGrad struct {
 
@@ -29,20 +65,47 @@ type ConjGrad struct {
 	Convergence // auxiliary object to check convergence
 
 	// configuration
-	UseBrent    bool // use Brent method insted of LineSearch (Wolfe conditions)
-	UseFRmethod bool // use Fletcher-Reeves method instead of Polak-Ribiere
-	CheckJfcn   bool // check Jacobian function at all points during minimization
+	LineMethod  string  // line search method: "wolfe" (LineSearch), "brent", "hz" (HagerZhang) or "backtrack" (Backtrack) [default = "wolfe"]
+	UseBrent    bool    // use Brent method insted of LineSearch (Wolfe conditions) [overrides LineMethod]
+	UseFRmethod bool    // use Fletcher-Reeves method instead of Polak-Ribiere
+	CheckJfcn   bool    // check Jacobian function at all points during minimization [ignored if numerical]
+	JacStep     float64 // step size for the numerical gradient (if prob.Gfcn == nil) [default = 1e-3]
+
+	// box constraints
+	Lower la.Vector // [ndim] lower bounds [may be nil ⇒ unbounded; otherwise Upper must be set as well]
+	Upper la.Vector // [ndim] upper bounds [may be nil ⇒ unbounded; otherwise Lower must be set as well]
+
+	// scaling
+	Scale la.Vector // [ndim] typical magnitudes of x (all positive) [may be nil ⇒ no scaling]
+
+	// Precond [may be nil ⇒ identity] computes out := M⁻¹ ⋅ in, where M is a symmetric
+	// positive-definite approximation of the Hessian. NOTE: out and in are different vectors
+	Precond func(out, in la.Vector)
+
+	// Observer [may be nil] is called at the top of each iteration with the current position x,
+	// f(x) and the (projected) gradient grad @ x. Returning stop = true terminates the iterations
+	// with the current point (no error). NOTE: x and grad are copies of the internal vectors; thus
+	// they can be modified or kept by the observer; but they are overwritten at the next call
+	Observer func(iter int, fx float64, x, grad la.Vector) (stop bool)
 
 	// internal
-	u    la.Vector // direction vector for line minimization
-	g    la.Vector // conjugate direction vector
-	h    la.Vector // conjugate direction vector
-	tmp  la.Vector // auxiliary vector
-	zero float64   // constant to prevent division by zero
+	u      la.Vector // direction vector for line minimization
+	g      la.Vector // conjugate direction vector
+	h      la.Vector // conjugate direction vector
+	z      la.Vector // preconditioned residual z = M⁻¹ g
+	tmp    la.Vector // auxiliary vector
+	xg     la.Vector // auxiliary vector for the numerical gradient
+	xp     la.Vector // auxiliary vector: trial point of line search projected onto the box
+	xobs   la.Vector // copy of x passed to Observer
+	gobs   la.Vector // copy of the gradient passed to Observer
+	zero   float64   // constant to prevent division by zero
+	numJac bool      // gradient is computed numerically
 
 	// line solver
 	lines *LineSearch     // line search
 	lineb *num.LineSolver // line solver wrapping Brent's method
+	lineh *HagerZhang     // Hager-Zhang line search
+	linea *Backtrack      // backtracking line search (Armijo condition)
 }
 
 // add optimizer to database
@@ -50,20 +113,92 @@ func init() {
 	nlsMakersDB["conjgrad"] = func(prob *Problem) NonLinSolver { return NewConjGrad(prob) }
 }
 
-// NewConjGrad returns a new multidimensional optimizer using ConjGrad's method (no derivatives required)
+// NewConjGrad returns a new multidimensional optimizer using ConjGrad's method
+//   NOTE: prob.Gfcn may be nil; in this case, the gradient is computed numerically
 func NewConjGrad(prob *Problem) (o *ConjGrad) {
 	o = new(ConjGrad)
 	o.InitConvergence(prob.Ffcn, prob.Gfcn)
-	o.lines = NewLineSearch(prob.Ndim, o.Ffcn, o.Gfcn)
-	o.lineb = num.NewLineSolver(prob.Ndim, o.Ffcn, o.Gfcn)
+	o.JacStep = 1e-3
+	if prob.Gfcn == nil {
+		o.numJac = true
+		o.xg = la.NewVector(prob.Ndim)
+		o.Gfcn = func(g, x la.Vector) {
+			o.NumGeval++
+			o.numericalGradient(g, x)
+		}
+	}
+	o.lines = NewLineSearch(prob.Ndim, o.ffcnLine, o.gfcnLine)
+	o.lineb = num.NewLineSolver(prob.Ndim, o.ffcnLine, o.gfcnLine)
+	o.lineh = NewHagerZhang(prob.Ndim, o.ffcnLine, o.gfcnLine)
+	o.lineh.Sigma = 0.1 // more accurate steps are better for CG methods
+	o.linea = NewBacktrack(prob.Ndim, o.ffcnLine, o.gfcnLine)
+	o.LineMethod = "wolfe"
+	o.xp = la.NewVector(prob.Ndim)
+	o.xobs = la.NewVector(prob.Ndim)
+	o.gobs = la.NewVector(prob.Ndim)
 	o.u = la.NewVector(prob.Ndim)
 	o.g = la.NewVector(prob.Ndim)
 	o.h = la.NewVector(prob.Ndim)
+	o.z = la.NewVector(prob.Ndim)
 	o.tmp = la.NewVector(prob.Ndim)
 	o.zero = 1e-18
 	return
 }
 
+// NewJacobiPrecond returns the diagonal (Jacobi) preconditioner M = diag(hdiag) to be used as
+// ConjGrad.Precond; i.e. out[i] := in[i] / hdiag[i]
+//   hdiag -- [ndim] diagonal of the Hessian ∇²f (or an approximation of it) [all positive]
+//   NOTE: hdiag is copied; this function panics if any component of hdiag is not positive or
+//         finite
+func NewJacobiPrecond(hdiag la.Vector) (precond func(out, in la.Vector)) {
+	inv := la.NewVector(len(hdiag))
+	for i, d := range hdiag {
+		if !(d > 0) || math.IsInf(d, 0) {
+			chk.Panic("diagonal of Jacobi preconditioner must have positive and finite components. hdiag[%d]=%g is invalid\n", i, d)
+		}
+		inv[i] = 1.0 / d
+	}
+	return func(out, in la.Vector) {
+		mulElems(out, inv, in)
+	}
+}
+
+// SetLineCoefs sets the coefficients of the Wolfe conditions of the line search (LineSearch)
+//   c1 -- "sufficient decrease" coefficient [default = 1e-4]
+//   c2 -- "curvature condition" coefficient [default = 0.4]
+//   NOTE: the coefficients must satisfy 0 < c1 < c2 < 1; otherwise an error is returned. The
+//         coefficients may also be given to Min with the "coef1" and "coef2" parameters
+func (o *ConjGrad) SetLineCoefs(c1, c2 float64) (err error) {
+	return o.lines.SetCoefs(c1, c2)
+}
+
+// Reset clears the statistics (NumFeval, NumGeval, NumIter, BudgetHit, Status and Hist), the
+// internal vectors and the statistics of the line searches; thus the same instance can be reused
+// to solve the problem from a new starting point without allocating memory
+//   NOTE: the problem (Ffcn, Gfcn and Ndim) given to NewConjGrad stays fixed; the configuration
+//         (e.g. tolerances, LineMethod, box constraints, Scale and Observer) is not modified
+func (o *ConjGrad) Reset() {
+	o.NumFeval, o.NumGeval, o.NumIter = 0, 0, 0
+	o.BudgetHit = ""
+	o.Status = ConvNone
+	o.Hist = nil
+	o.u.Fill(0)
+	o.g.Fill(0)
+	o.h.Fill(0)
+	o.z.Fill(0)
+	o.tmp.Fill(0)
+	o.xp.Fill(0)
+	o.xobs.Fill(0)
+	o.gobs.Fill(0)
+	if o.numJac {
+		o.xg.Fill(0)
+	}
+	o.lines.NumFeval, o.lines.NumJeval, o.lines.NumIter, o.lines.NumIterZoom = 0, 0, 0, 0
+	o.lineb.NumFeval, o.lineb.NumJeval = 0, 0
+	o.lineh.NumFeval, o.lineh.NumJeval, o.lineh.NumIter = 0, 0, 0
+	o.linea.NumFeval, o.linea.NumJeval, o.linea.NumIter = 0, 0, 0
+}
+
 // Min solves minimization problem
 //
 //  Input:
@@ -74,8 +209,12 @@ func NewConjGrad(prob *Problem) (o *ConjGrad) {
 //                     &dbf.P{N: "maxit", V: 1000},
 //                     &dbf.P{N: "maxitls", V: 20},
 //                     &dbf.P{N: "maxitzoom", V: 20},
+//                     &dbf.P{N: "coef1", V: 1e-4},
+//                     &dbf.P{N: "coef2", V: 0.4},
 //                     &dbf.P{N: "ftol", V: 1e-2},
 //                     &dbf.P{N: "gtol", V: 1e-2},
+//                     &dbf.P{N: "maxfeval", V: 5000},
+//                     &dbf.P{N: "maxduration", V: 1.5},
 //                     &dbf.P{N: "hist", V: 1},
 //                     &dbf.P{N: "verb", V: 1},
 //                 )
@@ -84,28 +223,104 @@ func NewConjGrad(prob *Problem) (o *ConjGrad) {
 //    fmin -- f(x@min) minimum f({x}) found
 //    x -- [modify input] position of minimum f({x})
 //
+//  NOTE: this function panics if the solution does not converge. See TryMin
+//
 func (o *ConjGrad) Min(x la.Vector, params dbf.Params) (fmin float64) {
+	fmin, err := o.TryMin(x, params)
+	if err != nil {
+		chk.Panic("%v\n", err)
+	}
+	return
+}
+
+// TryMin solves minimization problem, returning an error instead of panicking
+//
+//  Input:
+//    x -- [ndim] initial starting point (will be modified)
+//    params -- [may be nil] optional parameters. See Min
+//
+//  Output:
+//    fmin -- f(x@min) minimum f({x}) found
+//    x -- [modify input] position of minimum f({x}) or last position if err != nil, if the
+//         Observer has stopped the iterations or if the budget has been exhausted (BudgetHit)
+//    err -- error if the solution did not converge after MaxIt iterations, if the Jacobian
+//           function is incorrect (CheckJfcn = true), if the box constraints or Scale are
+//           invalid, if the line search method is not available, if the coefficients of the
+//           Wolfe conditions given in params are invalid, or if Precond is not positive-definite
+//
+func (o *ConjGrad) TryMin(x la.Vector, params dbf.Params) (fmin float64, err error) {
+	return o.tryMin(context.Background(), x, params)
+}
+
+// MinWithContext solves minimization problem and stops if the context is cancelled. The context is
+// checked at the top of each iteration. The parameters are taken from the fields of ConjGrad
+// (and Convergence)
+//
+//  Input:
+//    ctx -- context
+//    x -- [ndim] initial starting point (will be modified)
+//
+//  Output:
+//    fmin -- f(x@min) minimum f({x}) found
+//    x -- [modify input] position of minimum f({x}) or last position if err != nil
+//    err -- ctx.Err() (e.g. context.Canceled or context.DeadlineExceeded) if the context is
+//           cancelled; otherwise, the same errors as TryMin
+//
+func (o *ConjGrad) MinWithContext(ctx context.Context, x la.Vector) (fmin float64, err error) {
+	return o.tryMin(ctx, x, nil)
+}
+
+// tryMin implements TryMin and MinWithContext
+func (o *ConjGrad) tryMin(ctx context.Context, x la.Vector, params dbf.Params) (fmin float64, err error) {
 
 	// set parameters
 	o.Convergence.SetParams(params)
+	o.startBudget()
 	o.UseBrent = params.GetBoolOrDefault("brent", o.UseBrent)
 	o.lines.SetParams(params)
+	o.lineh.SetParams(params)
+	o.linea.SetParams(params)
+
+	// box constraints
+	if err = o.checkBounds(len(x)); err != nil {
+		return
+	}
+
+	// scaling
+	if o.Scale != nil {
+		var unscale func()
+		if unscale, err = o.scaleProblem(x); err != nil {
+			return
+		}
+		defer unscale()
+	}
+	o.project(x, x)
 
-	// line search function and counters
-	linesearch := o.lines.Wolfe
+	// line search function
+	method := o.LineMethod
 	if o.UseBrent {
-		linesearch = func(x, u la.Vector, dum1 bool, dum2 float64) (λ, fmin float64) { return o.lineb.MinUpdateX(x, u) }
+		method = "brent"
+	}
+	linesearch, err := selectLineSearch(method, o.lines, o.lineb, o.lineh, o.linea)
+	if err != nil {
+		return
+	}
+	if err = o.lines.CheckCoefs(); err != nil {
+		return
 	}
 
 	// initializations
+	o.NumFeval, o.NumGeval = 0, 0
 	ndim := len(x)
 	fx := o.Ffcn(x) // fx := f(x)
 	o.Gfcn(o.u, x)  // u := dy/dx
+	o.projectGradient(o.u, x)
 	for j := 0; j < ndim; j++ {
-		o.u[j] = -o.u[j] // u := -dy/dx
-		o.g[j] = o.u[j]  // g := -dy/dx
-		o.h[j] = o.u[j]  // h := g
+		o.g[j] = -o.u[j] // g := -dy/dx
 	}
+	o.precondition(o.z, o.g) // z := M⁻¹ g
+	copy(o.u, o.z)           // u := z
+	copy(o.h, o.z)           // h := z
 	fmin = fx
 
 	// history
@@ -116,21 +331,63 @@ func (o *ConjGrad) Min(x la.Vector, params dbf.Params) (fmin float64) {
 
 	// auxiliary
 	var nume, deno, γ float64
+	step := 0.0 // length of the last step (for the diagnostics)
 
 	// estimate old f(x)
-	fold := fx + o.u.Norm()/2.0 // TODO: find reference to this
+	fold := fx + o.g.Norm()/2.0 // TODO: find reference to this
 
 	// iterations
+	done := ctx.Done() // nil if the context can never be cancelled
 	for o.NumIter = 0; o.NumIter < o.MaxIt; o.NumIter++ {
 
+		// diagnostics
+		o.logIter(fx, o.g, step)
+
+		// exit point # 0: context cancelled, budget exhausted or stopped by Observer
+		if done != nil {
+			select {
+			case <-done:
+				o.Status = ConvStopped
+				err = ctx.Err()
+				return
+			default:
+			}
+		}
+		if o.budgetExceeded() {
+			return
+		}
+		if o.Observer != nil {
+			copy(o.xobs, x)
+			o.gobs.Apply(-1, o.g) // g = -dy/dx
+			if o.Observer(o.NumIter, fx, o.xobs, o.gobs) {
+				o.Status = ConvStopped
+				return
+			}
+		}
+
 		// exit point # 1: old gradient is exactly zero
 		deno = la.VecDot(o.g, o.g)
 		if math.Abs(deno) < o.zero {
+			o.Status = ConvGradZero
 			return
 		}
+		if o.Precond != nil {
+			deno = la.VecDot(o.z, o.g) // deno := zOld ⋅ gOld
+			if !(deno > 0) {
+				err = chk.Err("preconditioner must be positive-definite. z⋅r = %g is invalid at iteration %d", deno, o.NumIter)
+				return
+			}
+		}
 
 		// line minimization
+		if method == "backtrack" {
+			o.linea.SetPhi0(fx, -la.VecDot(o.g, o.u)) // f and ∇f @ x are known (g = -∇f)
+		}
 		λhist, fmin = linesearch(x, o.u, true, fold) // x := x @ min
+		o.project(x, x)
+		if o.logging() {
+			step = math.Abs(λhist) * o.u.Norm()
+		}
 
 		// update fold
 		fold = fx
@@ -143,6 +400,7 @@ func (o *ConjGrad) Min(x la.Vector, params dbf.Params) (fmin float64) {
 
 		// exit point # 2: converged on f
 		if o.Fconvergence(fx, fmin) {
+			o.Status = ConvFtol
 			return
 		}
 
@@ -151,44 +409,64 @@ func (o *ConjGrad) Min(x la.Vector, params dbf.Params) (fmin float64) {
 		o.Gfcn(o.u, x) // u := dy/dx
 
 		// check Jacobian @ x
-		if o.CheckJfcn {
-			o.checkJacobian(x)
+		if o.CheckJfcn && !o.numJac {
+			err = o.checkJacobian(x)
+			if err != nil {
+				return
+			}
 		}
 
+		// projected gradient
+		o.projectGradient(o.u, x)
+
 		// exit point # 3: converged on dy/dx (new)
 		if o.Gconvergence(fx, x, o.u) {
+			o.Status = ConvGtol
 			return
 		}
 
 		// compute scaling factor, noting that, now:
 		//   u = -gNew
 		//   g =  gOld
+		//   z =  M⁻¹ gNew
+		o.u.Apply(-1, o.u)       // u := gNew
+		o.precondition(o.z, o.u) // z := M⁻¹ gNew
 		if o.UseFRmethod {
-			nume = la.VecDot(o.u, o.u) // nume := gNew ⋅ gNew  [Equation 10.8.5 page 517 of Ref 1]
+			nume = la.VecDot(o.z, o.u) // nume := zNew ⋅ gNew  [Equation 10.8.5 page 517 of Ref 1]
 		} else {
-			la.VecAdd(o.tmp, 1, o.u, 1, o.g) // tmp := u + g = -gNew + gOld
-			nume = la.VecDot(o.tmp, o.u)     // nume := (gOld - gNew) ⋅ (-gNew) = (gNew - gOld) ⋅ gNew  [Equation 10.8.7 page 517 of Ref 1]
-			nume = utl.Max(nume, 0)          // avoid negative values
+			la.VecAdd(o.tmp, 1, o.u, -1, o.g) // tmp := gNew - gOld
+			nume = la.VecDot(o.z, o.tmp)      // nume := zNew ⋅ (gNew - gOld)  [Equation 10.8.7 page 517 of Ref 1]
+			nume = utl.Max(nume, 0)           // avoid negative values
 		}
 
 		// update directions
 		γ = nume / deno
 		for j := 0; j < ndim; j++ {
-			o.g[j] = -o.u[j]           // g := -dy/dx = gNew
-			o.u[j] = o.g[j] + γ*o.h[j] // u := gNew + γ⋅hOld = hNew
+			o.g[j] = o.u[j]            // g := -dy/dx = gNew
+			o.u[j] = o.z[j] + γ*o.h[j] // u := zNew + γ⋅hOld = hNew
 			o.h[j] = o.u[j]            // h := hNew
 		}
+		o.projectDirection(x)
+
+		// restart with (preconditioned) steepest descent if hNew is not a descent direction; i.e.
+		// hNew ⋅ gNew ≤ 0
+		if la.VecDot(o.u, o.g) <= 0 {
+			copy(o.u, o.z)
+			copy(o.h, o.z)
+			o.projectDirection(x)
+		}
 	}
 
 	// did not converge
-	chk.Panic("fail to converge after %d iterations\n", o.NumIter)
+	o.Status = ConvMaxIt
+	err = chk.Err("fail to converge after %d iterations. ‖∇f‖ = %g", o.NumIter, o.g.Norm())
 	return
 }
 
 // auxiliary ///////////////////////////////////////////////////////////////////////////////////////
 
 // checkJacobian checks Jacobian at intermediate point x
-func (o *ConjGrad) checkJacobian(x la.Vector) {
+func (o *ConjGrad) checkJacobian(x la.Vector) (err error) {
 	ndim := len(x)
 	tolJ := 1e-12
 	for k := 0; k < ndim; k++ {
@@ -200,7 +478,195 @@ func (o *ConjGrad) checkJacobian(x la.Vector) {
 		})
 		diff := math.Abs(o.u[k] - dfdxk)
 		if diff > tolJ {
-			chk.Panic("Jacobian function is incorrect. diff = %v\n", diff)
+			return chk.Err("Jacobian function is incorrect at iteration %d. diff = %v (component %d)", o.NumIter, diff, k)
+		}
+	}
+	return
+}
+
+// numericalGradient computes g = df/dx @ x using central differences. The function evaluations
+// are counted in NumFeval
+func (o *ConjGrad) numericalGradient(g, x la.Vector) {
+	copy(o.xg, x)
+	for k := 0; k < len(x); k++ {
+		g[k] = num.DerivCen5(x[k], o.JacStep, func(xk float64) float64 {
+			o.xg[k] = xk
+			return o.Ffcn(o.xg)
+		})
+		o.xg[k] = x[k]
+	}
+}
+
+// precondition computes z := M⁻¹ g using Precond or z := g if Precond is nil
+func (o *ConjGrad) precondition(z, g la.Vector) {
+	if o.Precond == nil {
+		copy(z, g)
+		return
+	}
+	o.Precond(z, g)
+}
+
+// checkBounds checks the box constraints
+func (o *ConjGrad) checkBounds(ndim int) (err error) {
+	if o.Lower == nil && o.Upper == nil {
+		return
+	}
+	if len(o.Lower) != ndim || len(o.Upper) != ndim {
+		return chk.Err("Lower and Upper bounds must have length equal to ndim = %d. %d and %d are invalid", ndim, len(o.Lower), len(o.Upper))
+	}
+	for i := 0; i < ndim; i++ {
+		if o.Lower[i] > o.Upper[i] {
+			return chk.Err("lower bound must not be greater than upper bound. Lower[%d]=%g and Upper[%d]=%g are invalid", i, o.Lower[i], i, o.Upper[i])
+		}
+	}
+	return
+}
+
+// scaleProblem replaces x by y = x / Scale and the objective function, gradient, box
+// constraints and Observer by their counterparts in the scaled variables. The returned function
+// restores the original data, replaces y by x = Scale ⊙ y and converts History to original units
+func (o *ConjGrad) scaleProblem(x la.Vector) (unscale func(), err error) {
+
+	// check
+	ndim := len(x)
+	if len(o.Scale) != ndim {
+		return nil, chk.Err("Scale must have length equal to ndim = %d. %d is invalid", ndim, len(o.Scale))
+	}
+	for i := 0; i < ndim; i++ {
+		if !(o.Scale[i] > 0) || math.IsInf(o.Scale[i], 0) {
+			return nil, chk.Err("Scale must have positive and finite components. Scale[%d]=%g is invalid", i, o.Scale[i])
+		}
+	}
+
+	// save original data
+	S := o.Scale
+	ffcn, gfcn := o.Ffcn, o.Gfcn
+	lower, upper := o.Lower, o.Upper
+	observer := o.Observer
+
+	// scaled functions
+	xs := la.NewVector(ndim)
+	o.Ffcn = func(y la.Vector) float64 {
+		mulElems(xs, S, y)
+		return ffcn(xs)
+	}
+	if !o.numJac { // the numerical gradient is computed with the scaled o.Ffcn
+		o.Gfcn = func(g, y la.Vector) {
+			mulElems(xs, S, y)
+			gfcn(g, xs)
+			mulElems(g, S, g)
+		}
+	}
+
+	// scaled box
+	if lower != nil {
+		o.Lower = la.NewVector(ndim)
+		o.Upper = la.NewVector(ndim)
+		for i := 0; i < ndim; i++ {
+			o.Lower[i] = lower[i] / S[i]
+			o.Upper[i] = upper[i] / S[i]
+		}
+	}
+
+	// observer in original units
+	if observer != nil {
+		o.Observer = func(iter int, fx float64, y, grad la.Vector) (stop bool) {
+			for i := 0; i < ndim; i++ {
+				y[i] *= S[i]
+				grad[i] /= S[i]
+			}
+			return observer(iter, fx, y, grad)
+		}
+	}
+
+	// scaled variables
+	for i := 0; i < ndim; i++ {
+		x[i] /= S[i]
+	}
+
+	// restore
+	unscale = func() {
+		o.Ffcn, o.Gfcn = ffcn, gfcn
+		o.Lower, o.Upper = lower, upper
+		o.Observer = observer
+		mulElems(x, S, x)
+		if o.UseHist && o.Hist != nil {
+			for k := range o.Hist.HistX {
+				mulElems(o.Hist.HistX[k], S, o.Hist.HistX[k])
+				if o.Hist.HistU[k] != nil {
+					mulElems(o.Hist.HistU[k], S, o.Hist.HistU[k])
+				}
+			}
+			o.Hist.ffcn = ffcn
+		}
+	}
+	return
+}
+
+// mulElems computes the element-wise product res[i] := s[i] ⋅ v[i]
+//  NOTE: res and v may be the same vector
+func mulElems(res, s, v la.Vector) {
+	for i := 0; i < len(v); i++ {
+		res[i] = s[i] * v[i]
+	}
+}
+
+// project computes xp := P(x); i.e. the projection of x onto the box [Lower, Upper]
+//  NOTE: xp and x may be the same vector
+func (o *ConjGrad) project(xp, x la.Vector) {
+	if o.Lower == nil {
+		copy(xp, x)
+		return
+	}
+	for i := 0; i < len(x); i++ {
+		xp[i] = utl.Min(utl.Max(x[i], o.Lower[i]), o.Upper[i])
+	}
+}
+
+// projectGradient sets to zero the components of the gradient g @ x such that -g points
+// outwards at an active bound
+func (o *ConjGrad) projectGradient(g, x la.Vector) {
+	if o.Lower == nil {
+		return
+	}
+	for i := 0; i < len(x); i++ {
+		if (x[i] <= o.Lower[i] && g[i] > 0) || (x[i] >= o.Upper[i] && g[i] < 0) {
+			g[i] = 0
+		}
+	}
+}
+
+// projectDirection sets to zero the components of the conjugate direction u (and h) pointing
+// outwards at an active bound
+func (o *ConjGrad) projectDirection(x la.Vector) {
+	if o.Lower == nil {
+		return
+	}
+	for i := 0; i < len(x); i++ {
+		if (x[i] <= o.Lower[i] && o.u[i] < 0) || (x[i] >= o.Upper[i] && o.u[i] > 0) {
+			o.u[i] = 0
+			o.h[i] = 0
+		}
+	}
+}
+
+// ffcnLine computes f(P(x)) for the line search; i.e. the trial points are projected onto the box
+func (o *ConjGrad) ffcnLine(x la.Vector) float64 {
+	o.project(o.xp, x)
+	return o.Ffcn(o.xp)
+}
+
+// gfcnLine computes the gradient of f(P(x)) for the line search; i.e. the components of ∇f(P(x))
+// corresponding to clipped coordinates are set to zero
+func (o *ConjGrad) gfcnLine(g, x la.Vector) {
+	o.project(o.xp, x)
+	o.Gfcn(g, o.xp)
+	if o.Lower == nil {
+		return
+	}
+	for i := 0; i < len(x); i++ {
+		if x[i] < o.Lower[i] || x[i] > o.Upper[i] {
+			g[i] = 0
 		}
 	}
 }
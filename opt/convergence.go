@@ -1,14 +1,55 @@
 package opt
 
 import (
+	goio "io"
 	"math"
+	"time"
 
 	"github.com/cpmech/gosl/fun"
 	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/io"
 	"github.com/cpmech/gosl/la"
 	"github.com/cpmech/gosl/utl"
 )
 
+// ConvStatus indicates the reason for stopping the iterations of an optimizer
+type ConvStatus int
+
+// convergence status
+const (
+	ConvNone     ConvStatus = iota // not set; e.g. Min has not been called or it has failed with an error
+	ConvGradZero                   // the (old) gradient is exactly zero
+	ConvFtol                       // converged on f({x}) (or residual)
+	ConvGtol                       // converged on the gradient
+	ConvXtol                       // converged on x; e.g. step size or simplex size
+	ConvMaxIt                      // max number of iterations reached (without convergence)
+	ConvBudget                     // budget exhausted; see BudgetHit
+	ConvStopped                    // stopped by the Observer or by cancelling the context
+)
+
+// String returns the name of the convergence status; e.g. for logging
+func (o ConvStatus) String() string {
+	switch o {
+	case ConvNone:
+		return "none"
+	case ConvGradZero:
+		return "gradzero"
+	case ConvFtol:
+		return "ftol"
+	case ConvGtol:
+		return "gtol"
+	case ConvXtol:
+		return "xtol"
+	case ConvMaxIt:
+		return "maxit"
+	case ConvBudget:
+		return "budget"
+	case ConvStopped:
+		return "stopped"
+	}
+	return io.Sf("ConvStatus(%d)", int(o))
+}
+
 // Convergence assists in checking the convergence of numerical optimizers
 // Convergence can be accessed to set convergence parameters, max iteration number,
 // or to enable and access history of iterations.
@@ -24,16 +65,32 @@ type Convergence struct {
 	Gtol    float64 // convergence criterion for the zero gradient test
 	EpsF    float64 // small number to rectify the special case of converging to exactly zero function value
 	UseHist bool    // save history
-	Verbose bool    // show messages
+	Verbose bool    // show messages: per-iteration diagnostics written to stdout (if LogWriter == nil)
+
+	// LogWriter [may be nil] receives the per-iteration diagnostics instead of stdout (even if
+	// Verbose is false). Each line has the format "iter=%d f=%.15e gnorm=%.6e step=%.6e"; where
+	// gnorm is the norm of the gradient (NaN for derivative-free methods) and step is the length
+	// of the step from the previous iteration. NOTE: a writer shared by concurrent solvers must be
+	// safe for concurrent use
+	LogWriter goio.Writer
+
+	// budget (checked at the top of each iteration; the solver then stops with the best point so
+	// far, without error, and sets BudgetHit)
+	MaxFeval    int           // max number of function evaluations (NumFeval) [0 ⇒ unlimited]
+	MaxDuration time.Duration // max wall-clock duration of a call to Min [0 ⇒ unlimited]
 
 	// statistics and History (e.g. for debugging)
-	NumFeval int      // number of calls to Ffcn (function evaluations)
-	NumGeval int      // number of calls to Gfcn (Jacobian evaluations)
-	NumIter  int      // number of iterations from last call to Solve
-	Hist     *History // history of optimization data (for debugging)
+	NumFeval  int        // number of calls to Ffcn (function evaluations)
+	NumGeval  int        // number of calls to Gfcn (Jacobian evaluations)
+	NumIter   int        // number of iterations from last call to Solve
+	BudgetHit string     // budget that stopped the last call to Min: "" (none), "maxfeval" or "maxduration"
+	Status    ConvStatus // reason for stopping the last call to Min
+	Hist      *History   // history of optimization data (for debugging)
 
 	// internal
-	uhist la.Vector // direction of descents to be saved in History
+	uhist  la.Vector // direction of descents to be saved in History
+	tstart time.Time // starting time of the last call to Min
+	xlog   la.Vector // x of the previous iteration (for the diagnostics of derivative-free methods)
 }
 
 // InitConvergence initialize convergence parameters
@@ -65,6 +122,8 @@ func (o *Convergence) InitHist(x0 la.Vector) {
 //                 &dbf.P{N: "maxit", V: 1000},
 //                 &dbf.P{N: "ftol", V: 1e-2},
 //                 &dbf.P{N: "gtol", V: 1e-2},
+//                 &dbf.P{N: "maxfeval", V: 5000},
+//                 &dbf.P{N: "maxduration", V: 1.5}, // seconds
 //                 &dbf.P{N: "hist", V: 1},
 //                 &dbf.P{N: "verb", V: 1},
 //             ))
@@ -72,6 +131,10 @@ func (o *Convergence) SetParams(params dbf.Params) {
 	o.MaxIt = params.GetIntOrDefault("maxit", o.MaxIt)
 	o.Ftol = params.GetValueOrDefault("ftol", o.Ftol)
 	o.Gtol = params.GetValueOrDefault("gtol", o.Gtol)
+	o.MaxFeval = params.GetIntOrDefault("maxfeval", o.MaxFeval)
+	if p := params.Find("maxduration"); p != nil {
+		o.MaxDuration = time.Duration(p.V * float64(time.Second))
+	}
 	o.UseHist = params.GetBoolOrDefault("hist", o.UseHist)
 	o.Verbose = params.GetBoolOrDefault("verb", o.Verbose)
 }
@@ -83,6 +146,14 @@ func (o *Convergence) SetConvParams(maxIt int, ftol, gtol float64) {
 	o.Gtol = gtol
 }
 
+// SetBudget sets the max number of function evaluations and the max wall-clock duration of Min
+//   maxFeval    -- max number of function evaluations [0 ⇒ unlimited]
+//   maxDuration -- max duration [0 ⇒ unlimited]
+func (o *Convergence) SetBudget(maxFeval int, maxDuration time.Duration) {
+	o.MaxFeval = maxFeval
+	o.MaxDuration = maxDuration
+}
+
 // SetUseHistory sets use history parameter
 func (o *Convergence) SetUseHistory(useHist bool) {
 	o.UseHist = useHist
@@ -98,6 +169,71 @@ func (o *Convergence) AccessHistory() *History {
 	return o.Hist
 }
 
+// logging returns whether the per-iteration diagnostics are written; i.e. LogWriter != nil or Verbose
+func (o *Convergence) logging() bool {
+	return o.LogWriter != nil || o.Verbose
+}
+
+// logIter writes the diagnostics of the current iteration (NumIter) to LogWriter or to stdout if
+// Verbose; otherwise does nothing
+//   fx   -- current f({x})
+//   grad -- current gradient (or its negative) [may be nil ⇒ gnorm = NaN]
+//   step -- length of the step from the previous iteration
+func (o *Convergence) logIter(fx float64, grad la.Vector, step float64) {
+	if !o.logging() {
+		return
+	}
+	gnorm := math.NaN()
+	if grad != nil {
+		gnorm = grad.Norm()
+	}
+	line := io.Sf("iter=%d f=%.15e gnorm=%.6e step=%.6e\n", o.NumIter, fx, gnorm, step)
+	if o.LogWriter != nil {
+		goio.WriteString(o.LogWriter, line)
+		return
+	}
+	io.Pf("%s", line)
+}
+
+// logStep returns the distance between x and the x of the previous iteration (zero if NumIter = 0)
+// and saves x for the next iteration. Used by derivative-free methods for the diagnostics
+func (o *Convergence) logStep(x la.Vector) (step float64) {
+	if len(o.xlog) != len(x) {
+		o.xlog = la.NewVector(len(x))
+	}
+	if o.NumIter > 0 {
+		for i := 0; i < len(x); i++ {
+			step += (x[i] - o.xlog[i]) * (x[i] - o.xlog[i])
+		}
+		step = math.Sqrt(step)
+	}
+	copy(o.xlog, x)
+	return
+}
+
+// startBudget records the starting time and clears BudgetHit and Status
+func (o *Convergence) startBudget() {
+	o.tstart = time.Now()
+	o.BudgetHit = ""
+	o.Status = ConvNone
+}
+
+// budgetExceeded checks whether the budget has been exhausted and sets BudgetHit and Status
+// accordingly
+func (o *Convergence) budgetExceeded() bool {
+	if o.MaxFeval > 0 && o.NumFeval >= o.MaxFeval {
+		o.BudgetHit = "maxfeval"
+		o.Status = ConvBudget
+		return true
+	}
+	if o.MaxDuration > 0 && time.Since(o.tstart) >= o.MaxDuration {
+		o.BudgetHit = "maxduration"
+		o.Status = ConvBudget
+		return true
+	}
+	return false
+}
+
 // Fconvergence performs the check for f({x}) values
 //
 //   Input:
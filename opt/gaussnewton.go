@@ -0,0 +1,269 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/la"
+)
+
+// constants for the factorisation of Jᵀ⋅J
+const (
+	gaussNewtonPivTol  = 1e-12 // ε: min pivot L_ii² relative to max(1, max(diag(Jᵀ⋅J)))
+	gaussNewtonMaxDamp = 20    // max number of increases of the damping μ added to Jᵀ⋅J
+)
+
+// GaussNewton implements the Gauss-Newton method to solve nonlinear least-squares problems:
+//
+//   min F(x) = ½ ‖r(x)‖² = ½ r(x)ᵀ⋅r(x)    with    r: ℝⁿ → ℝᵐ (m residuals, n unknowns)
+//
+// The step h is computed from the normal equations (Eq. 10.23, page 254 of [1]):
+//
+//   (Jᵀ⋅J) ⋅ h = -Jᵀ⋅r    with    J = dr/dx
+//
+// and x ← x + a⋅h where a = 1 (full step) or a is given by a line search (Wolfe conditions)
+//
+//   NOTE: (1) Check Convergence to see how to set max number of iterations and history. Ftol is
+//             not used; the solution converges if (a) ‖Jᵀ⋅r‖∞ ≤ Gtol, (b) ‖r‖ ≤ Rtol, or
+//             (c) ‖a⋅h‖ ≤ Xtol⋅(‖x‖ + Xtol)
+//         (2) J should have full column rank; i.e. Jᵀ⋅J should be positive-definite. If the
+//             Cholesky factorisation of Jᵀ⋅J fails or yields a pivot L_ii² < ε⋅d, where
+//             d = max(1, max(diag(Jᵀ⋅J))) and ε = 1e-12 (rank-deficient J), the step is computed
+//             from (Jᵀ⋅J + μ⋅I) ⋅ h = -Jᵀ⋅r with the smallest μ = 10ᵏ⋅ε⋅d (k = 0, 1, ...) that
+//             passes this check. Use LevMar for ill-conditioned problems and for starting points
+//             far from the solution
+//         (3) Set UseLineSearch to damp the steps when the full Gauss-Newton step overshoots
+//         (4) NumFeval counts the calls to the residual function and NumGeval counts the calls
+//             to the Jacobian function
+//
+//   REFERENCES:
+//   [1] Nocedal, J. and Wright, S. (2006) Numerical Optimization.
+//       Springer Series in Operations Research. 2nd Edition. Springer. 664p
+//
+type GaussNewton struct {
+
+	// merge properties
+	Convergence // auxiliary object to check convergence
+
+	// configuration
+	Rtol          float64 // tolerance on the residual norm ‖r‖ [default = 1e-12]
+	Xtol          float64 // tolerance on the step size ‖a⋅h‖ (relative to ‖x‖) [default = 1e-12]
+	UseLineSearch bool    // use line search (Wolfe conditions) instead of full steps
+
+	// internal
+	nres  int         // m: number of residuals
+	rfcn  fun.Vv      // residual function r(x)
+	jfcn  fun.Mv      // Jacobian function J = dr/dx
+	r     la.Vector   // [nres] residual @ x
+	J     *la.Matrix  // [nres][ndim] Jacobian @ x
+	A     *la.Matrix  // [ndim][ndim] Jᵀ⋅J
+	L     *la.Matrix  // [ndim][ndim] Cholesky factor of A
+	g     la.Vector   // [ndim] gradient Jᵀ⋅r
+	h     la.Vector   // [ndim] step
+	mg    la.Vector   // [ndim] -g
+	xold  la.Vector   // [ndim] previous x
+	rw    la.Vector   // [nres] workspace for Ffcn and Gfcn
+	Jw    *la.Matrix  // [nres][ndim] workspace for Gfcn
+	lines *LineSearch // line search
+}
+
+// NewGaussNewton returns a new Gauss-Newton solver for nonlinear least-squares problems
+//   nres -- m: number of residuals
+//   ndim -- n: number of unknowns (ndim ≤ nres)
+//   rfcn -- residual function: r(x) with len(r) = nres
+//   Jfcn -- Jacobian function: J = dr/dx with dimensions [nres][ndim]
+func NewGaussNewton(nres, ndim int, rfcn fun.Vv, Jfcn fun.Mv) (o *GaussNewton) {
+	o = new(GaussNewton)
+	o.nres = nres
+	o.rfcn = rfcn
+	o.jfcn = Jfcn
+	o.rw = la.NewVector(nres)
+	o.Jw = la.NewMatrix(nres, ndim)
+	o.InitConvergence(func(x la.Vector) float64 {
+		o.rfcn(o.rw, x)
+		return la.VecDot(o.rw, o.rw) / 2.0
+	}, func(g, x la.Vector) {
+		o.rfcn(o.rw, x)
+		o.jfcn(o.Jw, x)
+		la.MatTrVecMul(g, 1, o.Jw, o.rw) // g := Jᵀ⋅r
+	})
+	o.Gtol = 1e-10
+	o.Rtol = 1e-12
+	o.Xtol = 1e-12
+	o.r = la.NewVector(nres)
+	o.J = la.NewMatrix(nres, ndim)
+	o.A = la.NewMatrix(ndim, ndim)
+	o.L = la.NewMatrix(ndim, ndim)
+	o.g = la.NewVector(ndim)
+	o.h = la.NewVector(ndim)
+	o.mg = la.NewVector(ndim)
+	o.xold = la.NewVector(ndim)
+	o.lines = NewLineSearch(ndim, o.Ffcn, o.Gfcn)
+	o.lines.Coef2 = 0.9 // recommended for Newton methods
+	return
+}
+
+// Min solves the least-squares problem
+//
+//  Input:
+//    x -- [ndim] initial starting point (will be modified)
+//    params -- [may be nil] optional parameters. e.g. "maxit", "linesearch". Example:
+//                 params := dbf.NewParams(
+//                     &dbf.P{N: "maxit", V: 1000},
+//                     &dbf.P{N: "gtol", V: 1e-10},
+//                     &dbf.P{N: "rtol", V: 1e-12},
+//                     &dbf.P{N: "xtol", V: 1e-12},
+//                     &dbf.P{N: "linesearch", V: 1},
+//                     &dbf.P{N: "maxitls", V: 10},
+//                     &dbf.P{N: "hist", V: 1},
+//                 )
+//
+//  Output:
+//    cost -- F(x@min) = ½ ‖r(x@min)‖²
+//    x -- [modify input] position of minimum F({x})
+//
+func (o *GaussNewton) Min(x la.Vector, params dbf.Params) (cost float64) {
+
+	// set parameters
+	o.Convergence.SetParams(params)
+	o.Rtol = params.GetValueOrDefault("rtol", o.Rtol)
+	o.Xtol = params.GetValueOrDefault("xtol", o.Xtol)
+	o.UseLineSearch = params.GetBoolOrDefault("linesearch", o.UseLineSearch)
+	o.lines.SetParams(params)
+
+	// initializations
+	o.NumFeval, o.NumGeval = 0, 0
+	o.startBudget()
+	o.update(x)
+	cost = la.VecDot(o.r, o.r) / 2.0
+
+	// history
+	if o.UseHist {
+		o.InitHist(x)
+	}
+
+	// iterations
+	step := 0.0 // length of the last step (for the diagnostics)
+	for o.NumIter = 0; o.NumIter < o.MaxIt; o.NumIter++ {
+
+		// diagnostics
+		o.logIter(cost, o.g, step)
+
+		// exit point # 1: converged on gradient or residual
+		if o.g.Largest(1) <= o.Gtol {
+			o.Status = ConvGtol
+			return
+		}
+		if math.Sqrt(2.0*cost) <= o.Rtol {
+			o.Status = ConvFtol
+			return
+		}
+
+		// exit point # 2: budget exhausted
+		if o.budgetExceeded() {
+			return
+		}
+
+		// solve (Jᵀ⋅J) ⋅ h = -g
+		la.MatTrMatMul(o.A, 1, o.J, o.J) // A := Jᵀ⋅J
+		o.mg.Apply(-1, o.g)
+		o.factorize()
+		la.CholeskySolve(o.h, o.L, o.mg)
+
+		// update x
+		copy(o.xold, x)
+		if o.UseLineSearch {
+			o.lines.Wolfe(x, o.h, false, 0) // x := x + a⋅h
+		} else {
+			la.VecAdd(x, 1, x, 1, o.h) // x := x + h
+		}
+		la.VecAdd(o.h, 1, x, -1, o.xold) // h := a⋅h
+		step = o.h.Norm()
+		o.update(x)
+		cost = la.VecDot(o.r, o.r) / 2.0
+
+		// history
+		if o.UseHist {
+			o.Hist.Append(cost, x, o.h)
+		}
+
+		// exit point # 3: converged on step size
+		if step <= o.Xtol*(x.Norm()+o.Xtol) {
+			o.Status = ConvXtol
+			return
+		}
+	}
+
+	// did not converge
+	o.Status = ConvMaxIt
+	chk.Panic("fail to converge after %d iterations\n", o.NumIter)
+	return
+}
+
+// factorize computes the Cholesky factor L of A = Jᵀ⋅J, adding μ⋅I to A if it is not
+// (numerically) positive-definite; i.e. if some L_ii² < ε⋅max(1, max(diag(A)))
+func (o *GaussNewton) factorize() {
+	amax := 1.0
+	for i := 0; i < o.A.M; i++ {
+		amax = math.Max(amax, math.Abs(o.A.Get(i, i)))
+	}
+	if o.tryFactorize(amax) {
+		return
+	}
+	mu, muOld := gaussNewtonPivTol*amax, 0.0
+	for k := 0; k < gaussNewtonMaxDamp; k++ {
+		for i := 0; i < o.A.M; i++ {
+			o.A.Add(i, i, mu-muOld) // A := Jᵀ⋅J + μ⋅I
+		}
+		if o.tryFactorize(amax) {
+			return
+		}
+		mu, muOld = 10.0*mu, mu
+	}
+	chk.Panic("cannot compute Gauss-Newton step: Jᵀ⋅J + μ⋅I is not positive-definite with μ = %g\n", muOld)
+}
+
+// tryFactorize computes L and checks the pivots L_ii² ≥ ε⋅amax
+func (o *GaussNewton) tryFactorize(amax float64) bool {
+	if !tryCholesky(o.L, o.A) {
+		return false
+	}
+	for i := 0; i < o.A.M; i++ {
+		if lii := o.L.Get(i, i); lii*lii < gaussNewtonPivTol*amax {
+			return false
+		}
+	}
+	return true
+}
+
+// update computes r, J and g = Jᵀ⋅r @ x
+func (o *GaussNewton) update(x la.Vector) {
+	o.NumFeval++
+	o.rfcn(o.r, x)
+	o.NumGeval++
+	o.jfcn(o.J, x)
+	la.MatTrVecMul(o.g, 1, o.J, o.r) // g := Jᵀ⋅r
+}
+
+// tryCholesky computes the Cholesky factorization a = L⋅Lᵀ; returns false (instead of panicking)
+// if a is not (numerically) positive-definite
+func tryCholesky(L, a *la.Matrix) (ok bool) {
+	defer func() {
+		if err := recover(); err != nil {
+			ok = false
+		}
+	}()
+	la.Cholesky(L, a)
+	for i := 0; i < a.M; i++ {
+		if lii := L.Get(i, i); math.IsNaN(lii) || math.IsInf(lii, 0) || lii <= 0 {
+			return false
+		}
+	}
+	return true
+}
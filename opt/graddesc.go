@@ -20,10 +20,13 @@ type GradDesc struct {
 	Convergence // auxiliary object to check convergence
 
 	// configuration
-	Alpha float64 // rate to take descents
+	Alpha        float64 // rate to take descents
+	UseBacktrack bool    // use the backtracking line search (Backtrack) starting from Alpha instead of the fixed rate Alpha
 
 	// internal
-	dfdx la.Vector // gradient vector
+	dfdx  la.Vector  // gradient vector
+	u     la.Vector  // descent direction -dfdx (for the line search)
+	linea *Backtrack // backtracking line search (Armijo condition)
 }
 
 // add optimizer to database
@@ -37,6 +40,8 @@ func NewGradDesc(prob *Problem) (o *GradDesc) {
 	o.InitConvergence(prob.Ffcn, prob.Gfcn)
 	o.Alpha = 1e-3
 	o.dfdx = la.NewVector(prob.Ndim)
+	o.u = la.NewVector(prob.Ndim)
+	o.linea = NewBacktrack(prob.Ndim, o.Ffcn, o.Gfcn)
 	return
 }
 
@@ -47,6 +52,7 @@ func NewGradDesc(prob *Problem) (o *GradDesc) {
 //    params -- [may be nil] optional parameters. e.g. "alpha", "maxit". Example:
 //                 params := dbf.NewParams(
 //                     &dbf.P{N: "alpha", V: 0.5},
+//                     &dbf.P{N: "backtrack", V: 1},
 //                     &dbf.P{N: "maxit", V: 1000},
 //                     &dbf.P{N: "ftol", V: 1e-2},
 //                     &dbf.P{N: "gtol", V: 1e-2},
@@ -62,12 +68,15 @@ func (o *GradDesc) Min(x la.Vector, params dbf.Params) (fmin float64) {
 	// set parameters
 	o.Convergence.SetParams(params)
 	o.Alpha = params.GetValueOrDefault("alpha", o.Alpha)
+	o.UseBacktrack = params.GetBoolOrDefault("backtrack", o.UseBacktrack)
+	o.linea.SetParams(params)
 	io.Pforan("α = %v\n", o.Alpha)
 	io.Pforan("nit = %v\n", o.MaxIt)
 	io.Pforan("ftol = %v\n", o.Convergence.Ftol)
 
 	// initializations
 	o.NumFeval, o.NumGeval = 0, 0
+	o.startBudget()
 	fmin = o.Ffcn(x)
 	fprev := fmin
 
@@ -77,34 +86,53 @@ func (o *GradDesc) Min(x la.Vector, params dbf.Params) (fmin float64) {
 	}
 
 	// iterations
+	step := 0.0 // length of the last step (for the diagnostics)
 	for o.NumIter = 0; o.NumIter < o.MaxIt; o.NumIter++ {
 
+		// exit point # 0: budget exhausted
+		if o.budgetExceeded() {
+			return
+		}
+
 		// compute and check gradient
 		o.Gfcn(o.dfdx, x)
+		o.logIter(fmin, o.dfdx, step)
 		if o.Gconvergence(fprev, x, o.dfdx) {
+			o.Status = ConvGtol
 			return
 		}
 
-		// perform descent
-		la.VecAdd(x, 1, x, -o.Alpha, o.dfdx) // x := x - α⋅dfdx
-
-		// compute objective function
+		// perform descent and compute objective function
+		α := o.Alpha
 		fprev = fmin
-		fmin = o.Ffcn(x)
+		if o.UseBacktrack {
+			o.u.Apply(-1, o.dfdx)
+			o.linea.Alpha0 = o.Alpha
+			o.linea.SetPhi0(fmin, -la.VecDot(o.dfdx, o.dfdx))
+			α, fmin = o.linea.Find(x, o.u, false, 0) // x := x - α⋅dfdx
+		} else {
+			la.VecAdd(x, 1, x, -α, o.dfdx) // x := x - α⋅dfdx
+			fmin = o.Ffcn(x)
+		}
+		if o.logging() {
+			step = α * o.dfdx.Norm()
+		}
 
 		// history
 		if o.UseHist {
-			o.uhist.Apply(-o.Alpha, o.dfdx)
+			o.uhist.Apply(-α, o.dfdx)
 			o.Hist.Append(fmin, x, o.uhist)
 		}
 
 		// compute and check objective function
 		if o.Fconvergence(fprev, fmin) {
+			o.Status = ConvFtol
 			return
 		}
 	}
 
 	// did not converge
+	o.Status = ConvMaxIt
 	chk.Panic("fail to converge after %d iterations\n", o.NumIter)
 	return
 }
@@ -0,0 +1,142 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/rnd"
+	"github.com/cpmech/gosl/utl"
+)
+
+// GridSearch finds the minimum of objective by evaluating all points of the Cartesian product of
+// the per-dimension grids
+//
+//   INPUT:
+//     objective -- function to be minimized; must be safe for concurrent calls if nworkers > 1
+//     grid      -- [ndim][nvals_i] values of each parameter (dimension)
+//     nworkers  -- number of goroutines; use ≤ 0 for the number of CPUs
+//
+//   OUTPUT:
+//     bestParams -- parameters corresponding to the minimum value
+//     bestVal    -- minimum value of objective (NaN values are ignored)
+//
+//   NOTE: (1) the number of evaluations is Π_i nvals_i
+//         (2) ties are resolved by selecting the first point in lexicographic order of grid indices
+//
+func GridSearch(objective func(params la.Vector) float64, grid [][]float64, nworkers int) (bestParams la.Vector, bestVal float64) {
+
+	// check
+	ndim := len(grid)
+	if ndim < 1 {
+		chk.Panic("grid must have at least one dimension\n")
+	}
+	npts := 1
+	for i, g := range grid {
+		if len(g) < 1 {
+			chk.Panic("grid of dimension %d must have at least one value\n", i)
+		}
+		npts *= len(g)
+	}
+
+	// evaluate points; index k maps to grid indices with the last dimension varying fastest
+	point := func(params la.Vector, k int) {
+		for i := ndim - 1; i >= 0; i-- {
+			params[i] = grid[i][k%len(grid[i])]
+			k /= len(grid[i])
+		}
+	}
+	vals := evalParallel(npts, ndim, nworkers, objective, point)
+
+	// best
+	bestVal, kbest := utl.ArgMin(vals)
+	bestParams = la.NewVector(ndim)
+	if kbest >= 0 {
+		point(bestParams, kbest)
+	}
+	return
+}
+
+// RandomSearch finds the minimum of objective by evaluating npts points sampled uniformly within
+// the box defined by lower and upper
+//
+//   INPUT:
+//     objective -- function to be minimized; must be safe for concurrent calls if nworkers > 1
+//     lower     -- [ndim] lower bounds
+//     upper     -- [ndim] upper bounds
+//     npts      -- number of random points
+//     nworkers  -- number of goroutines; use ≤ 0 for the number of CPUs
+//     rng       -- random numbers generator; use nil for the global generator
+//
+//   OUTPUT:
+//     bestParams -- parameters corresponding to the minimum value
+//     bestVal    -- minimum value of objective (NaN values are ignored)
+//
+//   NOTE: the points are generated sequentially with rng before the evaluations; thus the results
+//         are reproducible with a seeded generator (e.g. rnd.NewRNG(seed)) regardless of nworkers
+//
+func RandomSearch(objective func(params la.Vector) float64, lower, upper la.Vector, npts, nworkers int, rng *rnd.RNG) (bestParams la.Vector, bestVal float64) {
+
+	// check
+	ndim := len(lower)
+	if ndim < 1 || len(upper) != ndim {
+		chk.Panic("lower and upper bounds must have the same length ≥ 1. %d and %d are invalid\n", len(lower), len(upper))
+	}
+	if npts < 1 {
+		chk.Panic("number of points must be at least 1. npts=%d is invalid\n", npts)
+	}
+
+	// generate points
+	X := la.NewMatrix(npts, ndim)
+	for k := 0; k < npts; k++ {
+		for i := 0; i < ndim; i++ {
+			X.Set(k, i, rng.Float64(lower[i], upper[i]))
+		}
+	}
+	point := func(params la.Vector, k int) {
+		for i := 0; i < ndim; i++ {
+			params[i] = X.Get(k, i)
+		}
+	}
+	vals := evalParallel(npts, ndim, nworkers, objective, point)
+
+	// best
+	bestVal, kbest := utl.ArgMin(vals)
+	bestParams = la.NewVector(ndim)
+	if kbest >= 0 {
+		point(bestParams, kbest)
+	}
+	return
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// evalParallel evaluates objective at npts points defined by point(params, k) using nworkers
+func evalParallel(npts, ndim, nworkers int, objective func(params la.Vector) float64, point func(params la.Vector, k int)) (vals []float64) {
+	if nworkers < 1 {
+		nworkers = runtime.NumCPU()
+	}
+	if nworkers > npts {
+		nworkers = npts
+	}
+	vals = make([]float64, npts)
+	var wg sync.WaitGroup
+	for w := 0; w < nworkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			params := la.NewVector(ndim)
+			for k := w; k < npts; k += nworkers {
+				point(params, k)
+				vals[k] = objective(params)
+			}
+		}(w)
+	}
+	wg.Wait()
+	return
+}
@@ -0,0 +1,356 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/num"
+	"github.com/cpmech/gosl/utl"
+)
+
+// HagerZhang finds the scalar 'a' that gives a substantial reduction of f({x}+a⋅{u}) using the
+// line search of Hager and Zhang [1,2]. With φ(a) = f({x}+a⋅{u}), the step 'a' satisfies either
+// the Wolfe conditions:
+//
+//   φ(a) - φ(0) ≤ δ⋅a⋅φ'(0)    and    φ'(a) ≥ σ⋅φ'(0)
+//
+// or the approximate Wolfe conditions:
+//
+//   (2⋅δ - 1)⋅φ'(0) ≥ φ'(a) ≥ σ⋅φ'(0)    and    φ(a) ≤ φ(0) + ε⋅|φ(0)|
+//
+// The approximate conditions are more robust near the minimum, where the Wolfe conditions cannot
+// be checked accurately because of rounding errors in φ(a) - φ(0). An interval [a,b] with
+// φ'(a) < 0 ≤ φ'(b) is bracketed first and then reduced with double secant steps (secant²) or
+// bisection.
+//
+//   REFERENCES:
+//   [1] Hager WW and Zhang H (2005) A new conjugate gradient method with guaranteed descent and an
+//       efficient line search. SIAM Journal on Optimization, 16(1):170-192
+//   [2] Hager WW and Zhang H (2006) Algorithm 851: CG_DESCENT, a conjugate gradient method with
+//       guaranteed descent. ACM Transactions on Mathematical Software, 32(1):113-137
+//
+type HagerZhang struct {
+
+	// configuration
+	MaxIt   int     // max iterations
+	Delta   float64 // δ: "sufficient decrease" coefficient (0 < δ < 0.5) [default = 0.1]
+	Sigma   float64 // σ: "curvature condition" coefficient (δ ≤ σ < 1) [default = 0.9]
+	Epsilon float64 // ε: tolerance on the increase of f in the approximate Wolfe conditions [default = 1e-6]
+	Theta   float64 // θ: bisection coefficient of the update step (0 < θ < 1) [default = 0.5]
+	Gamma   float64 // γ: bisection is used if the interval is not reduced by γ after secant² (0 < γ < 1) [default = 0.66]
+	Rho     float64 // ρ: expansion factor of the bracketing step (ρ > 1) [default = 5]
+
+	// statistics
+	NumFeval int // number of calls to Ffcn (function evaluations)
+	NumJeval int // number of calls to Jfcn (Jacobian evaluations)
+	NumIter  int // number of iterations from last call to Find
+
+	// internal
+	ffcn fun.Sv    // scalar function of vector: y = f({x})
+	Jfcn fun.Vv    // vector function of vector: {J} = df/d{x} @ {x}
+	xnew la.Vector // {xnew} = {x} + a⋅{p}
+	dfdx la.Vector // derivative df/d{x}
+	p0   hzPoint   // φ and φ' @ a = 0
+	epsk float64   // ε⋅|φ(0)|
+
+	// pointers
+	x la.Vector // starting point
+	u la.Vector // direction
+}
+
+// hzPoint holds a trial point of the HagerZhang line search
+type hzPoint struct {
+	a float64 // step
+	f float64 // φ(a)
+	g float64 // φ'(a)
+}
+
+// NewHagerZhang returns a new HagerZhang object
+//   ndim -- length(x)
+//   ffcn -- function y = f({x})
+//   Jfcn -- Jacobian {J} = df/d{x} @ {x}
+func NewHagerZhang(ndim int, ffcn fun.Sv, Jfcn fun.Vv) (o *HagerZhang) {
+	o = new(HagerZhang)
+	o.MaxIt = 50
+	o.Delta = 0.1
+	o.Sigma = 0.9
+	o.Epsilon = 1e-6
+	o.Theta = 0.5
+	o.Gamma = 0.66
+	o.Rho = 5
+	o.ffcn = ffcn
+	o.Jfcn = Jfcn
+	o.xnew = la.NewVector(ndim)
+	o.dfdx = la.NewVector(ndim)
+	return
+}
+
+// SetParams sets parameters
+//   Example:
+//             o.SetParams(dbf.NewParams(
+//                 &dbf.P{N: "maxitls", V: 50},
+//                 &dbf.P{N: "hzdelta", V: 0.1},
+//                 &dbf.P{N: "hzsigma", V: 0.9},
+//                 &dbf.P{N: "hzeps", V: 1e-6},
+//                 &dbf.P{N: "hztheta", V: 0.5},
+//                 &dbf.P{N: "hzgamma", V: 0.66},
+//                 &dbf.P{N: "hzrho", V: 5},
+//             ))
+func (o *HagerZhang) SetParams(params dbf.Params) {
+	o.MaxIt = params.GetIntOrDefault("maxitls", o.MaxIt)
+	o.Delta = params.GetValueOrDefault("hzdelta", o.Delta)
+	o.Sigma = params.GetValueOrDefault("hzsigma", o.Sigma)
+	o.Epsilon = params.GetValueOrDefault("hzeps", o.Epsilon)
+	o.Theta = params.GetValueOrDefault("hztheta", o.Theta)
+	o.Gamma = params.GetValueOrDefault("hzgamma", o.Gamma)
+	o.Rho = params.GetValueOrDefault("hzrho", o.Rho)
+}
+
+// Find finds the scalar 'a' that gives a substantial reduction of f({x}+a⋅{u}) (approximate
+// Wolfe conditions). The call signature is the same as LineSearch.Wolfe
+//
+//  Input:
+//    x -- initial point
+//    u -- direction (must be a descent direction)
+//    useFold -- estimate the initial step from fold; otherwise use a = 1
+//    fold -- previous f(x) [used if useFold == true]
+//
+//  Output:
+//    a -- scale parameter
+//    f -- f @ a
+//    x -- x + a⋅u  [update input x]
+//
+//  Reference: Section 4 of [1]
+//
+func (o *HagerZhang) Find(x, u la.Vector, useFold bool, fold float64) (a, f float64) {
+
+	// check
+	if o.Delta <= 0 || o.Delta >= 0.5 || o.Sigma < o.Delta || o.Sigma >= 1 || o.Epsilon < 0 ||
+		o.Theta <= 0 || o.Theta >= 1 || o.Gamma <= 0 || o.Gamma >= 1 || o.Rho <= 1 {
+		chk.Panic("coefficients must satisfy 0 < δ < 0.5, δ ≤ σ < 1, ε ≥ 0, 0 < θ < 1, 0 < γ < 1 and ρ > 1. δ=%g, σ=%g, ε=%g, θ=%g, γ=%g and ρ=%g are invalid\n", o.Delta, o.Sigma, o.Epsilon, o.Theta, o.Gamma, o.Rho)
+	}
+
+	// update x
+	defer func() {
+		la.VecAdd(x, 1, o.x, a, o.u) // xnew := x + a⋅u
+	}()
+
+	// set pointers needed by F and G functions
+	o.Set(x, u)
+
+	// compute initial F and G
+	o.NumFeval = 0
+	o.NumJeval = 0
+	o.NumIter = 0
+	o.p0 = o.eval(0)
+	o.epsk = o.Epsilon * math.Abs(o.p0.f)
+	if o.p0.g >= 0 {
+		chk.Panic("u must be a descent direction. φ'(0) = %g is invalid\n", o.p0.g)
+	}
+
+	// estimate initial step
+	c := 1.0
+	if useFold {
+		c = utl.Min(1.0, 1.01*2*(o.p0.f-fold)/o.p0.g)
+		if c <= 0 {
+			c = 1.0
+		}
+	}
+
+	// bracket
+	pa, pb := o.bracket(c)
+
+	// iterations
+	for o.NumIter = 1; o.NumIter <= o.MaxIt; o.NumIter++ {
+
+		// exit point
+		if o.accept(pa) {
+			return pa.a, pa.f
+		}
+		if o.accept(pb) {
+			return pb.a, pb.f
+		}
+
+		// secant² step
+		width := pb.a - pa.a
+		pa, pb = o.secant2(pa, pb)
+
+		// bisection if the interval was not reduced enough
+		if pb.a-pa.a > o.Gamma*width {
+			pc := o.eval(pa.a + (pb.a-pa.a)/2)
+			if o.accept(pc) {
+				return pc.a, pc.f
+			}
+			pa, pb = o.update(pa, pb, pc)
+		}
+	}
+
+	// failure
+	chk.Panic("failed to converge after %d iterations\n", o.NumIter)
+	return
+}
+
+// Set sets x and u vectors as required by F(a) and G(a) functions
+func (o *HagerZhang) Set(x, u la.Vector) {
+	o.x = x
+	o.u = u
+}
+
+// F implements f(a) := f({xnew}(a,u)) where {xnew}(a,u) := {x} + a⋅{u}
+func (o *HagerZhang) F(a float64) float64 {
+	o.NumFeval++
+	la.VecAdd(o.xnew, 1, o.x, a, o.u) // xnew := x + a⋅u
+	return o.ffcn(o.xnew)
+}
+
+// G implements g(a) = df/da|({xnew}(a,u)) = df/d{xnew}⋅d{xnew}/da where {xnew} == {x} + a⋅{u}
+func (o *HagerZhang) G(a float64) float64 {
+	o.NumJeval++
+	la.VecAdd(o.xnew, 1, o.x, a, o.u) // xnew := x + a⋅u
+	o.Jfcn(o.dfdx, o.xnew)            // dfdx @ xnew
+	return la.VecDot(o.dfdx, o.u)     // dfdx ⋅ u
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// eval computes φ(a) and φ'(a)
+func (o *HagerZhang) eval(a float64) hzPoint {
+	return hzPoint{a, o.F(a), o.G(a)}
+}
+
+// accept checks the Wolfe and approximate Wolfe conditions
+func (o *HagerZhang) accept(p hzPoint) bool {
+	if p.a <= 0 || p.g < o.Sigma*o.p0.g {
+		return false
+	}
+	if p.f-o.p0.f <= o.Delta*p.a*o.p0.g { // Wolfe
+		return true
+	}
+	return p.g <= (2*o.Delta-1)*o.p0.g && p.f <= o.p0.f+o.epsk // approximate Wolfe
+}
+
+// bracket finds an interval [a,b] satisfying φ'(a) < 0 ≤ φ'(b) and φ(a) ≤ φ(0) + εk, starting
+// from the trial step c. NOTE: a = b is returned if a point satisfying the (approximate) Wolfe
+// conditions is found
+//  Reference: procedure "bracket", page 184 of [1]
+func (o *HagerZhang) bracket(c float64) (pa, pb hzPoint) {
+	pa = o.p0
+	for it := 0; it < o.MaxIt; it++ {
+		pc := o.eval(c)
+		if o.accept(pc) {
+			return pc, pc
+		}
+		if pc.g >= 0 {
+			return pa, pc
+		}
+		if pc.f > o.p0.f+o.epsk {
+			return o.update3(o.p0, pc)
+		}
+		pa = pc
+		c *= o.Rho
+	}
+	chk.Panic("failed to bracket the step after %d iterations\n", o.MaxIt)
+	return
+}
+
+// update updates the interval [a,b] using the trial point c
+//  Reference: procedure "update", page 183 of [1]
+func (o *HagerZhang) update(pa, pb, pc hzPoint) (hzPoint, hzPoint) {
+	if pc.a <= pa.a || pc.a >= pb.a {
+		return pa, pb
+	}
+	if pc.g >= 0 {
+		return pa, pc
+	}
+	if pc.f <= o.p0.f+o.epsk {
+		return pc, pb
+	}
+	return o.update3(pa, pc)
+}
+
+// update3 reduces [a,b] by bisection (with θ) when φ'(b) < 0 and φ(b) > φ(0) + εk; i.e. until the
+// conditions of the bracket are satisfied. NOTE: a = b is returned if a point satisfying the
+// (approximate) Wolfe conditions is found
+//  Reference: step U3 of procedure "update", page 183 of [1]
+func (o *HagerZhang) update3(pa, pb hzPoint) (hzPoint, hzPoint) {
+	for it := 0; it < o.MaxIt; it++ {
+		pd := o.eval((1-o.Theta)*pa.a + o.Theta*pb.a)
+		if o.accept(pd) {
+			return pd, pd
+		}
+		if pd.g >= 0 {
+			return pa, pd
+		}
+		if pd.f <= o.p0.f+o.epsk {
+			pa = pd
+		} else {
+			pb = pd
+		}
+	}
+	chk.Panic("update step did not converge after %d iterations\n", o.MaxIt)
+	return pa, pb
+}
+
+// secant2 performs the double secant step. NOTE: a = b is returned if a point satisfying the
+// (approximate) Wolfe conditions is found
+//  Reference: procedure "secant²", page 184 of [1]
+func (o *HagerZhang) secant2(pa, pb hzPoint) (hzPoint, hzPoint) {
+	pc := o.eval(o.secant(pa, pb))
+	if o.accept(pc) {
+		return pc, pc
+	}
+	pA, pB := o.update(pa, pb, pc)
+	if pc.a == pB.a {
+		pc = o.eval(o.secant(pb, pB))
+	} else if pc.a == pA.a {
+		pc = o.eval(o.secant(pa, pA))
+	} else {
+		return pA, pB
+	}
+	if o.accept(pc) {
+		return pc, pc
+	}
+	return o.update(pA, pB, pc)
+}
+
+// secant returns the minimiser of the quadratic interpolating φ'(a) and φ'(b); or the midpoint of
+// [a,b] if the secant cannot be computed
+func (o *HagerZhang) secant(pa, pb hzPoint) float64 {
+	den := pb.g - pa.g
+	if den != 0 {
+		c := (pa.a*pb.g - pb.a*pa.g) / den
+		if !math.IsNaN(c) && !math.IsInf(c, 0) {
+			return c
+		}
+	}
+	return pa.a + (pb.a-pa.a)/2
+}
+
+// line search selection ///////////////////////////////////////////////////////////////////////////
+
+// lineSearchFunc defines the signature of line search functions; e.g. LineSearch.Wolfe
+type lineSearchFunc func(x, u la.Vector, useFold bool, fold float64) (a, f float64)
+
+// selectLineSearch returns the line search function corresponding to method
+//   method -- "wolfe" (or "") ⇒ LineSearch.Wolfe; "brent" ⇒ num.LineSolver.MinUpdateX; "hz" ⇒ HagerZhang.Find;
+//             "backtrack" ⇒ Backtrack.Find
+func selectLineSearch(method string, lines *LineSearch, lineb *num.LineSolver, lineh *HagerZhang, linea *Backtrack) (linesearch lineSearchFunc, err error) {
+	switch method {
+	case "", "wolfe":
+		return lines.Wolfe, nil
+	case "brent":
+		return func(x, u la.Vector, dum1 bool, dum2 float64) (λ, fmin float64) { return lineb.MinUpdateX(x, u) }, nil
+	case "hz":
+		return lineh.Find, nil
+	case "backtrack":
+		return linea.Find, nil
+	}
+	return nil, chk.Err("line search method %q is not available. Options: \"wolfe\", \"brent\", \"hz\" or \"backtrack\"", method)
+}
@@ -5,8 +5,14 @@
 package opt
 
 import (
+	"encoding/csv"
+	goio "io"
 	"math"
+	"strconv"
+	"strings"
+	"sync"
 
+	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/fun"
 	"github.com/cpmech/gosl/io"
 	"github.com/cpmech/gosl/la"
@@ -15,6 +21,11 @@ import (
 )
 
 // History holds history of optmization using directiors; e.g. for Debugging
+//
+//   NOTE: Append and SaveCSV are safe for concurrent use; e.g. when a History is shared by
+//         several goroutines. The other methods and the direct access to the data assume that
+//         no other goroutine is appending values
+//
 type History struct {
 
 	// data
@@ -33,7 +44,8 @@ type History struct {
 	GapXj   float64   // expand {ximin, ximax}
 
 	// internal
-	ffcn fun.Sv // f({x}) function
+	ffcn fun.Sv     // f({x}) function
+	lock sync.Mutex // guards the data during Append and SaveCSV
 }
 
 // NewHistory returns new object
@@ -52,12 +64,105 @@ func NewHistory(nMaxIt int, f0 float64, x0 la.Vector, ffcn fun.Sv) (o *History)
 
 // Append appends new x and u vectors, and updates F and I arrays
 func (o *History) Append(fx float64, x, u la.Vector) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
 	o.HistX = append(o.HistX, x.GetCopy())
 	o.HistU = append(o.HistU, u.GetCopy())
 	o.HistF = append(o.HistF, fx)
 	o.HistI = append(o.HistI, float64(len(o.HistI)))
 }
 
+// SaveCSV writes the history in CSV format with the columns: iteration, f-value, components of x
+// and step length (norm of u; zero for the first row). The first row is the header. Example:
+//
+//     iter,f,x0,x1,step
+//     0,24.2,-1.2,1,0
+//     1,4.73,-1.03,1.07,0.18
+//
+func (o *History) SaveCSV(w goio.Writer) (err error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	cw := csv.NewWriter(w)
+	header := []string{"iter", "f"}
+	for j := 0; j < o.Ndim; j++ {
+		header = append(header, io.Sf("x%d", j))
+	}
+	header = append(header, "step")
+	if err = cw.Write(header); err != nil {
+		return chk.Err("cannot write CSV header: %v", err)
+	}
+	rec := make([]string, o.Ndim+3)
+	for k := 0; k < len(o.HistF); k++ {
+		step := 0.0
+		if o.HistU[k] != nil {
+			step = o.HistU[k].Norm()
+		}
+		rec[0] = strconv.FormatFloat(o.HistI[k], 'g', -1, 64)
+		rec[1] = strconv.FormatFloat(o.HistF[k], 'g', -1, 64)
+		for j := 0; j < o.Ndim; j++ {
+			rec[2+j] = strconv.FormatFloat(o.HistX[k][j], 'g', -1, 64)
+		}
+		rec[2+o.Ndim] = strconv.FormatFloat(step, 'g', -1, 64)
+		if err = cw.Write(rec); err != nil {
+			return chk.Err("cannot write CSV record %d: %v", k, err)
+		}
+	}
+	cw.Flush()
+	if err = cw.Error(); err != nil {
+		return chk.Err("cannot write CSV data: %v", err)
+	}
+	return
+}
+
+// LoadCSV reads a history written by SaveCSV
+//
+//   NOTE: (1) the u vectors are not saved by SaveCSV; thus HistU is set with the differences
+//             between consecutive x vectors, which correspond to the steps of most solvers
+//         (2) the objective function is not available; thus PlotC cannot be used
+//
+func LoadCSV(r goio.Reader) (o *History, err error) {
+
+	// read records
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, chk.Err("cannot read CSV data: %v", err)
+	}
+	if len(records) < 2 {
+		return nil, chk.Err("CSV data must have a header and at least one record")
+	}
+	ndim := len(records[0]) - 3
+	if ndim < 1 || records[0][0] != "iter" || records[0][1] != "f" || records[0][ndim+2] != "step" {
+		return nil, chk.Err("CSV header %q is invalid", strings.Join(records[0], ","))
+	}
+
+	// parse values
+	o = new(History)
+	o.Ndim = ndim
+	o.NptsI = 41
+	o.NptsJ = 41
+	for i, rec := range records[1:] {
+		vals := make([]float64, len(rec))
+		for j, str := range rec {
+			if vals[j], err = strconv.ParseFloat(strings.TrimSpace(str), 64); err != nil {
+				return nil, chk.Err("line %d of CSV data has invalid numbers: %q", i+2, strings.Join(rec, ","))
+			}
+		}
+		x := la.NewVectorSlice(vals[2 : 2+ndim])
+		var u la.Vector
+		if i > 0 {
+			u = la.NewVector(ndim)
+			la.VecAdd(u, 1, x, -1, o.HistX[i-1])
+		}
+		o.HistI = append(o.HistI, vals[0])
+		o.HistF = append(o.HistF, vals[1])
+		o.HistX = append(o.HistX, x)
+		o.HistU = append(o.HistU, u)
+	}
+	return
+}
+
 // Limits computes range of X variables
 func (o *History) Limits() (Xmin []float64, Xmax []float64) {
 	Xmin = make([]float64, o.Ndim)
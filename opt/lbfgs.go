@@ -0,0 +1,266 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/num"
+)
+
+// LBFGS implements the multidimensional minimization by the limited-memory BFGS quasi-Newton
+// method. The inverse Hessian is not stored; instead, the last M pairs of
+//
+//   s = x_{k+1} - x_k    and    y = ∇f_{k+1} - ∇f_k
+//
+// are kept and the search direction is computed by the two-loop recursion. Thus, the method is
+// suitable for problems with many variables.
+//
+//   NOTE: Check Convergence to see how to set convergence parameters,
+//         max iteration number, or to enable and access history of iterations
+//
+//   REFERENCES:
+//   [1] Nocedal, J. and Wright, S. (2006) Numerical Optimization.
+//       Springer Series in Operations Research. 2nd Edition. Springer. 664p
+//
+type LBFGS struct {
+
+	// merge properties
+	Convergence // auxiliary object to check convergence
+
+	// configuration
+	M          int    // number of (s,y) pairs kept in memory [default = 10]
+	LineMethod string // line search method: "wolfe" (LineSearch), "brent", "hz" (HagerZhang) or "backtrack" (Backtrack) [default = "wolfe"]
+
+	// internal
+	s     []la.Vector // last M steps s = x_{k+1} - x_k (circular buffer)
+	y     []la.Vector // last M gradient changes y = ∇f_{k+1} - ∇f_k (circular buffer)
+	ρ     []float64   // ρ = 1 / (yᵀ⋅s)
+	α     []float64   // coefficients of the two-loop recursion
+	npair int         // number of stored pairs
+	ipair int         // index of the next pair to be stored
+	g     la.Vector   // gradient
+	gold  la.Vector   // previous gradient
+	xold  la.Vector   // previous x
+	u     la.Vector   // search direction
+
+	// line search
+	lines *LineSearch     // line search
+	lineb *num.LineSolver // line solver wrapping Brent's method
+	lineh *HagerZhang     // Hager-Zhang line search
+	linea *Backtrack      // backtracking line search (Armijo condition)
+}
+
+// add optimizer to database
+func init() {
+	nlsMakersDB["lbfgs"] = func(prob *Problem) NonLinSolver { return NewLBFGS(prob) }
+}
+
+// NewLBFGS returns a new multidimensional optimizer using the limited-memory BFGS method
+func NewLBFGS(prob *Problem) (o *LBFGS) {
+	o = new(LBFGS)
+	o.InitConvergence(prob.Ffcn, prob.Gfcn)
+	o.M = 10
+	o.lines = NewLineSearch(prob.Ndim, o.Ffcn, o.Gfcn)
+	o.lines.Coef2 = 0.9 // recommended for quasi-Newton methods
+	o.lineb = num.NewLineSolver(prob.Ndim, o.Ffcn, o.Gfcn)
+	o.lineh = NewHagerZhang(prob.Ndim, o.Ffcn, o.Gfcn)
+	o.linea = NewBacktrack(prob.Ndim, o.Ffcn, o.Gfcn)
+	o.LineMethod = "wolfe"
+	o.g = la.NewVector(prob.Ndim)
+	o.gold = la.NewVector(prob.Ndim)
+	o.xold = la.NewVector(prob.Ndim)
+	o.u = la.NewVector(prob.Ndim)
+	return
+}
+
+// Min solves minimization problem
+//
+//  Input:
+//    x -- [ndim] initial starting point (will be modified)
+//    params -- [may be nil] optional parameters. e.g. "m", "maxit". Example:
+//                 params := dbf.NewParams(
+//                     &dbf.P{N: "m", V: 10},
+//                     &dbf.P{N: "maxit", V: 1000},
+//                     &dbf.P{N: "maxitls", V: 20},
+//                     &dbf.P{N: "maxitzoom", V: 20},
+//                     &dbf.P{N: "ftol", V: 1e-2},
+//                     &dbf.P{N: "gtol", V: 1e-2},
+//                     &dbf.P{N: "maxfeval", V: 5000},
+//                     &dbf.P{N: "maxduration", V: 1.5},
+//                     &dbf.P{N: "hist", V: 1},
+//                     &dbf.P{N: "verb", V: 1},
+//                 )
+//
+//  Output:
+//    fmin -- f(x@min) minimum f({x}) found
+//    x -- [modify input] position of minimum f({x})
+//
+func (o *LBFGS) Min(x la.Vector, params dbf.Params) (fmin float64) {
+
+	// set parameters
+	o.Convergence.SetParams(params)
+	o.M = params.GetIntOrDefault("m", o.M)
+	o.lines.SetParams(params)
+	o.lineh.SetParams(params)
+	o.linea.SetParams(params)
+	linesearch, err := selectLineSearch(o.LineMethod, o.lines, o.lineb, o.lineh, o.linea)
+	if err != nil {
+		chk.Panic("%v\n", err)
+	}
+	if o.M < 1 {
+		chk.Panic("number of pairs in memory must be at least 1. M=%d is invalid\n", o.M)
+	}
+
+	// memory
+	ndim := len(x)
+	if len(o.s) != o.M {
+		o.s, o.y = make([]la.Vector, o.M), make([]la.Vector, o.M)
+		for i := 0; i < o.M; i++ {
+			o.s[i], o.y[i] = la.NewVector(ndim), la.NewVector(ndim)
+		}
+		o.ρ, o.α = make([]float64, o.M), make([]float64, o.M)
+	}
+	o.npair, o.ipair = 0, 0
+
+	// initializations
+	o.NumFeval, o.NumGeval = 0, 0
+	o.startBudget()
+	fx := o.Ffcn(x) // fx := f(x)
+	o.Gfcn(o.g, x)  // g := df/dx
+	fmin = fx
+
+	// history
+	var λhist float64
+	if o.UseHist {
+		o.InitHist(x)
+	}
+
+	// estimate old f(x) for the first step
+	fold := fx + o.g.Norm()/2.0
+
+	// iterations
+	step := 0.0 // length of the last step (for the diagnostics)
+	for o.NumIter = 0; o.NumIter < o.MaxIt; o.NumIter++ {
+
+		// diagnostics
+		o.logIter(fx, o.g, step)
+
+		// exit point # 0: budget exhausted
+		if o.budgetExceeded() {
+			return
+		}
+
+		// exit point # 1: converged on df/dx (e.g. initial point is the minimum)
+		if o.NumIter == 0 && o.Gconvergence(fx, x, o.g) {
+			o.Status = ConvGtol
+			return
+		}
+
+		// search direction u := -H⋅g; restart with steepest descent if not a descent direction
+		o.direction()
+		if la.VecDot(o.u, o.g) >= 0 {
+			o.npair, o.ipair = 0, 0
+			o.direction()
+		}
+
+		// line minimization; the first step is estimated from fold, the others use a = 1
+		copy(o.xold, x)
+		copy(o.gold, o.g)
+		if o.LineMethod == "backtrack" {
+			o.linea.SetPhi0(fx, la.VecDot(o.g, o.u)) // f and ∇f @ x are known
+		}
+		λhist, fmin = linesearch(x, o.u, o.npair == 0, fold) // x := x @ min
+
+		if o.logging() {
+			step = math.Abs(λhist) * o.u.Norm()
+		}
+
+		// update fold
+		fold = fx
+
+		// history
+		if o.UseHist {
+			o.uhist.Apply(λhist, o.u)
+			o.Hist.Append(fmin, x, o.uhist)
+		}
+
+		// exit point # 2: converged on f
+		if o.Fconvergence(fx, fmin) {
+			o.Status = ConvFtol
+			return
+		}
+
+		// update fx and gradient
+		fx = fmin
+		o.Gfcn(o.g, x)
+
+		// exit point # 3: converged on df/dx
+		if o.Gconvergence(fx, x, o.g) {
+			o.Status = ConvGtol
+			return
+		}
+
+		// store pair (s,y) if the curvature condition sᵀ⋅y > 0 holds
+		la.VecAdd(o.xold, 1, x, -1, o.xold)   // xold := s = x - xold
+		la.VecAdd(o.gold, 1, o.g, -1, o.gold) // gold := y = g - gold
+		sy := la.VecDot(o.xold, o.gold)
+		if sy > 1e-10*o.xold.Norm()*o.gold.Norm() {
+			copy(o.s[o.ipair], o.xold)
+			copy(o.y[o.ipair], o.gold)
+			o.ρ[o.ipair] = 1.0 / sy
+			o.ipair = (o.ipair + 1) % o.M
+			if o.npair < o.M {
+				o.npair++
+			}
+		}
+	}
+
+	// did not converge
+	o.Status = ConvMaxIt
+	chk.Panic("fail to converge after %d iterations\n", o.NumIter)
+	return
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// direction computes the search direction u := -H⋅g using the two-loop recursion (Algorithm 7.4,
+// page 178 of [1]) with initial Hessian H0 = γ⋅I and γ = sᵀ⋅y / yᵀ⋅y of the latest pair
+func (o *LBFGS) direction() {
+
+	// steepest descent
+	copy(o.u, o.g)
+	if o.npair == 0 {
+		o.u.Apply(-1, o.u)
+		return
+	}
+
+	// first loop: from newest to oldest
+	for k := 0; k < o.npair; k++ {
+		i := (o.ipair - 1 - k + o.M) % o.M
+		o.α[i] = o.ρ[i] * la.VecDot(o.s[i], o.u)
+		la.VecAdd(o.u, 1, o.u, -o.α[i], o.y[i])
+	}
+
+	// initial Hessian
+	newest := (o.ipair - 1 + o.M) % o.M
+	yy := la.VecDot(o.y[newest], o.y[newest])
+	γ := 1.0 / (o.ρ[newest] * yy)
+	if math.IsInf(γ, 0) || math.IsNaN(γ) {
+		γ = 1
+	}
+	o.u.Apply(γ, o.u)
+
+	// second loop: from oldest to newest
+	for k := o.npair - 1; k >= 0; k-- {
+		i := (o.ipair - 1 - k + o.M) % o.M
+		β := o.ρ[i] * la.VecDot(o.y[i], o.u)
+		la.VecAdd(o.u, 1, o.u, o.α[i]-β, o.s[i])
+	}
+	o.u.Apply(-1, o.u)
+}
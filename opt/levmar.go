@@ -0,0 +1,265 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// LevMar implements the Levenberg-Marquardt method to solve nonlinear least-squares problems:
+//
+//   min F(x) = ½ ‖r(x)‖² = ½ r(x)ᵀ⋅r(x)    with    r: ℝⁿ → ℝᵐ (m residuals, n unknowns)
+//
+// The step h is computed from the damped Gauss-Newton (normal) equations:
+//
+//   (Jᵀ⋅J + λ⋅I) ⋅ h = -Jᵀ⋅r    with    J = dr/dx
+//
+// where λ (Marquardt parameter) is updated after each trial step according to the gain ratio
+// ϱ = (F(x) - F(x+h)) / (L(0) - L(h)) between the actual and the predicted decrease with
+// L(0) - L(h) = ½ hᵀ⋅(λ⋅h - Jᵀ⋅r) (Algorithm 3.16 of [1]):
+//
+//   ϱ > 0 (accept):  λ ← λ⋅max(⅓, 1 - (2ϱ - 1)³)  and  ν ← 2
+//   ϱ ≤ 0 (reject):  λ ← λ⋅ν                       and  ν ← 2⋅ν
+//
+//   NOTE: (1) Check Convergence to see how to set max number of iterations and history. Ftol is
+//             not used; the solution converges if (a) ‖Jᵀ⋅r‖∞ ≤ Gtol, (b) ‖r‖ ≤ Rtol, or
+//             (c) ‖h‖ ≤ Xtol⋅(‖x‖ + Xtol)
+//         (2) NumFeval counts the calls to the residual function and NumGeval counts the calls
+//             to the Jacobian function
+//         (3) The history records the cost F(x) and the accepted steps; HistLambda records λ
+//             after each iteration (if UseHist = true)
+//
+//   REFERENCES:
+//   [1] Madsen K, Nielsen HB and Tingleff O (2004) Methods for non-linear least squares
+//       problems. 2nd Edition. Informatics and Mathematical Modelling, Technical University
+//       of Denmark. 60p
+//   [2] Nocedal, J. and Wright, S. (2006) Numerical Optimization.
+//       Springer Series in Operations Research. 2nd Edition. Springer. 664p
+//
+type LevMar struct {
+
+	// merge properties
+	Convergence // auxiliary object to check convergence
+
+	// configuration
+	Rtol float64 // tolerance on the residual norm ‖r‖ [default = 1e-12]
+	Xtol float64 // tolerance on the step size ‖h‖ (relative to ‖x‖) [default = 1e-12]
+	Tau  float64 // τ: the initial λ is τ⋅max(diag(Jᵀ⋅J)) [default = 1e-3]
+
+	// output
+	Lambda     float64   // λ: latest Marquardt parameter
+	HistLambda []float64 // [it] history of λ (if UseHist = true)
+
+	// internal
+	nres int        // m: number of residuals
+	rfcn fun.Vv     // residual function r(x)
+	jfcn fun.Mv     // Jacobian function J = dr/dx
+	r    la.Vector  // [nres] residual @ x
+	rnew la.Vector  // [nres] residual @ x + h
+	J    *la.Matrix // [nres][ndim] Jacobian @ x
+	A    *la.Matrix // [ndim][ndim] Jᵀ⋅J + λ⋅I
+	L    *la.Matrix // [ndim][ndim] Cholesky factor of A
+	g    la.Vector  // [ndim] gradient Jᵀ⋅r
+	h    la.Vector  // [ndim] step
+	xnew la.Vector  // [ndim] trial point x + h
+	mg   la.Vector  // [ndim] -g
+	rw   la.Vector  // [nres] workspace for Ffcn and Gfcn
+	Jw   *la.Matrix // [nres][ndim] workspace for Gfcn
+	diag []float64  // [ndim] diagonal of Jᵀ⋅J
+}
+
+// NewLevMar returns a new Levenberg-Marquardt solver for nonlinear least-squares problems
+//   nres -- m: number of residuals
+//   ndim -- n: number of unknowns (ndim ≤ nres is expected)
+//   rfcn -- residual function: r(x) with len(r) = nres
+//   Jfcn -- Jacobian function: J = dr/dx with dimensions [nres][ndim]
+func NewLevMar(nres, ndim int, rfcn fun.Vv, Jfcn fun.Mv) (o *LevMar) {
+	o = new(LevMar)
+	o.nres = nres
+	o.rfcn = rfcn
+	o.jfcn = Jfcn
+	o.rw = la.NewVector(nres)
+	o.Jw = la.NewMatrix(nres, ndim)
+	o.InitConvergence(func(x la.Vector) float64 {
+		o.rfcn(o.rw, x)
+		return la.VecDot(o.rw, o.rw) / 2.0
+	}, func(g, x la.Vector) {
+		o.rfcn(o.rw, x)
+		o.jfcn(o.Jw, x)
+		la.MatTrVecMul(g, 1, o.Jw, o.rw) // g := Jᵀ⋅r
+	})
+	o.Gtol = 1e-10
+	o.Rtol = 1e-12
+	o.Xtol = 1e-12
+	o.Tau = 1e-3
+	o.r = la.NewVector(nres)
+	o.rnew = la.NewVector(nres)
+	o.J = la.NewMatrix(nres, ndim)
+	o.A = la.NewMatrix(ndim, ndim)
+	o.L = la.NewMatrix(ndim, ndim)
+	o.g = la.NewVector(ndim)
+	o.h = la.NewVector(ndim)
+	o.xnew = la.NewVector(ndim)
+	o.mg = la.NewVector(ndim)
+	o.diag = make([]float64, ndim)
+	return
+}
+
+// Min solves the least-squares problem
+//
+//  Input:
+//    x -- [ndim] initial starting point (will be modified)
+//    params -- [may be nil] optional parameters. e.g. "maxit", "tau". Example:
+//                 params := dbf.NewParams(
+//                     &dbf.P{N: "maxit", V: 1000},
+//                     &dbf.P{N: "gtol", V: 1e-10},
+//                     &dbf.P{N: "rtol", V: 1e-12},
+//                     &dbf.P{N: "xtol", V: 1e-12},
+//                     &dbf.P{N: "tau", V: 1e-3},
+//                     &dbf.P{N: "maxfeval", V: 5000},
+//                     &dbf.P{N: "hist", V: 1},
+//                 )
+//
+//  Output:
+//    cost -- F(x@min) = ½ ‖r(x@min)‖²
+//    x -- [modify input] position of minimum F({x})
+//
+func (o *LevMar) Min(x la.Vector, params dbf.Params) (cost float64) {
+
+	// set parameters
+	o.Convergence.SetParams(params)
+	o.Rtol = params.GetValueOrDefault("rtol", o.Rtol)
+	o.Xtol = params.GetValueOrDefault("xtol", o.Xtol)
+	o.Tau = params.GetValueOrDefault("tau", o.Tau)
+	if o.Tau <= 0 {
+		chk.Panic("τ must be positive. τ=%g is invalid\n", o.Tau)
+	}
+
+	// initializations
+	o.NumFeval, o.NumGeval = 0, 0
+	o.startBudget()
+	o.residual(o.r, x)
+	o.jacobian(x)
+	cost = la.VecDot(o.r, o.r) / 2.0
+
+	// initial λ
+	o.Lambda = 0
+	for i := 0; i < len(x); i++ {
+		o.Lambda = utl.Max(o.Lambda, o.diag[i])
+	}
+	o.Lambda *= o.Tau
+	ν := 2.0
+
+	// history
+	o.HistLambda = nil
+	if o.UseHist {
+		o.InitHist(x)
+		o.HistLambda = append(o.HistLambda, o.Lambda)
+	}
+
+	// iterations
+	step := 0.0 // length of the last accepted step (for the diagnostics)
+	for o.NumIter = 0; o.NumIter < o.MaxIt; o.NumIter++ {
+
+		// diagnostics
+		o.logIter(cost, o.g, step)
+
+		// exit point # 1: converged on gradient or residual
+		if o.g.Largest(1) <= o.Gtol {
+			o.Status = ConvGtol
+			return
+		}
+		if math.Sqrt(2.0*cost) <= o.Rtol {
+			o.Status = ConvFtol
+			return
+		}
+
+		// exit point # 2: budget exhausted
+		if o.budgetExceeded() {
+			return
+		}
+
+		// solve (Jᵀ⋅J + λ⋅I) ⋅ h = -g
+		o.step(o.Lambda)
+
+		// exit point # 3: converged on step size
+		if o.h.Norm() <= o.Xtol*(x.Norm()+o.Xtol) {
+			o.Status = ConvXtol
+			return
+		}
+
+		// gain ratio
+		la.VecAdd(o.xnew, 1, x, 1, o.h) // xnew := x + h
+		o.residual(o.rnew, o.xnew)
+		costNew := la.VecDot(o.rnew, o.rnew) / 2.0
+		pred := (o.Lambda*la.VecDot(o.h, o.h) - la.VecDot(o.h, o.g)) / 2.0 // L(0) - L(h)
+		ϱ := (cost - costNew) / pred
+
+		// accept step and decrease λ; or reject step and increase λ
+		if ϱ > 0 && pred > 0 {
+			copy(x, o.xnew)
+			copy(o.r, o.rnew)
+			o.jacobian(x)
+			cost = costNew
+			step = o.h.Norm()
+			o.Lambda *= utl.Max(1.0/3.0, 1.0-math.Pow(2.0*ϱ-1.0, 3))
+			ν = 2.0
+			if o.UseHist {
+				o.Hist.Append(cost, x, o.h)
+			}
+		} else {
+			step = 0
+			o.Lambda *= ν
+			ν *= 2.0
+		}
+		if o.UseHist {
+			o.HistLambda = append(o.HistLambda, o.Lambda)
+		}
+	}
+
+	// did not converge
+	o.Status = ConvMaxIt
+	chk.Panic("fail to converge after %d iterations\n", o.NumIter)
+	return
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// residual computes r @ x
+func (o *LevMar) residual(r, x la.Vector) {
+	o.NumFeval++
+	o.rfcn(r, x)
+}
+
+// jacobian computes J @ x, the gradient g = Jᵀ⋅r and the diagonal of Jᵀ⋅J
+//  NOTE: r must have been computed @ x
+func (o *LevMar) jacobian(x la.Vector) {
+	o.NumGeval++
+	o.jfcn(o.J, x)
+	la.MatTrVecMul(o.g, 1, o.J, o.r) // g := Jᵀ⋅r
+	for j := 0; j < len(x); j++ {
+		o.diag[j] = 0
+		for i := 0; i < o.nres; i++ {
+			o.diag[j] += o.J.Get(i, j) * o.J.Get(i, j)
+		}
+	}
+}
+
+// step solves (Jᵀ⋅J + λ⋅I) ⋅ h = -g by means of the Cholesky factorisation
+func (o *LevMar) step(λ float64) {
+	la.MatTrMatMul(o.A, 1, o.J, o.J) // A := Jᵀ⋅J
+	for j := 0; j < o.A.M; j++ {
+		o.A.Add(j, j, λ)
+	}
+	o.mg.Apply(-1, o.g)
+	la.Cholesky(o.L, o.A)
+	la.CholeskySolve(o.h, o.L, o.mg)
+}
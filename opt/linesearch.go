@@ -100,6 +100,24 @@ func (o *LineSearch) SetParams(params dbf.Params) {
 	o.CoefCubic = params.GetValueOrDefault("coefcubic", o.CoefCubic)
 }
 
+// SetCoefs sets the coefficients of the Wolfe conditions
+//   c1 -- "sufficient decrease" coefficient (Coef1)
+//   c2 -- "curvature condition" coefficient (Coef2)
+//   NOTE: the coefficients must satisfy 0 < c1 < c2 < 1; otherwise an error is returned and the
+//         coefficients are not modified
+func (o *LineSearch) SetCoefs(c1, c2 float64) (err error) {
+	if err = checkWolfeCoefs(c1, c2); err != nil {
+		return
+	}
+	o.Coef1, o.Coef2 = c1, c2
+	return
+}
+
+// CheckCoefs checks whether the coefficients of the Wolfe conditions satisfy 0 < Coef1 < Coef2 < 1
+func (o *LineSearch) CheckCoefs() (err error) {
+	return checkWolfeCoefs(o.Coef1, o.Coef2)
+}
+
 // Wolfe finds the scalar 'a' that gives a substantial reduction of f({x}+a⋅{u}) (Wolfe conditions)
 //
 //  Input:
@@ -113,8 +131,15 @@ func (o *LineSearch) SetParams(params dbf.Params) {
 //
 //  Reference: Algorithm 3.5, page 60 of [1]
 //
+//  NOTE: this function panics if the coefficients are invalid. See CheckCoefs
+//
 func (o *LineSearch) Wolfe(x, u la.Vector, useFold bool, fold float64) (a, f float64) {
 
+	// check
+	if err := o.CheckCoefs(); err != nil {
+		chk.Panic("%v\n", err)
+	}
+
 	// update x
 	defer func() {
 		la.VecAdd(x, 1, o.x, a, o.u) // xnew := x + a⋅u
@@ -331,6 +356,16 @@ func (o *LineSearch) PlotF(a, amin, amax float64, npts int) {
 	plt.Gll("$a$", "$f(a)$", nil)
 }
 
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// checkWolfeCoefs checks the coefficients of the Wolfe conditions
+func checkWolfeCoefs(c1, c2 float64) (err error) {
+	if c1 <= 0 || c2 <= c1 || c2 >= 1 {
+		return chk.Err("coefficients of the Wolfe conditions must satisfy 0 < c1 < c2 < 1. c1=%g and c2=%g are invalid", c1, c2)
+	}
+	return
+}
+
 // temporary ///////////////////////////////////////////////////////////////////////////////////////
 
 func cubicmin(a, fa, fpa, b, fb, c, fc float64) (xmin float64) {
@@ -0,0 +1,150 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/rnd"
+)
+
+// MultiStartRun holds the summary of one run (start) of MultiStart
+type MultiStartRun struct {
+	X0   la.Vector // initial point
+	X    la.Vector // final point
+	Fmin float64   // minimum found by this run (NaN if the solver failed)
+	Err  error     // error (panic) of the solver; e.g. fail to converge [nil if successful]
+}
+
+// MultiStart runs a minimizer from several initial points sampled uniformly within a box and
+// selects the best result; useful for multimodal objective functions
+//
+//   NOTE: (1) a new solver is created by Maker for each start; thus the solvers are independent
+//             and may run concurrently if NumWorkers ≠ 1. In this case, the objective function
+//             (and gradient) must be safe for concurrent calls
+//         (2) the initial points are generated sequentially before the runs; thus, with Seed > 0,
+//             the results are reproducible regardless of NumWorkers
+//         (3) panics of the solvers (e.g. fail to converge) are recorded in the summaries
+//
+type MultiStart struct {
+
+	// input
+	Maker func() NonLinSolver // creates a new solver; e.g. func() NonLinSolver { return NewConjGrad(prob) }
+	Lower la.Vector           // [ndim] lower bounds of the box for the initial points
+	Upper la.Vector           // [ndim] upper bounds of the box for the initial points
+
+	// configuration
+	NumStarts  int // number of starts [default = 10]
+	NumWorkers int // number of goroutines; use ≤ 0 for the number of CPUs [default = 1]
+	Seed       int // seed of the random numbers generator; use ≤ 0 for the global generator of rnd [default = 0]
+
+	// results
+	Runs []*MultiStartRun // [NumStarts] summary of each run
+	Best int              // index of the best run (ties are resolved by the first run); -1 if all runs failed
+}
+
+// NewMultiStart returns a new MultiStart object
+//   maker -- creates a new solver for each start
+//   lower -- [ndim] lower bounds of the box for the initial points
+//   upper -- [ndim] upper bounds of the box for the initial points
+func NewMultiStart(maker func() NonLinSolver, lower, upper la.Vector) (o *MultiStart) {
+	if len(lower) < 1 || len(upper) != len(lower) {
+		chk.Panic("lower and upper bounds must have the same length ≥ 1. %d and %d are invalid\n", len(lower), len(upper))
+	}
+	o = new(MultiStart)
+	o.Maker = maker
+	o.Lower = lower
+	o.Upper = upper
+	o.NumStarts = 10
+	o.NumWorkers = 1
+	return
+}
+
+// Min runs the solvers and returns the best result
+//
+//  Input:
+//    params -- [may be nil] optional parameters passed to the Min function of each solver. Also
+//              "nstarts", "nworkers" and "seed" set the corresponding fields of MultiStart
+//
+//  Output:
+//    xbest -- [ndim] x corresponding to the best run (nil if all runs failed)
+//    fmin  -- minimum of the best run (NaN if all runs failed)
+//
+func (o *MultiStart) Min(params dbf.Params) (xbest la.Vector, fmin float64) {
+
+	// set parameters
+	o.NumStarts = params.GetIntOrDefault("nstarts", o.NumStarts)
+	o.NumWorkers = params.GetIntOrDefault("nworkers", o.NumWorkers)
+	o.Seed = params.GetIntOrDefault("seed", o.Seed)
+	if o.NumStarts < 1 {
+		chk.Panic("number of starts must be at least 1. NumStarts=%d is invalid\n", o.NumStarts)
+	}
+
+	// generate initial points
+	var rng *rnd.RNG
+	if o.Seed > 0 {
+		rng = rnd.NewRNG(o.Seed)
+	}
+	ndim := len(o.Lower)
+	o.Runs = make([]*MultiStartRun, o.NumStarts)
+	for k := 0; k < o.NumStarts; k++ {
+		run := &MultiStartRun{X0: la.NewVector(ndim), X: la.NewVector(ndim), Fmin: math.NaN()}
+		for i := 0; i < ndim; i++ {
+			run.X0[i] = rng.Float64(o.Lower[i], o.Upper[i])
+		}
+		o.Runs[k] = run
+	}
+
+	// run solvers
+	nworkers := o.NumWorkers
+	if nworkers < 1 {
+		nworkers = runtime.NumCPU()
+	}
+	if nworkers > o.NumStarts {
+		nworkers = o.NumStarts
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < nworkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for k := w; k < o.NumStarts; k += nworkers {
+				o.Runs[k].solve(o.Maker(), params)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	// best
+	fmin, o.Best = math.NaN(), -1
+	for k, run := range o.Runs {
+		if run.Err == nil && !math.IsNaN(run.Fmin) && (o.Best < 0 || run.Fmin < fmin) {
+			fmin, o.Best = run.Fmin, k
+		}
+	}
+	if o.Best >= 0 {
+		xbest = o.Runs[o.Best].X.GetCopy()
+	}
+	return
+}
+
+// solve runs solver from X0 and records the results
+func (o *MultiStartRun) solve(solver NonLinSolver, params dbf.Params) {
+	defer func() {
+		if e := recover(); e != nil {
+			o.Fmin = math.NaN()
+			o.Err = chk.Err("solver failed: %s", strings.TrimSpace(fmt.Sprint(e)))
+		}
+	}()
+	copy(o.X, o.X0)
+	o.Fmin = solver.Min(o.X, params)
+}
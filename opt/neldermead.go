@@ -0,0 +1,260 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// NelderMead implements the multidimensional minimization by the Nelder-Mead (downhill simplex)
+// method (no derivatives required). A simplex with ndim+1 vertices is moved by reflection,
+// expansion, contraction and shrink steps [1,2]:
+//
+//   reflection:  xr = xc + α⋅(xc - xw)
+//   expansion:   xe = xc + γ⋅(xr - xc)
+//   contraction: xk = xc + ρ⋅(xr - xc)  (outside)  or  xk = xc + ρ⋅(xw - xc)  (inside)
+//   shrink:      xi = xb + σ⋅(xi - xb)
+//
+//   where xb is the best vertex, xw is the worst vertex and xc is the centroid of all vertices
+//   but the worst one
+//
+//   NOTE: (1) Check Convergence to see how to set convergence parameters,
+//             max iteration number, or to enable and access history of iterations
+//         (2) The history records the best vertex of each iteration
+//         (3) The solution converges if the size of the simplex; i.e. max ‖xi - xb‖∞, is
+//             smaller than Xtol⋅(1 + ‖xb‖∞). Ftol and Gtol are not used
+//
+//   REFERENCES:
+//   [1] Nelder JA and Mead R (1965) A simplex method for function minimization. The Computer
+//       Journal, 7(4):308-313
+//   [2] Lagarias JC, Reeds JA, Wright MH and Wright PE (1998) Convergence properties of the
+//       Nelder-Mead simplex method in low dimensions. SIAM Journal on Optimization, 9(1):112-147
+//
+type NelderMead struct {
+
+	// merge properties
+	Convergence // auxiliary object to check convergence
+
+	// configuration
+	Alpha float64 // α: reflection coefficient (α > 0) [default = 1]
+	Gamma float64 // γ: expansion coefficient (γ > 1 and γ > α) [default = 2]
+	Rho   float64 // ρ: contraction coefficient (0 < ρ < 1) [default = 0.5]
+	Sigma float64 // σ: shrink coefficient (0 < σ < 1) [default = 0.5]
+	Scale float64 // scale of the initial simplex: xi = x0 + Scale⋅max(|x0[i]|,1)⋅e_i [default = 0.05]
+	Xtol  float64 // tolerance on the size of the simplex [default = 1e-8]
+
+	// internal
+	verts []la.Vector // [ndim+1] vertices of the simplex
+	fvals []float64   // [ndim+1] function values at the vertices
+	idx   []int       // [ndim+1] indices of sorted vertices (best to worst)
+	xc    la.Vector   // centroid
+	xr    la.Vector   // reflected point
+	xe    la.Vector   // expanded or contracted point
+}
+
+// add optimizer to database
+func init() {
+	nlsMakersDB["neldermead"] = func(prob *Problem) NonLinSolver { return NewNelderMead(prob) }
+}
+
+// NewNelderMead returns a new multidimensional optimizer using the Nelder-Mead method (no derivatives required)
+func NewNelderMead(prob *Problem) (o *NelderMead) {
+	o = new(NelderMead)
+	o.InitConvergence(prob.Ffcn, nil)
+	o.Alpha = 1
+	o.Gamma = 2
+	o.Rho = 0.5
+	o.Sigma = 0.5
+	o.Scale = 0.05
+	o.Xtol = 1e-8
+	n := prob.Ndim
+	o.verts = make([]la.Vector, n+1)
+	for i := 0; i < n+1; i++ {
+		o.verts[i] = la.NewVector(n)
+	}
+	o.fvals = make([]float64, n+1)
+	o.idx = utl.IntRange(n + 1)
+	o.xc = la.NewVector(n)
+	o.xr = la.NewVector(n)
+	o.xe = la.NewVector(n)
+	return
+}
+
+// Min solves minimization problem
+//
+//  Input:
+//    x -- [ndim] initial starting point (will be modified)
+//    params -- [may be nil] optional parameters. e.g. "alpha", "maxit". Example:
+//                 params := dbf.NewParams(
+//                     &dbf.P{N: "alpha", V: 1},
+//                     &dbf.P{N: "gamma", V: 2},
+//                     &dbf.P{N: "rho", V: 0.5},
+//                     &dbf.P{N: "sigma", V: 0.5},
+//                     &dbf.P{N: "scale", V: 0.05},
+//                     &dbf.P{N: "xtol", V: 1e-8},
+//                     &dbf.P{N: "maxit", V: 1000},
+//                     &dbf.P{N: "hist", V: 1},
+//                 )
+//
+//  Output:
+//    fmin -- f(x@min) minimum f({x}) found
+//    x -- [modify input] position of minimum f({x}); i.e. the best vertex
+//
+func (o *NelderMead) Min(x la.Vector, params dbf.Params) (fmin float64) {
+
+	// set parameters
+	o.Convergence.SetParams(params)
+	o.Alpha = params.GetValueOrDefault("alpha", o.Alpha)
+	o.Gamma = params.GetValueOrDefault("gamma", o.Gamma)
+	o.Rho = params.GetValueOrDefault("rho", o.Rho)
+	o.Sigma = params.GetValueOrDefault("sigma", o.Sigma)
+	o.Scale = params.GetValueOrDefault("scale", o.Scale)
+	o.Xtol = params.GetValueOrDefault("xtol", o.Xtol)
+	if o.Alpha <= 0 || o.Gamma <= 1 || o.Gamma <= o.Alpha || o.Rho <= 0 || o.Rho >= 1 || o.Sigma <= 0 || o.Sigma >= 1 || o.Scale <= 0 {
+		chk.Panic("coefficients must satisfy α > 0, γ > max(1,α), 0 < ρ < 1, 0 < σ < 1 and scale > 0. α=%g, γ=%g, ρ=%g, σ=%g and scale=%g are invalid\n", o.Alpha, o.Gamma, o.Rho, o.Sigma, o.Scale)
+	}
+
+	// initial simplex
+	o.NumFeval, o.NumGeval = 0, 0
+	o.startBudget()
+	n := len(x)
+	for i := 0; i < n+1; i++ {
+		copy(o.verts[i], x)
+		if i > 0 {
+			o.verts[i][i-1] += o.Scale * utl.Max(math.Abs(x[i-1]), 1)
+		}
+		o.fvals[i] = o.Ffcn(o.verts[i])
+	}
+	o.sortVertices()
+	fmin = o.fvals[o.idx[0]]
+
+	// history
+	if o.UseHist {
+		o.InitHist(x)
+	}
+
+	// iterations
+	for o.NumIter = 0; o.NumIter < o.MaxIt; o.NumIter++ {
+
+		// best, second worst and worst vertices
+		ib, is, iw := o.idx[0], o.idx[n-1], o.idx[n]
+		fb, fs, fw := o.fvals[ib], o.fvals[is], o.fvals[iw]
+
+		// diagnostics: gradient not available; step of the best vertex
+		if o.logging() {
+			o.logIter(fb, nil, o.logStep(o.verts[ib]))
+		}
+
+		// exit point: converged on simplex size or budget exhausted
+		converged := o.simplexSize() <= o.Xtol*(1+o.verts[ib].Largest(1))
+		if converged || o.budgetExceeded() {
+			if converged {
+				o.Status = ConvXtol
+			}
+			copy(x, o.verts[ib])
+			return fb
+		}
+
+		// centroid of all vertices but the worst one
+		o.xc.Fill(0)
+		for k := 0; k < n; k++ {
+			la.VecAdd(o.xc, 1, o.xc, 1.0/float64(n), o.verts[o.idx[k]])
+		}
+
+		// reflection
+		la.VecAdd(o.xr, 1+o.Alpha, o.xc, -o.Alpha, o.verts[iw])
+		fr := o.Ffcn(o.xr)
+		switch {
+
+		// accept reflected point
+		case fb <= fr && fr < fs:
+			o.replaceWorst(o.xr, fr)
+
+		// expansion
+		case fr < fb:
+			la.VecAdd(o.xe, 1-o.Gamma, o.xc, o.Gamma, o.xr)
+			fe := o.Ffcn(o.xe)
+			if fe < fr {
+				o.replaceWorst(o.xe, fe)
+			} else {
+				o.replaceWorst(o.xr, fr)
+			}
+
+		// outside contraction
+		case fr < fw:
+			la.VecAdd(o.xe, 1-o.Rho, o.xc, o.Rho, o.xr)
+			fk := o.Ffcn(o.xe)
+			if fk <= fr {
+				o.replaceWorst(o.xe, fk)
+			} else {
+				o.shrink()
+			}
+
+		// inside contraction
+		default:
+			la.VecAdd(o.xe, 1-o.Rho, o.xc, o.Rho, o.verts[iw])
+			fk := o.Ffcn(o.xe)
+			if fk < fw {
+				o.replaceWorst(o.xe, fk)
+			} else {
+				o.shrink()
+			}
+		}
+		o.sortVertices()
+		fmin = o.fvals[o.idx[0]]
+
+		// history
+		if o.UseHist {
+			la.VecAdd(o.uhist, 1, o.verts[o.idx[0]], -1, o.Hist.HistX[len(o.Hist.HistX)-1])
+			o.Hist.Append(fmin, o.verts[o.idx[0]], o.uhist)
+		}
+	}
+
+	// did not converge
+	copy(x, o.verts[o.idx[0]])
+	o.Status = ConvMaxIt
+	chk.Panic("fail to converge after %d iterations\n", o.NumIter)
+	return
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// sortVertices sorts the indices of vertices from best to worst
+func (o *NelderMead) sortVertices() {
+	sort.SliceStable(o.idx, func(a, b int) bool { return o.fvals[o.idx[a]] < o.fvals[o.idx[b]] })
+}
+
+// replaceWorst replaces the worst vertex by x with f(x) = fx
+func (o *NelderMead) replaceWorst(x la.Vector, fx float64) {
+	iw := o.idx[len(o.idx)-1]
+	copy(o.verts[iw], x)
+	o.fvals[iw] = fx
+}
+
+// shrink shrinks all vertices towards the best one
+func (o *NelderMead) shrink() {
+	xb := o.verts[o.idx[0]]
+	for _, i := range o.idx[1:] {
+		la.VecAdd(o.verts[i], o.Sigma, o.verts[i], 1-o.Sigma, xb)
+		o.fvals[i] = o.Ffcn(o.verts[i])
+	}
+}
+
+// simplexSize returns max ‖xi - xb‖∞
+func (o *NelderMead) simplexSize() (size float64) {
+	xb := o.verts[o.idx[0]]
+	for _, i := range o.idx[1:] {
+		for j := 0; j < len(xb); j++ {
+			size = utl.Max(size, math.Abs(o.verts[i][j]-xb[j]))
+		}
+	}
+	return
+}
@@ -0,0 +1,202 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/num"
+)
+
+// Penalized implements the quadratic penalty method for problems with general nonlinear
+// inequality constraints (Chapter 17 of [1]):
+//
+//   min f(x)   subject to   c_i(x) ≤ 0
+//
+//   is replaced by a sequence of unconstrained problems with increasing μ:
+//
+//   min φ(x; μ) = f(x) + μ/2 ⋅ Σ max(0, c_i(x))²
+//
+//   NOTE: (1) the gradients of f and c_i may be nil; in this case, they are computed
+//             numerically using central differences (see JacStep)
+//         (2) F and G with the current Mu may be given to any solver; e.g. through Problem().
+//             SolvePenalized performs the outer loop that increases μ
+//
+//   REFERENCES:
+//   [1] Nocedal J and Wright S (2006) Numerical Optimization. Springer Series in Operations
+//       Research. 2nd Edition. Springer. 664p
+//
+type Penalized struct {
+
+	// input
+	Ndim   int      // length of x
+	Ffcn   fun.Sv   // objective function f(x)
+	Gfcn   fun.Vv   // gradient of objective function [may be nil]
+	Cfcns  []fun.Sv // inequality constraints c_i(x) ≤ 0
+	Cgrads []fun.Vv // gradients of constraints [may be nil or have nil entries]
+
+	// configuration
+	Mu0      float64 // initial penalty parameter [default = 10]
+	MuFactor float64 // factor to increase the penalty parameter: μ ← MuFactor⋅μ [default = 10]
+	MaxOuter int     // max number of outer iterations (unconstrained minimizations) [default = 20]
+	Ctol     float64 // tolerance on the violation of constraints: max_i max(0, c_i(x)) [default = 1e-6]
+	JacStep  float64 // step size for numerical gradients [default = 1e-3]
+
+	// statistics
+	Mu        float64 // current penalty parameter
+	NumOuter  int     // number of outer iterations of the last call to SolvePenalized
+	Violation float64 // violation of constraints at the solution of the last call to SolvePenalized
+
+	// internal
+	xg la.Vector // x for numerical gradients
+	gc la.Vector // gradient of one constraint
+}
+
+// NewPenalized returns a new object to solve problems with inequality constraints by the
+// quadratic penalty method
+//   ndim   -- length of x
+//   ffcn   -- objective function f(x)
+//   gfcn   -- gradient of objective function [may be nil]
+//   cfcns  -- inequality constraints c_i(x) ≤ 0
+//   cgrads -- gradients of constraints [may be nil or have nil entries]
+func NewPenalized(ndim int, ffcn fun.Sv, gfcn fun.Vv, cfcns []fun.Sv, cgrads []fun.Vv) (o *Penalized) {
+	if cgrads != nil && len(cgrads) != len(cfcns) {
+		chk.Panic("number of gradients of constraints must be equal to the number of constraints = %d. %d is invalid\n", len(cfcns), len(cgrads))
+	}
+	o = new(Penalized)
+	o.Ndim = ndim
+	o.Ffcn = ffcn
+	o.Gfcn = gfcn
+	o.Cfcns = cfcns
+	o.Cgrads = cgrads
+	o.Mu0 = 10
+	o.MuFactor = 10
+	o.MaxOuter = 20
+	o.Ctol = 1e-6
+	o.JacStep = 1e-3
+	o.Mu = o.Mu0
+	o.xg = la.NewVector(ndim)
+	o.gc = la.NewVector(ndim)
+	return
+}
+
+// F computes the penalized objective function φ(x; μ) with the current Mu
+func (o *Penalized) F(x la.Vector) (φ float64) {
+	φ = o.Ffcn(x)
+	for _, c := range o.Cfcns {
+		if ci := c(x); ci > 0 {
+			φ += o.Mu / 2.0 * ci * ci
+		}
+	}
+	return
+}
+
+// G computes the gradient of the penalized objective function with the current Mu
+//   g = ∇f + μ ⋅ Σ max(0, c_i) ⋅ ∇c_i
+func (o *Penalized) G(g, x la.Vector) {
+	if o.Gfcn != nil {
+		o.Gfcn(g, x)
+	} else {
+		o.numericalGradient(g, x, o.Ffcn)
+	}
+	for i, c := range o.Cfcns {
+		ci := c(x)
+		if ci <= 0 {
+			continue
+		}
+		if o.Cgrads != nil && o.Cgrads[i] != nil {
+			o.Cgrads[i](o.gc, x)
+		} else {
+			o.numericalGradient(o.gc, x, c)
+		}
+		la.VecAdd(g, 1, g, o.Mu*ci, o.gc)
+	}
+}
+
+// Problem returns a problem with the penalized functions F and G (with the current Mu)
+func (o *Penalized) Problem() (p *Problem) {
+	return &Problem{Ndim: o.Ndim, Ffcn: o.F, Gfcn: o.G}
+}
+
+// MaxViolation returns the violation of constraints: max_i max(0, c_i(x))
+func (o *Penalized) MaxViolation(x la.Vector) (v float64) {
+	for _, c := range o.Cfcns {
+		v = math.Max(v, c(x))
+	}
+	return
+}
+
+// SolvePenalized minimizes f(x) subject to c_i(x) ≤ 0 by solving a sequence of unconstrained
+// problems with increasing penalty parameter μ until the violation of the constraints is smaller
+// than Ctol. Each unconstrained problem starts from the solution of the previous one
+//
+//  Input:
+//    o      -- penalized problem
+//    x      -- [ndim] initial starting point (will be modified)
+//    maker  -- creates a new solver for each unconstrained problem [may be nil ⇒ ConjGrad with
+//              Brent's line search]
+//    params -- [may be nil] optional parameters passed to the Min function of each solver
+//
+//  Output:
+//    fmin -- f(x@min) value of the (non-penalized) objective function
+//    x    -- [modify input] position of minimum
+//    err  -- error if a solver fails or if the constraints are still violated after MaxOuter
+//            outer iterations
+//
+func SolvePenalized(o *Penalized, x la.Vector, maker func(prob *Problem) NonLinSolver, params dbf.Params) (fmin float64, err error) {
+	if maker == nil {
+		maker = func(prob *Problem) NonLinSolver {
+			sol := NewConjGrad(prob)
+			sol.UseBrent = true // more robust than the Wolfe line search for large μ
+			return sol
+		}
+	}
+	o.Mu = o.Mu0
+	for o.NumOuter = 1; ; o.NumOuter++ {
+		if err = o.minimize(maker(o.Problem()), x, params); err != nil {
+			return o.Ffcn(x), err
+		}
+		o.Violation = o.MaxViolation(x)
+		if o.Violation <= o.Ctol {
+			return o.Ffcn(x), nil
+		}
+		if o.NumOuter >= o.MaxOuter {
+			break
+		}
+		o.Mu *= o.MuFactor
+	}
+	return o.Ffcn(x), chk.Err("violation of constraints = %g is greater than Ctol = %g after %d outer iterations (μ = %g)", o.Violation, o.Ctol, o.NumOuter, o.Mu)
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// minimize runs solver converting its panics into errors
+func (o *Penalized) minimize(solver NonLinSolver, x la.Vector, params dbf.Params) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = chk.Err("solver failed with μ = %g: %s", o.Mu, strings.TrimSpace(fmt.Sprint(e)))
+		}
+	}()
+	solver.Min(x, params)
+	return
+}
+
+// numericalGradient computes g = df/dx @ x using central differences
+func (o *Penalized) numericalGradient(g, x la.Vector, f fun.Sv) {
+	copy(o.xg, x)
+	for k := 0; k < len(x); k++ {
+		g[k] = num.DerivCen5(x[k], o.JacStep, func(xk float64) float64 {
+			o.xg[k] = xk
+			return f(o.xg)
+		})
+		o.xg[k] = x[k]
+	}
+}
@@ -5,6 +5,8 @@
 package opt
 
 import (
+	"math"
+
 	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/fun"
 	"github.com/cpmech/gosl/fun/dbf"
@@ -14,8 +16,11 @@ import (
 
 // Powell implements the multidimensional minimization by Powell's method (no derivatives required)
 //
-//   NOTE: Check Convergence to see how to set convergence parameters,
-//         max iteration number, or to enable and access history of iterations
+//   NOTE: (1) Check Convergence to see how to set convergence parameters,
+//             max iteration number, or to enable and access history of iterations
+//         (2) If the new (average) direction is nearly parallel to one of the remaining
+//             directions; i.e. |cos θ| > 1 - ParTol, the set of directions would become linearly
+//             dependent. In this case, the directions are reset to the unit vectors
 //
 //   REFERENCES:
 //   [1] Press WH, Teukolsky SA, Vetterling WT, Fnannery BP (2007) Numerical Recipes:
@@ -26,8 +31,12 @@ type Powell struct {
 	// merge properties
 	Convergence // auxiliary object to check convergence
 
+	// configuration
+	ParTol float64 // tolerance to detect (nearly) parallel directions [default = 1e-8]
+
 	// access
-	Umat *la.Matrix // matrix whose columns contain the directions u
+	Umat     *la.Matrix // matrix whose columns contain the directions u
+	NumReset int        // number of times the directions have been reset due to (nearly) parallel directions
 
 	// internal
 	line *num.LineSolver // line solver wrapping Brent's method
@@ -50,6 +59,7 @@ func NewPowell(prob *Problem) (o *Powell) {
 	o.xext = la.NewVector(prob.Ndim)
 	o.uave = la.NewVector(prob.Ndim)
 	o.Umat = la.NewMatrix(prob.Ndim, prob.Ndim)
+	o.ParTol = 1e-8
 	return
 }
 
@@ -77,6 +87,8 @@ func (o *Powell) Min(x la.Vector, params dbf.Params) (fmin float64) {
 
 	// initializations
 	o.NumFeval = 0
+	o.startBudget()
+	o.NumReset = 0
 	ndim := len(x)
 	fmin = o.Ffcn(x)
 
@@ -92,6 +104,16 @@ func (o *Powell) Min(x la.Vector, params dbf.Params) (fmin float64) {
 	// iterations
 	for o.NumIter = 0; o.NumIter < o.MaxIt; o.NumIter++ {
 
+		// diagnostics: gradient not available
+		if o.logging() {
+			o.logIter(fmin, nil, o.logStep(x))
+		}
+
+		// exit point # 0: budget exhausted
+		if o.budgetExceeded() {
+			return
+		}
+
 		// set iteration values
 		fx := fmin  // iteration f({x})
 		jdel := 0   // index of largest decrease
@@ -120,6 +142,7 @@ func (o *Powell) Min(x la.Vector, params dbf.Params) (fmin float64) {
 
 		// exit point
 		if o.Fconvergence(fx, fmin) {
+			o.Status = ConvFtol
 			return
 		}
 
@@ -140,10 +163,15 @@ func (o *Powell) Min(x la.Vector, params dbf.Params) (fmin float64) {
 				// minimize along average direction
 				λhist, fmin = o.line.MinUpdateX(x, o.uave)
 
-				// save average direction
-				for i := 0; i < ndim; i++ {
-					o.Umat.Set(i, jdel, o.Umat.Get(i, ndim-1))
-					o.Umat.Set(i, ndim-1, o.uave[i])
+				// save average direction or reset directions if it is (nearly) parallel to another one
+				if o.isParallel(o.uave, jdel) {
+					o.Umat.SetDiag(1)
+					o.NumReset++
+				} else {
+					for i := 0; i < ndim; i++ {
+						o.Umat.Set(i, jdel, o.Umat.Get(i, ndim-1))
+						o.Umat.Set(i, ndim-1, o.uave[i])
+					}
 				}
 
 				// history
@@ -162,6 +190,32 @@ func (o *Powell) Min(x la.Vector, params dbf.Params) (fmin float64) {
 	}
 
 	// did not converge
+	o.Status = ConvMaxIt
 	chk.Panic("fail to converge after %d iterations\n", o.NumIter)
 	return
 }
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// isParallel returns whether u is (nearly) parallel to any direction in Umat, except the one
+// with index jdel (to be discarded)
+func (o *Powell) isParallel(u la.Vector, jdel int) bool {
+	unrm := u.Norm()
+	if unrm == 0 {
+		return true
+	}
+	for j := 0; j < o.Umat.N; j++ {
+		if j == jdel {
+			continue
+		}
+		uj := o.Umat.Col(j)
+		den := unrm * uj.Norm()
+		if den == 0 {
+			continue
+		}
+		if math.Abs(la.VecDot(u, uj))/den > 1.0-o.ParTol {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/la"
+)
+
+// SGD implements the stochastic gradient descent method with momentum (heavy ball) for
+// objectives given by sums over batches of data (Eq. 8.15 and 8.16 of [1]):
+//
+//   v ← μ⋅v - α⋅g(x, batch)
+//   x ← x + v
+//
+//   where α is the learning rate and μ is the momentum (μ = 0 gives the plain SGD method)
+//
+//   NOTE: Check Stochastic to see how to set the number of epochs, learning rate schedule, loss
+//         function and history
+//
+//   REFERENCES:
+//   [1] Goodfellow I, Bengio Y and Courville A (2016) Deep Learning. MIT Press. 775p
+//
+type SGD struct {
+
+	// merge properties
+	Stochastic // auxiliary object with configuration and statistics
+
+	// configuration
+	Momentum float64 // μ: momentum (0 ≤ μ < 1) [default = 0.9]
+
+	// internal
+	v la.Vector // velocity
+}
+
+// NewSGD returns a new stochastic gradient descent optimizer
+//   ndim     -- length of x
+//   nbatches -- number of batches
+//   gfcn     -- stochastic gradient g(x, batch)
+func NewSGD(ndim, nbatches int, gfcn StochasticGrad) (o *SGD) {
+	o = new(SGD)
+	o.initStochastic(ndim, nbatches, gfcn, 0.01)
+	o.Momentum = 0.9
+	o.v = la.NewVector(ndim)
+	return
+}
+
+// Min minimizes the objective function
+//
+//  Input:
+//    x -- [ndim] initial starting point (will be modified)
+//    params -- [may be nil] optional parameters. e.g. "epochs", "alpha". Example:
+//                 params := dbf.NewParams(
+//                     &dbf.P{N: "epochs", V: 100},
+//                     &dbf.P{N: "alpha", V: 0.01},
+//                     &dbf.P{N: "momentum", V: 0.9},
+//                     &dbf.P{N: "hist", V: 1},
+//                 )
+//
+//  Output:
+//    loss -- running loss of the last epoch (NaN if Loss == nil)
+//    x -- [modify input] final x
+//
+func (o *SGD) Min(x la.Vector, params dbf.Params) (loss float64) {
+
+	// set parameters
+	o.setParams(params)
+	o.Momentum = params.GetValueOrDefault("momentum", o.Momentum)
+	if o.Momentum < 0 || o.Momentum >= 1 {
+		chk.Panic("momentum must satisfy 0 ≤ μ < 1. μ=%g is invalid\n", o.Momentum)
+	}
+
+	// run
+	o.v.Fill(0)
+	return o.run(x, func(x, g la.Vector, α float64) {
+		la.VecAdd(o.v, o.Momentum, o.v, -α, g) // v := μ⋅v - α⋅g
+		la.VecAdd(x, 1, x, 1, o.v)             // x := x + v
+	})
+}
@@ -0,0 +1,149 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/la"
+)
+
+// StochasticGrad defines a function that computes the (stochastic) gradient of the objective
+// function at x using the data of one batch (mini-batch); e.g. the gradient of the loss over the
+// samples of the batch
+//   batch -- index of batch in [0, NumBatches)
+type StochasticGrad func(x la.Vector, batch int) la.Vector
+
+// StochasticLoss defines a function that computes the loss (objective) at x over one batch
+//   batch -- index of batch in [0, NumBatches)
+type StochasticLoss func(x la.Vector, batch int) float64
+
+// Stochastic holds the configuration and statistics of stochastic optimizers (e.g. SGD, Adam).
+// Each epoch performs one update of x per batch, visiting the batches in order; thus, the data
+// should be shuffled by the user, if needed (e.g. by permuting the samples in each epoch).
+type Stochastic struct {
+
+	// input
+	NumBatches int            // number of batches
+	Gfcn       StochasticGrad // stochastic gradient
+	Loss       StochasticLoss // loss of batch [may be nil]; needed to compute the running loss
+
+	// configuration
+	NumEpochs int                    // number of epochs (passes over all batches) [default = 100]
+	LearnRate func(iter int) float64 // learning rate α(iter); iter = number of updates so far [may be nil ⇒ constant]
+	Alpha     float64                // constant learning rate used if LearnRate == nil
+	UseHist   bool                   // save history of running loss (requires Loss)
+
+	// Observer [may be nil] is called after each epoch with the running loss (NaN if Loss == nil)
+	// and the current x. Returning stop = true terminates the iterations
+	Observer func(epoch int, loss float64, x la.Vector) (stop bool)
+
+	// statistics and History
+	NumIter  int      // number of updates from last call to Min
+	NumEpoch int      // number of epochs from last call to Min
+	NumGeval int      // number of calls to Gfcn
+	NumFeval int      // number of calls to Loss
+	Hist     *History // history of running loss (mean loss of batches over each epoch) and x at the end of each epoch
+
+	// internal
+	xold la.Vector // x at the beginning of epoch
+	u    la.Vector // change of x during epoch (for History)
+}
+
+// initStochastic initialises the configuration
+func (o *Stochastic) initStochastic(ndim, nbatches int, gfcn StochasticGrad, alpha float64) {
+	if nbatches < 1 {
+		chk.Panic("number of batches must be at least 1. nbatches=%d is invalid\n", nbatches)
+	}
+	o.NumBatches = nbatches
+	o.Gfcn = gfcn
+	o.NumEpochs = 100
+	o.Alpha = alpha
+	o.xold = la.NewVector(ndim)
+	o.u = la.NewVector(ndim)
+}
+
+// setParams sets parameters
+func (o *Stochastic) setParams(params dbf.Params) {
+	o.NumEpochs = params.GetIntOrDefault("epochs", o.NumEpochs)
+	o.Alpha = params.GetValueOrDefault("alpha", o.Alpha)
+	o.UseHist = params.GetBoolOrDefault("hist", o.UseHist)
+}
+
+// rate returns the learning rate
+func (o *Stochastic) rate() float64 {
+	if o.LearnRate != nil {
+		return o.LearnRate(o.NumIter)
+	}
+	return o.Alpha
+}
+
+// grad computes the stochastic gradient
+func (o *Stochastic) grad(x la.Vector, batch int) (g la.Vector) {
+	o.NumGeval++
+	g = o.Gfcn(x, batch)
+	if len(g) != len(x) {
+		chk.Panic("length of stochastic gradient must be equal to ndim = %d. %d is invalid\n", len(x), len(g))
+	}
+	return
+}
+
+// loss computes the loss of batch if Loss != nil; otherwise returns NaN
+func (o *Stochastic) loss(x la.Vector, batch int) float64 {
+	if o.Loss == nil {
+		return math.NaN()
+	}
+	o.NumFeval++
+	return o.Loss(x, batch)
+}
+
+// run performs the epochs calling update for each batch and returns the running loss of the last
+// epoch; i.e. the mean of the losses of batches computed before the updates (NaN if Loss == nil)
+func (o *Stochastic) run(x la.Vector, update func(x, g la.Vector, α float64)) (loss float64) {
+
+	// check
+	if o.UseHist && o.Loss == nil {
+		chk.Panic("the loss function is needed to record the history of running loss\n")
+	}
+
+	// initialisation
+	o.NumIter, o.NumEpoch, o.NumGeval, o.NumFeval = 0, 0, 0, 0
+	loss = math.NaN()
+	o.Hist = nil
+	if o.UseHist {
+		loss0 := 0.0
+		for batch := 0; batch < o.NumBatches; batch++ {
+			loss0 += o.loss(x, batch)
+		}
+		o.Hist = NewHistory(o.NumEpochs, loss0/float64(o.NumBatches), x, nil)
+	}
+
+	// epochs
+	for o.NumEpoch = 0; o.NumEpoch < o.NumEpochs; {
+		copy(o.xold, x)
+		sum := 0.0
+		for batch := 0; batch < o.NumBatches; batch++ {
+			sum += o.loss(x, batch)
+			update(x, o.grad(x, batch), o.rate())
+			o.NumIter++
+		}
+		loss = sum / float64(o.NumBatches)
+		o.NumEpoch++
+
+		// history
+		if o.UseHist {
+			la.VecAdd(o.u, 1, x, -1, o.xold)
+			o.Hist.Append(loss, x, o.u)
+		}
+
+		// observer
+		if o.Observer != nil && o.Observer(o.NumEpoch, loss, x) {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,118 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/rnd"
+	"github.com/cpmech/gosl/stat"
+)
+
+// branin implements the Branin-Hoo function with minimum f = 0.397887 @ (-π,12.275), (π,2.275)
+// and (9.42478,2.475)
+func branin(x la.Vector) float64 {
+	a, b, c := 1.0, 5.1/(4*math.Pi*math.Pi), 5/math.Pi
+	r, s, t := 6.0, 10.0, 1/(8*math.Pi)
+	return a*math.Pow(x[1]-b*x[0]*x[0]+c*x[0]-r, 2) + s*(1-t)*math.Cos(x[0]) + s
+}
+
+func TestBayesOpt01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("BayesOpt01. Gaussian-process surrogate")
+
+	// 1D function
+	f := func(x la.Vector) float64 { return math.Sin(3*x[0]) + x[0]*x[0] - 0.7*x[0] }
+	o := NewBayesOpt(la.Vector{-1}, la.Vector{2}, stat.KernelRBF{Sigma2: 1, Length: 0.2})
+	o.Rng = rnd.NewRNG(1357)
+	for _, x := range []float64{-1, -0.5, 0, 0.5, 1, 1.5, 2} {
+		o.Tell(la.Vector{x}, f(la.Vector{x}))
+	}
+	o.Ask() // fit
+
+	// the surrogate interpolates the observations
+	for k, x := range o.X {
+		μ, σ := o.Predict(x)
+		chk.Float64(tst, io.Sf("μ(%g)", x[0]), 1e-4, μ, o.Y[k])
+		if σ > 1e-2 {
+			tst.Errorf("σ at observed point should be small. σ = %g\n", σ)
+		}
+	}
+	_, σ := o.Predict(la.Vector{0.25})
+	io.Pforan("σ(0.25) = %v\n", σ)
+	if σ < 1e-3 {
+		tst.Errorf("σ between observed points should not vanish. σ = %g\n", σ)
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	NewBayesOpt(la.Vector{0, 1}, la.Vector{1, 1}, nil)
+}
+
+func TestBayesOpt02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("BayesOpt02. Branin function: Bayesian optimization versus random search")
+
+	lower, upper := la.Vector{-5, 0}, la.Vector{10, 15}
+	fref := 0.397887
+	neval := 40
+
+	// Bayesian optimization
+	o := NewBayesOpt(lower, upper, nil)
+	o.Rng = rnd.NewRNG(1234)
+	found := -1
+	for i := 0; i < neval; i++ {
+		x := o.Ask()
+		o.Tell(x, branin(x))
+		if _, fb := o.Best(); found < 0 && fb < fref+0.05 {
+			found = i + 1
+		}
+	}
+	xbest, fbest := o.Best()
+	io.Pforan("BayesOpt:     xbest = %v  fbest = %v  (found after %d evaluations)\n", xbest, fbest, found)
+
+	// random search with many more evaluations
+	nrand := 400
+	xrand, frand := RandomSearch(branin, lower, upper, nrand, 1, rnd.NewRNG(1234))
+	io.Pforan("RandomSearch: xbest = %v  fbest = %v  (%d evaluations)\n", xrand, frand, nrand)
+
+	// check
+	if fbest > fref+0.05 {
+		tst.Errorf("BayesOpt should find the minimum within %d evaluations. fbest = %g\n", neval, fbest)
+	}
+	if frand <= fbest {
+		tst.Errorf("BayesOpt with %d evaluations should be better than random search with %d evaluations\n", neval, nrand)
+	}
+}
+
+func TestBayesOpt03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("BayesOpt03. repeated points and zero noise (jitter)")
+
+	// repeated observations make the covariance matrix singular if Noise = 0
+	f := func(x la.Vector) float64 { return (x[0] - 0.3) * (x[0] - 0.3) }
+	o := NewBayesOpt(la.Vector{0}, la.Vector{1}, stat.KernelRBF{Sigma2: 1, Length: 0.3})
+	o.Rng = rnd.NewRNG(4321)
+	o.Noise = 0
+	for _, x := range []float64{0.1, 0.5, 0.5, 0.9, 0.9} {
+		o.Tell(la.Vector{x}, f(la.Vector{x}))
+	}
+	x := o.Ask()
+	io.Pforan("x = %v\n", x)
+	chk.Float64(tst, "Noise", 1e-15, o.Noise, 0)
+	if x[0] < 0 || x[0] > 1 {
+		tst.Errorf("next point must be within the box. x = %v\n", x)
+		return
+	}
+	μ, _ := o.Predict(la.Vector{0.5})
+	chk.Float64(tst, "μ(0.5)", 1e-4, μ, f(la.Vector{0.5}))
+}
@@ -0,0 +1,92 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func runBFGSTest(tst *testing.T, p *Problem, x0 la.Vector, params dbf.Params, tolf, tolx float64) (sol *BFGS) {
+	xmin := x0.GetCopy()
+	sol = NewBFGS(p)
+	sol.UseHist = true
+	fmin := sol.Min(xmin, params)
+	io.Pforan("NumIter = %v\n", sol.NumIter)
+	io.Pf("NumFeval = %v\n", sol.NumFeval)
+	io.Pf("NumGeval = %v\n", sol.NumGeval)
+	chk.Float64(tst, "fmin", tolf, fmin, p.Fref)
+	chk.Array(tst, "xmin", tolx, xmin, p.Xref)
+	return
+}
+
+func TestBFGS01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("BFGS01. quadratic optimization in 2D and 3D")
+
+	// 2D
+	p := Factory.SimpleQuadratic2d()
+	runBFGSTest(tst, p, la.NewVectorSlice([]float64{1.5, -0.75}), nil, 1e-13, 1e-7)
+
+	// 3D
+	p = Factory.SimpleQuadratic3d()
+	sol := runBFGSTest(tst, p, la.NewVectorSlice([]float64{1, 2, 3}), nil, 1e-13, 1e-7)
+
+	// the inverse Hessian approximation is symmetric and close to the inverse Hessian
+	chk.Deep2(tst, "H", 1e-15, sol.H.GetDeep2(), sol.H.GetTranspose().GetDeep2())
+	Hess := la.NewMatrix(3, 3)
+	p.Hfcn(Hess, p.Xref)
+	HH := la.NewMatrix(3, 3)
+	la.MatMatMul(HH, 1, sol.H, Hess)
+	io.Pf("H⋅Hess =\n%v\n", HH.Print("%10.6f"))
+	I := la.NewMatrix(3, 3)
+	I.SetDiag(1)
+	chk.Deep2(tst, "H⋅Hess", 0.05, HH.GetDeep2(), I.GetDeep2()) // inexact line searches
+}
+
+func TestBFGS02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("BFGS02. Rosenbrock function")
+
+	// objective function: Rosenbrock
+	p := Factory.RosenbrockMulti(5)
+	x0 := la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9, 1.2})
+	params := dbf.NewParams(
+		&dbf.P{N: "ftol", V: 1e-12},
+	)
+	sol := runBFGSTest(tst, p, x0, params, 1e-13, 1e-6)
+
+	// compare with conjugate gradients
+	xcg := x0.GetCopy()
+	cg := NewConjGrad(p)
+	cg.Min(xcg, nil)
+	io.Pf("ConjGrad: NumIter = %v  NumFeval = %v  NumGeval = %v\n", cg.NumIter, cg.NumFeval, cg.NumGeval)
+	if sol.NumIter > cg.NumIter {
+		tst.Errorf("BFGS should require less iterations than ConjGrad\n")
+	}
+
+	// warm restart from a nearby point
+	x1 := la.NewVectorSlice([]float64{1.01, 0.99, 1.02, 0.98, 1.01})
+	sol.Min(x1, params)
+	nitWarm := sol.NumIter
+	chk.Array(tst, "xmin(warm)", 1e-6, x1, p.Xref)
+
+	// cold restart from the same point
+	x1 = la.NewVectorSlice([]float64{1.01, 0.99, 1.02, 0.98, 1.01})
+	sol.ResetHessian()
+	sol.Min(x1, params)
+	nitCold := sol.NumIter
+	chk.Array(tst, "xmin(cold)", 1e-6, x1, p.Xref)
+	io.Pforan("NumIter: warm = %v  cold = %v\n", nitWarm, nitCold)
+	if nitWarm > nitCold {
+		tst.Errorf("warm restart should require less iterations than cold restart\n")
+	}
+}
@@ -5,9 +5,13 @@
 package opt
 
 import (
+	"context"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
 	"github.com/cpmech/gosl/io"
 	"github.com/cpmech/gosl/la"
 	"github.com/cpmech/gosl/plt"
@@ -120,3 +124,690 @@ func TestConjGrad04(tst *testing.T) {
 	// run test
 	runConjGradTest(tst, "conjgrad04", p, x0, 1e-13, 1e-6)
 }
+
+func TestConjGrad05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ConjGrad05. errors instead of panics")
+
+	// not enough iterations
+	p := Factory.RosenbrockMulti(5)
+	sol := NewConjGrad(p)
+	x := la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9, 1.2})
+	_, err := sol.TryMin(x, dbf.NewParams(&dbf.P{N: "maxit", V: 3}))
+	io.Pforan("err = %v\n", err)
+	if err == nil {
+		tst.Errorf("TryMin should have failed with maxit = 3\n")
+		return
+	}
+	chk.Int(tst, "NumIter", sol.NumIter, 3)
+
+	// incorrect Jacobian
+	q := Factory.SimpleParaboloid()
+	Gfcn := q.Gfcn
+	q.Gfcn = func(g, x la.Vector) {
+		Gfcn(g, x)
+		g[0] *= 1.1
+	}
+	sol = NewConjGrad(q)
+	sol.CheckJfcn = true
+	_, err = sol.TryMin(la.NewVectorSlice([]float64{1, 1}), nil)
+	io.Pforan("err = %v\n", err)
+	if err == nil {
+		tst.Errorf("TryMin should have failed with incorrect Jacobian\n")
+	}
+
+	// Min panics
+	defer chk.RecoverTstPanicIsOK(tst)
+	sol = NewConjGrad(p)
+	sol.Min(la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9, 1.2}), dbf.NewParams(&dbf.P{N: "maxit", V: 3}))
+}
+
+func TestConjGrad05a(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ConjGrad05a. restart with steepest descent")
+
+	// multimodal function f(x) = Σ x_i²/10 - cos(2⋅x_i); the Polak-Ribiere direction computed
+	// after crossing a ridge is not a descent direction when starting at (3,-3)
+	p := new(Problem)
+	p.Ndim = 2
+	p.Ffcn = func(x la.Vector) (f float64) {
+		for i := 0; i < len(x); i++ {
+			f += x[i]*x[i]/10.0 - math.Cos(2.0*x[i])
+		}
+		return
+	}
+	p.Gfcn = func(g, x la.Vector) {
+		for i := 0; i < len(x); i++ {
+			g[i] = x[i]/5.0 + 2.0*math.Sin(2.0*x[i])
+		}
+	}
+
+	// unbounded and with box constraints (the restart direction must be projected)
+	g := la.NewVector(2)
+	for _, box := range []bool{false, true} {
+		for _, method := range []string{"wolfe", "brent", "hz"} {
+			sol := NewConjGrad(p)
+			sol.LineMethod = method
+			if box {
+				sol.Lower = la.NewVectorSlice([]float64{-5, -2.5})
+				sol.Upper = la.NewVectorSlice([]float64{5, 5})
+			}
+			x := la.NewVectorSlice([]float64{3, -3})
+			if box {
+				x[1] = -2.5
+			}
+			fmin, err := sol.TryMin(x, nil)
+			io.Pforan("box = %v, %5s: NumIter = %3d  x = %v  f = %v\n", box, method, sol.NumIter, x, fmin)
+			if err != nil {
+				tst.Errorf("box = %v, %s: %v\n", box, method, err)
+				return
+			}
+			p.Gfcn(g, x)
+			sol.projectGradient(g, x)
+			chk.Array(tst, io.Sf("box = %v, %s: projected ∇f", box, method), 1e-6, g, nil)
+		}
+	}
+}
+
+func TestConjGrad06(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ConjGrad06. numerical gradient")
+
+	// problems with and without gradient function
+	p := Factory.RosenbrockMulti(5)
+	q := &Problem{Ndim: p.Ndim, Ffcn: p.Ffcn, Fref: p.Fref, Xref: p.Xref}
+	x0 := la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9, 1.2})
+
+	// analytical gradient
+	sol1 := NewConjGrad(p)
+	xmin1 := x0.GetCopy()
+	fmin1 := sol1.Min(xmin1, nil)
+	checkConjGrad(tst, sol1, fmin1, p.Fref, 1e-13, 1e-6, xmin1, p.Xref)
+
+	// numerical gradient
+	sol2 := NewConjGrad(q)
+	sol2.CheckJfcn = true // ignored
+	xmin2 := x0.GetCopy()
+	fmin2, err := sol2.TryMin(xmin2, nil)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	checkConjGrad(tst, sol2, fmin2, q.Fref, 1e-11, 1e-5, xmin2, q.Xref)
+	if sol2.NumFeval < sol2.NumGeval*4*q.Ndim {
+		tst.Errorf("function evaluations of the numerical gradient must be counted in NumFeval\n")
+	}
+
+	// numerical gradient with Brent's method and smaller step
+	sol3 := NewConjGrad(q)
+	sol3.UseBrent = true
+	sol3.JacStep = 1e-4
+	xmin3 := x0.GetCopy()
+	fmin3 := sol3.Min(xmin3, nil)
+	checkConjGrad(tst, sol3, fmin3, q.Fref, 1e-11, 1e-5, xmin3, q.Xref)
+}
+
+func TestConjGrad07(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ConjGrad07. box constraints")
+
+	// quadratic function with minimum @ (2,-1) outside the box [0,1]×[0,1]; f is NaN outside
+	lower := la.NewVectorSlice([]float64{0, 0})
+	upper := la.NewVectorSlice([]float64{1, 1})
+	outside := 0
+	p := &Problem{
+		Ndim: 2,
+		Ffcn: func(x la.Vector) float64 {
+			if x[0] < 0 || x[0] > 1 || x[1] < 0 || x[1] > 1 {
+				outside++
+				return math.NaN()
+			}
+			return math.Pow(x[0]-2, 2) + math.Pow(x[1]+1, 2)
+		},
+		Gfcn: func(g, x la.Vector) {
+			g[0] = 2 * (x[0] - 2)
+			g[1] = 2 * (x[1] + 1)
+		},
+		Fref: 2,
+		Xref: []float64{1, 0},
+	}
+	for _, brent := range []bool{false, true} {
+		sol := NewConjGrad(p)
+		sol.UseBrent = brent
+		sol.Lower, sol.Upper = lower, upper
+		x := la.NewVectorSlice([]float64{0.2, 0.9})
+		fmin, err := sol.TryMin(x, nil)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		checkConjGrad(tst, sol, fmin, p.Fref, 1e-15, 1e-15, x, p.Xref)
+	}
+	chk.Int(tst, "number of evaluations outside the box", outside, 0)
+
+	// Rosenbrock function with x0 ≤ 0.5 ⇒ minimum @ (0.5, 0.25)
+	q := Factory.Rosenbrock2d(1, 100)
+	sol := NewConjGrad(q)
+	sol.Lower = la.NewVectorSlice([]float64{-2, -2})
+	sol.Upper = la.NewVectorSlice([]float64{0.5, 2})
+	x := la.NewVectorSlice([]float64{-1.2, 1})
+	fmin := sol.Min(x, nil)
+	checkConjGrad(tst, sol, fmin, 0.25, 1e-10, 1e-6, x, []float64{0.5, 0.25})
+
+	// errors
+	sol = NewConjGrad(p)
+	sol.Lower = la.NewVectorSlice([]float64{0})
+	sol.Upper = upper
+	_, err := sol.TryMin(la.NewVectorSlice([]float64{0.5, 0.5}), nil)
+	io.Pforan("err = %v\n", err)
+	if err == nil {
+		tst.Errorf("TryMin should have failed with mismatched bounds\n")
+	}
+	sol.Lower, sol.Upper = upper, lower
+	_, err = sol.TryMin(la.NewVectorSlice([]float64{0.5, 0.5}), nil)
+	io.Pforan("err = %v\n", err)
+	if err == nil {
+		tst.Errorf("TryMin should have failed with lower > upper\n")
+	}
+	sol.Lower, sol.Upper = lower, nil
+	_, err = sol.TryMin(la.NewVectorSlice([]float64{0.5, 0.5}), nil)
+	io.Pforan("err = %v\n", err)
+	if err == nil {
+		tst.Errorf("TryMin should have failed with missing upper bounds\n")
+	}
+}
+
+func TestConjGrad08(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ConjGrad08. observer")
+
+	// problem
+	p := Factory.RosenbrockMulti(5)
+	x0 := la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9, 1.2})
+
+	// observer checks the data and corrupts the copies
+	g := la.NewVector(p.Ndim)
+	var iters []int
+	var fxs []float64
+	xlast := la.NewVector(p.Ndim)
+	observer := func(iter int, fx float64, x, grad la.Vector) (stop bool) {
+		iters = append(iters, iter)
+		fxs = append(fxs, fx)
+		p.Gfcn(g, x)
+		chk.Float64(tst, io.Sf("f @ iter %d", iter), 1e-15, fx, p.Ffcn(x))
+		chk.Array(tst, io.Sf("grad @ iter %d", iter), 1e-15, grad, g)
+		copy(xlast, x)
+		x.Fill(123)
+		grad.Fill(123)
+		return
+	}
+
+	// compare with solution without observer
+	sol1 := NewConjGrad(p)
+	xmin1 := x0.GetCopy()
+	fmin1 := sol1.Min(xmin1, nil)
+	sol2 := NewConjGrad(p)
+	sol2.Observer = observer
+	xmin2 := x0.GetCopy()
+	fmin2 := sol2.Min(xmin2, nil)
+	io.Pforan("NumIter = %v  len(iters) = %v\n", sol2.NumIter, len(iters))
+	chk.Float64(tst, "fmin", 1e-15, fmin2, fmin1)
+	chk.Array(tst, "xmin", 1e-15, xmin2, xmin1)
+	chk.Int(tst, "NumIter", sol2.NumIter, sol1.NumIter)
+	chk.Int(tst, "number of calls", len(iters), sol2.NumIter+1)
+	chk.Int(tst, "first iteration", iters[0], 0)
+	chk.Float64(tst, "first fx", 1e-15, fxs[0], p.Ffcn(x0))
+
+	// stop early
+	iters = nil
+	sol3 := NewConjGrad(p)
+	sol3.Observer = func(iter int, fx float64, x, grad la.Vector) (stop bool) {
+		observer(iter, fx, x, grad)
+		return iter == 2
+	}
+	xmin3 := x0.GetCopy()
+	fmin3, err := sol3.TryMin(xmin3, nil)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Int(tst, "NumIter (stopped)", sol3.NumIter, 2)
+	chk.Int(tst, "number of calls (stopped)", len(iters), 3)
+	chk.Float64(tst, "fmin (stopped)", 1e-15, fmin3, fxs[len(fxs)-1])
+	chk.Array(tst, "xmin (stopped)", 1e-15, xmin3, xlast)
+}
+
+func TestConjGrad09(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ConjGrad09. cancellation with context")
+
+	// problem with function cancelling the context after nmax evaluations
+	p := Factory.RosenbrockMulti(5)
+	x0 := la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9, 1.2})
+	neval, nmax := 0, 30
+	var cancel context.CancelFunc
+	ffcn := p.Ffcn
+	p.Ffcn = func(x la.Vector) float64 {
+		neval++
+		if neval == nmax && cancel != nil {
+			cancel()
+		}
+		return ffcn(x)
+	}
+
+	// without cancellation
+	sol := NewConjGrad(p)
+	x := x0.GetCopy()
+	fmin, err := sol.MinWithContext(context.Background(), x)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	checkConjGrad(tst, sol, fmin, p.Fref, 1e-13, 1e-6, x, p.Xref)
+	niter := sol.NumIter
+
+	// cancelled by function
+	var ctx context.Context
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	neval = 0
+	x = x0.GetCopy()
+	fmin, err = sol.MinWithContext(ctx, x)
+	io.Pforan("err = %v  NumIter = %d  fmin = %v\n", err, sol.NumIter, fmin)
+	if err != context.Canceled {
+		tst.Errorf("error should be context.Canceled. err = %v\n", err)
+	}
+	if sol.NumIter >= niter {
+		tst.Errorf("solver should have stopped promptly\n")
+	}
+	chk.Float64(tst, "fmin = f(x)", 1e-15, fmin, ffcn(x))
+
+	// deadline exceeded
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-ctx.Done()
+	x = x0.GetCopy()
+	_, err = sol.MinWithContext(ctx, x)
+	if err != context.DeadlineExceeded {
+		tst.Errorf("error should be context.DeadlineExceeded. err = %v\n", err)
+	}
+	chk.Int(tst, "NumIter (deadline)", sol.NumIter, 0)
+	chk.Array(tst, "x (unchanged)", 1e-15, x, x0)
+}
+
+func TestConjGrad10(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ConjGrad10. budget: function evaluations and wall-clock time")
+
+	// problem
+	p := Factory.RosenbrockMulti(5)
+	x0 := la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9, 1.2})
+	f0 := p.Ffcn(x0)
+
+	// unlimited
+	sol := NewConjGrad(p)
+	x := x0.GetCopy()
+	fmin := sol.Min(x, nil)
+	checkConjGrad(tst, sol, fmin, p.Fref, 1e-13, 1e-6, x, p.Xref)
+	chk.String(tst, sol.BudgetHit, "")
+	nfeval := sol.NumFeval
+
+	// max number of function evaluations
+	maxFeval := nfeval / 4
+	x = x0.GetCopy()
+	fmin, err := sol.TryMin(x, dbf.NewParams(&dbf.P{N: "maxfeval", V: float64(maxFeval)}))
+	io.Pforan("maxfeval: err = %v  NumFeval = %d  NumIter = %d  fmin = %v\n", err, sol.NumFeval, sol.NumIter, fmin)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.String(tst, sol.BudgetHit, "maxfeval")
+	if sol.NumFeval < maxFeval || sol.NumFeval >= nfeval {
+		tst.Errorf("NumFeval = %d should be in [%d, %d)\n", sol.NumFeval, maxFeval, nfeval)
+	}
+	if fmin >= f0 {
+		tst.Errorf("best f so far should be smaller than f(x0)\n")
+	}
+	chk.Float64(tst, "fmin = f(x)", 1e-15, fmin, p.Ffcn(x))
+
+	// max duration with slow objective function
+	ffcn := p.Ffcn
+	p.Ffcn = func(x la.Vector) float64 {
+		time.Sleep(time.Millisecond)
+		return ffcn(x)
+	}
+	sol = NewConjGrad(p)
+	sol.SetBudget(0, 20*time.Millisecond)
+	x = x0.GetCopy()
+	t0 := time.Now()
+	fmin = sol.Min(x, nil)
+	elapsed := time.Since(t0)
+	io.Pforan("maxduration: elapsed = %v  NumFeval = %d  NumIter = %d  fmin = %v\n", elapsed, sol.NumFeval, sol.NumIter, fmin)
+	chk.String(tst, sol.BudgetHit, "maxduration")
+	if sol.NumFeval >= nfeval {
+		tst.Errorf("solver should have stopped before converging\n")
+	}
+	if fmin > f0 {
+		tst.Errorf("best f so far should not be greater than f(x0)\n")
+	}
+
+	// budget flag is reset by the next call
+	sol.SetBudget(0, 0)
+	x = x0.GetCopy()
+	fmin = sol.Min(x, nil)
+	chk.String(tst, sol.BudgetHit, "")
+	chk.Float64(tst, "fmin", 1e-13, fmin, p.Fref)
+}
+
+func TestConjGrad11(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ConjGrad11. convergence status")
+
+	// names
+	names := map[ConvStatus]string{ConvNone: "none", ConvGradZero: "gradzero", ConvFtol: "ftol", ConvGtol: "gtol",
+		ConvXtol: "xtol", ConvMaxIt: "maxit", ConvBudget: "budget", ConvStopped: "stopped", ConvStatus(100): "ConvStatus(100)"}
+	for status, name := range names {
+		chk.String(tst, status.String(), name)
+	}
+
+	// zero gradient: starting at the minimum
+	p := Factory.SimpleParaboloid()
+	sol := NewConjGrad(p)
+	chk.String(tst, sol.Status.String(), "none")
+	x := la.NewVector(2)
+	sol.Min(x, nil)
+	chk.String(tst, sol.Status.String(), "gradzero")
+
+	// default tolerances
+	p = Factory.RosenbrockMulti(5)
+	x0 := la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9, 1.2})
+	sol = NewConjGrad(p)
+	x = x0.GetCopy()
+	sol.Min(x, nil)
+	io.Pforan("default: Status = %v  NumIter = %d\n", sol.Status, sol.NumIter)
+	if sol.Status != ConvFtol && sol.Status != ConvGtol {
+		tst.Errorf("solver should have converged on f or on the gradient. Status = %v\n", sol.Status)
+	}
+
+	// converged on f: loose tolerance on f (shifted away from zero) and tight tolerance on gradient
+	shifted := Factory.RosenbrockMulti(5)
+	ffcn := shifted.Ffcn
+	shifted.Ffcn = func(x la.Vector) float64 { return ffcn(x) + 1 }
+	x = x0.GetCopy()
+	solShifted := NewConjGrad(shifted)
+	solShifted.Min(x, dbf.NewParams(&dbf.P{N: "ftol", V: 1e-3}, &dbf.P{N: "gtol", V: 1e-12}))
+	chk.String(tst, solShifted.Status.String(), "ftol")
+
+	// converged on gradient: test on f disabled
+	x = x0.GetCopy()
+	sol.Min(x, dbf.NewParams(&dbf.P{N: "ftol", V: 0}, &dbf.P{N: "gtol", V: 1e-3}))
+	chk.String(tst, sol.Status.String(), "gtol")
+
+	// budget
+	x = x0.GetCopy()
+	sol.Min(x, dbf.NewParams(&dbf.P{N: "maxfeval", V: 10}))
+	chk.String(tst, sol.Status.String(), "budget")
+	chk.String(tst, sol.BudgetHit, "maxfeval")
+
+	// max number of iterations
+	sol.SetBudget(0, 0)
+	x = x0.GetCopy()
+	if _, err := sol.TryMin(x, dbf.NewParams(&dbf.P{N: "maxit", V: 2})); err == nil {
+		tst.Errorf("TryMin should fail with maxit = 2\n")
+	}
+	chk.String(tst, sol.Status.String(), "maxit")
+
+	// stopped by observer
+	sol.MaxIt = 200
+	sol.Observer = func(iter int, fx float64, x, grad la.Vector) (stop bool) { return iter == 3 }
+	x = x0.GetCopy()
+	sol.Min(x, nil)
+	chk.String(tst, sol.Status.String(), "stopped")
+	chk.Int(tst, "NumIter", sol.NumIter, 3)
+}
+
+func TestConjGrad12(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ConjGrad12. variables with very different magnitudes (Scale)")
+
+	// badly scaled problem: f = (y0-2)² + (y1-3)² + y0⋅y1/2 with y = x / S
+	S := la.NewVectorSlice([]float64{1e-6, 1e6})
+	newProblem := func() *Problem {
+		p := new(Problem)
+		p.Ndim = 2
+		p.Ffcn = func(x la.Vector) float64 {
+			y0, y1 := x[0]/S[0], x[1]/S[1]
+			return math.Pow(y0-2, 2) + math.Pow(y1-3, 2) + y0*y1/2
+		}
+		p.Gfcn = func(g, x la.Vector) {
+			y0, y1 := x[0]/S[0], x[1]/S[1]
+			g[0] = (2*(y0-2) + y1/2) / S[0]
+			g[1] = (2*(y1-3) + y0/2) / S[1]
+		}
+		p.Xref = la.NewVectorSlice([]float64{4.0 / 3.0 * S[0], 8.0 / 3.0 * S[1]}) // 2y0 + y1/2 = 4 and y0/2 + 2y1 = 6
+		p.Fref = p.Ffcn(p.Xref)
+		return p
+	}
+	x0 := la.NewVectorSlice([]float64{5e-6, -1e6})
+
+	// without scaling: the line search fails or the solution is poor
+	p := newProblem()
+	errUnscaled := math.Inf(1)
+	func() {
+		defer func() {
+			if e := recover(); e != nil {
+				io.Pforan("unscaled: %v\n", e)
+			}
+		}()
+		sol := NewConjGrad(p)
+		x := x0.GetCopy()
+		_, err := sol.TryMin(x, nil)
+		io.Pforan("unscaled: err = %v  NumIter = %d  Status = %v  x = %v\n", err, sol.NumIter, sol.Status, x)
+		errUnscaled = math.Abs(x[0]-p.Xref[0]) / S[0]
+	}()
+
+	// unit Scale gives the same results as nil Scale
+	prob := Factory.RosenbrockMulti(5)
+	xini := la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9, 1.2})
+	solRef := NewConjGrad(prob)
+	xref := xini.GetCopy()
+	fref := solRef.Min(xref, nil)
+	sol := NewConjGrad(prob)
+	sol.Scale = la.NewVectorSlice([]float64{1, 1, 1, 1, 1})
+	x := xini.GetCopy()
+	fmin := sol.Min(x, nil)
+	chk.Float64(tst, "fmin(unit Scale)", 1e-15, fmin, fref)
+	chk.Array(tst, "x(unit Scale)", 1e-15, x, xref)
+	chk.Int(tst, "NumFeval(unit Scale)", sol.NumFeval, solRef.NumFeval)
+
+	// with scaling; observer and history in original units
+	var xobs []la.Vector
+	sol = NewConjGrad(p)
+	sol.Scale = S
+	sol.UseHist = true
+	sol.Observer = func(iter int, fx float64, x, grad la.Vector) (stop bool) {
+		chk.Float64(tst, "observer: fx", 1e-13, fx, p.Ffcn(x))
+		xobs = append(xobs, x.GetCopy())
+		return false
+	}
+	x = x0.GetCopy()
+	fmin, err := sol.TryMin(x, nil)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("scaled: NumIter = %d  Status = %v  x = %v\n", sol.NumIter, sol.Status, x)
+	chk.Float64(tst, "fmin", 1e-12, fmin, p.Fref)
+	chk.Float64(tst, "x0/S0", 1e-6, x[0]/S[0], p.Xref[0]/S[0])
+	chk.Float64(tst, "x1/S1", 1e-6, x[1]/S[1], p.Xref[1]/S[1])
+	if errScaled := math.Abs(x[0]-p.Xref[0]) / S[0]; errScaled >= errUnscaled {
+		tst.Errorf("scaling should improve the solution: %g ≥ %g\n", errScaled, errUnscaled)
+	}
+	chk.Array(tst, "observer: x0", 1e-15, xobs[0], x0)
+	chk.Array(tst, "history: x0", 1e-15, sol.Hist.HistX[0], x0)
+	chk.Array(tst, "history: xmin", 1e-15, sol.Hist.HistX[len(sol.Hist.HistX)-1], x)
+	if sol.Lower != nil || sol.Upper != nil || sol.Scale[0] != S[0] {
+		tst.Errorf("configuration should be restored\n")
+	}
+
+	// numerical gradient and box constraints
+	pnum := newProblem()
+	pnum.Gfcn = nil
+	sol = NewConjGrad(pnum)
+	sol.Scale = S
+	sol.Lower = la.NewVectorSlice([]float64{0, 0})
+	sol.Upper = la.NewVectorSlice([]float64{1e-6, 1e7})
+	x = x0.GetCopy()
+	sol.Min(x, nil)
+	io.Pforan("numerical gradient and box: NumIter = %d  x = %v\n", sol.NumIter, x)
+	chk.Float64(tst, "x0 (at upper bound)", 1e-15, x[0], 1e-6)
+	chk.Float64(tst, "x1/S1", 1e-6, x[1]/S[1], 2.75) // 2(y1-3) + 1/4 = 0
+	chk.Array(tst, "Lower", 1e-15, sol.Lower, []float64{0, 0})
+
+	// invalid Scale
+	sol.Scale = la.NewVectorSlice([]float64{1})
+	if _, err = sol.TryMin(x0.GetCopy(), nil); err == nil {
+		tst.Errorf("TryMin should fail with wrong length of Scale\n")
+	}
+	sol.Scale = la.NewVectorSlice([]float64{1, 0})
+	if _, err = sol.TryMin(x0.GetCopy(), nil); err == nil {
+		tst.Errorf("TryMin should fail with zero Scale\n")
+	}
+}
+
+func TestConjGrad13(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ConjGrad13. Reset and reuse")
+
+	// problem and starting points
+	p := Factory.RosenbrockMulti(5)
+	x0a := la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9, 1.2})
+	x0b := la.NewVectorSlice([]float64{0.8, 1.1, 0.9, 1.4, 0.6})
+
+	// first solve
+	sol := NewConjGrad(p)
+	sol.UseHist = true
+	x := x0a.GetCopy()
+	sol.Min(x, nil)
+	if sol.NumFeval == 0 || sol.Hist == nil {
+		tst.Errorf("first solve should have computed statistics and history\n")
+		return
+	}
+
+	// reset
+	sol.Reset()
+	chk.Int(tst, "NumFeval", sol.NumFeval, 0)
+	chk.Int(tst, "NumGeval", sol.NumGeval, 0)
+	chk.Int(tst, "NumIter", sol.NumIter, 0)
+	chk.String(tst, sol.Status.String(), "none")
+	chk.String(tst, sol.BudgetHit, "")
+	chk.Int(tst, "lines.NumFeval", sol.lines.NumFeval, 0)
+	chk.Array(tst, "u", 1e-15, sol.u, nil)
+	if sol.Hist != nil {
+		tst.Errorf("history should have been cleared\n")
+	}
+	if !sol.UseHist {
+		tst.Errorf("configuration should not be modified\n")
+	}
+
+	// second solve equals a new solver
+	x = x0b.GetCopy()
+	fmin := sol.Min(x, nil)
+	solNew := NewConjGrad(p)
+	solNew.UseHist = true
+	xnew := x0b.GetCopy()
+	fminNew := solNew.Min(xnew, nil)
+	chk.Float64(tst, "fmin", 1e-15, fmin, fminNew)
+	chk.Array(tst, "x", 1e-15, x, xnew)
+	chk.Int(tst, "NumFeval", sol.NumFeval, solNew.NumFeval)
+	chk.Int(tst, "NumGeval", sol.NumGeval, solNew.NumGeval)
+	chk.Int(tst, "NumIter", sol.NumIter, solNew.NumIter)
+	checkConjGrad(tst, sol, fmin, p.Fref, 1e-13, 1e-6, x, p.Xref)
+}
+
+func TestConjGrad14(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ConjGrad14. Jacobi preconditioner")
+
+	// ill-conditioned quadratic problem with tridiagonal A; diagonal from 1 to 10⁴
+	n := 20
+	A := la.NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		A.Set(i, i, math.Pow(10, 4*float64(i)/float64(n-1)))
+	}
+	for i := 0; i < n-1; i++ {
+		c := 0.25 * math.Sqrt(A.Get(i, i)*A.Get(i+1, i+1))
+		A.Set(i, i+1, c)
+		A.Set(i+1, i, c)
+	}
+	p := NewQuadraticProblem(A.GetDeep2())
+	x0 := la.NewVector(n)
+	x0.Fill(1)
+
+	// Hessian diagonal: H = 2A
+	hdiag := la.NewVector(n)
+	for i := 0; i < n; i++ {
+		hdiag[i] = 2 * A.Get(i, i)
+	}
+
+	// Polak-Ribiere and Fletcher-Reeves
+	params := dbf.NewParams(&dbf.P{N: "maxit", V: 2000})
+	for _, fr := range []bool{false, true} {
+
+		// without preconditioner
+		sol := NewConjGrad(p)
+		sol.UseFRmethod = fr
+		x := x0.GetCopy()
+		fmin := sol.Min(x, params)
+		checkConjGrad(tst, sol, fmin, p.Fref, 1e-12, 1e-6, x, p.Xref)
+		nitPlain := sol.NumIter
+
+		// identity preconditioner is the same as nil
+		solI := NewConjGrad(p)
+		solI.UseFRmethod = fr
+		solI.Precond = func(out, in la.Vector) { copy(out, in) }
+		xI := x0.GetCopy()
+		fminI := solI.Min(xI, params)
+		chk.Float64(tst, "fmin(identity)", 1e-15, fminI, fmin)
+		chk.Array(tst, "x(identity)", 1e-15, xI, x)
+		chk.Int(tst, "NumIter(identity)", solI.NumIter, nitPlain)
+
+		// Jacobi preconditioner
+		solJ := NewConjGrad(p)
+		solJ.UseFRmethod = fr
+		solJ.Precond = NewJacobiPrecond(hdiag)
+		xJ := x0.GetCopy()
+		fminJ := solJ.Min(xJ, params)
+		checkConjGrad(tst, solJ, fminJ, p.Fref, 1e-12, 1e-6, xJ, p.Xref)
+		io.Pforan("FR = %v: NumIter = %d (plain) and %d (Jacobi)\n", fr, nitPlain, solJ.NumIter)
+		if solJ.NumIter >= nitPlain/2 {
+			tst.Errorf("Jacobi preconditioner should reduce the number of iterations significantly. %d ≥ %d/2\n", solJ.NumIter, nitPlain)
+			return
+		}
+	}
+
+	// preconditioner that is not positive-definite
+	sol := NewConjGrad(p)
+	sol.Precond = func(out, in la.Vector) { out.Apply(-1, in) }
+	_, err := sol.TryMin(x0.GetCopy(), nil)
+	if err == nil {
+		tst.Errorf("negative-definite preconditioner should cause an error\n")
+		return
+	}
+	io.Pforan("err = %v\n", err)
+
+	// invalid diagonal
+	defer chk.RecoverTstPanicIsOK(tst)
+	NewJacobiPrecond(la.NewVectorSlice([]float64{1, 0, 2}))
+}
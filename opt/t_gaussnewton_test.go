@@ -0,0 +1,121 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestGaussNewton01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GaussNewton01. curve fitting. comparison with LevMar")
+
+	// data: y(t) = a⋅t / (b + t) with small perturbations
+	aRef, bRef := 2.0, 0.5
+	m := 20
+	T := la.NewVector(m)
+	Y := la.NewVector(m)
+	for i := 0; i < m; i++ {
+		T[i] = 0.1 + 3*float64(i)/float64(m-1)
+		Y[i] = aRef*T[i]/(bRef+T[i]) + 1e-3*math.Cos(5*float64(i))
+	}
+
+	// residuals r[i] = a⋅t[i] / (b + t[i]) - y[i]
+	rfcn := func(r, x la.Vector) {
+		for i := 0; i < m; i++ {
+			r[i] = x[0]*T[i]/(x[1]+T[i]) - Y[i]
+		}
+	}
+	Jfcn := func(J *la.Matrix, x la.Vector) {
+		for i := 0; i < m; i++ {
+			d := x[1] + T[i]
+			J.Set(i, 0, T[i]/d)
+			J.Set(i, 1, -x[0]*T[i]/(d*d))
+		}
+	}
+
+	// Gauss-Newton
+	x0 := la.NewVectorSlice([]float64{1.5, 0.8})
+	gn := NewGaussNewton(m, 2, rfcn, Jfcn)
+	xgn := x0.GetCopy()
+	costGN := gn.Min(xgn, dbf.NewParams(&dbf.P{N: "hist", V: 1}))
+	io.Pforan("GaussNewton: NumIter = %d  NumFeval = %d  NumGeval = %d  cost = %g  x = %v\n", gn.NumIter, gn.NumFeval, gn.NumGeval, costGN, xgn)
+
+	// Levenberg-Marquardt
+	lm := NewLevMar(m, 2, rfcn, Jfcn)
+	xlm := x0.GetCopy()
+	costLM := lm.Min(xlm, nil)
+	io.Pforan("LevMar:      NumIter = %d  NumFeval = %d  NumGeval = %d  cost = %g  x = %v\n", lm.NumIter, lm.NumFeval, lm.NumGeval, costLM, xlm)
+
+	// check
+	chk.Array(tst, "x", 1e-3, xgn, []float64{aRef, bRef})
+	chk.Array(tst, "x: GN = LM", 1e-9, xgn, xlm)
+	chk.Float64(tst, "cost: GN = LM", 1e-15, costGN, costLM)
+	chk.Int(tst, "len(HistF)", len(gn.Hist.HistF), gn.NumIter+1)
+}
+
+func TestGaussNewton02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GaussNewton02. overshooting. line search")
+
+	// r(x) = atan(x): the full Gauss-Newton steps diverge if |x0| > 1.39
+	rfcn := func(r, x la.Vector) {
+		r[0] = math.Atan(x[0])
+	}
+	Jfcn := func(J *la.Matrix, x la.Vector) {
+		J.Set(0, 0, 1.0/(1.0+x[0]*x[0]))
+	}
+
+	// with line search
+	sol := NewGaussNewton(1, 1, rfcn, Jfcn)
+	sol.UseLineSearch = true
+	x := la.NewVectorSlice([]float64{2})
+	cost := sol.Min(x, nil)
+	io.Pforan("line search: NumIter = %d  NumFeval = %d  cost = %g  x = %v\n", sol.NumIter, sol.NumFeval, cost, x)
+	chk.Float64(tst, "x", 1e-10, x[0], 0)
+
+	// full steps: x = 2, -3.5, 14, -279, ...
+	sol.UseLineSearch = false
+	defer chk.RecoverTstPanicIsOK(tst)
+	x = la.NewVectorSlice([]float64{2})
+	sol.Min(x, dbf.NewParams(&dbf.P{N: "maxit", V: 3}))
+}
+
+func TestGaussNewton03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GaussNewton03. rank-deficient Jacobian")
+
+	// r_i(x) = exp(-(x0+x1)⋅t_i) - y_i: only x0+x1 is identifiable; i.e. Jᵀ⋅J is singular
+	t := []float64{0, 0.5, 1, 1.5, 2, 3}
+	rfcn := func(r, x la.Vector) {
+		for i, ti := range t {
+			r[i] = math.Exp(-(x[0]+x[1])*ti) - math.Exp(-0.5*ti)
+		}
+	}
+	Jfcn := func(J *la.Matrix, x la.Vector) {
+		for i, ti := range t {
+			d := -ti * math.Exp(-(x[0]+x[1])*ti)
+			J.Set(i, 0, d)
+			J.Set(i, 1, d)
+		}
+	}
+
+	// solve
+	sol := NewGaussNewton(len(t), 2, rfcn, Jfcn)
+	x := la.NewVectorSlice([]float64{1, 1})
+	cost := sol.Min(x, nil)
+	io.Pforan("NumIter = %d  cost = %g  x = %v  status = %v\n", sol.NumIter, cost, x, sol.Status)
+	chk.Float64(tst, "x0 + x1", 1e-8, x[0]+x[1], 0.5)
+	chk.Float64(tst, "cost", 1e-15, cost, 0)
+}
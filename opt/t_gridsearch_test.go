@@ -0,0 +1,88 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/rnd"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestGridSearch01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GridSearch01. minimum over coarse grid")
+
+	// f(x,y,z) = (x - 0.5)² + 2(y + 1)² + |z|
+	var neval int64
+	f := func(x la.Vector) float64 {
+		atomic.AddInt64(&neval, 1)
+		return math.Pow(x[0]-0.5, 2) + 2*math.Pow(x[1]+1, 2) + math.Abs(x[2])
+	}
+	grid := [][]float64{
+		utl.LinSpace(-2, 2, 9),
+		utl.LinSpace(-3, 1, 5),
+		{-1, 0, 1},
+	}
+
+	// serial and parallel runs give the same results
+	for _, nworkers := range []int{1, 4, 0} {
+		neval = 0
+		xbest, fbest := GridSearch(f, grid, nworkers)
+		io.Pforan("nworkers = %d: xbest = %v  fbest = %v\n", nworkers, xbest, fbest)
+		chk.Int(tst, "neval", int(neval), 9*5*3)
+		chk.Array(tst, "xbest", 1e-15, xbest, []float64{0.5, -1, 0})
+		chk.Float64(tst, "fbest", 1e-15, fbest, 0)
+	}
+
+	// NaN values are ignored
+	g := func(x la.Vector) float64 {
+		if x[0] < 0 {
+			return math.NaN()
+		}
+		return x[0]
+	}
+	xbest, fbest := GridSearch(g, [][]float64{{-2, -1, 3, 2, 4}}, 2)
+	chk.Array(tst, "g: xbest", 1e-15, xbest, []float64{2})
+	chk.Float64(tst, "g: fbest", 1e-15, fbest, 2)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	GridSearch(f, [][]float64{{1, 2}, {}}, 1)
+}
+
+func TestRandomSearch01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("RandomSearch01. random search within bounds")
+
+	// Rosenbrock
+	p := Factory.Rosenbrock2d(1, 100)
+	lower, upper := la.Vector{-2, -1}, la.Vector{2, 3}
+
+	// reproducible points
+	xbest, fbest := RandomSearch(p.Ffcn, lower, upper, 2000, 1, rnd.NewRNG(1234))
+	xbest4, fbest4 := RandomSearch(p.Ffcn, lower, upper, 2000, 4, rnd.NewRNG(1234))
+	io.Pforan("xbest = %v  fbest = %v\n", xbest, fbest)
+	chk.Array(tst, "xbest(nworkers=4)", 1e-15, xbest4, xbest)
+	chk.Float64(tst, "fbest(nworkers=4)", 1e-15, fbest4, fbest)
+
+	// close to the minimum and within bounds
+	if fbest > 0.05 {
+		tst.Errorf("random search should find a point close to the minimum. fbest = %g\n", fbest)
+	}
+	for i := range xbest {
+		if xbest[i] < lower[i] || xbest[i] > upper[i] {
+			tst.Errorf("xbest is out of bounds\n")
+		}
+	}
+	chk.Float64(tst, "f(xbest)", 1e-15, p.Ffcn(xbest), fbest)
+}
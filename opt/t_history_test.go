@@ -0,0 +1,111 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestHistory01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("History01. save and load CSV")
+
+	// run solver
+	prob := Factory.Rosenbrock2d(1, 100)
+	sol := NewConjGrad(prob)
+	sol.UseHist = true
+	x := la.NewVectorSlice([]float64{-1.2, 1})
+	sol.Min(x, nil)
+	hist := sol.AccessHistory()
+
+	// save
+	var buf bytes.Buffer
+	err := hist.SaveCSV(&buf)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	io.Pf("%s\n", strings.Join(lines[:3], "\n"))
+	chk.Int(tst, "number of lines", len(lines), len(hist.HistF)+1)
+	chk.String(tst, lines[0], "iter,f,x0,x1,step")
+
+	// load
+	res, err := LoadCSV(&buf)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Int(tst, "Ndim", res.Ndim, 2)
+	chk.Array(tst, "HistI", 1e-17, res.HistI, hist.HistI)
+	chk.Array(tst, "HistF", 1e-17, res.HistF, hist.HistF)
+	chk.Int(tst, "len(HistX)", len(res.HistX), len(hist.HistX))
+	chk.Int(tst, "len(HistU)", len(res.HistU), len(hist.HistU))
+	for k := 0; k < len(hist.HistX); k++ {
+		chk.Array(tst, io.Sf("x%d", k), 1e-17, res.HistX[k], hist.HistX[k])
+		if k == 0 {
+			if res.HistU[k] != nil {
+				tst.Errorf("first u must be nil\n")
+			}
+			continue
+		}
+		chk.Array(tst, io.Sf("u%d", k), 1e-12, res.HistU[k], hist.HistU[k])
+	}
+	xmin, xmax := res.Limits()
+	xminRef, xmaxRef := hist.Limits()
+	chk.Array(tst, "xmin", 1e-17, xmin, xminRef)
+	chk.Array(tst, "xmax", 1e-17, xmax, xmaxRef)
+
+	// errors
+	for _, data := range []string{"", "iter,f,x0,step\n", "iter,f,step\n0,1,0\n", "iter,f,x0,step\n0,a,1,0\n", "iter,f,x0,step\n0,1,1\n"} {
+		if _, err = LoadCSV(strings.NewReader(data)); err == nil {
+			tst.Errorf("LoadCSV should fail with %q\n", data)
+		} else {
+			io.Pf("error: %v\n", err)
+		}
+	}
+}
+
+func TestHistory02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("History02. concurrent Append")
+
+	hist := NewHistory(0, 0, la.NewVector(3), nil)
+	ngoroutines, nappend := 8, 100
+	var wg sync.WaitGroup
+	for w := 0; w < ngoroutines; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			x := la.NewVectorSlice([]float64{float64(w), 1, 2})
+			for k := 0; k < nappend; k++ {
+				hist.Append(float64(w), x, x)
+			}
+		}(w)
+	}
+	wg.Wait()
+	n := 1 + ngoroutines*nappend
+	chk.Int(tst, "len(HistF)", len(hist.HistF), n)
+	chk.Int(tst, "len(HistX)", len(hist.HistX), n)
+	chk.Int(tst, "len(HistU)", len(hist.HistU), n)
+	chk.Array(tst, "HistI", 1e-17, hist.HistI, utl.LinSpace(0, float64(n-1), n))
+	for k := 1; k < n; k++ {
+		if hist.HistF[k] != hist.HistX[k][0] {
+			tst.Errorf("f and x of entry %d do not correspond to the same call to Append\n", k)
+			return
+		}
+	}
+}
+
@@ -0,0 +1,106 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func runLBFGSTest(tst *testing.T, p *Problem, x0 la.Vector, params dbf.Params, tolf, tolx float64) (sol *LBFGS) {
+	xmin := x0.GetCopy()
+	sol = NewLBFGS(p)
+	sol.UseHist = true
+	fmin := sol.Min(xmin, params)
+	io.Pforan("NumIter = %v\n", sol.NumIter)
+	io.Pf("NumFeval = %v\n", sol.NumFeval)
+	io.Pf("NumGeval = %v\n", sol.NumGeval)
+	chk.Float64(tst, "fmin", tolf, fmin, p.Fref)
+	chk.Array(tst, "xmin", tolx, xmin, p.Xref)
+	return
+}
+
+func TestLBFGS01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LBFGS01. quadratic optimization in 2D and 3D")
+
+	// 2D
+	p := Factory.SimpleQuadratic2d()
+	runLBFGSTest(tst, p, la.NewVectorSlice([]float64{1.5, -0.75}), nil, 1e-14, 1e-7)
+
+	// 3D
+	p = Factory.SimpleQuadratic3d()
+	runLBFGSTest(tst, p, la.NewVectorSlice([]float64{1, 2, 3}), nil, 1e-14, 1e-7)
+}
+
+func TestLBFGS02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LBFGS02. Rosenbrock function")
+
+	// objective function: Rosenbrock
+	p := Factory.RosenbrockMulti(5)
+	x0 := la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9, 1.2})
+
+	// default memory
+	sol := runLBFGSTest(tst, p, x0, dbf.NewParams(
+		&dbf.P{N: "ftol", V: 1e-12},
+	), 1e-13, 1e-6)
+
+	// compare with conjugate gradients
+	xcg := x0.GetCopy()
+	cg := NewConjGrad(p)
+	cg.Min(xcg, nil)
+	io.Pf("ConjGrad: NumIter = %v  NumFeval = %v  NumGeval = %v\n", cg.NumIter, cg.NumFeval, cg.NumGeval)
+	if sol.NumIter > cg.NumIter {
+		tst.Errorf("L-BFGS should require less iterations than ConjGrad\n")
+	}
+
+	// small memory
+	runLBFGSTest(tst, p, x0, dbf.NewParams(
+		&dbf.P{N: "m", V: 2},
+		&dbf.P{N: "ftol", V: 1e-12},
+	), 1e-12, 1e-5)
+
+	// history
+	chk.Int(tst, "len(Hist.HistF)", len(sol.Hist.HistF), sol.NumIter+2)
+}
+
+func TestLBFGS03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LBFGS03. Rosenbrock function with many variables")
+
+	// objective function: Rosenbrock
+	N := 1000
+	p := Factory.RosenbrockMulti(N)
+
+	// run
+	x0 := la.NewVector(N)
+	for i := 0; i < N; i++ {
+		x0[i] = 1.2
+		if i%2 == 0 {
+			x0[i] = 0.8
+		}
+	}
+	sol := NewLBFGS(p)
+	sol.MaxIt = 1000
+	sol.Ftol = 1e-14
+	xmin := x0.GetCopy()
+	fmin := sol.Min(xmin, nil)
+	io.Pforan("NumIter = %v  NumFeval = %v  NumGeval = %v\n", sol.NumIter, sol.NumFeval, sol.NumGeval)
+	chk.Float64(tst, "fmin", 1e-10, fmin, 0)
+	chk.Array(tst, "xmin", 1e-5, xmin, p.Xref)
+
+	// invalid memory
+	defer chk.RecoverTstPanicIsOK(tst)
+	sol.M = 0
+	sol.Min(x0, nil)
+}
@@ -0,0 +1,112 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestLevMar01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LevMar01. Rosenbrock as least squares")
+
+	// r = [10⋅(x1 - x0²), 1 - x0]  ⇒  F = ½ ‖r‖² = ½ f_rosenbrock
+	rfcn := func(r, x la.Vector) {
+		r[0] = 10 * (x[1] - x[0]*x[0])
+		r[1] = 1 - x[0]
+	}
+	Jfcn := func(J *la.Matrix, x la.Vector) {
+		J.Set(0, 0, -20*x[0])
+		J.Set(0, 1, 10)
+		J.Set(1, 0, -1)
+		J.Set(1, 1, 0)
+	}
+
+	// solve
+	sol := NewLevMar(2, 2, rfcn, Jfcn)
+	x := la.NewVectorSlice([]float64{-1.2, 1})
+	cost := sol.Min(x, dbf.NewParams(&dbf.P{N: "hist", V: 1}))
+	io.Pforan("NumIter = %d  NumFeval = %d  NumGeval = %d  λ = %g\n", sol.NumIter, sol.NumFeval, sol.NumGeval, sol.Lambda)
+	chk.Float64(tst, "cost", 1e-20, cost, 0)
+	chk.Array(tst, "x", 1e-10, x, []float64{1, 1})
+
+	// history
+	chk.Int(tst, "len(HistLambda)", len(sol.HistLambda), sol.NumIter+1)
+	chk.Int(tst, "len(HistF)", len(sol.Hist.HistF), sol.NumGeval)
+	for i := 1; i < len(sol.Hist.HistF); i++ {
+		if sol.Hist.HistF[i] >= sol.Hist.HistF[i-1] {
+			tst.Errorf("cost must decrease at accepted steps\n")
+			return
+		}
+	}
+}
+
+func TestLevMar02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LevMar02. curve fitting")
+
+	// data: y(t) = a⋅exp(-b⋅t) + c with small perturbations
+	aRef, bRef, cRef := 5.0, 1.3, 0.5
+	m := 40
+	T := la.NewVector(m)
+	Y := la.NewVector(m)
+	for i := 0; i < m; i++ {
+		T[i] = 4 * float64(i) / float64(m-1)
+		Y[i] = aRef*math.Exp(-bRef*T[i]) + cRef + 1e-3*math.Sin(7*float64(i))
+	}
+
+	// residuals r[i] = a⋅exp(-b⋅t[i]) + c - y[i]
+	rfcn := func(r, x la.Vector) {
+		for i := 0; i < m; i++ {
+			r[i] = x[0]*math.Exp(-x[1]*T[i]) + x[2] - Y[i]
+		}
+	}
+	Jfcn := func(J *la.Matrix, x la.Vector) {
+		for i := 0; i < m; i++ {
+			e := math.Exp(-x[1] * T[i])
+			J.Set(i, 0, e)
+			J.Set(i, 1, -x[0]*T[i]*e)
+			J.Set(i, 2, 1)
+		}
+	}
+
+	// solve
+	sol := NewLevMar(m, 3, rfcn, Jfcn)
+	x := la.NewVectorSlice([]float64{1, 0.1, 0})
+	cost := sol.Min(x, nil)
+	io.Pforan("NumIter = %d  NumFeval = %d  NumGeval = %d  cost = %g\n", sol.NumIter, sol.NumFeval, sol.NumGeval, cost)
+	io.Pforan("x = %v\n", x)
+	chk.Array(tst, "x", 1e-3, x, []float64{aRef, bRef, cRef})
+
+	// the gradient Jᵀ⋅r vanishes at the solution
+	g := la.NewVector(3)
+	sol.Gfcn(g, x)
+	chk.Array(tst, "Jᵀ⋅r", 1e-9, g, nil)
+	chk.Float64(tst, "cost = Ffcn(x)", 1e-15, cost, sol.Ffcn(x))
+
+	// nonzero residual: the cost is ½ Σ (1e-3⋅sin(7i))² approximately
+	if cost > 0.5*float64(m)*1e-6 {
+		tst.Errorf("cost = %g is too large\n", cost)
+	}
+
+	// budget
+	x = la.NewVectorSlice([]float64{1, 0.1, 0})
+	sol.Min(x, dbf.NewParams(&dbf.P{N: "maxfeval", V: 3}))
+	chk.String(tst, sol.BudgetHit, "maxfeval")
+	chk.Int(tst, "NumFeval", sol.NumFeval, 3)
+
+	// non-convergence
+	defer chk.RecoverTstPanicIsOK(tst)
+	x = la.NewVectorSlice([]float64{1, 0.1, 0})
+	sol.Min(x, dbf.NewParams(&dbf.P{N: "maxit", V: 2}, &dbf.P{N: "maxfeval", V: 0}))
+}
@@ -5,6 +5,7 @@
 package opt
 
 import (
+	"math"
 	"testing"
 
 	"github.com/cpmech/gosl/chk"
@@ -74,3 +75,315 @@ func TestLineSearch01(tst *testing.T) {
 		plt.Save("/tmp/gosl/opt", "linesearch01")
 	}
 }
+
+func TestLineSearch02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LineSearch02. Hager-Zhang")
+
+	// function (see LineSearch01)
+	ffcn := func(x la.Vector) float64 {
+		return x[0]*x[0] + x[1]*x[1] - 0.5
+	}
+	Jfcn := func(dfdx, x la.Vector) {
+		dfdx[0] = 2.0 * x[0]
+		dfdx[1] = 2.0 * x[1]
+	}
+
+	// the secant step is exact for quadratic functions
+	x := la.NewVectorSlice([]float64{-2, -2})
+	u := la.NewVectorSlice([]float64{4, 4})
+	line := NewHagerZhang(2, ffcn, Jfcn)
+	a, f := line.Find(x, u, false, 0)
+	io.Pforan("a = %v  f = %v  NumFeval = %v  NumJeval = %v\n", a, f, line.NumFeval, line.NumJeval)
+	chk.Float64(tst, "a", 1e-15, a, 0.5)
+	chk.Float64(tst, "f", 1e-15, f, -0.5)
+	chk.Array(tst, "x", 1e-15, x, []float64{0, 0})
+	chk.Int(tst, "NumFeval", line.NumFeval, 3)
+	chk.Int(tst, "NumJeval", line.NumJeval, 3)
+
+	// Rosenbrock function along the steepest descent direction: check the (approximate) Wolfe conditions
+	p := Factory.Rosenbrock2d(1, 100)
+	line = NewHagerZhang(2, p.Ffcn, p.Gfcn)
+	line.SetParams(dbf.NewParams(
+		&dbf.P{N: "maxitls", V: 20},
+		&dbf.P{N: "hzdelta", V: 0.1},
+		&dbf.P{N: "hzsigma", V: 0.5},
+		&dbf.P{N: "hzeps", V: 1e-6},
+	))
+	chk.Int(tst, "MaxIt", line.MaxIt, 20)
+	chk.Float64(tst, "Sigma", 1e-15, line.Sigma, 0.5)
+	for _, x0 := range [][]float64{{-1.2, 1}, {0, 0}, {2, 2}, {0.9, 0.8}} {
+		x = la.NewVectorSlice(x0).GetCopy()
+		g := la.NewVector(2)
+		p.Gfcn(g, x)
+		u = la.NewVectorSlice([]float64{-g[0], -g[1]})
+		f0, g0 := p.Ffcn(x), la.VecDot(g, u)
+		a, f = line.Find(x, u, false, 0)
+		p.Gfcn(g, x)
+		ga := la.VecDot(g, u)
+		io.Pforan("x0 = %v  a = %.6e  f = %.6e  NumFeval = %v\n", x0, a, f, line.NumFeval)
+		chk.Float64(tst, "f = f(x)", 1e-15, f, p.Ffcn(x))
+		if a <= 0 || f > f0 {
+			tst.Errorf("step must reduce f\n")
+		}
+		wolfe := f-f0 <= line.Delta*a*g0 && ga >= line.Sigma*g0
+		approx := (2*line.Delta-1)*g0 >= ga && ga >= line.Sigma*g0 && f <= f0+line.Epsilon*f0
+		if !wolfe && !approx {
+			tst.Errorf("Wolfe conditions are not satisfied\n")
+		}
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	line.Find(la.NewVectorSlice([]float64{0, 0}), la.NewVectorSlice([]float64{-1, 0}), false, 0) // ascent direction
+}
+
+func TestLineSearch03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LineSearch03. line search methods of ConjGrad, BFGS and LBFGS")
+
+	// ill-conditioned quadratic function: f = ½ Σ λi⋅xi² with λi = 1 … 1e6
+	n := 10
+	λ := la.NewVector(n)
+	for i := 0; i < n; i++ {
+		λ[i] = math.Pow(10, 6*float64(i)/float64(n-1))
+	}
+	quad := &Problem{
+		Ndim: n,
+		Ffcn: func(x la.Vector) (f float64) {
+			for i := 0; i < n; i++ {
+				f += 0.5 * λ[i] * x[i] * x[i]
+			}
+			return
+		},
+		Gfcn: func(g, x la.Vector) {
+			for i := 0; i < n; i++ {
+				g[i] = λ[i] * x[i]
+			}
+		},
+		Fref: 0,
+		Xref: la.NewVector(n),
+	}
+	x0quad := la.NewVector(n)
+	x0quad.Fill(1)
+
+	// Rosenbrock function
+	rosen := Factory.RosenbrockMulti(5)
+	x0rosen := la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9, 1.2})
+
+	// solvers
+	params := dbf.NewParams(&dbf.P{N: "maxit", V: 1000})
+	solvers := map[string]func(p *Problem, method string) NonLinSolver{
+		"conjgrad": func(p *Problem, method string) NonLinSolver { o := NewConjGrad(p); o.LineMethod = method; return o },
+		"bfgs":     func(p *Problem, method string) NonLinSolver { o := NewBFGS(p); o.LineMethod = method; return o },
+		"lbfgs":    func(p *Problem, method string) NonLinSolver { o := NewLBFGS(p); o.LineMethod = method; return o },
+	}
+	for _, kind := range []string{"conjgrad", "bfgs", "lbfgs"} {
+		for _, method := range []string{"wolfe", "brent", "hz", "backtrack"} {
+			for k, p := range []*Problem{quad, rosen} {
+				if kind == "conjgrad" && method == "wolfe" && k == 0 {
+					continue // see below
+				}
+				if kind == "conjgrad" && method == "backtrack" {
+					continue // inexact steps are not suitable for CG methods; see LineSearch05
+				}
+				x := x0quad.GetCopy()
+				tolf, tolx := 1e-8, 1e-4
+				if k == 1 {
+					x = x0rosen.GetCopy()
+					tolf, tolx = 1e-10, 1e-5
+				}
+				sol := solvers[kind](p, method)
+				fmin := sol.Min(x, params)
+				io.Pf("%8s: %5s: fmin = %.3e\n", kind, method, fmin)
+				chk.Float64(tst, io.Sf("%s: %s: fmin", kind, method), tolf, fmin, p.Fref)
+				chk.Array(tst, io.Sf("%s: %s: xmin", kind, method), tolx, x, p.Xref)
+			}
+		}
+	}
+
+	// the Hager-Zhang line search is more robust than Wolfe in ConjGrad
+	cg := NewConjGrad(quad)
+	if _, err := cg.TryMin(x0quad.GetCopy(), params); err == nil {
+		tst.Errorf("ConjGrad with Wolfe line search should have failed\n")
+	}
+	cg.LineMethod = "hz"
+	x := x0quad.GetCopy()
+	if _, err := cg.TryMin(x, params); err != nil {
+		tst.Errorf("%v\n", err)
+	}
+	io.Pf("conjgrad:    hz: NumIter = %d\n", cg.NumIter)
+	chk.Array(tst, "conjgrad: hz: xmin", 1e-4, x, quad.Xref)
+
+	// errors
+	cg = NewConjGrad(rosen)
+	cg.LineMethod = "unknown"
+	if _, err := cg.TryMin(x0rosen.GetCopy(), nil); err == nil {
+		tst.Errorf("unknown line search method should cause an error\n")
+	}
+	defer chk.RecoverTstPanicIsOK(tst)
+	bfgs := NewBFGS(rosen)
+	bfgs.LineMethod = "unknown"
+	bfgs.Min(x0rosen.GetCopy(), nil)
+}
+
+func TestLineSearch04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LineSearch04. coefficients of the Wolfe conditions")
+
+	// Rosenbrock function along the steepest descent direction
+	p := Factory.Rosenbrock2d(1, 100)
+	x0 := la.NewVectorSlice([]float64{-1.2, 1})
+	g := la.NewVector(2)
+	p.Gfcn(g, x0)
+	u := la.NewVectorSlice([]float64{-g[0], -g[1]})
+	g0 := la.VecDot(g, u)
+
+	// the curvature condition |φ'(a)| ≤ c2⋅|φ'(0)| is satisfied
+	line := NewLineSearch(2, p.Ffcn, p.Gfcn)
+	for _, c2 := range []float64{0.9, 0.4, 0.1, 0.01} {
+		err := line.SetCoefs(1e-4, c2)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		x := x0.GetCopy()
+		a, _ := line.Wolfe(x, u, false, 0)
+		p.Gfcn(g, x)
+		ga := la.VecDot(g, u)
+		io.Pforan("c2 = %4g  a = %.6e  |φ'(a)/φ'(0)| = %.3e  NumFeval = %d\n", c2, a, math.Abs(ga/g0), line.NumFeval)
+		if math.Abs(ga) > c2*math.Abs(g0) {
+			tst.Errorf("curvature condition is not satisfied with c2 = %g\n", c2)
+		}
+	}
+
+	// invalid coefficients
+	for _, c := range [][]float64{{0, 0.5}, {0.5, 0.4}, {1e-4, 1}, {-1, 0.5}} {
+		err := line.SetCoefs(c[0], c[1])
+		io.Pforan("err = %v\n", err)
+		if err == nil {
+			tst.Errorf("c1=%g and c2=%g should cause an error\n", c[0], c[1])
+		}
+	}
+	chk.Float64(tst, "Coef1 (unchanged)", 1e-15, line.Coef1, 1e-4)
+	chk.Float64(tst, "Coef2 (unchanged)", 1e-15, line.Coef2, 0.01)
+
+	// ConjGrad
+	sol := NewConjGrad(p)
+	if err := sol.SetLineCoefs(0.5, 0.1); err == nil {
+		tst.Errorf("SetLineCoefs should have failed\n")
+	}
+	if err := sol.SetLineCoefs(1e-4, 0.1); err != nil {
+		tst.Errorf("%v\n", err)
+	}
+	chk.Float64(tst, "ConjGrad: Coef2", 1e-15, sol.lines.Coef2, 0.1)
+	x := x0.GetCopy()
+	fmin, err := sol.TryMin(x, nil)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	checkConjGrad(tst, sol, fmin, p.Fref, 1e-10, 1e-5, x, p.Xref)
+	_, err = sol.TryMin(x0.GetCopy(), dbf.NewParams(&dbf.P{N: "coef1", V: 0.2}, &dbf.P{N: "coef2", V: 0.1}))
+	io.Pforan("err = %v\n", err)
+	if err == nil {
+		tst.Errorf("TryMin should have failed with invalid coefficients\n")
+	}
+
+	// Wolfe panics
+	defer chk.RecoverTstPanicIsOK(tst)
+	line.Coef1 = 0.5
+	line.Wolfe(x0.GetCopy(), u, false, 0)
+}
+
+func TestLineSearch05(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("LineSearch05. backtracking line search (Armijo condition)")
+
+	// Rosenbrock function along the steepest descent direction
+	p := Factory.Rosenbrock2d(1, 100)
+	x0 := la.NewVectorSlice([]float64{-1.2, 1})
+	g := la.NewVector(2)
+	p.Gfcn(g, x0)
+	u := la.NewVectorSlice([]float64{-g[0], -g[1]})
+	f0, g0 := p.Ffcn(x0), la.VecDot(g, u)
+
+	// computing φ(0) and φ'(0)
+	line := NewBacktrack(2, p.Ffcn, p.Gfcn)
+	x := x0.GetCopy()
+	a, f := line.Find(x, u, false, 0)
+	io.Pforan("a = %g  f = %g  NumIter = %d  NumFeval = %d  NumJeval = %d\n", a, f, line.NumIter, line.NumFeval, line.NumJeval)
+	if f > f0+line.Coef*a*g0 {
+		tst.Errorf("Armijo condition is not satisfied\n")
+	}
+	if a/line.Tau <= 1 && f0+line.Coef*(a/line.Tau)*g0 >= p.Ffcn(la.NewVectorSlice([]float64{x0[0] + a/line.Tau*u[0], x0[1] + a/line.Tau*u[1]})) {
+		tst.Errorf("the previous step a/τ should not satisfy the Armijo condition\n")
+	}
+	chk.Float64(tst, "f", 1e-15, f, p.Ffcn(x))
+	chk.Array(tst, "x = x0 + a⋅u", 1e-15, x, []float64{x0[0] + a*u[0], x0[1] + a*u[1]})
+	chk.Int(tst, "NumFeval", line.NumFeval, line.NumIter+1)
+	chk.Int(tst, "NumJeval", line.NumJeval, 1)
+
+	// with φ(0) and φ'(0) given: same step without gradient evaluations
+	nosens := NewBacktrack(2, p.Ffcn, nil)
+	nosens.SetPhi0(f0, g0)
+	x = x0.GetCopy()
+	anos, fnos := nosens.Find(x, u, false, 0)
+	chk.Float64(tst, "a (SetPhi0)", 1e-15, anos, a)
+	chk.Float64(tst, "f (SetPhi0)", 1e-15, fnos, f)
+	chk.Int(tst, "NumFeval (SetPhi0)", nosens.NumFeval, nosens.NumIter)
+	chk.Int(tst, "NumJeval (SetPhi0)", nosens.NumJeval, 0)
+
+	// BFGS: fewer gradient evaluations than with the Wolfe line search
+	rosen := Factory.RosenbrockMulti(5)
+	x0rosen := la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9, 1.2})
+	ngeval := map[string]int{}
+	for _, method := range []string{"wolfe", "backtrack"} {
+		sol := NewBFGS(rosen)
+		sol.LineMethod = method
+		x = x0rosen.GetCopy()
+		fmin := sol.Min(x, nil)
+		io.Pforan("bfgs: %9s: fmin = %.3e  NumIter = %d  NumFeval = %d  NumGeval = %d\n", method, fmin, sol.NumIter, sol.NumFeval, sol.NumGeval)
+		chk.Float64(tst, "bfgs: "+method+": fmin", 1e-10, fmin, rosen.Fref)
+		chk.Array(tst, "bfgs: "+method+": xmin", 1e-5, x, rosen.Xref)
+		ngeval[method] = sol.NumGeval
+	}
+	if ngeval["backtrack"] > ngeval["wolfe"] {
+		tst.Errorf("backtracking should not need more gradient evaluations than Wolfe\n")
+	}
+
+	// ConjGrad and GradDesc on a well-conditioned problem
+	parab := Factory.SimpleParaboloid()
+	cg := NewConjGrad(parab)
+	cg.LineMethod = "backtrack"
+	x = la.NewVectorSlice([]float64{1, 1})
+	fmin := cg.Min(x, nil)
+	chk.Float64(tst, "conjgrad: backtrack: fmin", 1e-12, fmin, parab.Fref)
+	chk.Array(tst, "conjgrad: backtrack: xmin", 1e-6, x, parab.Xref)
+	gd := NewGradDesc(parab)
+	x = la.NewVectorSlice([]float64{1, 1})
+	fmin = gd.Min(x, dbf.NewParams(&dbf.P{N: "alpha", V: 1.5}, &dbf.P{N: "backtrack", V: 1}, &dbf.P{N: "ftol", V: 1e-15}))
+	io.Pforan("graddesc: backtrack: fmin = %.3e  NumIter = %d\n", fmin, gd.NumIter)
+	chk.Float64(tst, "graddesc: backtrack: fmin", 1e-12, fmin, parab.Fref)
+	chk.Array(tst, "graddesc: backtrack: xmin", 1e-5, x, parab.Xref)
+
+	// panics
+	for k, test := range []func(){
+		func() { line.Tau = 1; line.Find(x0.GetCopy(), u, false, 0) },
+		func() { NewBacktrack(2, p.Ffcn, nil).Find(x0.GetCopy(), u, false, 0) },
+		func() { NewBacktrack(2, p.Ffcn, p.Gfcn).Find(x0.GetCopy(), g, false, 0) },
+	} {
+		func() {
+			defer func() {
+				if e := recover(); e == nil {
+					tst.Errorf("test %d should have panicked\n", k)
+				}
+			}()
+			test()
+		}()
+	}
+}
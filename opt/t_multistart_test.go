@@ -0,0 +1,131 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// multimodalProblem returns f(x) = Σ x_i²/10 - cos(2⋅x_i) with global minimum at x = 0
+func multimodalProblem(ndim int) (p *Problem) {
+	p = new(Problem)
+	p.Ndim = ndim
+	p.Ffcn = func(x la.Vector) (f float64) {
+		for i := 0; i < len(x); i++ {
+			f += x[i]*x[i]/10.0 - math.Cos(2.0*x[i])
+		}
+		return
+	}
+	p.Gfcn = func(g, x la.Vector) {
+		for i := 0; i < len(x); i++ {
+			g[i] = x[i]/5.0 + 2.0*math.Sin(2.0*x[i])
+		}
+	}
+	p.Fref = -float64(ndim)
+	p.Xref = la.NewVector(ndim)
+	return
+}
+
+func TestMultiStart01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("MultiStart01. ConjGrad on multimodal function")
+
+	// problem
+	prob := multimodalProblem(2)
+	maker := func() NonLinSolver { return NewConjGrad(prob) }
+
+	// single start lands in a local minimum
+	x := la.NewVectorSlice([]float64{3, -3})
+	fsingle := NewConjGrad(prob).Min(x, nil)
+	io.Pforan("single start: x = %v  f = %v\n", x, fsingle)
+	if fsingle < prob.Fref+0.5 {
+		tst.Errorf("single start should not find the global minimum\n")
+	}
+
+	// multi-start: serial
+	lower := la.NewVectorSlice([]float64{-5, -5})
+	upper := la.NewVectorSlice([]float64{5, 5})
+	ms := NewMultiStart(maker, lower, upper)
+	xbest, fmin := ms.Min(dbf.NewParams(&dbf.P{N: "nstarts", V: 30}, &dbf.P{N: "seed", V: 1234}))
+	io.Pforan("multi-start: x = %v  f = %v  best run = %d\n", xbest, fmin, ms.Best)
+	chk.Int(tst, "len(Runs)", len(ms.Runs), 30)
+	chk.Array(tst, "xbest", 1e-6, xbest, prob.Xref)
+	chk.Float64(tst, "fmin", 1e-12, fmin, prob.Fref)
+	chk.Float64(tst, "fmin = Runs[Best].Fmin", 1e-15, fmin, ms.Runs[ms.Best].Fmin)
+	nfailed := 0
+	for k, run := range ms.Runs {
+		if run.Err != nil {
+			io.Pf("run %d failed: %v\n", k, run.Err)
+			nfailed++
+			if !math.IsNaN(run.Fmin) {
+				tst.Errorf("Fmin of failed run %d should be NaN\n", k)
+			}
+		}
+		if run.Fmin < fmin {
+			tst.Errorf("run %d has a smaller minimum than the best run\n", k)
+		}
+		for i := 0; i < prob.Ndim; i++ {
+			if run.X0[i] < lower[i] || run.X0[i] >= upper[i] {
+				tst.Errorf("initial point of run %d is outside the box: %v\n", k, run.X0)
+			}
+		}
+	}
+	if nfailed > 5 {
+		tst.Errorf("too many runs failed: %d\n", nfailed)
+	}
+
+	// multi-start: parallel runs with the same seed give identical results
+	for _, nworkers := range []int{3, 0} {
+		par := NewMultiStart(maker, lower, upper)
+		par.NumStarts = 30
+		par.NumWorkers = nworkers
+		par.Seed = 1234
+		xpar, fpar := par.Min(nil)
+		chk.Int(tst, io.Sf("nworkers=%d: Best", nworkers), par.Best, ms.Best)
+		chk.Array(tst, io.Sf("nworkers=%d: xbest", nworkers), 1e-17, xpar, xbest)
+		chk.Float64(tst, io.Sf("nworkers=%d: fmin", nworkers), 1e-17, fpar, fmin)
+		for k, run := range par.Runs {
+			chk.Array(tst, io.Sf("nworkers=%d: X0 of run %d", nworkers, k), 1e-17, run.X0, ms.Runs[k].X0)
+			if run.Err == nil {
+				chk.Float64(tst, io.Sf("nworkers=%d: Fmin of run %d", nworkers, k), 1e-17, run.Fmin, ms.Runs[k].Fmin)
+			} else if ms.Runs[k].Err == nil {
+				tst.Errorf("nworkers=%d: run %d should not have failed\n", nworkers, k)
+			}
+		}
+	}
+}
+
+func TestMultiStart02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("MultiStart02. failures of solvers")
+
+	// all runs fail to converge
+	prob := multimodalProblem(2)
+	ms := NewMultiStart(func() NonLinSolver { return NewConjGrad(prob) }, la.NewVector(2), la.NewVectorSlice([]float64{2, 2}))
+	ms.Seed = 1
+	xbest, fmin := ms.Min(dbf.NewParams(&dbf.P{N: "maxit", V: 1}))
+	if xbest != nil || !math.IsNaN(fmin) {
+		tst.Errorf("xbest should be nil and fmin should be NaN if all runs fail\n")
+	}
+	chk.Int(tst, "Best", ms.Best, -1)
+	for k, run := range ms.Runs {
+		if run.Err == nil || !math.IsNaN(run.Fmin) {
+			tst.Errorf("run %d should have failed\n", k)
+		}
+	}
+	io.Pf("error of first run: %v\n", ms.Runs[0].Err)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	NewMultiStart(nil, la.NewVector(2), la.NewVector(3))
+}
@@ -0,0 +1,116 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/plt"
+)
+
+func runNelderMeadTest(tst *testing.T, fnkey string, p *Problem, x0 la.Vector, tolf, tolx float64, params dbf.Params) (sol *NelderMead) {
+
+	// solve
+	xmin := x0.GetCopy()
+	sol = NewNelderMead(p)
+	sol.UseHist = true
+	fmin := sol.Min(xmin, params)
+
+	// check
+	name := "NelderMead"
+	io.Pforan("%s: NumIter = %v\n", name, sol.NumIter)
+	io.Pf("%s: NumFeval = %v\n", name, sol.NumFeval)
+	chk.Float64(tst, io.Sf("%s: fmin", name), tolf, fmin, p.Fref)
+	chk.Array(tst, io.Sf("%s: xmin", name), tolx, xmin, p.Xref)
+	chk.Int(tst, "len(HistF)", len(sol.Hist.HistF), sol.NumIter+1)
+	chk.Float64(tst, "last HistF", 1e-17, sol.Hist.HistF[len(sol.Hist.HistF)-1], fmin)
+	for i := 1; i < len(sol.Hist.HistF); i++ {
+		if sol.Hist.HistF[i] > sol.Hist.HistF[i-1] {
+			tst.Errorf("best vertex must not get worse\n")
+			return
+		}
+	}
+	io.Pl()
+
+	// plot
+	if chk.Verbose {
+		if p.Ndim > 2 {
+			plt.Reset(true, &plt.A{WidthPt: 600, Dpi: 150, Prop: 0.8})
+			sol.Hist.PlotAll3d(name, xmin)
+		} else {
+			plt.Reset(true, &plt.A{WidthPt: 300, Dpi: 150, Prop: 1.5})
+			sol.Hist.PlotAll2d(name, xmin)
+		}
+		plt.Save("/tmp/gosl/opt", fnkey)
+		io.Pl()
+	}
+	return
+}
+
+func TestNelderMead01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("NelderMead01. Very simple bi-dimensional optimization")
+
+	// problem
+	p := Factory.SimpleParaboloid()
+
+	// initial point
+	x0 := la.NewVectorSlice([]float64{1, 1})
+
+	// run test
+	runNelderMeadTest(tst, "neldermead01", p, x0, 1e-15, 1e-8, nil)
+}
+
+func TestNelderMead02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("NelderMead02. Rosenbrock functions")
+
+	// 2D
+	p := Factory.Rosenbrock2d(1, 100)
+	x0 := la.NewVectorSlice([]float64{-1.2, 1})
+	runNelderMeadTest(tst, "neldermead02a", p, x0, 1e-14, 1e-7, nil)
+
+	// 5D
+	q := Factory.RosenbrockMulti(5)
+	y0 := la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9, 1.2})
+	runNelderMeadTest(tst, "neldermead02b", q, y0, 1e-12, 1e-6, dbf.NewParams(
+		&dbf.P{N: "maxit", V: 5000},
+		&dbf.P{N: "scale", V: 0.1},
+	))
+}
+
+func TestNelderMead03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("NelderMead03. non-smooth function and errors")
+
+	// f = |x0 - 1| + 2⋅|x1 + 0.5| has no gradient at the minimum
+	p := &Problem{
+		Ndim: 2,
+		Ffcn: func(x la.Vector) float64 { return math.Abs(x[0]-1) + 2*math.Abs(x[1]+0.5) },
+		Fref: 0,
+		Xref: []float64{1, -0.5},
+	}
+	sol := NewNelderMead(p)
+	x := la.NewVectorSlice([]float64{3, 2})
+	fmin := sol.Min(x, nil)
+	io.Pforan("NumIter = %v  fmin = %v  x = %v\n", sol.NumIter, fmin, x)
+	chk.Float64(tst, "fmin", 1e-7, fmin, p.Fref)
+	chk.Array(tst, "xmin", 1e-7, x, p.Xref)
+
+	// database
+	chk.String(tst, io.Sf("%T", GetNonLinSolver("NelderMead", p)), "*opt.NelderMead")
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	sol.Min(x, dbf.NewParams(&dbf.P{N: "rho", V: 1.5}))
+}
@@ -5,6 +5,11 @@
 package opt
 
 import (
+	"bytes"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/cpmech/gosl/chk"
@@ -21,7 +26,7 @@ func TestNLS01(tst *testing.T) {
 	p := Factory.SimpleParaboloid()
 	x := la.NewVectorSlice([]float64{1, 1})
 
-	for _, kind := range []string{"conjgrad", "powell", "graddesc"} {
+	for _, kind := range []string{"conjgrad", "powell", "graddesc", "lbfgs", "bfgs"} {
 		io.Pf(">>>>>>>>>>>>>>>>>>> running %q <<<<<<<<<<<<<<<<<<<<\n", kind)
 		sol := GetNonLinSolver(kind, p)
 		fmin := sol.Min(x, nil)
@@ -30,3 +35,77 @@ func TestNLS01(tst *testing.T) {
 		chk.Array(tst, "xmin", 1e-10, x, p.Xref)
 	}
 }
+
+func TestNLS02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("NLS02. LogWriter")
+
+	// problem
+	p := Factory.SimpleParaboloid()
+	cg, bfgs, lbfgs, gd := NewConjGrad(p), NewBFGS(p), NewLBFGS(p), NewGradDesc(p)
+	pw, nm, tr := NewPowell(p), NewNelderMead(p), NewTrustRegion(p)
+	gd.Alpha = 0.4
+	solvers := []struct {
+		kind    string
+		sol     NonLinSolver
+		conv    *Convergence
+		derfree bool
+	}{
+		{"conjgrad", cg, &cg.Convergence, false},
+		{"bfgs", bfgs, &bfgs.Convergence, false},
+		{"lbfgs", lbfgs, &lbfgs.Convergence, false},
+		{"graddesc", gd, &gd.Convergence, false},
+		{"trustregion", tr, &tr.Convergence, false},
+		{"powell", pw, &pw.Convergence, true},
+		{"neldermead", nm, &nm.Convergence, true},
+	}
+
+	// run solvers
+	re := regexp.MustCompile(`^iter=(\d+) f=(\S+) gnorm=(\S+) step=(\S+)$`)
+	for _, s := range solvers {
+		var buf bytes.Buffer
+		s.conv.LogWriter = &buf
+		x := la.NewVectorSlice([]float64{1, 1})
+		fmin := s.sol.Min(x, nil)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		io.Pf("%s: fmin = %v  NumIter = %d\n%s\n", s.kind, fmin, s.conv.NumIter, buf.String())
+		if len(lines) < s.conv.NumIter || len(lines) > s.conv.NumIter+1 {
+			tst.Errorf("%s: number of lines = %d is incorrect. NumIter = %d\n", s.kind, len(lines), s.conv.NumIter)
+			continue
+		}
+		for k, line := range lines {
+			m := re.FindStringSubmatch(line)
+			if m == nil {
+				tst.Errorf("%s: line %q has incorrect format\n", s.kind, line)
+				break
+			}
+			chk.String(tst, m[1], io.Sf("%d", k))
+			gnorm, _ := strconv.ParseFloat(m[3], 64)
+			step, _ := strconv.ParseFloat(m[4], 64)
+			if math.IsNaN(gnorm) != s.derfree {
+				tst.Errorf("%s: gnorm = %v is incorrect\n", s.kind, gnorm)
+			}
+			if k == 0 && step != 0 {
+				tst.Errorf("%s: first step should be zero\n", s.kind)
+			}
+			if k > 0 && !(step >= 0) {
+				tst.Errorf("%s: step = %v is incorrect\n", s.kind, step)
+			}
+		}
+		f0, _ := strconv.ParseFloat(re.FindStringSubmatch(lines[0])[2], 64)
+		chk.Float64(tst, s.kind+": f0", 1e-14, f0, p.Ffcn(la.NewVectorSlice([]float64{1, 1})))
+	}
+
+	// nothing else is written to the previous LogWriter after it is removed
+	var buf bytes.Buffer
+	cg.LogWriter = &buf
+	cg.Min(la.NewVectorSlice([]float64{1, 1}), nil)
+	n := buf.Len()
+	if n == 0 {
+		tst.Errorf("LogWriter should have received the diagnostics\n")
+	}
+	cg.LogWriter = nil
+	cg.Min(la.NewVectorSlice([]float64{1, 1}), nil)
+	chk.Int(tst, "len(buf)", buf.Len(), n)
+}
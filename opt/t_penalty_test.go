@@ -0,0 +1,97 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/num"
+)
+
+func TestPenalized01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Penalized01. linear constraint with analytical gradients")
+
+	// min (x-2)² + (y-1)²  subject to  x + y ≤ 2  ⇒  x = (1.5, 0.5)
+	ffcn := func(x la.Vector) float64 { return (x[0]-2)*(x[0]-2) + (x[1]-1)*(x[1]-1) }
+	gfcn := func(g, x la.Vector) {
+		g[0] = 2 * (x[0] - 2)
+		g[1] = 2 * (x[1] - 1)
+	}
+	cfcns := []fun.Sv{func(x la.Vector) float64 { return x[0] + x[1] - 2 }}
+	cgrads := []fun.Vv{func(g, x la.Vector) { g[0], g[1] = 1, 1 }}
+	pen := NewPenalized(2, ffcn, gfcn, cfcns, cgrads)
+
+	// check gradient of penalized function
+	pen.Mu = 100
+	x := la.NewVectorSlice([]float64{1.7, 0.8})
+	g := la.NewVector(2)
+	pen.G(g, x)
+	for k := 0; k < 2; k++ {
+		dnum := num.DerivCen5(x[k], 1e-3, func(xk float64) float64 {
+			xx := x.GetCopy()
+			xx[k] = xk
+			return pen.F(xx)
+		})
+		chk.AnaNum(tst, io.Sf("dφ/dx%d", k), 1e-8, g[k], dnum, chk.Verbose)
+	}
+
+	// solve
+	x = la.NewVectorSlice([]float64{0, 0})
+	fmin, err := SolvePenalized(pen, x, nil, nil)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("NumOuter = %d  μ = %g  violation = %g  fmin = %v  x = %v\n", pen.NumOuter, pen.Mu, pen.Violation, fmin, x)
+	chk.Array(tst, "x", 1e-5, x, []float64{1.5, 0.5})
+	chk.Float64(tst, "fmin", 1e-5, fmin, 0.5)
+	if pen.Violation > pen.Ctol {
+		tst.Errorf("violation should be smaller than Ctol\n")
+	}
+	chk.Int(tst, "NumOuter", pen.NumOuter, 6) // violation = 1/(1+μ)
+
+	// not enough outer iterations
+	pen.MaxOuter = 2
+	x = la.NewVectorSlice([]float64{0, 0})
+	_, err = SolvePenalized(pen, x, func(prob *Problem) NonLinSolver { return NewBFGS(prob) }, nil)
+	if err == nil {
+		tst.Errorf("SolvePenalized should fail with MaxOuter = 2\n")
+	} else {
+		io.Pf("error: %v\n", err)
+	}
+}
+
+func TestPenalized02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Penalized02. nonlinear constraint with numerical gradients")
+
+	// min x + y  subject to  x² + y² ≤ 2  and  y ≤ 5  ⇒  x = (-1, -1)
+	ffcn := func(x la.Vector) float64 { return x[0] + x[1] }
+	cfcns := []fun.Sv{
+		func(x la.Vector) float64 { return x[0]*x[0] + x[1]*x[1] - 2 },
+		func(x la.Vector) float64 { return x[1] - 5 },
+	}
+	pen := NewPenalized(2, ffcn, nil, cfcns, nil)
+	x := la.NewVectorSlice([]float64{0.5, 0.5})
+	fmin, err := SolvePenalized(pen, x, nil, nil)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("NumOuter = %d  μ = %g  violation = %g  fmin = %v  x = %v\n", pen.NumOuter, pen.Mu, pen.Violation, fmin, x)
+	chk.Array(tst, "x", 1e-5, x, []float64{-1, -1})
+	chk.Float64(tst, "fmin", 1e-5, fmin, -2)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	NewPenalized(2, ffcn, nil, cfcns, []fun.Vv{nil})
+}
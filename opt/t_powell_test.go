@@ -91,3 +91,40 @@ func TestPowell03(tst *testing.T) {
 	α := 0.1
 	runPowellTest(tst, "powell03", p, x0, 1e-10, 1e-5, α)
 }
+
+func TestPowell04(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Powell04. (nearly) parallel directions")
+
+	// detection
+	p := Factory.SimpleQuadratic3d()
+	sol := NewPowell(p)
+	sol.Umat.SetDiag(1)
+	if !sol.isParallel(la.NewVectorSlice([]float64{0, -2, 1e-9}), 0) {
+		tst.Errorf("direction should be parallel to u1\n")
+	}
+	if sol.isParallel(la.NewVectorSlice([]float64{-2, 1e-9, 1e-9}), 0) {
+		tst.Errorf("u0 is discarded and should not be checked\n")
+	}
+	if sol.isParallel(la.NewVectorSlice([]float64{1, 1, 0}), 2) {
+		tst.Errorf("direction should not be parallel to u0 or u1\n")
+	}
+	if !sol.isParallel(la.NewVectorSlice([]float64{0, 0, 0}), 2) {
+		tst.Errorf("zero direction should be reported as parallel\n")
+	}
+
+	// large tolerance forces the reset of directions. NOTE: f is shifted such that fmin ≠ 0
+	quad := p.Ffcn
+	q := &Problem{Ndim: 3, Ffcn: func(x la.Vector) float64 { return quad(x) + 1 }, Fref: p.Fref + 1, Xref: p.Xref}
+	sol = NewPowell(q)
+	sol.ParTol = 0.5
+	x := la.NewVectorSlice([]float64{1, 2, 3})
+	fmin := sol.Min(x, nil)
+	io.Pforan("NumIter = %v  NumReset = %v\n", sol.NumIter, sol.NumReset)
+	chk.Float64(tst, "fmin", 1e-10, fmin, q.Fref)
+	chk.Array(tst, "xmin", 1e-4, x, q.Xref)
+	if sol.NumReset == 0 {
+		tst.Errorf("directions should have been reset\n")
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+// linearRegressionBatches returns the loss and gradient of the mean squared error of the linear
+// model y = x0 + x1⋅t1 + x2⋅t2 over batches of data generated with y = 1 + 2⋅t1 - 3⋅t2 + noise
+func linearRegressionBatches(nbatches, nsamples int) (loss StochasticLoss, grad StochasticGrad, xref la.Vector) {
+	xref = la.NewVectorSlice([]float64{1, 2, -3})
+	n := nbatches * nsamples
+	T1, T2, Y := la.NewVector(n), la.NewVector(n), la.NewVector(n)
+	for i := 0; i < n; i++ {
+		T1[i] = math.Sin(float64(i))
+		T2[i] = math.Cos(float64(3 * i))
+		Y[i] = xref[0] + xref[1]*T1[i] + xref[2]*T2[i] + 1e-3*math.Sin(float64(7*i))
+	}
+	g := la.NewVector(3)
+	loss = func(x la.Vector, batch int) (l float64) {
+		for i := batch * nsamples; i < (batch+1)*nsamples; i++ {
+			e := x[0] + x[1]*T1[i] + x[2]*T2[i] - Y[i]
+			l += e * e
+		}
+		return l / float64(nsamples)
+	}
+	grad = func(x la.Vector, batch int) la.Vector {
+		g.Fill(0)
+		for i := batch * nsamples; i < (batch+1)*nsamples; i++ {
+			e := x[0] + x[1]*T1[i] + x[2]*T2[i] - Y[i]
+			g[0] += 2 * e / float64(nsamples)
+			g[1] += 2 * e * T1[i] / float64(nsamples)
+			g[2] += 2 * e * T2[i] / float64(nsamples)
+		}
+		return g
+	}
+	return
+}
+
+func checkStochastic(tst *testing.T, name string, o *Stochastic, loss float64, x, xref la.Vector, tolx float64) {
+	io.Pforan("%s: NumEpoch = %d  NumIter = %d  NumGeval = %d  NumFeval = %d  loss = %g\n", name, o.NumEpoch, o.NumIter, o.NumGeval, o.NumFeval, loss)
+	io.Pf("%s: x = %v\n", name, x)
+	chk.Array(tst, io.Sf("%s: x", name), tolx, x, xref)
+	chk.Int(tst, io.Sf("%s: NumIter", name), o.NumIter, o.NumEpoch*o.NumBatches)
+	chk.Int(tst, io.Sf("%s: NumGeval", name), o.NumGeval, o.NumIter)
+	if o.Hist != nil {
+		chk.Int(tst, io.Sf("%s: len(HistF)", name), len(o.Hist.HistF), o.NumEpoch+1)
+		chk.Float64(tst, io.Sf("%s: HistF[last] = loss", name), 1e-15, o.Hist.HistF[o.NumEpoch], loss)
+		if loss >= o.Hist.HistF[0]*1e-3 {
+			tst.Errorf("%s: running loss should have decreased significantly\n", name)
+		}
+	}
+}
+
+func TestStochastic01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Stochastic01. SGD with momentum. linear regression")
+
+	// data
+	nbatches := 10
+	loss, grad, xref := linearRegressionBatches(nbatches, 20)
+
+	// SGD with momentum
+	sgd := NewSGD(3, nbatches, grad)
+	sgd.Loss = loss
+	x := la.NewVector(3)
+	l := sgd.Min(x, dbf.NewParams(&dbf.P{N: "epochs", V: 100}, &dbf.P{N: "hist", V: 1}))
+	checkStochastic(tst, "SGD(μ=0.9)", &sgd.Stochastic, l, x, xref, 1e-2)
+
+	// plain SGD with decaying learning rate
+	var iters []int
+	sgd.UseHist = false
+	sgd.Loss = nil
+	sgd.LearnRate = func(iter int) float64 {
+		iters = append(iters, iter)
+		return 0.2 / (1.0 + float64(iter)/100.0)
+	}
+	x = la.NewVector(3)
+	l = sgd.Min(x, dbf.NewParams(&dbf.P{N: "momentum", V: 0}))
+	checkStochastic(tst, "SGD(μ=0)", &sgd.Stochastic, l, x, xref, 1e-2)
+	if !math.IsNaN(l) {
+		tst.Errorf("running loss should be NaN without Loss function\n")
+	}
+	chk.Int(tst, "number of calls to LearnRate", len(iters), sgd.NumIter)
+	chk.Int(tst, "last iter", iters[len(iters)-1], sgd.NumIter-1)
+
+	// history requires loss
+	defer chk.RecoverTstPanicIsOK(tst)
+	sgd.UseHist = true
+	sgd.Min(x, nil)
+}
+
+func TestStochastic02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Stochastic02. Adam. linear regression")
+
+	// data
+	nbatches := 10
+	loss, grad, xref := linearRegressionBatches(nbatches, 20)
+
+	// Adam
+	adam := NewAdam(3, nbatches, grad)
+	adam.Loss = loss
+	x := la.NewVector(3)
+	l := adam.Min(x, dbf.NewParams(
+		&dbf.P{N: "epochs", V: 300},
+		&dbf.P{N: "alpha", V: 0.05},
+		&dbf.P{N: "hist", V: 1},
+	))
+	checkStochastic(tst, "Adam", &adam.Stochastic, l, x, xref, 1e-2)
+
+	// observer stops early
+	adam.Observer = func(epoch int, loss float64, x la.Vector) (stop bool) {
+		return epoch == 5
+	}
+	x = la.NewVector(3)
+	adam.Min(x, nil)
+	chk.Int(tst, "NumEpoch (stopped by observer)", adam.NumEpoch, 5)
+	chk.Int(tst, "NumIter (stopped by observer)", adam.NumIter, 5*nbatches)
+}
@@ -0,0 +1,42 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestTracing01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Tracing01. trace of objective function calls")
+
+	// problem with traced objective
+	p := Factory.SimpleQuadratic2d()
+	ffcn := p.Ffcn
+	var trace *[]TracePoint
+	p.Ffcn, trace = TracingObjective(ffcn)
+
+	// solve
+	x := la.NewVectorSlice([]float64{1.5, -0.75})
+	sol := NewConjGrad(p)
+	fmin := sol.Min(x, nil)
+	io.Pforan("NumFeval = %v  len(trace) = %v\n", sol.NumFeval, len(*trace))
+
+	// check
+	chk.Int(tst, "len(trace)", len(*trace), sol.NumFeval)
+	chk.Array(tst, "trace[0].X", 1e-17, (*trace)[0].X, []float64{1.5, -0.75})
+	for i, pt := range *trace {
+		chk.Float64(tst, io.Sf("trace[%d].F", i), 1e-15, pt.F, ffcn(pt.X))
+		if pt.F < fmin-1e-15 {
+			tst.Errorf("trace should not contain values smaller than fmin\n")
+			return
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/num"
+)
+
+func runTrustRegionTest(tst *testing.T, p *Problem, x0 la.Vector, method string, tolf, tolx float64) (sol *TrustRegion) {
+	xmin := x0.GetCopy()
+	sol = NewTrustRegion(p)
+	sol.Method = method
+	sol.UseHist = true
+	fmin := sol.Min(xmin, dbf.NewParams(&dbf.P{N: "ftol", V: 1e-14}, &dbf.P{N: "gtol", V: 1e-10}))
+	io.Pforan("%s: NumIter = %v\n", method, sol.NumIter)
+	io.Pf("%s: NumFeval = %v  NumGeval = %v  NumHeval = %v  Δ = %g\n", method, sol.NumFeval, sol.NumGeval, sol.NumHeval, sol.Delta)
+	chk.Float64(tst, io.Sf("%s: fmin", method), tolf, fmin, p.Fref)
+	chk.Array(tst, io.Sf("%s: xmin", method), tolx, xmin, p.Xref)
+	chk.Int(tst, io.Sf("%s: len(HistDelta)", method), len(sol.HistDelta), sol.NumIter+1)
+	for _, δ := range sol.HistDelta {
+		if δ <= 0 || δ > sol.DeltaMax {
+			tst.Errorf("trust radius = %g is invalid\n", δ)
+			return
+		}
+	}
+	return
+}
+
+func TestTrustRegion01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("TrustRegion01. quadratic functions")
+
+	// the Newton step is taken once the radius is large enough
+	for _, method := range []string{"dogleg", "steihaug"} {
+		p := Factory.SimpleQuadratic3d()
+		sol := runTrustRegionTest(tst, p, la.NewVectorSlice([]float64{1, 2, 3}), method, 1e-15, 1e-8)
+		if sol.NumIter > 6 {
+			tst.Errorf("%s: too many iterations\n", method)
+		}
+	}
+
+	// database
+	p := Factory.SimpleParaboloid()
+	sol := GetNonLinSolver("trustregion", p)
+	x := la.NewVectorSlice([]float64{0.3, -0.2})
+	fmin := sol.Min(x, nil)
+	chk.Float64(tst, "paraboloid: fmin", 1e-15, fmin, p.Fref)
+
+	// Hessian is required
+	defer chk.RecoverTstPanicIsOK(tst)
+	p = Factory.SimpleParaboloid()
+	p.Hfcn = nil
+	NewTrustRegion(p)
+}
+
+func TestTrustRegion02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("TrustRegion02. Rosenbrock functions")
+
+	// check Hessians of factory problems
+	checkHess := func(p *Problem, x la.Vector) {
+		n := len(x)
+		H := la.NewMatrix(n, n)
+		p.Hfcn(H, x)
+		g := la.NewVector(n)
+		xtmp := x.GetCopy()
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				dgidxj := num.DerivCen5(x[j], 1e-3, func(xj float64) float64 {
+					xtmp[j] = xj
+					p.Gfcn(g, xtmp)
+					xtmp[j] = x[j]
+					return g[i]
+				})
+				chk.AnaNum(tst, io.Sf("H%d%d", i, j), 1e-8, H.Get(i, j), dgidxj, chk.Verbose)
+			}
+		}
+	}
+	checkHess(Factory.Rosenbrock2d(1, 100), la.NewVectorSlice([]float64{-1.2, 1}))
+	checkHess(Factory.RosenbrockMulti(4), la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9}))
+
+	// the Hessian is not positive-definite at the starting points
+	for _, method := range []string{"dogleg", "steihaug"} {
+		runTrustRegionTest(tst, Factory.Rosenbrock2d(1, 100), la.NewVectorSlice([]float64{-1.2, 1}), method, 1e-15, 1e-7)
+		runTrustRegionTest(tst, Factory.RosenbrockMulti(5), la.NewVectorSlice([]float64{1.3, 0.7, 0.8, 1.9, 1.2}), method, 1e-15, 1e-7)
+	}
+}
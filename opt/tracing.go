@@ -0,0 +1,36 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/la"
+)
+
+// TracePoint holds one evaluation of an objective function; e.g. for debugging
+type TracePoint struct {
+	X la.Vector // point where f was evaluated (copy)
+	F float64   // f({x})
+}
+
+// TracingObjective wraps an objective function such that every call is recorded
+//
+//  INPUT:
+//   ffcn -- objective function f({x})
+//
+//  OUTPUT:
+//   wrapped -- drop-in replacement of ffcn (e.g. for Problem.Ffcn) recording all calls
+//   trace   -- pointer to the list of recorded points (in the order of evaluation)
+//
+//  NOTE: the wrapped function is not safe for concurrent use
+func TracingObjective(ffcn fun.Sv) (wrapped fun.Sv, trace *[]TracePoint) {
+	trace = new([]TracePoint)
+	wrapped = func(x la.Vector) float64 {
+		f := ffcn(x)
+		*trace = append(*trace, TracePoint{X: x.GetCopy(), F: f})
+		return f
+	}
+	return
+}
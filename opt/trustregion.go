@@ -0,0 +1,335 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package opt
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/utl"
+)
+
+// TrustRegion implements the multidimensional minimization by the trust-region Newton method.
+// At each iteration, the step p approximately minimises the quadratic model of f within the
+// trust region ‖p‖ ≤ Δ (Algorithm 4.1, page 69 of [1]):
+//
+//   m(p) = f + gᵀ⋅p + ½ pᵀ⋅H⋅p    with    g = df/dx  and  H = d²f/dx²
+//
+// The step is accepted if the ratio ϱ = (f(x) - f(x+p)) / (m(0) - m(p)) between the actual and
+// the predicted reductions is greater than Eta. The radius Δ is decreased if ϱ < ¼ and increased
+// if ϱ > ¾ and the step reaches the boundary of the region.
+//
+//  Methods to compute p:
+//    "dogleg"   -- dogleg path between the Cauchy point and the Newton step pN = -H⁻¹⋅g
+//                  (Section 4.1 of [1]). If H is not positive-definite, the Steihaug-CG step is
+//                  used instead
+//    "steihaug" -- conjugate gradients stopped at negative curvature or at the boundary of the
+//                  region (Algorithm 7.2, page 171 of [1])
+//
+//   NOTE: (1) Check Convergence to see how to set convergence parameters,
+//             max iteration number, or to enable and access history of iterations
+//         (2) the problem must have the Hessian function (prob.Hfcn)
+//         (3) the history records the accepted steps; HistDelta records Δ after each iteration
+//             (if UseHist = true)
+//
+//   REFERENCES:
+//   [1] Nocedal, J. and Wright, S. (2006) Numerical Optimization.
+//       Springer Series in Operations Research. 2nd Edition. Springer. 664p
+//
+type TrustRegion struct {
+
+	// merge properties
+	Convergence // auxiliary object to check convergence
+
+	// input
+	Hfcn fun.Mv // Hessian function d²f/dx² (counting NumHeval)
+
+	// configuration
+	Method   string  // method to compute the steps: "dogleg" or "steihaug" [default = "dogleg"]
+	Delta0   float64 // Δ0: initial radius [default = 1]
+	DeltaMax float64 // Δmax: max radius [default = 100]
+	Eta      float64 // η: min ratio ϱ to accept the step (0 ≤ η < ¼) [default = 0.1]
+
+	// output
+	Delta     float64   // Δ: latest radius
+	NumHeval  int       // number of calls to Hfcn (Hessian evaluations)
+	HistDelta []float64 // [it] history of Δ (if UseHist = true)
+
+	// internal
+	H    *la.Matrix // Hessian @ x
+	L    *la.Matrix // Cholesky factor of H
+	g    la.Vector  // gradient @ x
+	p    la.Vector  // step
+	pN   la.Vector  // Newton step
+	pC   la.Vector  // Cauchy point (dogleg) or search direction (Steihaug)
+	Hv   la.Vector  // H⋅v
+	r    la.Vector  // residual of Steihaug-CG
+	xnew la.Vector  // trial point x + p
+}
+
+// add optimizer to database
+func init() {
+	nlsMakersDB["trustregion"] = func(prob *Problem) NonLinSolver { return NewTrustRegion(prob) }
+}
+
+// NewTrustRegion returns a new multidimensional optimizer using the trust-region Newton method
+//   NOTE: prob.Hfcn is required
+func NewTrustRegion(prob *Problem) (o *TrustRegion) {
+	if prob.Hfcn == nil {
+		chk.Panic("TrustRegion requires the Hessian function of the problem (Hfcn)\n")
+	}
+	o = new(TrustRegion)
+	o.InitConvergence(prob.Ffcn, prob.Gfcn)
+	o.Hfcn = func(h *la.Matrix, x la.Vector) {
+		o.NumHeval++
+		prob.Hfcn(h, x)
+	}
+	o.Method = "dogleg"
+	o.Delta0 = 1
+	o.DeltaMax = 100
+	o.Eta = 0.1
+	n := prob.Ndim
+	o.H = la.NewMatrix(n, n)
+	o.L = la.NewMatrix(n, n)
+	o.g = la.NewVector(n)
+	o.p = la.NewVector(n)
+	o.pN = la.NewVector(n)
+	o.pC = la.NewVector(n)
+	o.Hv = la.NewVector(n)
+	o.r = la.NewVector(n)
+	o.xnew = la.NewVector(n)
+	return
+}
+
+// Min solves minimization problem
+//
+//  Input:
+//    x -- [ndim] initial starting point (will be modified)
+//    params -- [may be nil] optional parameters. e.g. "delta0", "maxit". Example:
+//                 params := dbf.NewParams(
+//                     &dbf.P{N: "delta0", V: 1},
+//                     &dbf.P{N: "deltamax", V: 100},
+//                     &dbf.P{N: "eta", V: 0.1},
+//                     &dbf.P{N: "steihaug", V: 1},
+//                     &dbf.P{N: "maxit", V: 1000},
+//                     &dbf.P{N: "ftol", V: 1e-10},
+//                     &dbf.P{N: "gtol", V: 1e-8},
+//                     &dbf.P{N: "hist", V: 1},
+//                 )
+//
+//  Output:
+//    fmin -- f(x@min) minimum f({x}) found
+//    x -- [modify input] position of minimum f({x})
+//
+func (o *TrustRegion) Min(x la.Vector, params dbf.Params) (fmin float64) {
+
+	// set parameters
+	o.Convergence.SetParams(params)
+	o.Delta0 = params.GetValueOrDefault("delta0", o.Delta0)
+	o.DeltaMax = params.GetValueOrDefault("deltamax", o.DeltaMax)
+	o.Eta = params.GetValueOrDefault("eta", o.Eta)
+	if params.GetBoolOrDefault("steihaug", false) {
+		o.Method = "steihaug"
+	}
+	if o.Method != "dogleg" && o.Method != "steihaug" {
+		chk.Panic("method to compute the steps must be \"dogleg\" or \"steihaug\". %q is invalid\n", o.Method)
+	}
+	if o.Delta0 <= 0 || o.DeltaMax < o.Delta0 || o.Eta < 0 || o.Eta >= 0.25 {
+		chk.Panic("parameters must satisfy 0 < Δ0 ≤ Δmax and 0 ≤ η < ¼. Δ0=%g, Δmax=%g and η=%g are invalid\n", o.Delta0, o.DeltaMax, o.Eta)
+	}
+
+	// initializations
+	o.NumFeval, o.NumGeval, o.NumHeval = 0, 0, 0
+	o.startBudget()
+	fx := o.Ffcn(x)
+	o.Gfcn(o.g, x)
+	o.Hfcn(o.H, x)
+	fmin = fx
+	o.Delta = o.Delta0
+
+	// history
+	o.HistDelta = nil
+	if o.UseHist {
+		o.InitHist(x)
+		o.HistDelta = append(o.HistDelta, o.Delta)
+	}
+
+	// iterations
+	step := 0.0 // length of the last accepted step (for the diagnostics)
+	for o.NumIter = 0; o.NumIter < o.MaxIt; o.NumIter++ {
+
+		// diagnostics
+		o.logIter(fx, o.g, step)
+
+		// exit point # 1: converged on df/dx
+		if o.Gconvergence(fx, x, o.g) {
+			o.Status = ConvGtol
+			return
+		}
+
+		// exit point # 2: budget exhausted
+		if o.budgetExceeded() {
+			return
+		}
+
+		// step and predicted reduction m(0) - m(p) = -gᵀ⋅p - ½ pᵀ⋅H⋅p
+		if o.Method == "steihaug" || !o.dogleg() {
+			o.steihaug()
+		}
+		la.MatVecMul(o.Hv, 1, o.H, o.p)
+		pred := -la.VecDot(o.g, o.p) - la.VecDot(o.p, o.Hv)/2.0
+
+		// ratio between actual and predicted reductions
+		la.VecAdd(o.xnew, 1, x, 1, o.p) // xnew := x + p
+		fnew := o.Ffcn(o.xnew)
+		ϱ := -1.0
+		if pred > 0 {
+			ϱ = (fx - fnew) / pred
+		}
+
+		// update radius
+		pnorm := o.p.Norm()
+		if ϱ < 0.25 {
+			o.Delta = 0.25 * pnorm
+		} else if ϱ > 0.75 && pnorm >= 0.99*o.Delta {
+			o.Delta = utl.Min(2.0*o.Delta, o.DeltaMax)
+		}
+		if o.UseHist {
+			o.HistDelta = append(o.HistDelta, o.Delta)
+		}
+
+		// reject step
+		if ϱ <= o.Eta {
+			step = 0
+			continue
+		}
+
+		// accept step
+		step = pnorm
+		copy(x, o.xnew)
+		fmin = fnew
+		if o.UseHist {
+			o.Hist.Append(fmin, x, o.p)
+		}
+
+		// exit point # 3: converged on f
+		if o.Fconvergence(fx, fmin) {
+			o.Status = ConvFtol
+			return
+		}
+
+		// update fx, gradient and Hessian
+		fx = fmin
+		o.Gfcn(o.g, x)
+		o.Hfcn(o.H, x)
+	}
+
+	// did not converge
+	o.Status = ConvMaxIt
+	chk.Panic("fail to converge after %d iterations\n", o.NumIter)
+	return
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// dogleg computes the dogleg step p. Returns false if H is not positive-definite
+func (o *TrustRegion) dogleg() (ok bool) {
+
+	// Newton step pN = -H⁻¹⋅g
+	if !o.newtonStep() {
+		return false
+	}
+	if o.pN.Norm() <= o.Delta {
+		copy(o.p, o.pN)
+		return true
+	}
+
+	// Cauchy point pC = -(gᵀ⋅g / gᵀ⋅H⋅g)⋅g; i.e. the minimum of m along -g
+	la.MatVecMul(o.Hv, 1, o.H, o.g)
+	gg := la.VecDot(o.g, o.g)
+	o.pC.Apply(-gg/la.VecDot(o.g, o.Hv), o.g)
+	if o.pC.Norm() >= o.Delta {
+		o.p.Apply(-o.Delta/math.Sqrt(gg), o.g)
+		return true
+	}
+
+	// intersection of the segment pC → pN with the boundary
+	la.VecAdd(o.r, 1, o.pN, -1, o.pC) // r := pN - pC
+	τ := o.boundary(o.pC, o.r)
+	la.VecAdd(o.p, 1, o.pC, τ, o.r) // p := pC + τ⋅(pN - pC)
+	return true
+}
+
+// newtonStep solves H⋅pN = -g by means of the Cholesky factorisation. Returns false if H is not
+// positive-definite
+func (o *TrustRegion) newtonStep() (ok bool) {
+	defer func() {
+		if e := recover(); e != nil {
+			ok = false
+		}
+	}()
+	la.Cholesky(o.L, o.H)
+	o.r.Apply(-1, o.g)
+	la.CholeskySolve(o.pN, o.L, o.r)
+	return true
+}
+
+// steihaug computes the step p by the conjugate gradients method stopped at negative curvature
+// or at the boundary of the trust region (Algorithm 7.2, page 171 of [1])
+func (o *TrustRegion) steihaug() {
+	o.p.Fill(0)
+	gnorm := o.g.Norm()
+	if gnorm == 0 {
+		return
+	}
+	copy(o.r, o.g)
+	o.pC.Apply(-1, o.g) // d := -r
+	tol := utl.Min(0.5, math.Sqrt(gnorm)) * gnorm
+	rr := la.VecDot(o.r, o.r)
+	for j := 0; j < 2*len(o.p); j++ {
+
+		// negative curvature: go to the boundary along d
+		la.MatVecMul(o.Hv, 1, o.H, o.pC)
+		dHd := la.VecDot(o.pC, o.Hv)
+		if dHd <= 0 {
+			τ := o.boundary(o.p, o.pC)
+			la.VecAdd(o.p, 1, o.p, τ, o.pC)
+			return
+		}
+
+		// step beyond the boundary: stop at the boundary
+		α := rr / dHd
+		la.VecAdd(o.pN, 1, o.p, α, o.pC) // pN := p + α⋅d
+		if o.pN.Norm() >= o.Delta {
+			τ := o.boundary(o.p, o.pC)
+			la.VecAdd(o.p, 1, o.p, τ, o.pC)
+			return
+		}
+		copy(o.p, o.pN)
+
+		// converged
+		la.VecAdd(o.r, 1, o.r, α, o.Hv) // r := r + α⋅H⋅d
+		rrNew := la.VecDot(o.r, o.r)
+		if math.Sqrt(rrNew) < tol {
+			return
+		}
+
+		// new direction
+		β := rrNew / rr
+		la.VecAdd(o.pC, -1, o.r, β, o.pC) // d := -r + β⋅d
+		rr = rrNew
+	}
+}
+
+// boundary computes τ ≥ 0 such that ‖z + τ⋅d‖ = Δ with ‖z‖ ≤ Δ
+func (o *TrustRegion) boundary(z, d la.Vector) (τ float64) {
+	a := la.VecDot(d, d)
+	b := 2.0 * la.VecDot(z, d)
+	c := la.VecDot(z, z) - o.Delta*o.Delta
+	return (-b + math.Sqrt(utl.Max(b*b-4.0*a*c, 0))) / (2.0 * a)
+}
@@ -0,0 +1,131 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pde
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+	"github.com/cpmech/gosl/la"
+)
+
+// BcKind defines the kind of boundary condition
+type BcKind int
+
+const (
+	// BcDirichlet prescribes the value:  u = g
+	BcDirichlet BcKind = iota
+
+	// BcNeumann prescribes the flux (outward normal derivative):  ∂u/∂n = g
+	BcNeumann
+
+	// BcRobin prescribes a mixed condition:  α⋅u + β⋅∂u/∂n = g
+	BcRobin
+)
+
+// BC holds data for a boundary condition applied on one edge of the domain
+//
+//    α⋅u + β⋅∂u/∂n = g({x},t)
+//
+//  where n is the outward normal. Dirichlet conditions have α=1, β=0 whereas
+//  Neumann conditions have α=0, β=1
+//
+//  NOTE: the conditions are given to FdmLaplacian.AddBc; Dirichlet conditions are then stored
+//        in the essential boundary conditions (BoundaryConds) and the natural ones (Neumann and
+//        Robin) are applied to the equations of the boundary nodes by Apply and Rhs
+type BC struct {
+	Kind  BcKind  // kind of boundary condition
+	Alpha float64 // α coefficient
+	Beta  float64 // β coefficient
+	G     fun.Svs // g({x},t) function
+}
+
+// NewBC returns a new boundary condition structure
+//   kind   -- BcDirichlet, BcNeumann or BcRobin
+//   α, β   -- coefficients of the Robin condition [ignored if kind is Dirichlet or Neumann]
+//   cvalue -- constant value g [optional]; or
+//   fvalue -- function value g({x},t) [optional]
+func NewBC(kind BcKind, α, β, cvalue float64, fvalue fun.Svs) (o *BC) {
+	o = new(BC)
+	o.Kind = kind
+	switch kind {
+	case BcDirichlet:
+		o.Alpha, o.Beta = 1, 0
+	case BcNeumann:
+		o.Alpha, o.Beta = 0, 1
+	case BcRobin:
+		if β == 0 {
+			chk.Panic("Robin condition requires β ≠ 0 (use Dirichlet instead)\n")
+		}
+		o.Alpha, o.Beta = α, β
+	default:
+		chk.Panic("kind of boundary condition %d is invalid\n", kind)
+	}
+	o.G = fvalue
+	if fvalue == nil {
+		o.G = func(x la.Vector, t float64) float64 { return cvalue }
+	}
+	return
+}
+
+// NewBcDirichlet returns a new Dirichlet boundary condition:  u = g
+func NewBcDirichlet(cvalue float64, fvalue fun.Svs) *BC {
+	return NewBC(BcDirichlet, 0, 0, cvalue, fvalue)
+}
+
+// NewBcNeumann returns a new Neumann boundary condition:  ∂u/∂n = g
+func NewBcNeumann(cvalue float64, fvalue fun.Svs) *BC {
+	return NewBC(BcNeumann, 0, 0, cvalue, fvalue)
+}
+
+// NewBcRobin returns a new Robin boundary condition:  α⋅u + β⋅∂u/∂n = g
+func NewBcRobin(α, β, cvalue float64, fvalue fun.Svs) *BC {
+	return NewBC(BcRobin, α, β, cvalue, fvalue)
+}
+
+// Apply adds the contribution of the natural (Neumann or Robin) condition to the FDM equation of
+// the boundary node I. See Rhs for the contribution to the right-hand side
+//
+//   The value at the ghost node G outside the domain (the mirror of the neighbour J along the
+//   inward normal) is eliminated using the central difference of the normal derivative
+//
+//     ∂u     u[G] - u[J]                     2⋅h
+//     —— ≈ ———————————    ⇒    u[G] = u[J] + ——— ⋅ (g - α⋅u[I])
+//     ∂n         2⋅h                          β
+//
+//   thus, if c is the coefficient of u[G] in the stencil of node I, the coefficient c is added to
+//   u[J] (by the caller; e.g. FdmLaplacian.Assemble) and -2⋅h⋅c⋅α/β is added to u[I]
+//
+//  INPUT:
+//   eqs -- equations; the stencil of I is assembled by the caller
+//   I   -- boundary node
+//   c   -- coefficient of the ghost node in the stencil of I; e.g. kx/dx² or ky/dy²
+//   h   -- distance between nodes I and J
+//
+//  NOTE: Dirichlet conditions are essential; i.e. they are prescribed with BoundaryConds (see
+//        FdmLaplacian.AddBc)
+func (o *BC) Apply(eqs *la.Equations, I int, c, h float64) {
+	if o.Kind == BcDirichlet {
+		chk.Panic("Dirichlet conditions must be prescribed as essential boundary conditions\n")
+	}
+	if o.Alpha != 0 {
+		eqs.Put(I, I, -2.0*h*c*o.Alpha/o.Beta)
+	}
+}
+
+// Rhs returns the contribution of the natural (Neumann or Robin) condition to the right-hand side
+// of the FDM equation of the boundary node I; i.e. the term c⋅2⋅h⋅g/β moved to the right-hand side
+//
+//   c -- coefficient of the ghost node in the stencil of I; e.g. kx/dx² or ky/dy²
+//   h -- distance between nodes I and J
+//   x -- coordinates of node I
+//   t -- time
+//
+//  See Apply for details
+func (o *BC) Rhs(c, h float64, x la.Vector, t float64) float64 {
+	if o.Kind == BcDirichlet {
+		chk.Panic("Dirichlet conditions must be prescribed as essential boundary conditions\n")
+	}
+	return -2.0 * h * c * o.G(x, t) / o.Beta
+}
@@ -0,0 +1,52 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pde
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/ode"
+)
+
+// CFLTimeStep returns the time step satisfying the Courant-Friedrichs-Lewy (CFL) condition
+//
+//         C ⋅ Δx
+//    Δt = ——————
+//           |a|
+//
+//  INPUT:
+//   waveSpeed -- maximum wave speed a (e.g. advection velocity)
+//   dx        -- grid spacing Δx
+//   cfl       -- Courant number C; e.g. C ≤ 1 for the upwind scheme with forward Euler
+//
+//  OUTPUT:
+//   dt -- time step. Returns +Inf if waveSpeed is zero
+func CFLTimeStep(waveSpeed, dx, cfl float64) (dt float64) {
+	if dx <= 0 || cfl <= 0 {
+		chk.Panic("grid spacing and Courant number must be positive. dx=%g, cfl=%g are invalid\n", dx, cfl)
+	}
+	if waveSpeed == 0 {
+		return math.Inf(1)
+	}
+	return cfl * dx / math.Abs(waveSpeed)
+}
+
+// SetCFLStepLimit limits the step size of the ODE solver (variable steps) according to the
+// CFL condition. See CFLTimeStep.
+//
+//  OUTPUT:
+//   dt -- the maximum time step set in conf (+Inf means no limit)
+//
+//  NOTE: fixed steps are not affected; use dt (or smaller) in conf.SetFixedH instead
+func SetCFLStepLimit(conf *ode.Config, waveSpeed, dx, cfl float64) (dt float64) {
+	dt = CFLTimeStep(waveSpeed, dx, cfl)
+	if math.IsInf(dt, 1) {
+		conf.SetStepLimits(0, 0)
+		return
+	}
+	conf.SetStepLimits(0, dt)
+	return
+}
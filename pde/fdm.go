@@ -27,6 +27,7 @@ type FdmLaplacian struct {
 	Grid     *gm.Grid       // grid
 	Source   fun.Svs        // source term function s({x},t)
 	EssenBcs *BoundaryConds // essential boundary conditions
+	NaturBcs map[int]*BC    // natural (Neumann or Robin) boundary conditions; edge tag ⇒ condition
 	Eqs      *la.Equations  // equations
 	bcsReady bool           // boundary conditions are set
 }
@@ -46,6 +47,7 @@ func NewFdmLaplacian(params dbf.Params, grid *gm.Grid, source fun.Svs) (o *FdmLa
 	o.Grid = grid
 	o.Source = source
 	o.EssenBcs = NewBoundaryCondsGrid(grid, 1) // 1:maxNdof
+	o.NaturBcs = make(map[int]*BC)
 	o.bcsReady = false
 	return
 }
@@ -59,6 +61,26 @@ func (o *FdmLaplacian) AddEbc(tag int, cvalue float64, fvalue fun.Svs) {
 	o.EssenBcs.AddUsingTag(tag, 0, cvalue, fvalue)
 }
 
+// AddBc adds Dirichlet, Neumann or Robin boundary condition given tag of edge [2D only]
+//   tag -- edge tag in grid: 10 (left), 11 (right), 20 (bottom) or 21 (top)
+//   bc  -- boundary condition; Dirichlet conditions are added to EssenBcs (see AddEbc)
+//  NOTE: (1) edges without conditions have zero normal derivative (homogeneous Neumann)
+//        (2) at corners, essential conditions take precedence; otherwise, the natural conditions
+//            of both edges are applied
+func (o *FdmLaplacian) AddBc(tag int, bc *BC) {
+	if bc.Kind == BcDirichlet {
+		o.AddEbc(tag, 0, bc.G)
+		return
+	}
+	if o.Grid.Ndim() != 2 {
+		chk.Panic("TODO: Implement natural boundary conditions in 3D\n")
+	}
+	if tag != 10 && tag != 11 && tag != 20 && tag != 21 {
+		chk.Panic("edge tag must be 10, 11, 20 or 21. tag=%d is invalid\n", tag)
+	}
+	o.NaturBcs[tag] = bc
+}
+
 // SetHbc sets homogeneous boundary conditions; i.e. all boundaries with zero EBC
 func (o *FdmLaplacian) SetHbc() {
 	if o.Grid.Ndim() == 2 {
@@ -120,6 +142,7 @@ func (o *FdmLaplacian) Assemble(reactions bool) {
 			for k, J := range jays { // loop over non-zero columns
 				o.Eqs.Put(I, J, mol[k])
 			}
+			o.naturalBcs(I, func(bc *BC, c, h float64) { bc.Apply(o.Eqs, I, c, h) })
 		}
 		return
 	}
@@ -160,9 +183,37 @@ func (o *FdmLaplacian) calcXk(I int, t float64) float64 {
 // calcBu calculates RHS vector (e.g. source) corresponding to known values of {u} (CalcBu in la.Equations)
 //  I -- node number
 //  t -- time
-func (o *FdmLaplacian) calcBu(I int, t float64) float64 {
+func (o *FdmLaplacian) calcBu(I int, t float64) (res float64) {
 	if o.Source != nil {
-		return o.Source(o.Grid.Node(I), t)
+		res = o.Source(o.Grid.Node(I), t)
+	}
+	o.naturalBcs(I, func(bc *BC, c, h float64) { res += bc.Rhs(c, h, o.Grid.Node(I), t) })
+	return
+}
+
+// naturalBcs calls fcn for each natural boundary condition at node I [2D only]
+//  I   -- node number; nodes with essential boundary conditions are skipped
+//  fcn -- function receiving the condition, the coefficient c of the ghost node and the spacing h
+func (o *FdmLaplacian) naturalBcs(I int, fcn func(bc *BC, c, h float64)) {
+	if len(o.NaturBcs) == 0 || o.EssenBcs.Has(I) {
+		return
+	}
+	nx := o.Grid.Npts(0)
+	ny := o.Grid.Npts(1)
+	dx := o.Grid.Xlen(0) / float64(nx-1)
+	dy := o.Grid.Xlen(1) / float64(ny-1)
+	col := I % nx
+	row := I / nx
+	if bc, ok := o.NaturBcs[10]; ok && col == 0 {
+		fcn(bc, o.Kx/(dx*dx), dx)
+	}
+	if bc, ok := o.NaturBcs[11]; ok && col == nx-1 {
+		fcn(bc, o.Kx/(dx*dx), dx)
+	}
+	if bc, ok := o.NaturBcs[20]; ok && row == 0 {
+		fcn(bc, o.Ky/(dy*dy), dy)
+	}
+	if bc, ok := o.NaturBcs[21]; ok && row == ny-1 {
+		fcn(bc, o.Ky/(dy*dy), dy)
 	}
-	return 0
 }
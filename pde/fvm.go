@@ -0,0 +1,79 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pde
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// NumFlux defines the numerical flux F(uL,uR) evaluated at the interface between a left (uL)
+// and a right (uR) cell in finite volume schemes
+type NumFlux func(uL, uR float64) float64
+
+// FiniteVolume1D returns the semi-discrete operator (right-hand side) of the 1D conservation law
+//
+//    ∂u     ∂f(u)
+//    —— + ——————— = 0
+//    ∂t      ∂x
+//
+//  discretised with nx uniform cells of size dx and periodic boundaries. The returned function
+//  computes:
+//
+//    du[i] = -(F(u[i],u[i+1]) - F(u[i-1],u[i])) / dx
+//
+//  INPUT:
+//   nx   -- number of cells
+//   dx   -- cell size
+//   flux -- numerical flux F(uL,uR); e.g. FluxLaxFriedrichs or FluxUpwind
+//
+//  OUTPUT:
+//   rhs -- function computing du/dt given u (both with len = nx)
+//
+//  NOTE: use a closure to adapt rhs to ode.Func; e.g.
+//        fcn := func(f la.Vector, h, t float64, u la.Vector) { rhs(f, u) }
+func FiniteVolume1D(nx int, dx float64, flux NumFlux) (rhs func(du, u la.Vector)) {
+	if nx < 2 {
+		chk.Panic("number of cells must be at least 2. nx = %d is invalid\n", nx)
+	}
+	if dx <= 0 {
+		chk.Panic("cell size must be positive. dx = %g is invalid\n", dx)
+	}
+	return func(du, u la.Vector) {
+		Fleft := flux(u[nx-1], u[0]) // flux @ left face of cell 0 (periodic)
+		for i := 0; i < nx; i++ {
+			Fright := flux(u[i], u[(i+1)%nx])
+			du[i] = -(Fright - Fleft) / dx
+			Fleft = Fright
+		}
+	}
+}
+
+// FluxLaxFriedrichs returns the (local) Lax-Friedrichs (Rusanov) numerical flux
+//
+//    F(uL,uR) = ½ (f(uL) + f(uR)) - ½ α (uR - uL)
+//
+//  where α ≥ max|f'(u)| is the maximum wave speed
+func FluxLaxFriedrichs(f func(u float64) float64, α float64) NumFlux {
+	return func(uL, uR float64) float64 {
+		return 0.5*(f(uL)+f(uR)) - 0.5*math.Abs(α)*(uR-uL)
+	}
+}
+
+// FluxUpwind returns the upwind numerical flux for the linear advection flux f(u) = a⋅u
+//
+//    F(uL,uR) = a⋅uL  if a ≥ 0
+//    F(uL,uR) = a⋅uR  if a < 0
+//
+func FluxUpwind(a float64) NumFlux {
+	return func(uL, uR float64) float64 {
+		if a >= 0 {
+			return a * uL
+		}
+		return a * uR
+	}
+}
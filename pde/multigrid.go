@@ -0,0 +1,196 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pde
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// constants for the multigrid solver
+const (
+	mgNpre    = 2    // number of pre-smoothing Gauss-Seidel sweeps
+	mgNpost   = 2    // number of post-smoothing Gauss-Seidel sweeps
+	mgNcoarse = 200  // number of Gauss-Seidel sweeps on the coarsest grid
+	mgMaxIt   = 100  // maximum number of V-cycles
+	mgTolDef  = 1e-8 // default tolerance
+)
+
+// MultigridPoisson2D solves the Poisson equation with homogeneous Dirichlet boundary conditions
+// using geometric multigrid V-cycles
+//
+//        ∂²u   ∂²u
+//    - ( ——— + ——— ) = f(x,y)    in Ω = [0,1] × [0,1]   with   u = 0 on ∂Ω
+//        ∂x²   ∂y²
+//
+//  The discretisation employs the 5-point finite difference stencil on a uniform grid. The V-cycle
+//  uses Gauss-Seidel smoothing, full-weighting restriction and bilinear prolongation.
+//
+//  INPUT:
+//   rhs    -- f(x,y) at grid nodes: rhs.Get(i,j) = f(xᵢ,yⱼ) with xᵢ = i/(rhs.M-1), yⱼ = j/(rhs.N-1)
+//             NOTE: rhs.M-1 and rhs.N-1 must be divisible by 2^(levels-1) and the coarsest grid
+//             must still have interior nodes
+//   levels -- number of grids in the hierarchy (≥ 2). The first level is the finest grid
+//   tol    -- tolerance on the relative residual: ‖r‖∞ ≤ tol ⋅ ‖f‖∞. Use 0 for default [1e-8]
+//
+//  OUTPUT:
+//   u   -- solution at grid nodes (same dimensions as rhs)
+//   err -- error if the input is invalid or the method did not converge
+func MultigridPoisson2D(rhs *la.Matrix, levels int, tol float64) (u *la.Matrix, err error) {
+	u, _, err = multigridPoisson2D(rhs, levels, tol)
+	return
+}
+
+// mgLevel holds the data of one grid in the multigrid hierarchy
+type mgLevel struct {
+	hx, hy float64    // grid spacing
+	u      *la.Matrix // solution (or correction) [m][n]
+	f      *la.Matrix // right-hand side [m][n]
+	r      *la.Matrix // residual [m][n]
+}
+
+// multigridPoisson2D implements MultigridPoisson2D and also returns the number of V-cycles
+func multigridPoisson2D(rhs *la.Matrix, levels int, tol float64) (u *la.Matrix, nit int, err error) {
+
+	// check
+	if rhs == nil {
+		return nil, 0, chk.Err("right-hand side matrix must not be nil")
+	}
+	if levels < 2 {
+		return nil, 0, chk.Err("number of levels must be at least 2. levels = %d is invalid", levels)
+	}
+	if tol <= 0 {
+		tol = mgTolDef
+	}
+	div := 1 << uint(levels-1)
+	mx, my := rhs.M-1, rhs.N-1
+	if mx < 2*div || my < 2*div || mx%div != 0 || my%div != 0 {
+		return nil, 0, chk.Err("grid with %d×%d nodes cannot be coarsened %d times", rhs.M, rhs.N, levels-1)
+	}
+
+	// hierarchy of grids
+	lev := make([]*mgLevel, levels)
+	for l := 0; l < levels; l++ {
+		m, n := mx/(1<<uint(l))+1, my/(1<<uint(l))+1
+		lev[l] = &mgLevel{
+			hx: 1.0 / float64(m-1),
+			hy: 1.0 / float64(n-1),
+			u:  la.NewMatrix(m, n),
+			f:  la.NewMatrix(m, n),
+			r:  la.NewMatrix(m, n),
+		}
+	}
+	rhs.CopyInto(lev[0].f, 1)
+	mgClearBry(lev[0].f)
+
+	// trivial solution
+	fnorm := lev[0].f.Largest(1)
+	if fnorm == 0 {
+		return lev[0].u, 0, nil
+	}
+
+	// V-cycles
+	for nit = 1; nit <= mgMaxIt; nit++ {
+		mgVcycle(lev, 0)
+		mgResidual(lev[0])
+		if lev[0].r.Largest(1) <= tol*fnorm {
+			return lev[0].u, nit, nil
+		}
+	}
+	return lev[0].u, mgMaxIt, chk.Err("multigrid did not converge after %d V-cycles", mgMaxIt)
+}
+
+// mgVcycle performs one V-cycle starting at level l
+func mgVcycle(lev []*mgLevel, l int) {
+	o := lev[l]
+	if l == len(lev)-1 {
+		for k := 0; k < mgNcoarse; k++ {
+			mgSmooth(o)
+		}
+		return
+	}
+	for k := 0; k < mgNpre; k++ {
+		mgSmooth(o)
+	}
+	mgResidual(o)
+	c := lev[l+1]
+	mgRestrict(c.f, o.r)
+	c.u.Fill(0)
+	mgVcycle(lev, l+1)
+	mgProlongAdd(o.u, c.u)
+	for k := 0; k < mgNpost; k++ {
+		mgSmooth(o)
+	}
+}
+
+// mgSmooth performs one lexicographic Gauss-Seidel sweep over the interior nodes
+func mgSmooth(o *mgLevel) {
+	ax, ay := 1.0/(o.hx*o.hx), 1.0/(o.hy*o.hy)
+	d := 2.0*ax + 2.0*ay
+	m, n := o.u.M, o.u.N
+	for j := 1; j < n-1; j++ {
+		for i := 1; i < m-1; i++ {
+			v := o.f.Get(i, j) +
+				ax*(o.u.Get(i-1, j)+o.u.Get(i+1, j)) +
+				ay*(o.u.Get(i, j-1)+o.u.Get(i, j+1))
+			o.u.Set(i, j, v/d)
+		}
+	}
+}
+
+// mgResidual computes r = f - A⋅u at interior nodes (zero on the boundary)
+func mgResidual(o *mgLevel) {
+	ax, ay := 1.0/(o.hx*o.hx), 1.0/(o.hy*o.hy)
+	m, n := o.u.M, o.u.N
+	o.r.Fill(0)
+	for j := 1; j < n-1; j++ {
+		for i := 1; i < m-1; i++ {
+			Au := ax*(2.0*o.u.Get(i, j)-o.u.Get(i-1, j)-o.u.Get(i+1, j)) +
+				ay*(2.0*o.u.Get(i, j)-o.u.Get(i, j-1)-o.u.Get(i, j+1))
+			o.r.Set(i, j, o.f.Get(i, j)-Au)
+		}
+	}
+}
+
+// mgRestrict computes the coarse values (cf) from fine ones (ff) using full weighting
+func mgRestrict(cf, ff *la.Matrix) {
+	cf.Fill(0)
+	for J := 1; J < cf.N-1; J++ {
+		for I := 1; I < cf.M-1; I++ {
+			i, j := 2*I, 2*J
+			v := 4.0*ff.Get(i, j) +
+				2.0*(ff.Get(i-1, j)+ff.Get(i+1, j)+ff.Get(i, j-1)+ff.Get(i, j+1)) +
+				ff.Get(i-1, j-1) + ff.Get(i+1, j-1) + ff.Get(i-1, j+1) + ff.Get(i+1, j+1)
+			cf.Set(I, J, v/16.0)
+		}
+	}
+}
+
+// mgProlongAdd interpolates the coarse correction (cu) bilinearly and adds it to the fine values (fu)
+func mgProlongAdd(fu, cu *la.Matrix) {
+	for J := 0; J < cu.N-1; J++ {
+		for I := 0; I < cu.M-1; I++ {
+			i, j := 2*I, 2*J
+			c00, c10, c01, c11 := cu.Get(I, J), cu.Get(I+1, J), cu.Get(I, J+1), cu.Get(I+1, J+1)
+			fu.Add(i, j, c00)
+			fu.Add(i+1, j, (c00+c10)/2.0)
+			fu.Add(i, j+1, (c00+c01)/2.0)
+			fu.Add(i+1, j+1, (c00+c10+c01+c11)/4.0)
+		}
+	}
+	mgClearBry(fu)
+}
+
+// mgClearBry sets the boundary values to zero
+func mgClearBry(a *la.Matrix) {
+	for i := 0; i < a.M; i++ {
+		a.Set(i, 0, 0)
+		a.Set(i, a.N-1, 0)
+	}
+	for j := 0; j < a.N; j++ {
+		a.Set(0, j, 0)
+		a.Set(a.M-1, j, 0)
+	}
+}
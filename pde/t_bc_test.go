@@ -0,0 +1,129 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pde
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun/dbf"
+	"github.com/cpmech/gosl/gm"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestBC01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("BC01. apply Neumann and Robin")
+
+	x := la.NewVector(2)
+	c, h := 4.0, 0.5
+	eqs := la.NewEquations(2, nil)
+	eqs.Alloc(nil, false, false)
+	eqs.Start()
+	eqs.Put(0, 0, 1)
+	eqs.Put(1, 1, 1)
+
+	// Neumann: ∂u/∂n = 2 + t
+	bc := NewBcNeumann(0, func(x la.Vector, t float64) float64 { return 2 + t })
+	bc.Apply(eqs, 0, c, h)
+	chk.Deep2(tst, "A(neumann)", 1e-17, eqs.Auu.ToDense().GetDeep2(), [][]float64{{1, 0}, {0, 1}})
+	chk.Float64(tst, "b(neumann)", 1e-17, bc.Rhs(c, h, x, 1), -12)
+
+	// Robin: 4⋅u + 0.5⋅∂u/∂n = -1
+	bc = NewBcRobin(4, 0.5, -1, nil)
+	bc.Apply(eqs, 1, c, h)
+	chk.Deep2(tst, "A(robin)", 1e-17, eqs.Auu.ToDense().GetDeep2(), [][]float64{{1, 0}, {0, -31}})
+	chk.Float64(tst, "b(robin)", 1e-17, bc.Rhs(c, h, x, 0), 8)
+
+	// Dirichlet
+	bc = NewBcDirichlet(3, nil)
+	chk.Float64(tst, "α(dirichlet)", 1e-17, bc.Alpha, 1)
+	chk.Float64(tst, "β(dirichlet)", 1e-17, bc.Beta, 0)
+	chk.Float64(tst, "g(dirichlet)", 1e-17, bc.G(x, 0), 3)
+
+	// errors
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		NewBcRobin(1, 0, 0, nil)
+	}()
+	defer chk.RecoverTstPanicIsOK(tst)
+	bc.Apply(eqs, 0, c, h)
+}
+
+func TestBC02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("BC02. FdmLaplacian with Dirichlet conditions given by AddBc")
+
+	// 3x3 grid ⇒ 9 equations
+	g := new(gm.Grid)
+	g.RectGenUniform([]float64{0, 0}, []float64{2, 2}, []int{3, 3})
+
+	// Dirichlet conditions are essential
+	s := NewFdmLaplacian(dbf.Params{{N: "kx", V: 1}, {N: "ky", V: 1}}, g, nil)
+	s.AddBc(10, NewBcDirichlet(1, nil))
+	s.AddBc(11, NewBcDirichlet(2, nil))
+	s.AddBc(20, NewBcDirichlet(3, nil))
+	s.AddBc(21, NewBcDirichlet(4, nil))
+	chk.Int(tst, "number of natural conditions", len(s.NaturBcs), 0)
+	chk.Ints(tst, "nodes with essential conditions", s.EssenBcs.Nodes(), []int{0, 1, 2, 3, 5, 6, 7, 8})
+	for _, node := range []int{1, 3, 5, 7} {
+		_, val, _ := s.EssenBcs.Value(node, 0, 0)
+		chk.Float64(tst, io.Sf("u(%d)", node), 1e-17, val, []float64{3, 1, 2, 4}[(node-1)/2])
+	}
+
+	// solve
+	s.Assemble(false)
+	u, _ := s.SolveSteady(false)
+	io.Pf("u = %v\n", u)
+	chk.Float64(tst, "u(4)", 1e-15, u[4], 2.5)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	s.AddBc(30, NewBcNeumann(0, nil))
+}
+
+func TestBC03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("BC03. FdmLaplacian with mixed conditions")
+
+	// exact solution: u = x² + y  in [0,1]×[0,1]  with  L{u} = 2
+	g := new(gm.Grid)
+	g.RectGenUniform([]float64{0, 0}, []float64{1, 1}, []int{5, 4})
+	uana := la.NewVector(g.Size())
+	for I := 0; I < g.Size(); I++ {
+		x := g.Node(I)
+		uana[I] = x[0]*x[0] + x[1]
+	}
+	p := dbf.Params{{N: "kx", V: 1}, {N: "ky", V: 1}}
+	source := func(x la.Vector, t float64) float64 { return 2 }
+	y := func(x la.Vector, t float64) float64 { return x[1] }
+
+	// Dirichlet (left) and Neumann (right, bottom, top)
+	s := NewFdmLaplacian(p, g, source)
+	s.AddBc(10, NewBcDirichlet(0, y))
+	s.AddBc(11, NewBcNeumann(2, nil))
+	s.AddBc(20, NewBcNeumann(-1, nil))
+	s.AddBc(21, NewBcNeumann(1, nil))
+	s.Assemble(false)
+	u, _ := s.SolveSteady(false)
+	io.Pf("u = %v\n", u)
+	chk.Array(tst, "u(neumann)", 1e-13, u, uana)
+
+	// Robin (left) and Dirichlet (right) with Neumann (bottom, top)
+	//   left: α⋅u + β⋅∂u/∂n = 1⋅y + 1⋅0 = y
+	s = NewFdmLaplacian(p, g, source)
+	s.AddBc(10, NewBcRobin(1, 1, 0, y))
+	s.AddBc(11, NewBcDirichlet(0, func(x la.Vector, t float64) float64 { return 1 + x[1] }))
+	s.AddBc(20, NewBcNeumann(-1, nil))
+	s.AddBc(21, NewBcNeumann(1, nil))
+	s.Assemble(false)
+	u, _ = s.SolveSteady(false)
+	io.Pf("u = %v\n", u)
+	chk.Array(tst, "u(robin)", 1e-13, u, uana)
+}
@@ -0,0 +1,90 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pde
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/ode"
+)
+
+// cflAdvect solves the linear advection of a square pulse with the upwind flux and forward Euler
+// using fixed steps and returns max(|u|) at the final time
+func cflAdvect(a, dx, dt, tf float64, nx int) float64 {
+	u := la.NewVector(nx)
+	for i := 0; i < nx; i++ {
+		x := (float64(i) + 0.5) * dx
+		if x > 0.25 && x < 0.5 {
+			u[i] = 1
+		}
+	}
+	rhs := FiniteVolume1D(nx, dx, FluxUpwind(a))
+	fcn := func(f la.Vector, h, t float64, y la.Vector) { rhs(f, y) }
+	conf := ode.NewConfig("fweuler", "", nil)
+	conf.SetFixedH(dt, tf)
+	sol := ode.NewSolver(nx, conf, fcn, nil, nil)
+	defer sol.Free()
+	sol.Solve(u, 0, tf)
+	return u.Largest(1)
+}
+
+func TestCFL01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("CFL01. stable and unstable time steps")
+
+	chk.Float64(tst, "dt", 1e-17, CFLTimeStep(-2, 0.1, 0.5), 0.025)
+	if !math.IsInf(CFLTimeStep(0, 0.1, 0.5), 1) {
+		tst.Errorf("dt should be +Inf with zero wave speed\n")
+	}
+
+	// advection with stable and unstable steps
+	a, nx, tf := 1.0, 50, 1.0
+	dx := 1.0 / float64(nx)
+	dtStable := CFLTimeStep(a, dx, 0.9)
+	dtUnstable := CFLTimeStep(a, dx, 1.5)
+	umaxStable := cflAdvect(a, dx, dtStable, tf, nx)
+	umaxUnstable := cflAdvect(a, dx, dtUnstable, tf, nx)
+	io.Pforan("max(|u|): stable = %v  unstable = %v\n", umaxStable, umaxUnstable)
+	if umaxStable > 1+1e-14 {
+		tst.Errorf("solution with dt=%g should be stable. max(|u|) = %g\n", dtStable, umaxStable)
+	}
+	if umaxUnstable < 1e3 {
+		tst.Errorf("solution with dt=%g should diverge. max(|u|) = %g\n", dtUnstable, umaxUnstable)
+	}
+}
+
+func TestCFL02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("CFL02. step limits with variable steps")
+
+	a, nx, tf := 1.0, 50, 0.5
+	dx := 1.0 / float64(nx)
+	u := la.NewVector(nx)
+	for i := 0; i < nx; i++ {
+		u[i] = math.Sin(2.0 * math.Pi * (float64(i) + 0.5) * dx)
+	}
+	rhs := FiniteVolume1D(nx, dx, FluxUpwind(a))
+	fcn := func(f la.Vector, h, t float64, y la.Vector) { rhs(f, y) }
+
+	conf := ode.NewConfig("dopri5", "", nil)
+	conf.SetTol(1e-2)
+	conf.SetStepOut(true, nil)
+	dt := SetCFLStepLimit(conf, a, dx, 0.8)
+	sol := ode.NewSolver(nx, conf, fcn, nil, nil)
+	defer sol.Free()
+	sol.Solve(u, 0, tf)
+
+	hmax := la.NewVectorSlice(sol.Out.GetStepH()).Max()
+	io.Pforan("dt = %v  max(h) = %v\n", dt, hmax)
+	if hmax > dt+1e-15 {
+		tst.Errorf("step size should not exceed CFL limit: %g > %g\n", hmax, dt)
+	}
+}
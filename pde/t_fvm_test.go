@@ -0,0 +1,102 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pde
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/ode"
+	"github.com/cpmech/gosl/plt"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestFvm01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Fvm01. upwind and Lax-Friedrichs fluxes")
+
+	a := 2.0
+	up := FluxUpwind(a)
+	chk.Float64(tst, "upwind(a>0)", 1e-17, up(1, 3), 2)
+	up = FluxUpwind(-a)
+	chk.Float64(tst, "upwind(a<0)", 1e-17, up(1, 3), -6)
+
+	lf := FluxLaxFriedrichs(func(u float64) float64 { return a * u }, a)
+	chk.Float64(tst, "lax-friedrichs", 1e-17, lf(1, 3), 2)
+	chk.Float64(tst, "lax-friedrichs(uL=uR)", 1e-17, lf(3, 3), 6)
+
+	// constant state ⇒ zero rhs
+	rhs := FiniteVolume1D(5, 0.2, lf)
+	u := la.NewVector(5)
+	u.Fill(1.5)
+	du := la.NewVector(5)
+	rhs(du, u)
+	chk.Array(tst, "du(constant)", 1e-15, du, nil)
+}
+
+func TestFvm02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Fvm02. linear advection: traveling wave")
+
+	// problem: ∂u/∂t + a ∂u/∂x = 0 with periodic boundaries in [0,1]
+	a := 1.0
+	uana := func(x, t float64) float64 { return math.Sin(2.0 * math.Pi * (x - a*t)) }
+
+	// solve with both fluxes
+	tf := 0.05
+	nx := 200
+	dx := 1.0 / float64(nx)
+	f := func(u float64) float64 { return a * u }
+	names := []string{"upwind", "lax-friedrichs"}
+	fluxes := []NumFlux{FluxUpwind(a), FluxLaxFriedrichs(f, a)}
+	for k, flux := range fluxes {
+
+		// initial values @ cell centers
+		X := la.NewVector(nx)
+		u := la.NewVector(nx)
+		for i := 0; i < nx; i++ {
+			X[i] = (float64(i) + 0.5) * dx
+			u[i] = uana(X[i], 0)
+		}
+
+		// solve
+		rhs := FiniteVolume1D(nx, dx, flux)
+		fcn := func(f la.Vector, h, t float64, y la.Vector) { rhs(f, y) }
+		ode.Solve("rk4", fcn, nil, u, tf, 0.2*dx, 0, 0, false, true, false, false)
+
+		// check
+		errMax := 0.0
+		for i := 0; i < nx; i++ {
+			errMax = math.Max(errMax, math.Abs(u[i]-uana(X[i], tf)))
+		}
+		io.Pforan("%s: max(error) = %v\n", names[k], errMax)
+		if errMax > 0.02 {
+			tst.Errorf("%s: error is too large: %v\n", names[k], errMax)
+		}
+
+		// plot
+		if chk.Verbose {
+			if k == 0 {
+				plt.Reset(true, nil)
+				xx := utl.LinSpace(0, 1, 101)
+				yy := make([]float64, len(xx))
+				for i, x := range xx {
+					yy[i] = uana(x, tf)
+				}
+				plt.Plot(xx, yy, &plt.A{C: "k", L: "exact"})
+			}
+			plt.Plot(X, u, &plt.A{C: plt.C(k, 0), M: plt.M(k, 0), Me: 10, L: names[k], NoClip: true})
+			if k == 1 {
+				plt.Gll("$x$", "$u$", nil)
+				plt.Save("/tmp/gosl/pde", "fvm02")
+			}
+		}
+	}
+}
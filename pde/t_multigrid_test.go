@@ -0,0 +1,102 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pde
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestMultigrid01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Multigrid01. manufactured solution")
+
+	// exact solution: u = x⋅(1-x)⋅sin(πy)  ⇒  f = -∇²u = 2 sin(πy) + π² x⋅(1-x)⋅sin(πy)
+	uana := func(x, y float64) float64 { return x * (1 - x) * math.Sin(math.Pi*y) }
+	fsrc := func(x, y float64) float64 {
+		return (2.0 + math.Pi*math.Pi*x*(1-x)) * math.Sin(math.Pi*y)
+	}
+
+	// solve on a sequence of grids
+	var nits []int
+	var errs []float64
+	for _, npts := range []int{17, 33, 65, 129} {
+		levels := int(math.Log2(float64(npts-1))) - 1 // coarsest grid with 5×5 nodes
+		rhs := la.NewMatrix(npts, npts)
+		h := 1.0 / float64(npts-1)
+		for i := 0; i < npts; i++ {
+			for j := 0; j < npts; j++ {
+				rhs.Set(i, j, fsrc(float64(i)*h, float64(j)*h))
+			}
+		}
+		u, nit, err := multigridPoisson2D(rhs, levels, 1e-10)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		emax := 0.0
+		for i := 0; i < npts; i++ {
+			for j := 0; j < npts; j++ {
+				emax = math.Max(emax, math.Abs(u.Get(i, j)-uana(float64(i)*h, float64(j)*h)))
+			}
+		}
+		io.Pforan("npts = %3d  levels = %d  nit = %2d  max(error) = %.3e\n", npts, levels, nit, emax)
+		nits = append(nits, nit)
+		errs = append(errs, emax)
+	}
+
+	// check grid-independent convergence
+	for k := 1; k < len(nits); k++ {
+		if nits[k] > nits[0]+2 {
+			tst.Errorf("number of V-cycles should not grow with refinement: %v\n", nits)
+			return
+		}
+	}
+
+	// check second order accuracy of discretisation
+	for k := 1; k < len(errs); k++ {
+		ratio := errs[k-1] / errs[k]
+		if ratio < 3.5 {
+			tst.Errorf("error should decrease by about 4 when refining the grid: ratio = %g\n", ratio)
+		}
+	}
+
+	// high-level function
+	rhs := la.NewMatrix(9, 17)
+	rhs.Fill(1)
+	u, err := MultigridPoisson2D(rhs, 3, 0)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "u(bry)", 1e-17, u.Get(0, 8), 0)
+	if u.Get(4, 8) <= 0 {
+		tst.Errorf("solution should be positive at the center\n")
+	}
+}
+
+func TestMultigrid02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Multigrid02. invalid input")
+
+	if _, err := MultigridPoisson2D(nil, 2, 0); err == nil {
+		tst.Errorf("nil rhs should cause an error\n")
+	}
+	if _, err := MultigridPoisson2D(la.NewMatrix(9, 9), 1, 0); err == nil {
+		tst.Errorf("levels < 2 should cause an error\n")
+	}
+	if _, err := MultigridPoisson2D(la.NewMatrix(10, 9), 2, 0); err == nil {
+		tst.Errorf("incompatible grid size should cause an error\n")
+	}
+	if _, err := MultigridPoisson2D(la.NewMatrix(9, 9), 4, 0); err == nil {
+		tst.Errorf("too many levels should cause an error\n")
+	}
+}
@@ -55,6 +55,11 @@ func (o DistFrechet) Cdf(x float64) float64 {
 	return math.Exp(-math.Pow(z, -o.A))
 }
 
+// Support returns the interval [lo, hi] outside which the probability density is zero
+func (o DistFrechet) Support() (lo, hi float64) {
+	return o.L, math.Inf(1)
+}
+
 // Mean returns the expected value
 func (o DistFrechet) Mean() float64 {
 	if o.A > 1.0 {
@@ -31,7 +31,7 @@ func (o *DistGumbel) Init(p *Variable) {
 // Pdf computes the probability density function @ x
 func (o DistGumbel) Pdf(x float64) float64 {
 	mz := (o.U - x) / o.B
-	return math.Exp(mz) * math.Exp(-math.Exp(mz)) / o.B
+	return math.Exp(mz-math.Exp(mz)) / o.B
 }
 
 // Cdf computes the cumulative probability function @ x
@@ -39,3 +39,8 @@ func (o DistGumbel) Cdf(x float64) float64 {
 	mz := (o.U - x) / o.B
 	return math.Exp(-math.Exp(mz))
 }
+
+// Support returns the interval [lo, hi] outside which the probability density is zero
+func (o DistGumbel) Support() (lo, hi float64) {
+	return math.Inf(-1), math.Inf(1)
+}
@@ -69,3 +69,8 @@ func (o DistLogNormal) Cdf(x float64) float64 {
 	}
 	return (1.0 + math.Erf((math.Log(x)-o.N)/(o.Z*math.Sqrt2))) / 2.0
 }
+
+// Support returns the interval [lo, hi] outside which the probability density is zero
+func (o DistLogNormal) Support() (lo, hi float64) {
+	return 0, math.Inf(1)
+}
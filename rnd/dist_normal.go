@@ -70,3 +70,8 @@ func (o DistNormal) Pdf(x float64) float64 {
 func (o DistNormal) Cdf(x float64) float64 {
 	return (1.0 + math.Erf((x-o.Mu)/(o.Sig*math.Sqrt2))) / 2.0
 }
+
+// Support returns the interval [lo, hi] outside which the probability density is zero
+func (o DistNormal) Support() (lo, hi float64) {
+	return math.Inf(-1), math.Inf(1)
+}
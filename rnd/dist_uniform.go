@@ -53,3 +53,8 @@ func (o DistUniform) Cdf(x float64) float64 {
 	}
 	return (x - o.A) / (o.B - o.A)
 }
+
+// Support returns the interval [lo, hi] outside which the probability density is zero
+func (o DistUniform) Support() (lo, hi float64) {
+	return o.A, o.B
+}
@@ -0,0 +1,74 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"math/rand"
+	"time"
+)
+
+// RNG implements an independent pseudo random numbers generator with its own seed
+//
+//  NOTE: a nil *RNG is valid and uses the global generator of this package (see Init); thus
+//        functions accepting an *RNG argument may be called with nil
+//
+type RNG struct {
+	r *rand.Rand
+}
+
+// NewRNG returns a new random numbers generator
+//  Input:
+//   seed -- seed value; use seed <= 0 to use current time
+func NewRNG(seed int) (o *RNG) {
+	if seed <= 0 {
+		seed = int(time.Now().UnixNano())
+	}
+	return &RNG{rand.New(rand.NewSource(int64(seed)))}
+}
+
+// Int generates pseudo random integer between low and high (inclusive)
+func (o *RNG) Int(low, high int) int {
+	return o.Intn(high-low+1) + low
+}
+
+// Intn generates pseudo random integer in [0, n)
+func (o *RNG) Intn(n int) int {
+	if o == nil {
+		return rand.Intn(n)
+	}
+	return o.r.Intn(n)
+}
+
+// Float64 generates a pseudo random real number between low and high; i.e. in [low, right)
+func (o *RNG) Float64(low, high float64) float64 {
+	if o == nil {
+		return low + (high-low)*rand.Float64()
+	}
+	return low + (high-low)*o.r.Float64()
+}
+
+// Normal generates a pseudo random number with normal distribution of mean μ and deviation σ
+func (o *RNG) Normal(μ, σ float64) float64 {
+	if o == nil {
+		return μ + σ*rand.NormFloat64()
+	}
+	return μ + σ*o.r.NormFloat64()
+}
+
+// Perm returns a random permutation of the integers in [0, n)
+func (o *RNG) Perm(n int) []int {
+	if o == nil {
+		return rand.Perm(n)
+	}
+	return o.r.Perm(n)
+}
+
+// IntShuffle shuffles a slice of integers (Fisher-Yates)
+func (o *RNG) IntShuffle(values []int) {
+	for i := len(values) - 1; i > 0; i-- {
+		j := o.Intn(i + 1)
+		values[i], values[j] = values[j], values[i]
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rnd
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/utl"
+)
+
+func Test_rng01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("rng01. independent generators")
+
+	// same seed ⇒ same sequence
+	a, b := NewRNG(1234), NewRNG(1234)
+	for i := 0; i < 10; i++ {
+		chk.Float64(tst, "Float64", 1e-17, a.Float64(-1, 1), b.Float64(-1, 1))
+		chk.Int(tst, "Int", a.Int(2, 5), b.Int(2, 5))
+	}
+
+	// ranges
+	for i := 0; i < 100; i++ {
+		k := a.Int(2, 5)
+		x := a.Float64(10, 20)
+		if k < 2 || k > 5 {
+			tst.Errorf("Int(2,5) = %d is out of range\n", k)
+			return
+		}
+		if x < 10 || x >= 20 {
+			tst.Errorf("Float64(10,20) = %g is out of range\n", x)
+			return
+		}
+	}
+
+	// shuffle and permutation
+	vals := utl.IntRange(10)
+	a.IntShuffle(vals)
+	io.Pforan("shuffled = %v\n", vals)
+	sort.Ints(vals)
+	chk.Ints(tst, "sorted(shuffled)", vals, utl.IntRange(10))
+	perm := a.Perm(10)
+	sort.Ints(perm)
+	chk.Ints(tst, "sorted(perm)", perm, utl.IntRange(10))
+
+	// nil generator uses the global one
+	var c *RNG
+	Init(1234)
+	x := c.Float64(0, 1)
+	Init(1234)
+	chk.Float64(tst, "nil generator", 1e-17, x, Float64(0, 1))
+	io.Pforan("normal = %v\n", c.Normal(0, 1))
+}
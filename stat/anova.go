@@ -0,0 +1,81 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// OneWayANOVA performs the one-way analysis of variance to test the null hypothesis that all
+// groups have the same mean
+//
+//   SSB = Σ_g n_g (mean_g - mean)²     (between groups; k - 1 degrees of freedom)
+//   SSW = Σ_g Σ_i (x_gi - mean_g)²     (within groups; N - k degrees of freedom)
+//
+//   F = (SSB / (k - 1)) / (SSW / (N - k))
+//
+//  INPUT:
+//   groups -- [k][n_g] samples of each group; k ≥ 2 and n_g ≥ 1 with N = Σ n_g > k
+//
+//  OUTPUT:
+//   fStat  -- F statistic
+//   pValue -- probability of observing a larger F under the null hypothesis
+//
+//  NOTE: if there is no variance within groups (SSW = 0), F = +Inf and p = 0 if the group means
+//        differ (SSB > 0); otherwise (all values equal), F = NaN and p = NaN
+//
+func OneWayANOVA(groups []la.Vector) (fStat, pValue float64) {
+
+	// check
+	k := len(groups)
+	if k < 2 {
+		chk.Panic("at least two groups are required. %d is invalid\n", k)
+	}
+	ntot := 0
+	for g, x := range groups {
+		if len(x) < 1 {
+			chk.Panic("all groups must have data. group %d is empty\n", g)
+		}
+		ntot += len(x)
+	}
+	if ntot <= k {
+		chk.Panic("total number of samples must be greater than the number of groups. %d ≤ %d is invalid\n", ntot, k)
+	}
+
+	// grand mean
+	mean := 0.0
+	for _, x := range groups {
+		mean += x.Accum()
+	}
+	mean /= float64(ntot)
+
+	// sum of squares
+	var ssb, ssw float64
+	for _, x := range groups {
+		n := float64(len(x))
+		mg := x.Accum() / n
+		ssb += n * (mg - mean) * (mg - mean)
+		for _, xi := range x {
+			ssw += (xi - mg) * (xi - mg)
+		}
+	}
+
+	// no variance within groups
+	if ssw == 0 {
+		if ssb == 0 {
+			return math.NaN(), math.NaN()
+		}
+		return math.Inf(1), 0
+	}
+
+	// statistic and p-value
+	dfb, dfw := float64(k-1), float64(ntot-k)
+	fStat = (ssb / dfb) / (ssw / dfw)
+	pValue = FSf(fStat, dfb, dfw)
+	return
+}
@@ -0,0 +1,77 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/rnd"
+	"github.com/cpmech/gosl/utl"
+)
+
+// KFold splits the indices 0…n-1 into k folds for cross-validation
+//
+//  INPUT:
+//   n       -- number of samples
+//   k       -- number of folds; 2 ≤ k ≤ n
+//   shuffle -- shuffle the indices before splitting; otherwise, folds hold consecutive indices
+//   rng     -- random numbers generator; use nil for the global generator
+//
+//  OUTPUT:
+//   folds -- [k] sorted test indices of each fold. The first n%k folds have n/k+1 indices and
+//            the others have n/k indices. The training indices of fold i are all the others
+//
+func KFold(n, k int, shuffle bool, rng *rnd.RNG) (folds [][]int) {
+	if k < 2 || k > n {
+		chk.Panic("number of folds must be in [2, %d]. k=%d is invalid\n", n, k)
+	}
+	idx := utl.IntRange(n)
+	if shuffle {
+		rng.IntShuffle(idx)
+	}
+	folds = make([][]int, k)
+	start := 0
+	for i := 0; i < k; i++ {
+		size := n / k
+		if i < n%k {
+			size++
+		}
+		folds[i] = make([]int, size)
+		copy(folds[i], idx[start:start+size])
+		sort.Ints(folds[i])
+		start += size
+	}
+	return
+}
+
+// TrainTestSplit randomly splits the indices 0…n-1 into training and test sets
+//
+//  INPUT:
+//   n        -- number of samples
+//   testFrac -- fraction of samples in the test set; 0 < testFrac < 1. The number of test
+//               samples is ceil(testFrac⋅n) and there is at least one training sample
+//   rng      -- random numbers generator; use nil for the global generator
+//
+//  OUTPUT:
+//   trainIdx -- sorted training indices
+//   testIdx  -- sorted test indices
+//
+func TrainTestSplit(n int, testFrac float64, rng *rnd.RNG) (trainIdx, testIdx []int) {
+	if n < 2 {
+		chk.Panic("number of samples must be at least 2. n=%d is invalid\n", n)
+	}
+	if testFrac <= 0 || testFrac >= 1 {
+		chk.Panic("fraction of test samples must be in (0, 1). testFrac=%g is invalid\n", testFrac)
+	}
+	ntest := utl.Imin(int(math.Ceil(testFrac*float64(n)-1e-10)), n-1)
+	idx := rng.Perm(n)
+	testIdx = idx[:ntest]
+	trainIdx = idx[ntest:]
+	sort.Ints(testIdx)
+	sort.Ints(trainIdx)
+	return
+}
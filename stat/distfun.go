@@ -0,0 +1,67 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/fun"
+)
+
+// FCdf computes the cumulative distribution function of the F (Fisher-Snedecor) distribution
+//
+//   CDF(x; d1, d2) = I_{d1⋅x/(d1⋅x+d2)}(d1/2, d2/2)
+//
+//  INPUT:
+//   x  -- value ≥ 0
+//   d1 -- degrees of freedom of the numerator
+//   d2 -- degrees of freedom of the denominator
+//
+func FCdf(x, d1, d2 float64) float64 {
+	if d1 <= 0 || d2 <= 0 {
+		chk.Panic("degrees of freedom must be positive. d1=%g and d2=%g are invalid\n", d1, d2)
+	}
+	if x <= 0 {
+		return 0
+	}
+	return fun.BetaInc(d1/2.0, d2/2.0, d1*x/(d1*x+d2))
+}
+
+// FSf computes the survival function (complementary CDF) of the F distribution; i.e. 1 - CDF(x)
+//  NOTE: this function is more accurate than 1 - FCdf(x) for large x
+func FSf(x, d1, d2 float64) float64 {
+	if d1 <= 0 || d2 <= 0 {
+		chk.Panic("degrees of freedom must be positive. d1=%g and d2=%g are invalid\n", d1, d2)
+	}
+	if x <= 0 {
+		return 1
+	}
+	return fun.BetaInc(d2/2.0, d1/2.0, d2/(d2+d1*x))
+}
+
+// TCdf computes the cumulative distribution function of the Student's t distribution
+//
+//   CDF(t; ν) = 1 - ½ I_{ν/(ν+t²)}(ν/2, ½)    for t ≥ 0
+//
+//  INPUT:
+//   t -- value
+//   ν -- degrees of freedom
+//
+func TCdf(t, ν float64) float64 {
+	return 1.0 - TSf(t, ν)
+}
+
+// TSf computes the survival function (complementary CDF) of the Student's t distribution; i.e.
+// 1 - CDF(t)
+//  NOTE: this function is more accurate than 1 - TCdf(t) for large t
+func TSf(t, ν float64) float64 {
+	if ν <= 0 {
+		chk.Panic("degrees of freedom must be positive. ν=%g is invalid\n", ν)
+	}
+	tail := 0.5 * fun.BetaInc(ν/2.0, 0.5, ν/(ν+t*t))
+	if t < 0 {
+		return 1.0 - tail
+	}
+	return tail
+}
@@ -0,0 +1,132 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/num"
+)
+
+// Distribution defines the probability distribution required by Expectation
+//
+//  The distribution may also implement the following methods (e.g. as the distributions in rnd):
+//
+//   Support() (lo, hi float64) -- interval outside which the density is zero [default = (-∞,∞)]
+//   Cdf(x float64) float64     -- cumulative distribution used to locate the bulk of the density
+//
+type Distribution interface {
+	Pdf(x float64) float64 // probability density function @ x
+}
+
+// Expectation computes the expected value of g(X) where X has the given distribution
+//
+//                 hi
+//   E[g(X)] = ∫  g(x) ⋅ pdf(x) dx
+//             lo
+//
+//  The integral is split at the median c of the distribution. Infinite parts of the support are
+//  mapped onto [0,1) by
+//
+//   x = c ± s ⋅ t / (1 - t)    with    dx = s / (1 - t)² dt
+//
+//  where s is the interquartile range. Both c and s are computed from the Cdf if available;
+//  otherwise, c = 0 (or the finite end of the support) and s = 1
+//
+//  OUTPUT:
+//   res -- expected value
+//   err -- error if the adaptive quadrature does not converge
+//
+func Expectation(dist Distribution, g func(x float64) float64) (res float64, err error) {
+
+	// support
+	lo, hi := math.Inf(-1), math.Inf(1)
+	if d, ok := dist.(interface {
+		Support() (lo, hi float64)
+	}); ok {
+		lo, hi = d.Support()
+	}
+	if !(lo < hi) {
+		return 0, chk.Err("support of distribution [%g, %g] is invalid", lo, hi)
+	}
+
+	// center and scale
+	c, s := 0.0, 1.0
+	if !math.IsInf(lo, 0) {
+		c = lo
+	} else if !math.IsInf(hi, 0) {
+		c = hi
+	}
+	if d, ok := dist.(interface {
+		Cdf(x float64) float64
+	}); ok {
+		c = quantile(d.Cdf, 0.5, lo, hi, c)
+		iqr := quantile(d.Cdf, 0.75, lo, hi, c) - quantile(d.Cdf, 0.25, lo, hi, c)
+		if iqr > 0 && !math.IsInf(iqr, 0) {
+			s = iqr
+		}
+	}
+
+	// integrand
+	f := func(x float64) float64 {
+		p := dist.Pdf(x)
+		if p == 0 {
+			return 0
+		}
+		return g(x) * p
+	}
+
+	// integrate left and right of c
+	const tol = 1e-10
+	var left, right float64
+	if math.IsInf(lo, 0) {
+		left, err = num.IntegratorGauss{}.Integrate(func(t float64) float64 {
+			u := 1.0 - t
+			return f(c-s*t/u) * s / (u * u)
+		}, 0, 1, tol)
+	} else {
+		left, err = num.AutoIntegrate(f, lo, c, tol)
+	}
+	if err != nil {
+		return 0, chk.Err("integration over (%g, %g] failed: %v", lo, c, err)
+	}
+	if math.IsInf(hi, 0) {
+		right, err = num.IntegratorGauss{}.Integrate(func(t float64) float64 {
+			u := 1.0 - t
+			return f(c+s*t/u) * s / (u * u)
+		}, 0, 1, tol)
+	} else {
+		right, err = num.AutoIntegrate(f, c, hi, tol)
+	}
+	if err != nil {
+		return 0, chk.Err("integration over [%g, %g) failed: %v", c, hi, err)
+	}
+	return left + right, nil
+}
+
+// quantile finds x such that cdf(x) = p in the support [lo, hi], starting the search @ x0
+func quantile(cdf func(x float64) float64, p, lo, hi, x0 float64) float64 {
+
+	// bracket
+	a, b := x0, x0
+	for w := 1.0; cdf(a) > p && w < 1e300; w *= 2 {
+		a = math.Max(x0-w, lo)
+	}
+	for w := 1.0; cdf(b) < p && w < 1e300; w *= 2 {
+		b = math.Min(x0+w, hi)
+	}
+	if a == b {
+		return a
+	}
+
+	// bisection
+	solver := num.RootBisection{MaxIt: 200}
+	x, err := solver.FindRoot(func(x float64) float64 { return cdf(x) - p }, a, b, 1e-12*math.Max(1, b-a))
+	if err != nil {
+		return (a + b) / 2.0
+	}
+	return x
+}
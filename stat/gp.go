@@ -0,0 +1,253 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package stat implements statistical learning and inference tools such as Gaussian process
+// regression, clustering, hypothesis tests and regression models
+package stat
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// GP implements the Gaussian process regression model (zero prior mean)
+//
+//   mean(x*)     = k*ᵀ ⋅ (K + σn²I)⁻¹ ⋅ y
+//   variance(x*) = k(x*,x*) - k*ᵀ ⋅ (K + σn²I)⁻¹ ⋅ k*
+//
+//  where K[i][j] = k(xi,xj) and k*[i] = k(x*,xi)
+//
+//  Reference:
+//    [1] Rasmussen CE and Williams CKI (2006) Gaussian Processes for Machine Learning. MIT Press
+//
+type GP struct {
+	Kernel Kernel  // covariance function k(a,b)
+	Noise  float64 // noise variance σn² added to the diagonal of K
+
+	// data (after Fit)
+	X     *la.Matrix // [npts][ndim] training points (rows); not copied
+	L     *la.Matrix // [npts][npts] Cholesky factor of K + σn²I
+	Alpha la.Vector  // [npts] (K + σn²I)⁻¹ ⋅ y
+	lml   float64    // log marginal likelihood of the training data
+
+	// workspace
+	xi la.Vector // a training point
+	xs la.Vector // a prediction point
+	v  la.Vector // [npts] k* and L⁻¹ ⋅ k*
+}
+
+// NewGP returns a new Gaussian process regression model
+//  kernel -- covariance function; e.g. KernelRBF{Sigma2: 1, Length: 0.5}. NOTE: a Kernel (instead
+//            of a func(a, b la.Vector) float64) is required such that parameterized kernels can
+//            be inspected and modified; e.g. KernelScalable used by opt.BayesOpt to select the
+//            length scale. Use KernelFunc(f) to pass a function f(a, b)
+//  noise  -- noise variance σn² ≥ 0; a small value (e.g. 1e-10) improves the conditioning of K
+func NewGP(kernel Kernel, noise float64) (o *GP) {
+	if kernel == nil {
+		chk.Panic("kernel function must be given\n")
+	}
+	if noise < 0 {
+		chk.Panic("noise variance must be non-negative. noise=%g is invalid\n", noise)
+	}
+	o = new(GP)
+	o.Kernel = kernel
+	o.Noise = noise
+	return
+}
+
+// Fit computes the posterior of the Gaussian process given the training data
+//  X -- [npts][ndim] training points (rows); not copied
+//  y -- [npts] observed values
+func (o *GP) Fit(X *la.Matrix, y la.Vector) {
+
+	// check
+	n := X.M
+	if n < 1 {
+		chk.Panic("at least one training point is required\n")
+	}
+	if len(y) != n {
+		chk.Panic("number of values must be equal to the number of points. %d != %d\n", len(y), n)
+	}
+
+	// covariance matrix
+	o.X = X
+	o.xi = la.NewVector(X.N)
+	o.xs = la.NewVector(X.N)
+	xj := la.NewVector(X.N)
+	K := la.NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		rowInto(o.xi, X, i)
+		for j := 0; j <= i; j++ {
+			rowInto(xj, X, j)
+			kij := o.Kernel.Eval(o.xi, xj)
+			K.Set(i, j, kij)
+			K.Set(j, i, kij)
+		}
+		K.Add(i, i, o.Noise)
+	}
+
+	// Cholesky factorization
+	o.L = la.NewMatrix(n, n)
+	la.Cholesky(o.L, K)
+
+	// α = L⁻ᵀ ⋅ L⁻¹ ⋅ y
+	o.Alpha = la.NewVector(n)
+	la.CholeskySolve(o.Alpha, o.L, y)
+	o.v = la.NewVector(n)
+
+	// log p(y|X) = -½ yᵀ⋅α - Σ log L[i][i] - ½ n log(2π)
+	o.lml = -0.5*la.VecDot(y, o.Alpha) - 0.5*float64(n)*math.Log(2.0*math.Pi)
+	for i := 0; i < n; i++ {
+		o.lml -= math.Log(o.L.Get(i, i))
+	}
+}
+
+// LogMarginalLikelihood returns the log marginal likelihood of the training data [1, Eq. 2.30]
+//
+//   log p(y|X) = -½ yᵀ⋅(K + σn²I)⁻¹⋅y - ½ log|K + σn²I| - ½ n log(2π)
+//
+//  NOTE: this value can be used to select the kernel parameters (e.g. the length scale)
+func (o *GP) LogMarginalLikelihood() float64 {
+	if o.L == nil {
+		chk.Panic("Fit must be called before LogMarginalLikelihood\n")
+	}
+	return o.lml
+}
+
+// Predict computes the posterior mean and variance at the prediction points
+//  Xstar -- [nstar][ndim] prediction points (rows)
+func (o *GP) Predict(Xstar *la.Matrix) (mean, variance la.Vector) {
+	if o.L == nil {
+		chk.Panic("Fit must be called before Predict\n")
+	}
+	if Xstar.N != o.X.N {
+		chk.Panic("prediction points must have %d dimensions. %d is invalid\n", o.X.N, Xstar.N)
+	}
+	mean = la.NewVector(Xstar.M)
+	variance = la.NewVector(Xstar.M)
+	for p := 0; p < Xstar.M; p++ {
+		rowInto(o.xs, Xstar, p)
+		mean[p], variance[p] = o.PredictPoint(o.xs)
+	}
+	return
+}
+
+// PredictPoint computes the posterior mean and variance at a single prediction point
+//  xstar -- [ndim] prediction point
+func (o *GP) PredictPoint(xstar la.Vector) (mean, variance float64) {
+	if o.L == nil {
+		chk.Panic("Fit must be called before PredictPoint\n")
+	}
+	if len(xstar) != o.X.N {
+		chk.Panic("prediction point must have %d dimensions. %d is invalid\n", o.X.N, len(xstar))
+	}
+	for i := 0; i < o.X.M; i++ {
+		rowInto(o.xi, o.X, i)
+		o.v[i] = o.Kernel.Eval(xstar, o.xi)
+	}
+	mean = la.VecDot(o.v, o.Alpha)
+	la.CholeskySolveL(o.v, o.L, o.v) // v = L⁻¹ ⋅ k*
+	variance = math.Max(o.Kernel.Eval(xstar, xstar)-la.VecDot(o.v, o.v), 0)
+	return
+}
+
+// kernels /////////////////////////////////////////////////////////////////////////////////////////
+
+// Kernel defines the covariance function k(a,b) of Gaussian processes
+type Kernel interface {
+	Eval(a, b la.Vector) float64 // k(a,b)
+}
+
+// KernelScalable defines kernels whose length scale can be modified; e.g. to be selected by
+// maximizing the marginal likelihood
+type KernelScalable interface {
+	Kernel
+	WithLength(length float64) Kernel // returns a copy of the kernel with a new length scale
+}
+
+// KernelFunc adapts a function k(a,b) to the Kernel interface
+type KernelFunc func(a, b la.Vector) float64
+
+// KernelRBF implements the squared-exponential (radial basis function) covariance function
+//
+//   k(a,b) = σ² ⋅ exp(-r² / (2ℓ²))    with r = |a - b|
+//
+type KernelRBF struct {
+	Sigma2 float64 // σ² variance
+	Length float64 // ℓ length scale
+}
+
+// KernelMatern32 implements the Matérn covariance function with ν = 3/2
+//
+//   k(a,b) = σ² ⋅ (1 + √3 r/ℓ) ⋅ exp(-√3 r/ℓ)    with r = |a - b|
+//
+type KernelMatern32 struct {
+	Sigma2 float64 // σ² variance
+	Length float64 // ℓ length scale
+}
+
+// KernelMatern52 implements the Matérn covariance function with ν = 5/2
+//
+//   k(a,b) = σ² ⋅ (1 + √5 r/ℓ + 5r²/(3ℓ²)) ⋅ exp(-√5 r/ℓ)    with r = |a - b|
+//
+type KernelMatern52 struct {
+	Sigma2 float64 // σ² variance
+	Length float64 // ℓ length scale
+}
+
+// Eval computes k(a,b)
+func (o KernelFunc) Eval(a, b la.Vector) float64 {
+	return o(a, b)
+}
+
+// Eval computes k(a,b)
+func (o KernelRBF) Eval(a, b la.Vector) float64 {
+	return o.Sigma2 * math.Exp(-0.5*sqDist(a, b)/(o.Length*o.Length))
+}
+
+// WithLength returns a copy of the kernel with a new length scale
+func (o KernelRBF) WithLength(length float64) Kernel {
+	return KernelRBF{Sigma2: o.Sigma2, Length: length}
+}
+
+// Eval computes k(a,b)
+func (o KernelMatern32) Eval(a, b la.Vector) float64 {
+	s := math.Sqrt(3.0*sqDist(a, b)) / o.Length
+	return o.Sigma2 * (1.0 + s) * math.Exp(-s)
+}
+
+// WithLength returns a copy of the kernel with a new length scale
+func (o KernelMatern32) WithLength(length float64) Kernel {
+	return KernelMatern32{Sigma2: o.Sigma2, Length: length}
+}
+
+// Eval computes k(a,b)
+func (o KernelMatern52) Eval(a, b la.Vector) float64 {
+	s := math.Sqrt(5.0*sqDist(a, b)) / o.Length
+	return o.Sigma2 * (1.0 + s + s*s/3.0) * math.Exp(-s)
+}
+
+// WithLength returns a copy of the kernel with a new length scale
+func (o KernelMatern52) WithLength(length float64) Kernel {
+	return KernelMatern52{Sigma2: o.Sigma2, Length: length}
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// rowInto copies row i of the matrix X into v
+func rowInto(v la.Vector, X *la.Matrix, i int) {
+	for j := 0; j < X.N; j++ {
+		v[j] = X.Get(i, j)
+	}
+}
+
+// sqDist computes the squared Euclidean distance between a and b
+func sqDist(a, b la.Vector) (d float64) {
+	for i := 0; i < len(a); i++ {
+		d += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return
+}
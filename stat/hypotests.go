@@ -0,0 +1,200 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// TTest2 performs the two-sample t-test of the null hypothesis that a and b have the same mean
+//
+//  equalVar = true  ⇒  Student's t-test with pooled variance and ν = na + nb - 2
+//
+//   t = (mean_a - mean_b) / (sp ⋅ √(1/na + 1/nb))    with sp² = ((na-1) sa² + (nb-1) sb²) / ν
+//
+//  equalVar = false ⇒  Welch's t-test with the Welch-Satterthwaite degrees of freedom
+//
+//   t = (mean_a - mean_b) / √(sa²/na + sb²/nb)
+//   ν = (sa²/na + sb²/nb)² / ((sa²/na)²/(na-1) + (sb²/nb)²/(nb-1))
+//
+//  OUTPUT:
+//   t -- t statistic
+//   p -- two-sided p-value
+//
+//  NOTE: if both samples have zero variance, t = ±Inf and p = 0 if the means differ; otherwise
+//        (all values equal), t = NaN and p = NaN
+//
+func TTest2(a, b la.Vector, equalVar bool) (t, p float64) {
+
+	// check
+	na, nb := float64(len(a)), float64(len(b))
+	if len(a) < 2 || len(b) < 2 {
+		chk.Panic("samples must have at least two values each. len(a)=%d and len(b)=%d are invalid\n", len(a), len(b))
+	}
+
+	// means and variances
+	ma, va := meanVar(a)
+	mb, vb := meanVar(b)
+
+	// no variance
+	if va == 0 && vb == 0 {
+		if ma == mb {
+			return math.NaN(), math.NaN()
+		}
+		return math.Copysign(math.Inf(1), ma-mb), 0
+	}
+
+	// statistic and degrees of freedom
+	var ν float64
+	if equalVar {
+		ν = na + nb - 2.0
+		sp2 := ((na-1.0)*va + (nb-1.0)*vb) / ν
+		t = (ma - mb) / math.Sqrt(sp2*(1.0/na+1.0/nb))
+	} else {
+		qa, qb := va/na, vb/nb
+		ν = (qa + qb) * (qa + qb) / (qa*qa/(na-1.0) + qb*qb/(nb-1.0))
+		t = (ma - mb) / math.Sqrt(qa+qb)
+	}
+
+	// p-value
+	p = 2.0 * TSf(math.Abs(t), ν)
+	return
+}
+
+// MannWhitneyU performs the Mann-Whitney U (Wilcoxon rank-sum) test of the null hypothesis that
+// the distributions of a and b are equal
+//
+//   U = Ra - na (na + 1) / 2
+//
+//  where Ra is the sum of the ranks of a in the combined sample (ties receive the average rank)
+//
+//  The p-value is computed from the exact distribution of U if there are no ties and
+//  na, nb ≤ 20. Otherwise, the normal approximation with tie and continuity corrections is used
+//
+//  OUTPUT:
+//   u -- statistic U of sample a (the statistic of b is na⋅nb - u)
+//   p -- two-sided p-value
+//
+func MannWhitneyU(a, b la.Vector) (u, p float64) {
+
+	// check
+	na, nb := len(a), len(b)
+	if na < 1 || nb < 1 {
+		chk.Panic("samples must not be empty. len(a)=%d and len(b)=%d are invalid\n", na, nb)
+	}
+
+	// ranks
+	n := na + nb
+	all := make([]float64, n)
+	copy(all, a)
+	copy(all[na:], b)
+	ranks, tieSum := rankAverage(all)
+	ra := 0.0
+	for i := 0; i < na; i++ {
+		ra += ranks[i]
+	}
+	u = ra - float64(na*(na+1))/2.0
+
+	// exact p-value
+	if tieSum == 0 && na <= 20 && nb <= 20 {
+		umin := math.Min(u, float64(na*nb)-u)
+		p = math.Min(1.0, 2.0*mannWhitneyCdf(int(umin), na, nb))
+		return
+	}
+
+	// normal approximation
+	n1n2 := float64(na * nb)
+	nn := float64(n)
+	μ := n1n2 / 2.0
+	σ := math.Sqrt(n1n2 / 12.0 * ((nn + 1.0) - tieSum/(nn*(nn-1.0))))
+	if σ == 0 {
+		return u, 1
+	}
+	z := math.Max(math.Abs(u-μ)-0.5, 0) / σ
+	p = math.Min(1.0, math.Erfc(z/math.Sqrt2))
+	return
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// meanVar computes the mean and the sample (unbiased) variance of x
+func meanVar(x la.Vector) (mean, variance float64) {
+	n := float64(len(x))
+	mean = x.Accum() / n
+	for _, xi := range x {
+		variance += (xi - mean) * (xi - mean)
+	}
+	variance /= n - 1.0
+	return
+}
+
+// rankAverage computes the ranks (1…n) of x with ties receiving the average rank. It also returns
+// the tie correction term Σ (t³ - t) where t is the size of each group of ties
+func rankAverage(x []float64) (ranks []float64, tieSum float64) {
+	n := len(x)
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool { return x[idx[i]] < x[idx[j]] })
+	ranks = make([]float64, n)
+	for i := 0; i < n; {
+		j := i + 1
+		for j < n && x[idx[j]] == x[idx[i]] {
+			j++
+		}
+		r := float64(i+j+1) / 2.0 // average of ranks i+1 … j
+		for k := i; k < j; k++ {
+			ranks[idx[k]] = r
+		}
+		t := float64(j - i)
+		tieSum += t*t*t - t
+		i = j
+	}
+	return
+}
+
+// mannWhitneyCdf computes P(U ≤ u) using the exact distribution of U without ties. The number
+// of arrangements c(i,j,u) of i values of a and j values of b with statistic u follows
+//
+//   c(i,j,u) = c(i-1,j,u-j) + c(i,j-1,u)
+//
+func mannWhitneyCdf(u, na, nb int) float64 {
+	umax := na * nb
+	prev := make([][]float64, nb+1) // c(i-1,⋅,⋅)
+	curr := make([][]float64, nb+1) // c(i,⋅,⋅)
+	for j := 0; j <= nb; j++ {
+		prev[j] = make([]float64, umax+1)
+		curr[j] = make([]float64, umax+1)
+		prev[j][0] = 1 // c(0,j,0) = 1
+	}
+	for i := 1; i <= na; i++ {
+		for j := 0; j <= nb; j++ {
+			for v := 0; v <= umax; v++ {
+				c := 0.0
+				if v >= j {
+					c += prev[j][v-j]
+				}
+				if j > 0 {
+					c += curr[j-1][v]
+				}
+				curr[j][v] = c
+			}
+		}
+		prev, curr = curr, prev
+	}
+	var count, total float64
+	for v := 0; v <= umax; v++ {
+		if v <= u {
+			count += prev[nb][v]
+		}
+		total += prev[nb][v]
+	}
+	return count / total
+}
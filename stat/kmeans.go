@@ -0,0 +1,165 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/rnd"
+)
+
+// KMeans partitions data into k clusters using the k-means++ initialization followed by Lloyd's
+// iterations
+//
+//  Reference:
+//    [1] Arthur D and Vassilvitskii S (2007) k-means++: the advantages of careful seeding.
+//        In: Proc. of the 18th Annual ACM-SIAM Symposium on Discrete Algorithms, 1027-1035
+//
+//  INPUT:
+//   data    -- [npts][ndim] points (rows)
+//   k       -- number of clusters; 1 ≤ k ≤ npts
+//   maxIter -- maximum number of Lloyd's iterations; maxIter ≥ 1
+//   rng     -- random numbers generator; use nil for the global generator
+//
+//  OUTPUT:
+//   centroids -- [k][ndim] cluster centers (rows)
+//   labels    -- [npts] index of cluster of each point
+//   inertia   -- total within-cluster sum of squared distances to the centroids
+//
+//  NOTE: empty clusters are reseeded with the point farthest from its centroid
+//
+func KMeans(data *la.Matrix, k int, maxIter int, rng *rnd.RNG) (centroids *la.Matrix, labels []int, inertia float64) {
+
+	// check
+	npts, ndim := data.M, data.N
+	if k < 1 || k > npts {
+		chk.Panic("number of clusters must be in [1, %d]. k=%d is invalid\n", npts, k)
+	}
+	if maxIter < 1 {
+		chk.Panic("maximum number of iterations must be at least 1. maxIter=%d is invalid\n", maxIter)
+	}
+
+	// points
+	points := make([]la.Vector, npts)
+	for i := 0; i < npts; i++ {
+		points[i] = la.NewVector(ndim)
+		rowInto(points[i], data, i)
+	}
+
+	// k-means++ initialization
+	centers := make([]la.Vector, k)
+	centers[0] = points[rng.Intn(npts)].GetCopy()
+	dmin := la.NewVector(npts)
+	for i := 0; i < npts; i++ {
+		dmin[i] = sqDist(points[i], centers[0])
+	}
+	for c := 1; c < k; c++ {
+		sum := dmin.Accum()
+		chosen := npts - 1
+		if sum > 0 {
+			target := rng.Float64(0, sum)
+			for i := 0; i < npts; i++ {
+				target -= dmin[i]
+				if target < 0 {
+					chosen = i
+					break
+				}
+			}
+		} else { // all points coincide with the centers
+			chosen = rng.Intn(npts)
+		}
+		centers[c] = points[chosen].GetCopy()
+		for i := 0; i < npts; i++ {
+			dmin[i] = math.Min(dmin[i], sqDist(points[i], centers[c]))
+		}
+	}
+
+	// Lloyd's iterations
+	labels = make([]int, npts)
+	for i := 0; i < npts; i++ {
+		labels[i] = -1
+	}
+	counts := make([]int, k)
+	for it := 0; it < maxIter; it++ {
+
+		// assignment step
+		changed := kmeansAssign(labels, points, centers)
+		if !changed {
+			break
+		}
+
+		// update step
+		for c := 0; c < k; c++ {
+			centers[c].Fill(0)
+			counts[c] = 0
+		}
+		for i, c := range labels {
+			la.VecAdd(centers[c], 1, centers[c], 1, points[i])
+			counts[c]++
+		}
+		for c := 0; c < k; c++ {
+			if counts[c] > 0 {
+				centers[c].Apply(1.0/float64(counts[c]), centers[c])
+			}
+		}
+
+		// reseed empty clusters with the point farthest from its centroid
+		for c := 0; c < k; c++ {
+			if counts[c] > 0 {
+				continue
+			}
+			far, dfar := -1, -1.0
+			for i, ci := range labels {
+				if counts[ci] < 2 {
+					continue
+				}
+				d := sqDist(points[i], centers[ci])
+				if d > dfar {
+					far, dfar = i, d
+				}
+			}
+			if far < 0 {
+				break // cannot happen with k ≤ npts
+			}
+			old := labels[far]
+			la.VecAdd(centers[old], float64(counts[old])/float64(counts[old]-1), centers[old], -1.0/float64(counts[old]-1), points[far])
+			counts[old]--
+			copy(centers[c], points[far])
+			labels[far], counts[c] = c, 1
+		}
+	}
+
+	// results
+	centroids = la.NewMatrix(k, ndim)
+	for c := 0; c < k; c++ {
+		for j := 0; j < ndim; j++ {
+			centroids.Set(c, j, centers[c][j])
+		}
+	}
+	for i, c := range labels {
+		inertia += sqDist(points[i], centers[c])
+	}
+	return
+}
+
+// kmeansAssign assigns each point to the nearest center. Returns true if any label has changed
+func kmeansAssign(labels []int, points, centers []la.Vector) (changed bool) {
+	for i, p := range points {
+		best, dbest := 0, math.Inf(1)
+		for c, center := range centers {
+			d := sqDist(p, center)
+			if d < dbest {
+				best, dbest = c, d
+			}
+		}
+		if labels[i] != best {
+			labels[i] = best
+			changed = true
+		}
+	}
+	return
+}
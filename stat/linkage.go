@@ -0,0 +1,176 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// Merge holds the data of one merge step of the agglomerative clustering
+type Merge struct {
+	A, B   int     // ids of the merged clusters; leaves are 0…npts-1 and merge i creates npts+i
+	Height float64 // linkage distance between A and B
+	Size   int     // number of points in the new cluster
+}
+
+// Dendrogram holds the merge tree of the agglomerative (hierarchical) clustering
+type Dendrogram struct {
+	Npts   int     // number of points (leaves)
+	Merges []Merge // [npts-1] merges sorted by increasing height
+}
+
+// Linkage performs the agglomerative hierarchical clustering of data
+//
+//  The distances between clusters are updated with the Lance-Williams formula, using the
+//  Euclidean distance between points:
+//
+//   single   -- d(I,J) = min d(i,j)
+//   complete -- d(I,J) = max d(i,j)
+//   average  -- d(I,J) = mean d(i,j)  (UPGMA)
+//   ward     -- minimum increase of the within-cluster sum of squares
+//
+//  INPUT:
+//   data   -- [npts][ndim] points (rows)
+//   method -- "single", "complete", "average" or "ward"
+//
+//  OUTPUT:
+//   dendrogram -- merge tree
+//   err        -- error if the method is invalid or there are no points
+//
+//  NOTE: this function uses a dense distance matrix with O(npts²) memory and O(npts³) time
+//
+func Linkage(data *la.Matrix, method string) (dendrogram *Dendrogram, err error) {
+
+	// check
+	n := data.M
+	if n < 1 {
+		return nil, chk.Err("at least one point is required")
+	}
+	var update func(dki, dkj, dij float64, ni, nj, nk int) float64
+	switch method {
+	case "single":
+		update = func(dki, dkj, dij float64, ni, nj, nk int) float64 {
+			return math.Min(dki, dkj)
+		}
+	case "complete":
+		update = func(dki, dkj, dij float64, ni, nj, nk int) float64 {
+			return math.Max(dki, dkj)
+		}
+	case "average":
+		update = func(dki, dkj, dij float64, ni, nj, nk int) float64 {
+			return (float64(ni)*dki + float64(nj)*dkj) / float64(ni+nj)
+		}
+	case "ward":
+		update = func(dki, dkj, dij float64, ni, nj, nk int) float64 {
+			a, b, c := float64(ni+nk), float64(nj+nk), float64(nk)
+			return math.Sqrt(math.Max(a*dki*dki+b*dkj*dkj-c*dij*dij, 0) / float64(ni+nj+nk))
+		}
+	default:
+		return nil, chk.Err("linkage method %q is invalid. Options are: single, complete, average, ward", method)
+	}
+
+	// distance matrix
+	D := la.NewMatrix(n, n)
+	xi, xj := la.NewVector(data.N), la.NewVector(data.N)
+	for i := 0; i < n; i++ {
+		rowInto(xi, data, i)
+		for j := 0; j < i; j++ {
+			rowInto(xj, data, j)
+			dij := math.Sqrt(sqDist(xi, xj))
+			D.Set(i, j, dij)
+			D.Set(j, i, dij)
+		}
+	}
+
+	// active clusters: slot i holds cluster id[i] with size[i] points
+	id := make([]int, n)
+	size := make([]int, n)
+	active := make([]bool, n)
+	for i := 0; i < n; i++ {
+		id[i], size[i], active[i] = i, 1, true
+	}
+
+	// merges
+	dendrogram = &Dendrogram{Npts: n, Merges: make([]Merge, n-1)}
+	for m := 0; m < n-1; m++ {
+
+		// closest pair of active clusters
+		bi, bj, dmin := -1, -1, math.Inf(1)
+		for i := 0; i < n; i++ {
+			if !active[i] {
+				continue
+			}
+			for j := i + 1; j < n; j++ {
+				if active[j] && D.Get(i, j) < dmin {
+					bi, bj, dmin = i, j, D.Get(i, j)
+				}
+			}
+		}
+
+		// record merge
+		a, b := id[bi], id[bj]
+		if a > b {
+			a, b = b, a
+		}
+		dendrogram.Merges[m] = Merge{A: a, B: b, Height: dmin, Size: size[bi] + size[bj]}
+
+		// new cluster stored in slot bi
+		for k := 0; k < n; k++ {
+			if !active[k] || k == bi || k == bj {
+				continue
+			}
+			dk := update(D.Get(k, bi), D.Get(k, bj), dmin, size[bi], size[bj], size[k])
+			D.Set(k, bi, dk)
+			D.Set(bi, k, dk)
+		}
+		id[bi], size[bi] = n+m, size[bi]+size[bj]
+		active[bj] = false
+	}
+	return
+}
+
+// Cut returns the labels of the points after cutting the tree into k flat clusters; i.e. by
+// undoing the last k-1 merges. The labels are numbered 0…k-1 in the order of the lowest point
+// index in each cluster
+func (o *Dendrogram) Cut(k int) (labels []int) {
+
+	// check
+	if k < 1 || k > o.Npts {
+		chk.Panic("number of clusters must be in [1, %d]. k=%d is invalid\n", o.Npts, k)
+	}
+
+	// apply the first npts-k merges with a union-find structure
+	parent := make([]int, 2*o.Npts-1)
+	for i := range parent {
+		parent[i] = i
+	}
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+	for m := 0; m < o.Npts-k; m++ {
+		c := o.Npts + m
+		parent[find(o.Merges[m].A)] = c
+		parent[find(o.Merges[m].B)] = c
+	}
+
+	// labels
+	labels = make([]int, o.Npts)
+	number := make(map[int]int)
+	for i := 0; i < o.Npts; i++ {
+		root := find(i)
+		if _, ok := number[root]; !ok {
+			number[root] = len(number)
+		}
+		labels[i] = number[root]
+	}
+	return
+}
@@ -0,0 +1,114 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+)
+
+// ConfusionMatrix computes the confusion matrix of a classification
+//
+//   C[i][j] = number of samples of true class i predicted as class j
+//
+//  INPUT:
+//   trueLabels -- [nsamples] true classes in [0, nClasses)
+//   predLabels -- [nsamples] predicted classes in [0, nClasses)
+//   nClasses   -- number of classes
+//
+func ConfusionMatrix(trueLabels, predLabels []int, nClasses int) (C *la.Matrix) {
+	if len(trueLabels) != len(predLabels) {
+		chk.Panic("number of true and predicted labels must be the same. %d != %d\n", len(trueLabels), len(predLabels))
+	}
+	C = la.NewMatrix(nClasses, nClasses)
+	for k := 0; k < len(trueLabels); k++ {
+		i, j := trueLabels[k], predLabels[k]
+		if i < 0 || i >= nClasses || j < 0 || j >= nClasses {
+			chk.Panic("labels must be in [0, %d). true=%d and pred=%d of sample %d are invalid\n", nClasses, i, j, k)
+		}
+		C.Add(i, j, 1)
+	}
+	return
+}
+
+// Accuracy computes the fraction of correctly classified samples from the confusion matrix
+//  NOTE: returns 0 if there are no samples
+func Accuracy(C *la.Matrix) float64 {
+	var correct, total float64
+	for i := 0; i < C.M; i++ {
+		for j := 0; j < C.N; j++ {
+			total += C.Get(i, j)
+		}
+		correct += C.Get(i, i)
+	}
+	if total == 0 {
+		return 0
+	}
+	return correct / total
+}
+
+// Precision computes the per-class and macro-averaged precision from the confusion matrix
+//
+//   precision[i] = C[i][i] / Σ_k C[k][i]    (true positives / predicted positives)
+//
+//  NOTE: classes never predicted have zero precision. Classes absent from both the true and the
+//        predicted labels are excluded from the macro average
+func Precision(C *la.Matrix) (perClass la.Vector, macro float64) {
+	return classMetric(C, func(tp, npred, ntrue float64) float64 { return ratio(tp, npred) })
+}
+
+// Recall computes the per-class and macro-averaged recall from the confusion matrix
+//
+//   recall[i] = C[i][i] / Σ_k C[i][k]    (true positives / actual positives)
+//
+//  NOTE: classes without true samples have zero recall. Classes absent from both the true and the
+//        predicted labels are excluded from the macro average
+func Recall(C *la.Matrix) (perClass la.Vector, macro float64) {
+	return classMetric(C, func(tp, npred, ntrue float64) float64 { return ratio(tp, ntrue) })
+}
+
+// F1 computes the per-class and macro-averaged F1 score from the confusion matrix
+//
+//   f1[i] = 2 ⋅ precision[i] ⋅ recall[i] / (precision[i] + recall[i]) = 2 C[i][i] / (npred[i] + ntrue[i])
+//
+//  NOTE: the macro average is the mean of the per-class F1 scores of the classes present in the
+//        true or the predicted labels
+func F1(C *la.Matrix) (perClass la.Vector, macro float64) {
+	return classMetric(C, func(tp, npred, ntrue float64) float64 { return ratio(2*tp, npred+ntrue) })
+}
+
+// classMetric computes the per-class metric f(tp, npred, ntrue) and its macro average over the
+// classes present in the true or predicted labels
+func classMetric(C *la.Matrix, f func(tp, npred, ntrue float64) float64) (perClass la.Vector, macro float64) {
+	if C.M != C.N {
+		chk.Panic("confusion matrix must be square. %d×%d is invalid\n", C.M, C.N)
+	}
+	perClass = la.NewVector(C.M)
+	npresent := 0
+	for i := 0; i < C.M; i++ {
+		var npred, ntrue float64
+		for k := 0; k < C.M; k++ {
+			npred += C.Get(k, i)
+			ntrue += C.Get(i, k)
+		}
+		perClass[i] = f(C.Get(i, i), npred, ntrue)
+		if npred > 0 || ntrue > 0 {
+			macro += perClass[i]
+			npresent++
+		}
+	}
+	if npresent > 0 {
+		macro /= float64(npresent)
+	}
+	return
+}
+
+// ratio returns a/b or zero if b is zero
+func ratio(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return a / b
+}
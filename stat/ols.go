@@ -0,0 +1,209 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/num"
+	"github.com/cpmech/gosl/rnd"
+)
+
+// OLSResult holds the results of a linear regression y ≈ X⋅β
+type OLSResult struct {
+	Beta      la.Vector // [ncol] coefficients
+	Residuals la.Vector // [nrow] y - X⋅β
+	RSS       float64   // residual sum of squares
+	R2        float64   // coefficient of determination 1 - RSS/TSS [see NOTE (2) in Ridge for constant y]
+	Lambda    float64   // regularization parameter (zero for OLS)
+}
+
+// OLS performs the ordinary least-squares regression by solving the normal equations
+//
+//   (Xᵀ⋅X) β = Xᵀ⋅y
+//
+//  INPUT:
+//   X -- [nrow][ncol] design matrix; include a column of ones if an intercept is needed
+//   y -- [nrow] observations
+//
+//  NOTE: an error is returned if Xᵀ⋅X is singular (e.g. collinear predictors). Nearly collinear
+//        predictors give unstable coefficients; use Ridge in this case
+//
+func OLS(X *la.Matrix, y la.Vector) (res *OLSResult, err error) {
+	return Ridge(X, y, 0)
+}
+
+// Ridge performs the ridge (Tikhonov) regression by solving the regularized normal equations
+// with the Cholesky factorization
+//
+//   (Xᵀ⋅X + λ I) β = Xᵀ⋅y
+//
+//  INPUT:
+//   X      -- [nrow][ncol] design matrix; NOTE: all coefficients are penalized, including the
+//             intercept if X has a column of ones. Centering y and X avoids this effect
+//   y      -- [nrow] observations
+//   lambda -- regularization parameter λ ≥ 0
+//
+//  NOTE: (1) λ multiplies ‖β‖² directly; i.e. β = argmin ‖X⋅β - y‖² + λ⋅‖β‖². This differs from
+//            la.RegularizedLeastSquares, where λ² multiplies ‖x‖²; thus Ridge(X, y, λ) is
+//            equivalent to la.RegularizedLeastSquares(X, y, √λ)
+//        (2) R² is undefined if y is constant; i.e. if TSS = Σ(yᵢ - ȳ)² ≤ ε⋅‖y‖², where ε is the
+//            machine epsilon. In this case, R2 = 1 if RSS ≤ ε⋅‖y‖² (perfect fit) or R2 = 0
+//            otherwise
+//
+func Ridge(X *la.Matrix, y la.Vector, lambda float64) (res *OLSResult, err error) {
+
+	// check
+	if len(y) != X.M {
+		return nil, chk.Err("number of observations must be equal to the number of rows of X. %d != %d", len(y), X.M)
+	}
+	if lambda < 0 {
+		return nil, chk.Err("regularization parameter must be non-negative. lambda=%g is invalid", lambda)
+	}
+
+	// normal equations
+	A := la.NewMatrix(X.N, X.N)
+	la.MatTrMatMul(A, 1, X, X)
+	for i := 0; i < X.N; i++ {
+		A.Add(i, i, lambda)
+	}
+	b := la.NewVector(X.N)
+	la.MatTrVecMul(b, 1, X, y)
+
+	// solve
+	res = &OLSResult{Lambda: lambda}
+	res.Beta, err = solveSPD(A, b)
+	if err != nil {
+		return nil, chk.Err("cannot solve normal equations: %v", err)
+	}
+
+	// residuals and R²
+	res.Residuals = y.GetCopy()
+	la.MatVecMulAdd(res.Residuals, -1, X, res.Beta)
+	res.RSS = la.VecDot(res.Residuals, res.Residuals)
+	mean := y.Accum() / float64(len(y))
+	tss := 0.0
+	for _, yi := range y {
+		tss += (yi - mean) * (yi - mean)
+	}
+	small := num.MACHEPS * la.VecDot(y, y)
+	switch {
+	case tss > small:
+		res.R2 = 1.0 - res.RSS/tss
+	case res.RSS <= small:
+		res.R2 = 1
+	default:
+		res.R2 = 0
+	}
+	return
+}
+
+// RidgeCV selects the regularization parameter of Ridge by k-fold cross-validation
+//
+//  INPUT:
+//   X       -- [nrow][ncol] design matrix
+//   y       -- [nrow] observations
+//   lambdas -- candidate values of λ
+//   k       -- number of folds
+//   rng     -- random numbers generator for shuffling the folds; use nil for the global generator
+//
+//  OUTPUT:
+//   best -- λ with the smallest mean squared prediction error
+//   mse  -- [len(lambdas)] mean squared prediction error of each λ (Inf if a fit fails)
+//   err  -- error if all fits fail
+//
+func RidgeCV(X *la.Matrix, y la.Vector, lambdas []float64, k int, rng *rnd.RNG) (best float64, mse la.Vector, err error) {
+
+	// check
+	if len(lambdas) < 1 {
+		return 0, nil, chk.Err("at least one candidate lambda is required")
+	}
+	if len(y) != X.M {
+		return 0, nil, chk.Err("number of observations must be equal to the number of rows of X. %d != %d", len(y), X.M)
+	}
+
+	// folds
+	folds := KFold(X.M, k, true, rng)
+	mse = la.NewVector(len(lambdas))
+	for _, test := range folds {
+		train := complementIndices(X.M, test)
+		Xtrain, ytrain := selectRows(X, y, train)
+		Xtest, ytest := selectRows(X, y, test)
+		pred := la.NewVector(len(test))
+		for l, lambda := range lambdas {
+			if math.IsInf(mse[l], 1) {
+				continue
+			}
+			r, e := Ridge(Xtrain, ytrain, lambda)
+			if e != nil {
+				mse[l] = math.Inf(1)
+				continue
+			}
+			la.MatVecMul(pred, 1, Xtest, r.Beta)
+			for i := range pred {
+				mse[l] += (ytest[i] - pred[i]) * (ytest[i] - pred[i]) / float64(X.M)
+			}
+		}
+	}
+
+	// best
+	ibest := -1
+	for l := range lambdas {
+		if !math.IsInf(mse[l], 1) && (ibest < 0 || mse[l] < mse[ibest]) {
+			ibest = l
+		}
+	}
+	if ibest < 0 {
+		return 0, mse, chk.Err("all ridge fits failed")
+	}
+	return lambdas[ibest], mse, nil
+}
+
+// auxiliary ///////////////////////////////////////////////////////////////////////////////////////
+
+// solveSPD solves A⋅x = b with the Cholesky factorization of the symmetric positive-definite A
+func solveSPD(A *la.Matrix, b la.Vector) (x la.Vector, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = chk.Err("%v", e)
+		}
+	}()
+	x = la.NewVector(len(b))
+	la.SolveRealLinSysSPD(x, A, b)
+	for _, xi := range x {
+		if math.IsNaN(xi) || math.IsInf(xi, 0) {
+			return nil, chk.Err("matrix is not positive-definite")
+		}
+	}
+	return
+}
+
+// complementIndices returns the indices in 0…n-1 that are not in the sorted list idx
+func complementIndices(n int, idx []int) (comp []int) {
+	k := 0
+	for i := 0; i < n; i++ {
+		if k < len(idx) && idx[k] == i {
+			k++
+			continue
+		}
+		comp = append(comp, i)
+	}
+	return
+}
+
+// selectRows returns the rows idx of X and y
+func selectRows(X *la.Matrix, y la.Vector, idx []int) (Xs *la.Matrix, ys la.Vector) {
+	Xs = la.NewMatrix(len(idx), X.N)
+	ys = la.NewVector(len(idx))
+	for r, i := range idx {
+		for j := 0; j < X.N; j++ {
+			Xs.Set(r, j, X.Get(i, j))
+		}
+		ys[r] = y[i]
+	}
+	return
+}
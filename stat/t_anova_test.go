@@ -0,0 +1,71 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestFdist01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Fdist01. CDF of F distribution")
+
+	// closed-form expressions
+	//   d1 = 2 ⇒ SF(x) = (1 + 2x/d2)^(-d2/2)
+	//   d2 = 2 ⇒ CDF(x) = (d1⋅x/(d1⋅x + 2))^(d1/2)
+	for _, x := range []float64{0, 0.1, 0.5, 1, 3, 10, 100} {
+		for _, d := range []float64{1, 3, 7.5, 30} {
+			chk.Float64(tst, io.Sf("SF(%g;2,%g)", x, d), 1e-15, FSf(x, 2, d), math.Pow(1+2*x/d, -d/2))
+			chk.Float64(tst, io.Sf("CDF(%g;%g,2)", x, d), 1e-15, FCdf(x, d, 2), math.Pow(d*x/(d*x+2), d/2))
+			chk.Float64(tst, io.Sf("CDF+SF(%g;%g,%g)", x, d, d+1), 1e-14, FCdf(x, d, d+1)+FSf(x, d, d+1), 1)
+		}
+	}
+}
+
+func TestANOVA01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ANOVA01. one-way analysis of variance")
+
+	// textbook example (e.g. Wikipedia: One-way analysis of variance)
+	//   SSB = 84 with 2 degrees of freedom
+	//   SSW = 68 with 15 degrees of freedom
+	groups := []la.Vector{
+		{6, 8, 4, 5, 3, 4},
+		{8, 12, 9, 11, 6, 8},
+		{13, 9, 11, 8, 7, 12},
+	}
+	f, p := OneWayANOVA(groups)
+	io.Pforan("F = %v  p = %v\n", f, p)
+	fAna := (84.0 / 2.0) / (68.0 / 15.0)
+	chk.Float64(tst, "F", 1e-14, f, fAna)
+	chk.Float64(tst, "p", 1e-15, p, math.Pow(1+2*fAna/15, -7.5))
+	chk.Float64(tst, "p (reference)", 1e-5, p, 0.0024)
+
+	// identical group means ⇒ F = 0 and p = 1
+	f, p = OneWayANOVA([]la.Vector{{1, 2, 3}, {3, 2, 1}, {2, 2, 2}})
+	chk.Float64(tst, "F (equal means)", 1e-15, f, 0)
+	chk.Float64(tst, "p (equal means)", 1e-15, p, 1)
+
+	// no variance within groups ⇒ F = +Inf and p = 0; or NaN if all values are equal
+	f, p = OneWayANOVA([]la.Vector{{1, 1, 1}, {2, 2}})
+	if !math.IsInf(f, 1) || p != 0 {
+		tst.Errorf("zero within-group variance should give F = +Inf and p = 0. F=%g, p=%g\n", f, p)
+	}
+	f, p = OneWayANOVA([]la.Vector{{1, 1, 1}, {1, 1}})
+	if !math.IsNaN(f) || !math.IsNaN(p) {
+		tst.Errorf("all values equal should give F = NaN and p = NaN. F=%g, p=%g\n", f, p)
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	OneWayANOVA([]la.Vector{{1, 2, 3}, {}})
+}
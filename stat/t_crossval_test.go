@@ -0,0 +1,100 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/rnd"
+	"github.com/cpmech/gosl/utl"
+)
+
+// checkPartition checks that the sets partition the indices 0…n-1
+func checkPartition(tst *testing.T, msg string, n int, sets ...[]int) {
+	var all []int
+	for _, s := range sets {
+		all = append(all, s...)
+	}
+	sort.Ints(all)
+	chk.Ints(tst, msg, all, utl.IntRange(n))
+}
+
+func TestKFold01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("KFold01. k-fold splitting")
+
+	// consecutive
+	folds := KFold(10, 3, false, nil)
+	io.Pforan("folds = %v\n", folds)
+	chk.Ints(tst, "fold 0", folds[0], []int{0, 1, 2, 3})
+	chk.Ints(tst, "fold 1", folds[1], []int{4, 5, 6})
+	chk.Ints(tst, "fold 2", folds[2], []int{7, 8, 9})
+
+	// shuffled
+	rng := rnd.NewRNG(1234)
+	for _, n := range []int{5, 17, 100} {
+		for _, k := range []int{2, 5} {
+			folds = KFold(n, k, true, rng)
+			checkPartition(tst, io.Sf("n=%d k=%d", n, k), n, folds...)
+			for i, f := range folds {
+				size := n / k
+				if i < n%k {
+					size++
+				}
+				chk.Int(tst, io.Sf("size of fold %d", i), len(f), size)
+			}
+		}
+	}
+	io.Pforan("folds = %v\n", folds)
+
+	// same seed ⇒ same folds
+	a := KFold(20, 4, true, rnd.NewRNG(7))
+	b := KFold(20, 4, true, rnd.NewRNG(7))
+	for i := range a {
+		chk.Ints(tst, "reproducible", a[i], b[i])
+	}
+
+	// leave-one-out
+	folds = KFold(4, 4, false, nil)
+	for i := 0; i < 4; i++ {
+		chk.Ints(tst, "leave-one-out", folds[i], []int{i})
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	KFold(3, 4, false, nil)
+}
+
+func TestTrainTestSplit01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("TrainTestSplit01. train/test splitting")
+
+	rng := rnd.NewRNG(1234)
+	for _, n := range []int{2, 10, 101} {
+		for _, frac := range []float64{0.1, 0.25, 0.5, 0.9} {
+			train, test := TrainTestSplit(n, frac, rng)
+			ntest := int(frac*float64(n) + 0.999999)
+			if ntest > n-1 {
+				ntest = n - 1
+			}
+			chk.Int(tst, io.Sf("ntest(n=%d,frac=%g)", n, frac), len(test), ntest)
+			chk.Int(tst, io.Sf("ntrain(n=%d,frac=%g)", n, frac), len(train), n-ntest)
+			checkPartition(tst, io.Sf("n=%d frac=%g", n, frac), n, train, test)
+		}
+	}
+	train, test := TrainTestSplit(10, 0.3, rng)
+	io.Pforan("train = %v\n", train)
+	io.Pforan("test  = %v\n", test)
+	chk.Int(tst, "ntest", len(test), 3)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	TrainTestSplit(10, 1, nil)
+}
@@ -0,0 +1,110 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/rnd"
+)
+
+// laplace implements the Laplace distribution with density only (support is the real line)
+type laplace struct{ mu, b float64 }
+
+func (o laplace) Pdf(x float64) float64 { return math.Exp(-math.Abs(x-o.mu)/o.b) / (2 * o.b) }
+
+// exponential implements the exponential distribution with density and support
+type exponential struct{ rate float64 }
+
+func (o exponential) Pdf(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return o.rate * math.Exp(-o.rate*x)
+}
+
+func (o exponential) Support() (lo, hi float64) { return 0, math.Inf(1) }
+
+// checkMoments checks the mean and variance computed with Expectation
+func checkMoments(tst *testing.T, name string, tol float64, dist Distribution, mean, variance float64) {
+	m, err := Expectation(dist, func(x float64) float64 { return x })
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	v, err := Expectation(dist, func(x float64) float64 { return (x - mean) * (x - mean) })
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	one, err := Expectation(dist, func(x float64) float64 { return 1 })
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("%-12s: mean = %23.15e  variance = %23.15e\n", name, m, v)
+	chk.Float64(tst, name+": ∫pdf", tol, one, 1)
+	chk.Float64(tst, name+": mean", tol*math.Max(1, math.Abs(mean)), m, mean)
+	chk.Float64(tst, name+": variance", tol*math.Max(1, variance), v, variance)
+}
+
+func TestExpectation01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Expectation01. mean and variance of distributions in rnd")
+
+	// normal
+	normal := &rnd.DistNormal{Mu: 3, Sig: 2}
+	normal.CalcDerived()
+	checkMoments(tst, "normal", 1e-9, normal, 3, 4)
+
+	// normal with narrow density far from the origin
+	narrow := &rnd.DistNormal{Mu: 100, Sig: 0.01}
+	narrow.CalcDerived()
+	checkMoments(tst, "narrow", 1e-9, narrow, 100, 1e-4)
+
+	// lognormal
+	var lognormal rnd.DistLogNormal
+	lognormal.Init(&rnd.Variable{M: 2, S: 0.5})
+	checkMoments(tst, "lognormal", 1e-9, lognormal, 2, 0.25)
+
+	// uniform
+	checkMoments(tst, "uniform", 1e-12, rnd.DistUniform{A: 2, B: 5}, 3.5, 0.75)
+
+	// Gumbel
+	var gumbel rnd.DistGumbel
+	gumbel.Init(&rnd.Variable{M: 10, S: 3})
+	checkMoments(tst, "gumbel", 1e-9, gumbel, 10, 9)
+
+	// Frechet
+	var frechet rnd.DistFrechet
+	frechet.Init(&rnd.Variable{L: 1, C: 2, A: 6})
+	checkMoments(tst, "frechet", 1e-8, frechet, frechet.Mean(), frechet.Variance())
+}
+
+func TestExpectation02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Expectation02. user-defined distributions")
+
+	// density only
+	checkMoments(tst, "laplace", 1e-9, laplace{mu: -1, b: 0.5}, -1, 0.5)
+
+	// density and support
+	checkMoments(tst, "exponential", 1e-9, exponential{rate: 4}, 0.25, 1.0/16.0)
+
+	// E[exp(X)] with X ~ N(0,1) = exp(½)
+	std := &rnd.DistNormal{Mu: 0, Sig: 1}
+	std.CalcDerived()
+	res, err := Expectation(std, math.Exp)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "E[exp(X)]", 1e-9, res, math.Exp(0.5))
+}
@@ -0,0 +1,169 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/plt"
+	"github.com/cpmech/gosl/utl"
+)
+
+func TestGP01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GP01. Gaussian process regression in 1D")
+
+	// training data
+	xx := []float64{0, 1, 2, 4, 5}
+	X := la.NewMatrix(len(xx), 1)
+	y := la.NewVector(len(xx))
+	for i, x := range xx {
+		X.Set(i, 0, x)
+		y[i] = math.Sin(x)
+	}
+
+	for _, kernel := range []string{"rbf", "matern32", "matern52", "func"} {
+		io.Pf("\n%s\n", kernel)
+		var k Kernel
+		switch kernel {
+		case "rbf":
+			k = KernelRBF{Sigma2: 1, Length: 1}
+		case "matern32":
+			k = KernelMatern32{Sigma2: 1, Length: 1}
+		case "matern52":
+			k = KernelMatern52{Sigma2: 1, Length: 1}
+		case "func":
+			k = KernelFunc(func(a, b la.Vector) float64 {
+				return math.Exp(-0.5 * sqDist(a, b))
+			})
+		}
+		gp := NewGP(k, 1e-10)
+		gp.Fit(X, y)
+
+		// the mean interpolates the training points with nearly zero variance
+		mean, variance := gp.Predict(X)
+		io.Pforan("mean     = %v\n", mean)
+		io.Pforan("variance = %v\n", variance)
+		chk.Array(tst, "mean @ training points", 1e-6, mean, y)
+		chk.Array(tst, "variance @ training points", 1e-6, variance, nil)
+
+		// uncertainty grows away from the training points
+		Xs := la.NewMatrixDeep2([][]float64{{2}, {2.5}, {3}, {6}, {8}, {20}})
+		_, vs := gp.Predict(Xs)
+		io.Pfyel("variance @ 2, 2.5, 3, 6, 8, 20 = %v\n", vs)
+		for i := 1; i < 3; i++ {
+			if vs[i] <= vs[i-1] {
+				tst.Errorf("variance should grow from x=2 to x=3\n")
+			}
+		}
+		for i := 4; i < len(vs); i++ {
+			if vs[i] <= vs[i-1] {
+				tst.Errorf("variance should grow from x=5 to x=20\n")
+			}
+		}
+
+		// far away, the posterior reverts to the prior
+		chk.Float64(tst, "variance @ 20", 1e-8, vs[5], 1)
+	}
+
+	// plot
+	if chk.Verbose {
+		gp := NewGP(KernelRBF{Sigma2: 1, Length: 1}, 1e-10)
+		gp.Fit(X, y)
+		xs := utl.LinSpace(-1, 7, 201)
+		Xs := la.NewMatrix(len(xs), 1)
+		for i, x := range xs {
+			Xs.Set(i, 0, x)
+		}
+		mean, variance := gp.Predict(Xs)
+		lo, hi := make([]float64, len(xs)), make([]float64, len(xs))
+		for i := range xs {
+			lo[i] = mean[i] - 2*math.Sqrt(variance[i])
+			hi[i] = mean[i] + 2*math.Sqrt(variance[i])
+		}
+		plt.Reset(true, nil)
+		plt.Plot(xs, lo, &plt.A{C: "grey", Ls: "--", L: "mean-2σ", NoClip: true})
+		plt.Plot(xs, hi, &plt.A{C: "grey", Ls: "--", L: "mean+2σ", NoClip: true})
+		plt.Plot(xs, mean, &plt.A{C: "b", L: "mean", NoClip: true})
+		plt.Plot(xx, y, &plt.A{C: "r", M: "o", Ls: "none", L: "data", NoClip: true})
+		plt.Gll("$x$", "$y$", nil)
+		plt.Save("/tmp/gosl/stat", "gp01")
+	}
+}
+
+func TestGP02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GP02. Gaussian process regression in 2D")
+
+	// training data on a grid
+	f := func(x, y float64) float64 { return math.Cos(x) * math.Sin(y) }
+	var X [][]float64
+	var y []float64
+	for _, x0 := range utl.LinSpace(0, 2, 6) {
+		for _, x1 := range utl.LinSpace(0, 2, 6) {
+			X = append(X, []float64{x0, x1})
+			y = append(y, f(x0, x1))
+		}
+	}
+	gp := NewGP(KernelMatern52{Sigma2: 1, Length: 1}, 1e-10)
+	gp.Fit(la.NewMatrixDeep2(X), y)
+
+	// predictions between grid points
+	Xs := la.NewMatrixDeep2([][]float64{{0.3, 0.5}, {1.1, 1.7}, {1.5, 0.1}})
+	mean, variance := gp.Predict(Xs)
+	io.Pforan("mean     = %v\n", mean)
+	io.Pforan("variance = %v\n", variance)
+	for i := 0; i < Xs.M; i++ {
+		chk.Float64(tst, io.Sf("mean @ %v", Xs.GetRow(i)), 1e-2, mean[i], f(Xs.Get(i, 0), Xs.Get(i, 1)))
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	gp.Predict(la.NewMatrix(1, 3))
+}
+
+func TestGP03(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("GP03. log marginal likelihood and single-point prediction")
+
+	// two points: K = [[s+σn², k12], [k12, s+σn²]]
+	X := la.NewMatrixDeep2([][]float64{{0}, {1}})
+	y := la.Vector{0.5, -0.3}
+	noise := 0.01
+	gp := NewGP(KernelRBF{Sigma2: 1, Length: 1}, noise)
+	gp.Fit(X, y)
+
+	// analytical solution
+	a, b := 1+noise, math.Exp(-0.5)
+	det := a*a - b*b
+	yKy := (a*y[0]*y[0] - 2*b*y[0]*y[1] + a*y[1]*y[1]) / det
+	lml := -0.5*yKy - 0.5*math.Log(det) - math.Log(2*math.Pi)
+	io.Pforan("lml = %v\n", gp.LogMarginalLikelihood())
+	chk.Float64(tst, "lml", 1e-14, gp.LogMarginalLikelihood(), lml)
+
+	// PredictPoint matches Predict
+	Xs := la.NewMatrixDeep2([][]float64{{-0.5}, {0.3}, {2.5}})
+	mean, variance := gp.Predict(Xs)
+	for i := 0; i < Xs.M; i++ {
+		m, v := gp.PredictPoint(Xs.GetRow(i))
+		chk.Float64(tst, io.Sf("mean @ %g", Xs.Get(i, 0)), 1e-15, m, mean[i])
+		chk.Float64(tst, io.Sf("variance @ %g", Xs.Get(i, 0)), 1e-15, v, variance[i])
+	}
+
+	// errors
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		NewGP(KernelRBF{Sigma2: 1, Length: 1}, noise).LogMarginalLikelihood()
+	}()
+	defer chk.RecoverTstPanicIsOK(tst)
+	gp.PredictPoint(la.Vector{1, 2})
+}
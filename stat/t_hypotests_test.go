@@ -0,0 +1,115 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestTdist01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Tdist01. CDF of Student's t distribution")
+
+	// closed-form expressions
+	//   ν = 1 ⇒ CDF(t) = ½ + atan(t)/π
+	//   ν = 2 ⇒ CDF(t) = ½ + t/(2√(t²+2))
+	for _, t := range []float64{-20, -3, -1, -0.2, 0, 0.5, 1, 4, 50} {
+		chk.Float64(tst, io.Sf("CDF(%g;1)", t), 1e-15, TCdf(t, 1), 0.5+math.Atan(t)/math.Pi)
+		chk.Float64(tst, io.Sf("CDF(%g;2)", t), 1e-15, TCdf(t, 2), 0.5+t/(2*math.Sqrt(t*t+2)))
+		chk.Float64(tst, io.Sf("SF(%g;7)", t), 1e-15, TSf(t, 7), TCdf(-t, 7))
+	}
+}
+
+func TestTTest01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("TTest01. Student's and Welch's t-tests")
+
+	// Wikipedia: Welch's t-test. Example 1
+	//   Student: t = -2.46, ν = 28,   p = 0.021
+	//   Welch:   t = -2.46, ν = 25.0, p = 0.021
+	a := la.Vector{27.5, 21.0, 19.0, 23.6, 17.0, 17.9, 16.9, 20.1, 21.9, 22.6, 23.1, 19.6, 19.0, 21.7, 21.4}
+	b := la.Vector{27.1, 22.0, 20.8, 23.4, 23.4, 23.5, 25.8, 22.0, 24.8, 20.2, 21.9, 22.1, 22.9, 20.5, 24.4}
+	t, p := TTest2(a, b, true)
+	io.Pforan("Student: t = %v  p = %v\n", t, p)
+	chk.Float64(tst, "t (Student)", 5e-3, t, -2.46)
+	chk.Float64(tst, "p (Student)", 5e-4, p, 0.021)
+	t, p = TTest2(a, b, false)
+	io.Pforan("Welch:   t = %v  p = %v\n", t, p)
+	chk.Float64(tst, "t (Welch)", 5e-3, t, -2.46)
+	chk.Float64(tst, "p (Welch)", 5e-4, p, 0.021)
+
+	// two values per sample ⇒ ν = 2 and the p-value has a closed form
+	t, p = TTest2(la.Vector{1, 2}, la.Vector{4, 6}, true)
+	tAna := -3.5 / math.Sqrt(1.25)
+	chk.Float64(tst, "t (n=2)", 1e-15, t, tAna)
+	chk.Float64(tst, "p (n=2)", 1e-15, p, 1+tAna/math.Sqrt(tAna*tAna+2))
+
+	// zero variances ⇒ t = ±Inf and p = 0; or NaN if all values are equal
+	for _, equalVar := range []bool{true, false} {
+		t, p = TTest2(la.Vector{1, 1, 1}, la.Vector{2, 2}, equalVar)
+		if !math.IsInf(t, -1) || p != 0 {
+			tst.Errorf("zero variances should give t = -Inf and p = 0. t=%g, p=%g\n", t, p)
+		}
+		t, p = TTest2(la.Vector{3, 3}, la.Vector{3, 3, 3}, equalVar)
+		if !math.IsNaN(t) || !math.IsNaN(p) {
+			tst.Errorf("all values equal should give t = NaN and p = NaN. t=%g, p=%g\n", t, p)
+		}
+	}
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	TTest2(la.Vector{1}, b, false)
+}
+
+func TestMannWhitney01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("MannWhitney01. Mann-Whitney U test")
+
+	// exact distribution with na = nb = 3: 20 arrangements with counts 1,1,2,3,3,3,3,2,1,1
+	u, p := MannWhitneyU(la.Vector{1, 2, 3}, la.Vector{4, 5, 6})
+	io.Pforan("u = %v  p = %v\n", u, p)
+	chk.Float64(tst, "u (separated)", 1e-15, u, 0)
+	chk.Float64(tst, "p (separated)", 1e-15, p, 2.0/20.0)
+	u, p = MannWhitneyU(la.Vector{6, 5, 4}, la.Vector{1, 2, 3})
+	chk.Float64(tst, "u (reversed)", 1e-15, u, 9)
+	chk.Float64(tst, "p (reversed)", 1e-15, p, 2.0/20.0)
+	u, p = MannWhitneyU(la.Vector{1, 3, 5}, la.Vector{2, 4, 6})
+	chk.Float64(tst, "u (interleaved)", 1e-15, u, 3)
+	chk.Float64(tst, "p (interleaved)", 1e-15, p, 2.0*7.0/20.0)
+
+	// ties ⇒ normal approximation
+	//   ranks of a = {1, 3, 3, 5.5} ⇒ U = 12.5 - 10 = 2.5
+	//   tie correction Σ(t³-t) = 24 + 6 + 6 = 36
+	u, p = MannWhitneyU(la.Vector{1, 2, 2, 3}, la.Vector{2, 3, 4, 5, 5})
+	io.Pforan("u = %v  p = %v\n", u, p)
+	σ := math.Sqrt(20.0 / 12.0 * (10.0 - 36.0/72.0))
+	chk.Float64(tst, "u (ties)", 1e-15, u, 2.5)
+	chk.Float64(tst, "p (ties)", 1e-15, p, math.Erfc((7.5-0.5)/σ/math.Sqrt2))
+
+	// exact and normal approximation agree for larger samples
+	a := la.NewVector(20)
+	b := la.NewVector(20)
+	for i := 0; i < 20; i++ {
+		a[i] = float64(3*i%40) + 0.5
+		b[i] = float64(i) + 0.25*float64(i%3)
+	}
+	u, p = MannWhitneyU(a, b)
+	μ, σ := 200.0, math.Sqrt(400.0*41.0/12.0)
+	pNormal := math.Erfc((math.Abs(u-μ) - 0.5) / σ / math.Sqrt2)
+	io.Pforan("u = %v  p(exact) = %v  p(normal) = %v\n", u, p, pNormal)
+	chk.Float64(tst, "p (exact vs normal)", 5e-3, p, pNormal)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	MannWhitneyU(la.Vector{}, b)
+}
@@ -0,0 +1,121 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/plt"
+	"github.com/cpmech/gosl/rnd"
+)
+
+// gaussianBlobs generates npts points around each center with standard deviation σ
+func gaussianBlobs(centers [][]float64, npts int, σ float64, rng *rnd.RNG) (data *la.Matrix, labels []int) {
+	ndim := len(centers[0])
+	data = la.NewMatrix(len(centers)*npts, ndim)
+	labels = make([]int, len(centers)*npts)
+	for c, center := range centers {
+		for k := 0; k < npts; k++ {
+			i := c*npts + k
+			for j := 0; j < ndim; j++ {
+				data.Set(i, j, rng.Normal(center[j], σ))
+			}
+			labels[i] = c
+		}
+	}
+	return
+}
+
+func TestKMeans01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("KMeans01. well-separated Gaussian blobs")
+
+	rng := rnd.NewRNG(1234)
+	centers := [][]float64{{0, 0}, {10, 0}, {5, 8}}
+	data, correct := gaussianBlobs(centers, 50, 1.0, rng)
+
+	centroids, labels, inertia := KMeans(data, 3, 100, rng)
+	io.Pf("centroids =\n%v\n", centroids.Print("%8.4f"))
+	io.Pforan("inertia = %v\n", inertia)
+
+	// clusters are recovered up to a permutation of labels
+	perm := make(map[int]int)
+	for i, c := range labels {
+		if p, ok := perm[correct[i]]; ok {
+			if p != c {
+				tst.Errorf("point %d is in the wrong cluster\n", i)
+				return
+			}
+		} else {
+			perm[correct[i]] = c
+		}
+	}
+	chk.Int(tst, "number of clusters", len(perm), 3)
+	for c, center := range centers {
+		chk.Array(tst, io.Sf("centroid %d", c), 0.4, centroids.GetRow(perm[c]), center)
+	}
+
+	// inertia ≈ npts⋅ndim⋅σ²
+	chk.Float64(tst, "inertia", 60, inertia, 150*2*1.0)
+
+	// k = 1 gives the mean and k = npts gives zero inertia
+	c1, _, _ := KMeans(data, 1, 10, rng)
+	mean := la.NewVector(2)
+	for i := 0; i < data.M; i++ {
+		mean[0] += data.Get(i, 0) / float64(data.M)
+		mean[1] += data.Get(i, 1) / float64(data.M)
+	}
+	chk.Array(tst, "k=1", 1e-13, c1.GetRow(0), mean)
+	_, _, inertiaN := KMeans(data, data.M, 10, rng)
+	chk.Float64(tst, "inertia(k=npts)", 1e-15, inertiaN, 0)
+
+	// plot
+	if chk.Verbose {
+		plt.Reset(true, nil)
+		colors := []string{"r", "g", "b"}
+		for i := 0; i < data.M; i++ {
+			plt.PlotOne(data.Get(i, 0), data.Get(i, 1), &plt.A{C: colors[labels[i]], M: "."})
+		}
+		for c := 0; c < 3; c++ {
+			plt.PlotOne(centroids.Get(c, 0), centroids.Get(c, 1), &plt.A{C: "k", M: "*", Ms: 12})
+		}
+		plt.Equal()
+		plt.Save("/tmp/gosl/stat", "kmeans01")
+	}
+}
+
+func TestKMeans02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("KMeans02. duplicated points and empty clusters")
+
+	// only two distinct points but three clusters
+	data := la.NewMatrixDeep2([][]float64{{0, 0}, {0, 0}, {0, 0}, {1, 1}, {1, 1}})
+	centroids, labels, inertia := KMeans(data, 3, 10, rnd.NewRNG(1))
+	io.Pf("centroids =\n%v\n", centroids.Print("%8.4f"))
+	io.Pforan("labels = %v\n", labels)
+	chk.Float64(tst, "inertia", 1e-15, inertia, 0)
+	counts := make([]int, 3)
+	for _, c := range labels {
+		counts[c]++
+	}
+	for c := 0; c < 3; c++ {
+		if counts[c] == 0 {
+			tst.Errorf("cluster %d is empty\n", c)
+		}
+	}
+
+	// errors
+	func() {
+		defer chk.RecoverTstPanicIsOK(tst)
+		KMeans(data, 2, 0, nil)
+	}()
+	defer chk.RecoverTstPanicIsOK(tst)
+	KMeans(data, 6, 10, nil)
+}
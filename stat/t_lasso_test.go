@@ -0,0 +1,153 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/rnd"
+)
+
+// sparseData generates y = 5 + X⋅β + noise with random features of different scales
+func sparseData(n int, betaTrue []float64, σ float64, rng *rnd.RNG) (X *la.Matrix, y la.Vector) {
+	p := len(betaTrue)
+	X = la.NewMatrix(n, p)
+	y = la.NewVector(n)
+	for i := 0; i < n; i++ {
+		y[i] = 5 + rng.Normal(0, σ)
+		for j := 0; j < p; j++ {
+			x := rng.Normal(float64(j), 1+float64(j%3))
+			X.Set(i, j, x)
+			y[i] += betaTrue[j] * x
+		}
+	}
+	return
+}
+
+func TestLasso01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Lasso01. sparse coefficients")
+
+	rng := rnd.NewRNG(1234)
+	betaTrue := []float64{3, 0, -2, 0, 0, 1.5, 0, 0, 0, 0}
+	X, y := sparseData(200, betaTrue, 0.5, rng)
+
+	// LASSO zeroes out the irrelevant coefficients
+	beta, err := Lasso(X, y, 0.1, 1000)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("β(lasso) = %.4f\n", beta)
+	for j, b := range betaTrue {
+		if b == 0 {
+			chk.Float64(tst, io.Sf("β%d (irrelevant)", j), 1e-17, beta[j], 0)
+		} else {
+			chk.Float64(tst, io.Sf("β%d (relevant)", j), 0.15, beta[j], b)
+			if beta[j]*b <= 0 {
+				tst.Errorf("β%d has the wrong sign\n", j)
+			}
+		}
+	}
+
+	// OLS with a column of ones for the intercept does not give zeros
+	X1 := la.NewMatrix(X.M, X.N+1)
+	for i := 0; i < X.M; i++ {
+		X1.Set(i, 0, 1)
+		for j := 0; j < X.N; j++ {
+			X1.Set(i, j+1, X.Get(i, j))
+		}
+	}
+	ols, err := OLS(X1, y)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("β(OLS)   = %.4f\n", ols.Beta[1:])
+	for j := range betaTrue {
+		if ols.Beta[1+j] == 0 {
+			tst.Errorf("OLS coefficient %d should not be exactly zero\n", j)
+		}
+	}
+
+	// λ = 0 recovers OLS including the intercept
+	beta, b0, err := ElasticNet(X, y, 0, 1, 10000)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Array(tst, "β(λ=0)", 1e-8, beta, ols.Beta[1:])
+	chk.Float64(tst, "b0(λ=0)", 1e-8, b0, ols.Beta[0])
+}
+
+func TestElasticNet01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("ElasticNet01. elastic net and ridge limit")
+
+	rng := rnd.NewRNG(4321)
+	betaTrue := []float64{2, 0, -1, 0}
+	X, y := sparseData(100, betaTrue, 0.3, rng)
+
+	// α = 0 ⇒ ridge on standardized features: (ZᵀZ/n + λI) b = Zᵀ(y - ȳ)/n
+	lambda := 0.5
+	beta, _, err := ElasticNet(X, y, lambda, 0, 10000)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	n := float64(X.M)
+	Z := la.NewMatrix(X.M, X.N)
+	scales := la.NewVector(X.N)
+	for j := 0; j < X.N; j++ {
+		col := X.GetCol(j)
+		mean := col.Accum() / n
+		for i := range col {
+			col[i] -= mean
+		}
+		scales[j] = math.Sqrt(la.VecDot(col, col) / n)
+		for i := range col {
+			Z.Set(i, j, col[i]/scales[j])
+		}
+	}
+	yc := y.GetCopy()
+	ymean := y.Accum() / n
+	for i := range yc {
+		yc[i] -= ymean
+	}
+	ridge, err := Ridge(Z, yc, n*lambda)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	for j := range beta {
+		chk.Float64(tst, io.Sf("β%d (α=0)", j), 1e-9, beta[j]*scales[j], ridge.Beta[j])
+	}
+
+	// intermediate α: irrelevant coefficients are zeroed with larger λ
+	beta, _, err = ElasticNet(X, y, 0.3, 0.5, 1000)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("β(α=0.5) = %.4f\n", beta)
+	chk.Float64(tst, "β1 (α=0.5)", 1e-17, beta[1], 0)
+	chk.Float64(tst, "β3 (α=0.5)", 1e-17, beta[3], 0)
+
+	// errors
+	_, _, err = ElasticNet(X, y, 0.1, 2, 10)
+	if err == nil {
+		tst.Errorf("ElasticNet should have failed with invalid alpha\n")
+	}
+	_, err = Lasso(X, y, 1e-6, 1)
+	if err == nil {
+		tst.Errorf("Lasso should have failed to converge with one iteration\n")
+	}
+}
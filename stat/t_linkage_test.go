@@ -0,0 +1,83 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+)
+
+func TestLinkage01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Linkage01. merge heights in 1D")
+
+	// points: 0, 1, 3, 7
+	data := la.NewMatrixDeep2([][]float64{{0}, {1}, {3}, {7}})
+	heights := map[string][]float64{
+		"single":   {1, 2, 4},
+		"complete": {1, 3, 7},
+		"average":  {1, 2.5, 17.0 / 3.0},
+		"ward":     {1, math.Sqrt(4.0/3.0) * 2.5, math.Sqrt(1.5) * 17.0 / 3.0},
+	}
+	for _, method := range []string{"single", "complete", "average", "ward"} {
+		d, err := Linkage(data, method)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		io.Pforan("%-8s: %v\n", method, d.Merges)
+		h := make([]float64, len(d.Merges))
+		for i, m := range d.Merges {
+			h[i] = m.Height
+		}
+		chk.Array(tst, method, 1e-14, h, heights[method])
+		chk.Int(tst, "size of last cluster", d.Merges[2].Size, 4)
+		chk.Ints(tst, "first merge", []int{d.Merges[0].A, d.Merges[0].B}, []int{0, 1})
+		chk.Ints(tst, "cut(1)", d.Cut(1), []int{0, 0, 0, 0})
+		chk.Ints(tst, "cut(4)", d.Cut(4), []int{0, 1, 2, 3})
+	}
+
+	// errors
+	_, err := Linkage(data, "centroid")
+	if err == nil {
+		tst.Errorf("Linkage should have failed with invalid method\n")
+	}
+	_, err = Linkage(la.NewMatrix(0, 2), "single")
+	if err == nil {
+		tst.Errorf("Linkage should have failed with no points\n")
+	}
+}
+
+func TestLinkage02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Linkage02. nested clusters")
+
+	// two close groups (A and B) forming a super-cluster and a third group (C) far away
+	data := la.NewMatrixDeep2([][]float64{
+		{0, 0}, {20, 0}, {0.3, 0.1}, {4, 0}, {20.2, 0.3}, {4.1, 0.4}, {0.1, 0.4}, {19.8, 0.2}, {3.8, 0.2},
+	})
+	// group:  A      C        A         B       C           B          A           C           B
+	for _, method := range []string{"single", "complete", "average", "ward"} {
+		d, err := Linkage(data, method)
+		if err != nil {
+			tst.Errorf("%v\n", err)
+			return
+		}
+		io.Pf("%s\n", method)
+		chk.Ints(tst, "cut(2)", d.Cut(2), []int{0, 1, 0, 0, 1, 0, 0, 1, 0})
+		chk.Ints(tst, "cut(3)", d.Cut(3), []int{0, 1, 0, 2, 1, 2, 0, 1, 2})
+	}
+
+	// errors
+	d, _ := Linkage(data, "single")
+	defer chk.RecoverTstPanicIsOK(tst)
+	d.Cut(0)
+}
@@ -0,0 +1,83 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+)
+
+func TestMetrics01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Metrics01. binary classification")
+
+	// 3 true positives, 1 false negative, 3 true negatives and 1 false positive
+	trueLabels := []int{1, 1, 1, 1, 0, 0, 0, 0}
+	predLabels := []int{1, 1, 1, 0, 0, 0, 1, 0}
+	C := ConfusionMatrix(trueLabels, predLabels, 2)
+	io.Pf("C =\n%v\n", C.Print("%3g"))
+	chk.Deep2(tst, "C", 1e-17, C.GetDeep2(), [][]float64{
+		{3, 1},
+		{1, 3},
+	})
+	chk.Float64(tst, "accuracy", 1e-15, Accuracy(C), 6.0/8.0)
+
+	p, pm := Precision(C)
+	r, rm := Recall(C)
+	f, fm := F1(C)
+	chk.Array(tst, "precision", 1e-15, p, []float64{0.75, 0.75})
+	chk.Array(tst, "recall", 1e-15, r, []float64{0.75, 0.75})
+	chk.Array(tst, "f1", 1e-15, f, []float64{0.75, 0.75})
+	chk.Float64(tst, "macro precision", 1e-15, pm, 0.75)
+	chk.Float64(tst, "macro recall", 1e-15, rm, 0.75)
+	chk.Float64(tst, "macro f1", 1e-15, fm, 0.75)
+}
+
+func TestMetrics02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Metrics02. multiclass with empty class")
+
+	// class 3 never appears; class 2 is never predicted
+	trueLabels := []int{0, 0, 0, 1, 1, 2, 2, 2, 1, 0}
+	predLabels := []int{0, 0, 1, 1, 1, 0, 1, 1, 0, 0}
+	C := ConfusionMatrix(trueLabels, predLabels, 4)
+	io.Pf("C =\n%v\n", C.Print("%3g"))
+	chk.Deep2(tst, "C", 1e-17, C.GetDeep2(), [][]float64{
+		{3, 1, 0, 0},
+		{1, 2, 0, 0},
+		{1, 2, 0, 0},
+		{0, 0, 0, 0},
+	})
+	chk.Float64(tst, "accuracy", 1e-15, Accuracy(C), 0.5)
+
+	// precision: 3/5, 2/5, 0 (never predicted), 0 (absent)
+	p, pm := Precision(C)
+	chk.Array(tst, "precision", 1e-15, p, []float64{0.6, 0.4, 0, 0})
+	chk.Float64(tst, "macro precision", 1e-15, pm, 1.0/3.0)
+
+	// recall: 3/4, 2/3, 0
+	r, rm := Recall(C)
+	chk.Array(tst, "recall", 1e-15, r, []float64{0.75, 2.0 / 3.0, 0, 0})
+	chk.Float64(tst, "macro recall", 1e-15, rm, (0.75+2.0/3.0)/3.0)
+
+	// f1: 2⋅3/(5+4), 2⋅2/(5+3), 0
+	f, fm := F1(C)
+	chk.Array(tst, "f1", 1e-15, f, []float64{2.0 / 3.0, 0.5, 0, 0})
+	chk.Float64(tst, "macro f1", 1e-15, fm, (2.0/3.0+0.5)/3.0)
+
+	// no samples
+	E := ConfusionMatrix(nil, nil, 2)
+	chk.Float64(tst, "accuracy(empty)", 1e-17, Accuracy(E), 0)
+	_, em := F1(E)
+	chk.Float64(tst, "macro f1(empty)", 1e-17, em, 0)
+
+	// errors
+	defer chk.RecoverTstPanicIsOK(tst)
+	ConfusionMatrix([]int{0, 2}, []int{0, 1}, 2)
+}
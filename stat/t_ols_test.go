@@ -0,0 +1,169 @@
+// Copyright 2016 The Gosl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cpmech/gosl/chk"
+	"github.com/cpmech/gosl/io"
+	"github.com/cpmech/gosl/la"
+	"github.com/cpmech/gosl/rnd"
+)
+
+func TestOLS01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("OLS01. ordinary least squares")
+
+	// y = 1 + 2 x exactly
+	X := la.NewMatrixDeep2([][]float64{{1, 0}, {1, 1}, {1, 2}, {1, 3}})
+	y := la.Vector{1, 3, 5, 7}
+	res, err := OLS(X, y)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("β = %v\n", res.Beta)
+	chk.Array(tst, "β", 1e-13, res.Beta, []float64{1, 2})
+	chk.Array(tst, "residuals", 1e-13, res.Residuals, nil)
+	chk.Float64(tst, "R²", 1e-14, res.R2, 1)
+
+	// least squares fit: y = {1, 2, 2, 4} ⇒ β = (0.9, 0.9)
+	y = la.Vector{1, 2, 2, 4}
+	res, err = OLS(X, y)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Array(tst, "β", 1e-14, res.Beta, []float64{0.9, 0.9})
+	chk.Float64(tst, "RSS", 1e-14, res.RSS, 0.7)
+	chk.Float64(tst, "R²", 1e-14, res.R2, 1-0.7/4.75)
+
+	// constant y ⇒ TSS = 0: perfect fit (R² = 1) or not (R² = 0)
+	y = la.Vector{0.1, 0.1, 0.1, 0.1}
+	res, err = OLS(X, y)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Array(tst, "β (constant y)", 1e-14, res.Beta, []float64{0.1, 0})
+	chk.Float64(tst, "R² (constant y)", 1e-15, res.R2, 1)
+	res, err = Ridge(X, y, 1)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "R² (constant y, ridge)", 1e-15, res.R2, 0)
+	res, err = OLS(X, la.Vector{0, 0, 0, 0})
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	chk.Float64(tst, "R² (y = 0)", 1e-15, res.R2, 1)
+
+	// exactly collinear columns ⇒ singular normal equations
+	_, err = OLS(la.NewMatrixDeep2([][]float64{{1, 2}, {2, 4}, {3, 6}}), la.Vector{1, 2, 3})
+	io.Pforan("err = %v\n", err)
+	if err == nil {
+		tst.Errorf("OLS should have failed with collinear predictors\n")
+	}
+}
+
+func TestRidge01(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Ridge01. ridge regression with nearly collinear predictors")
+
+	// y = x1 + x2 + noise with x2 ≈ x1
+	rng := rnd.NewRNG(1234)
+	n := 30
+	X := la.NewMatrix(n, 2)
+	y := la.NewVector(n)
+	for i := 0; i < n; i++ {
+		x1 := float64(i) / float64(n-1)
+		x2 := x1 + rng.Normal(0, 1e-6)
+		X.Set(i, 0, x1)
+		X.Set(i, 1, x2)
+		y[i] = x1 + x2 + rng.Normal(0, 0.05)
+	}
+
+	// OLS gives huge coefficients of opposite signs
+	ols, err := OLS(X, y)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("OLS:   β = %v\n", ols.Beta)
+	if math.Abs(ols.Beta[0]) < 100 {
+		tst.Errorf("OLS coefficients should be unstable with nearly collinear predictors\n")
+	}
+
+	// ridge gives stable coefficients with β1 ≈ β2 ≈ 1
+	ridge, err := Ridge(X, y, 0.01)
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("Ridge: β = %v  R² = %v\n", ridge.Beta, ridge.R2)
+	chk.Array(tst, "β (ridge)", 0.05, ridge.Beta, []float64{1, 1})
+	chk.Float64(tst, "R² (ridge)", 0.01, ridge.R2, 1)
+
+	// λ = 0 ⇒ OLS
+	r0, _ := Ridge(X, y, 0)
+	chk.Array(tst, "β (λ=0)", 1e-17, r0.Beta, ols.Beta)
+
+	// Ridge(λ) ⇔ la.RegularizedLeastSquares(√λ)
+	xtik := la.RegularizedLeastSquares(X, y, math.Sqrt(0.01))
+	chk.Array(tst, "β: Ridge(λ) = RegularizedLeastSquares(√λ)", 1e-10, ridge.Beta, xtik)
+
+	// errors
+	_, err = Ridge(X, y, -1)
+	if err == nil {
+		tst.Errorf("Ridge should have failed with negative lambda\n")
+	}
+}
+
+func TestRidge02(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("Ridge02. selection of lambda by cross-validation")
+
+	// noisy quadratic fitted with a high-degree polynomial
+	rng := rnd.NewRNG(4321)
+	n, deg := 40, 9
+	X := la.NewMatrix(n, deg+1)
+	y := la.NewVector(n)
+	for i := 0; i < n; i++ {
+		x := -1 + 2*float64(i)/float64(n-1)
+		for j := 0; j <= deg; j++ {
+			X.Set(i, j, math.Pow(x, float64(j)))
+		}
+		y[i] = 1 - x + 2*x*x + rng.Normal(0, 0.3)
+	}
+	lambdas := []float64{0, 1e-8, 1e-4, 1e-2, 1e-1, 1, 10, 1000}
+	best, mse, err := RidgeCV(X, y, lambdas, 5, rnd.NewRNG(1))
+	if err != nil {
+		tst.Errorf("%v\n", err)
+		return
+	}
+	io.Pforan("mse  = %v\n", mse)
+	io.Pforan("best = %v\n", best)
+
+	// regularization improves the prediction error; too much regularization degrades it
+	if !(best > 1e-8 && best < 1000) {
+		tst.Errorf("best lambda should be moderate. best=%g is invalid\n", best)
+	}
+	ibest := 0
+	for l := range lambdas {
+		if lambdas[l] == best {
+			ibest = l
+		}
+	}
+	if !(mse[ibest] < mse[0] && mse[ibest] < mse[len(mse)-1]) {
+		tst.Errorf("cross-validation error of best lambda should be the smallest\n")
+	}
+}
@@ -681,6 +681,30 @@ func ArgMinMax(v []float64) (imin, imax int) {
 	return
 }
 
+// ArgMin returns the minimum value and its index in v
+//  NOTE: NaN values are ignored; if v is empty or all values are NaN, val=NaN and idx=-1
+func ArgMin(v []float64) (val float64, idx int) {
+	val, idx = math.NaN(), -1
+	for i, x := range v {
+		if !math.IsNaN(x) && (idx < 0 || x < val) {
+			val, idx = x, i
+		}
+	}
+	return
+}
+
+// ArgMax returns the maximum value and its index in v
+//  NOTE: NaN values are ignored; if v is empty or all values are NaN, val=NaN and idx=-1
+func ArgMax(v []float64) (val float64, idx int) {
+	val, idx = math.NaN(), -1
+	for i, x := range v {
+		if !math.IsNaN(x) && (idx < 0 || x > val) {
+			val, idx = x, i
+		}
+	}
+	return
+}
+
 // FromInts returns a new slice of float64 from a slice of ints
 func FromInts(a []int) (b []float64) {
 	b = make([]float64, len(a))
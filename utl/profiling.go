@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"runtime/pprof"
+	"time"
 
 	"github.com/cpmech/gosl/chk"
 	"github.com/cpmech/gosl/io"
@@ -128,3 +129,49 @@ func PrintMemStat(msg string) {
 	io.Pfyel("Mallocs    = %v\n", mem.Mallocs)
 	io.Pfyel("Frees      = %v\n", mem.Frees)
 }
+
+// Stopwatch accumulates the wall-clock time spent over many Start/Stop intervals
+//  NOTE: all methods do nothing if the receiver is nil; thus, a nil Stopwatch can be used to
+//        disable timing without changing the calling code
+type Stopwatch struct {
+	Total   time.Duration // accumulated time
+	t0      time.Time     // time when Start was called
+	running bool          // Start was called but not Stop
+}
+
+// Start starts (or restarts) the current interval
+func (o *Stopwatch) Start() {
+	if o == nil {
+		return
+	}
+	o.t0 = time.Now()
+	o.running = true
+}
+
+// Stop finishes the current interval, adds its duration to Total and returns it
+func (o *Stopwatch) Stop() (dt time.Duration) {
+	if o == nil || !o.running {
+		return
+	}
+	dt = time.Since(o.t0)
+	o.Total += dt
+	o.running = false
+	return
+}
+
+// Elapsed returns the accumulated time
+func (o *Stopwatch) Elapsed() time.Duration {
+	if o == nil {
+		return 0
+	}
+	return o.Total
+}
+
+// Reset clears the accumulated time
+func (o *Stopwatch) Reset() {
+	if o == nil {
+		return
+	}
+	o.Total = 0
+	o.running = false
+}
@@ -515,3 +515,22 @@ func TestMylab15(tst *testing.T) {
 	b := FromFloat64s(a)
 	chk.Ints(tst, "floats(a)", b, []int{1, 2, 3, 4, 5})
 }
+
+func TestMylab16(tst *testing.T) {
+
+	//verbose()
+	chk.PrintTitle("mylab16. ArgMin and ArgMax")
+
+	v := []float64{math.NaN(), 2, -3, 5, -3}
+	min, imin := ArgMin(v)
+	max, imax := ArgMax(v)
+	chk.Float64(tst, "min", 1e-17, min, -3)
+	chk.Float64(tst, "max", 1e-17, max, 5)
+	chk.Int(tst, "imin", imin, 2)
+	chk.Int(tst, "imax", imax, 3)
+
+	_, imin = ArgMin(nil)
+	_, imax = ArgMax([]float64{math.NaN()})
+	chk.Int(tst, "imin(empty)", imin, -1)
+	chk.Int(tst, "imax(NaN)", imax, -1)
+}